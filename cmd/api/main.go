@@ -1,6 +1,6 @@
 //	@title			E-Wallet API
 //	@version		1.0
-//	@description	E-Wallet service API
+//	@description	E-Wallet service API. Messages are returned in the language negotiated from the Accept-Language header (vi, en).
 //	@host			pi.local:5111
 //	@BasePath		/
 //	@securityDefinitions.apikey	BearerAuth
@@ -10,18 +10,28 @@
 package main
 
 import (
-	"fmt"
+	"context"
 	"log"
-	"net/http"
+	"os/signal"
+	"syscall"
 
+	"e-wallet/internal/adapters/clock"
 	httpserver "e-wallet/internal/adapters/handler/http"
+	"e-wallet/internal/adapters/holidays"
+	"e-wallet/internal/adapters/metrics"
+	"e-wallet/internal/adapters/repository/memory"
 	"e-wallet/internal/adapters/repository/postgres"
 	"e-wallet/internal/adapters/service"
+	"e-wallet/internal/adapters/storage"
 	accountapp "e-wallet/internal/application/account"
+	privacyapp "e-wallet/internal/application/privacy"
 	profileapp "e-wallet/internal/application/profile"
 	"e-wallet/internal/application/user"
 	"e-wallet/internal/config"
+	"e-wallet/internal/domain/account"
 	"e-wallet/pkg/logger"
+	"e-wallet/pkg/sentry"
+	"e-wallet/pkg/tracing"
 
 	sentrygo "github.com/getsentry/sentry-go"
 )
@@ -48,29 +58,133 @@ func main() {
 		applog.Fatalf("cannot init sentry: %v", err)
 	}
 
-	db, err := postgres.NewConnection(postgres.ParseFromConfig(cfg))
+	defer sentrygo.Flush(sentry.FlushTime)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		ServiceName:  cfg.Tracing.ServiceName,
+		Environment:  cfg.AppEnv,
+		Exporter:     cfg.Tracing.Exporter,
+		OTLPEndpoint: cfg.Tracing.OTLPEndpoint,
+		OTLPInsecure: cfg.Tracing.OTLPInsecure,
+		SampleRatio:  cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		applog.Fatalf("cannot init tracing: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			applog.Errorw("cannot flush traces", "error", err)
+		}
+	}()
+
+	dbOpts := postgres.ParseFromConfig(cfg)
+	dbOpts.Logger = applog
+	db, err := postgres.NewConnection(dbOpts)
 	if err != nil {
 		applog.Fatal(err)
 	}
+	defer func() {
+		if err := postgres.Close(db); err != nil {
+			applog.Errorw("cannot close database", "error", err)
+		}
+	}()
+
+	promMetrics := metrics.NewPrometheus()
+	if err := db.Use(promMetrics.GormPlugin()); err != nil {
+		applog.Fatalf("cannot register database metrics: %v", err)
+	}
+	if err := db.Use(postgres.NewTracingPlugin()); err != nil {
+		applog.Fatalf("cannot register database tracing: %v", err)
+	}
 
-	server, err := httpserver.New(httpserver.WithConfig(cfg))
+	serverOpts := []httpserver.Options{httpserver.WithConfig(cfg), httpserver.WithMetrics(promMetrics)}
+	if cfg.RateLimit.Enabled {
+		switch cfg.RateLimit.Store {
+		case "postgres":
+			serverOpts = append(serverOpts, httpserver.WithRateLimitStore(postgres.NewRateLimitStore(db)))
+		default:
+			serverOpts = append(serverOpts, httpserver.WithRateLimitStore(memory.NewRateLimitStore()))
+		}
+	}
+
+	server, err := httpserver.New(serverOpts...)
 	if err != nil {
 		applog.Fatal(err)
 	}
 
 	server.Logger = applog
+	txManager := postgres.NewTxManager(db)
 	userRepo := postgres.NewUserRepository(db)
 	passwordService := service.NewPasswordService()
-	server.UserService = user.NewUserService(userRepo, passwordService)
+	server.UserService = user.WithTracing(user.NewUserService(userRepo, passwordService))
+
+	keyProvider, err := service.NewLocalKeyProvider(cfg.KeyFile)
+	if err != nil {
+		applog.Fatalf("cannot load encryption keys: %v", err)
+	}
+	encryptor := service.NewEnvelopeEncryptor(keyProvider)
+
+	// profiles written before encryption escape the uniqueness checks until
+	// they have blind indexes; no request is served before they do
+	indexed, err := postgres.NewKeyRotator(db, encryptor, 500, false).IndexUserProfiles(context.Background())
+	if err != nil {
+		applog.Fatalf("cannot index user profiles: %v", err)
+	}
+	if indexed > 0 {
+		applog.Infow("user profiles indexed", "count", indexed)
+	}
 
-	profileRepo := postgres.NewProfileRepository(db)
-	server.ProfileService = profileapp.NewProfileService(userRepo, profileRepo)
+	profileRepo := postgres.NewProfileRepository(db, encryptor)
+	server.ProfileService = profileapp.WithTracing(profileapp.NewProfileService(userRepo, profileRepo, clock.System))
 
-	accountRepo := postgres.NewAccountRepository(db)
+	accountNumbers, err := account.NewNumberGenerator(cfg.AccountNumberPrefix)
+	if err != nil {
+		applog.Fatalf("cannot create account number generator: %v", err)
+	}
+	businessDays, err := holidays.NewCalendar(cfg.Calendar.Convention, cfg.Calendar.HolidayFiles...)
+	if err != nil {
+		applog.Fatalf("cannot load business calendar: %v", err)
+	}
+	accountRepo := postgres.NewAccountRepository(db, accountNumbers)
 	savingsRepo := postgres.NewSavingsAccountDetailRepository(db)
-	server.AccountService = accountapp.NewAccountService(userRepo, accountRepo, savingsRepo)
+	transactionRepo := postgres.NewTransactionRepository(db)
+	server.AccountService = accountapp.WithTracing(accountapp.NewAccountService(userRepo, accountRepo, savingsRepo, transactionRepo, txManager, promMetrics, clock.System, businessDays))
 
-	addr := fmt.Sprintf(":%d", cfg.Port)
-	applog.Info("server started!")
-	applog.Fatal(http.ListenAndServe(addr, server))
+	server.PrivacyService = privacyapp.WithTracing(privacyapp.NewPrivacyService(
+		userRepo,
+		profileRepo,
+		accountRepo,
+		savingsRepo,
+		transactionRepo,
+		postgres.NewInterestHistoryRepository(db),
+		postgres.NewDataExportRepository(db),
+		storage.NewLocalExportStorage(cfg.ExportDir),
+		txManager,
+		clock.System,
+	))
+
+	// exports cut short by a crash or an expired shutdown deadline would
+	// otherwise stay pending forever
+	failed, err := server.PrivacyService.FailStaleExports(context.Background())
+	if err != nil {
+		applog.Fatalf("cannot fail stale data exports: %v", err)
+	}
+	if failed > 0 {
+		applog.Infow("stale data exports failed", "count", failed)
+	}
+
+	server.AuditLogger = postgres.NewAuditLogger(db)
+	server.Encryptor = encryptor
+
+	server.Health.Register(postgres.NewHealthChecker(db), cfg.HealthCheckTimeout)
+	server.Health.Register(storage.NewLocalStorageHealthChecker(cfg.ExportDir), cfg.HealthCheckTimeout)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := server.Run(ctx); err != nil {
+		applog.Errorw("server stopped with error", "error", err)
+	}
 }
\ No newline at end of file
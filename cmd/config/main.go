@@ -0,0 +1,60 @@
+// Command config shows the configuration the services start with, after the
+// .env files, the APP_ENV overlay and the *_FILE secrets have been applied.
+//
+//	go run ./cmd/config print [-redacted]
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"e-wallet/internal/config"
+	"e-wallet/pkg/logger"
+)
+
+func main() {
+	applogger, err := logger.NewAppLogger()
+	if err != nil {
+		log.Fatalf("cannot load config: %v\n", err)
+	}
+
+	if len(os.Args) < 2 {
+		applogger.Fatal("usage: config <print> [flags]")
+	}
+
+	switch cmd, args := os.Args[1], os.Args[2:]; cmd {
+	case "print":
+		err = printConfig(args)
+	default:
+		err = fmt.Errorf("unknown command %q", cmd)
+	}
+	if err != nil {
+		applogger.Fatal(err)
+	}
+}
+
+// printConfig writes the effective configuration to stdout. An invalid
+// configuration is still printed, followed by its problems on stderr.
+func printConfig(args []string) error {
+	fs := flag.NewFlagSet("print", flag.ExitOnError)
+	redacted := fs.Bool("redacted", false, "replace secrets with "+logger.Redacted)
+	_ = fs.Parse(args)
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if err := cfg.Print(os.Stdout, *redacted); err != nil {
+		return err
+	}
+
+	var invalid *config.ValidationError
+	if err := cfg.Validate(); errors.As(err, &invalid) {
+		fmt.Fprintln(os.Stderr, invalid.Error())
+		os.Exit(1)
+	}
+	return nil
+}
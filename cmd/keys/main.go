@@ -0,0 +1,193 @@
+// Command keys manages the local encryption key file and re-encrypts data.
+//
+//	go run ./cmd/keys generate -out keys.json
+//	go run ./cmd/keys add -file keys.json -id 2026-10
+//	go run ./cmd/keys rotate [-dry-run] [-batch-size 500]
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"e-wallet/internal/adapters/repository/postgres"
+	"e-wallet/internal/adapters/service"
+	"e-wallet/internal/config"
+	"e-wallet/pkg/logger"
+)
+
+func main() {
+	applogger, err := logger.NewAppLogger()
+	if err != nil {
+		log.Fatalf("cannot load config: %v\n", err)
+	}
+
+	if len(os.Args) < 2 {
+		applogger.Fatal("usage: keys <generate|add|rotate> [flags]")
+	}
+
+	switch cmd, args := os.Args[1], os.Args[2:]; cmd {
+	case "generate":
+		err = generate(args)
+	case "add":
+		err = addKey(args)
+	case "rotate":
+		err = rotate(args)
+	default:
+		err = fmt.Errorf("unknown command %q", cmd)
+	}
+	if err != nil {
+		applogger.Fatal(err)
+	}
+}
+
+// generate writes a new key file with one active key and an index key.
+func generate(args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	out := fs.String("out", "keys.json", "path of the key file to create")
+	id := fs.String("id", time.Now().Format("2006-01"), "ID of the first key")
+	_ = fs.Parse(args)
+
+	key, err := service.NewRandomKey()
+	if err != nil {
+		return err
+	}
+	indexKey, err := service.NewRandomKey()
+	if err != nil {
+		return err
+	}
+
+	kf := service.KeyFile{
+		ActiveKeyID: *id,
+		Keys:        map[string]string{*id: key},
+		IndexKey:    indexKey,
+	}
+	return writeKeyFile(*out, &kf, false)
+}
+
+// addKey adds a new key to the key file and makes it the active one. Old keys
+// are kept so existing values can still be decrypted until rotate has run.
+func addKey(args []string) error {
+	fs := flag.NewFlagSet("add", flag.ExitOnError)
+	file := fs.String("file", os.Getenv("ENCRYPTION_KEY_FILE"), "path of the key file")
+	id := fs.String("id", time.Now().Format("2006-01-02"), "ID of the new key")
+	_ = fs.Parse(args)
+
+	raw, err := os.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+	var kf service.KeyFile
+	if err := json.Unmarshal(raw, &kf); err != nil {
+		return err
+	}
+	if _, exists := kf.Keys[*id]; exists {
+		return fmt.Errorf("key %q already exists", *id)
+	}
+
+	key, err := service.NewRandomKey()
+	if err != nil {
+		return err
+	}
+	kf.Keys[*id] = key
+	kf.ActiveKeyID = *id
+
+	return writeKeyFile(*file, &kf, true)
+}
+
+// rotate re-encrypts every sensitive column with the active key.
+func rotate(args []string) error {
+	fs := flag.NewFlagSet("rotate", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "only report how many rows would be rewritten")
+	batchSize := fs.Int("batch-size", 500, "rows read per query")
+	_ = fs.Parse(args)
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	keyProvider, err := service.NewLocalKeyProvider(cfg.KeyFile)
+	if err != nil {
+		return err
+	}
+
+	db, err := postgres.NewConnection(postgres.ParseFromConfig(cfg))
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	rotator := postgres.NewKeyRotator(db, service.NewEnvelopeEncryptor(keyProvider), *batchSize, *dryRun)
+
+	profiles, err := rotator.RotateUserProfiles(ctx)
+	if err != nil {
+		return fmt.Errorf("rotate user_profiles: %w", err)
+	}
+	bankLinks, err := rotator.RotateBankLinks(ctx)
+	if err != nil {
+		return fmt.Errorf("rotate bank_links: %w", err)
+	}
+
+	verb := "re-encrypted"
+	if *dryRun {
+		verb = "would re-encrypt"
+	}
+	fmt.Printf("%s %d user_profiles rows and %d bank_links rows\n", verb, profiles, bankLinks)
+	return nil
+}
+
+// writeKeyFile writes kf to path all at once: a crash leaves either the old
+// file or the new one, never a truncated one that would lose the keys. An
+// existing file is only replaced when replace is set.
+func writeKeyFile(path string, kf *service.KeyFile, replace bool) error {
+	raw, err := json.MarshalIndent(kf, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, ".keys-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(raw, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if replace {
+		err = os.Rename(tmp.Name(), path)
+	} else {
+		// unlike a rename, a link fails when path exists
+		err = os.Link(tmp.Name(), path)
+	}
+	if err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
+
+// syncDir makes the files created or renamed in dir durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
@@ -1,50 +1,225 @@
+// Command migrate applies the SQL migrations in migrations/. An advisory lock
+// makes concurrent runs wait for each other.
+//
+//	go run ./cmd/migrate up [-dry-run] [n]      apply n (default all) pending migrations
+//	go run ./cmd/migrate down [-dry-run] [n]    roll back the last n (default 1) migrations
+//	go run ./cmd/migrate redo [-dry-run]        roll back and re-apply the last migration
+//	go run ./cmd/migrate status                 list migrations and when they were applied
+//	go run ./cmd/migrate new <name>             create migrations/<timestamp>_<name>.sql
+//
+// Without a command, up is run.
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
 	"e-wallet/internal/adapters/repository/postgres"
 	"e-wallet/internal/config"
 	"e-wallet/pkg/logger"
-	"log"
-	"strconv"
 
 	migrate "github.com/rubenv/sql-migrate"
 )
 
+const (
+	migrationsDir = "migrations"
+	// migrationIDLayout matches the prefix of the existing migration files
+	migrationIDLayout = "20060102150405"
+)
+
+var migrationNameRegex = regexp.MustCompile(`^[a-z0-9_]+$`)
+
 func main() {
 	applogger, err := logger.NewAppLogger()
 	if err != nil {
 		log.Fatalf("cannot load config: %v\n", err)
 	}
 
-	cfg, err := config.LoadConfig()
+	cmd, args := "up", []string{}
+	if len(os.Args) >= 2 {
+		cmd, args = os.Args[1], os.Args[2:]
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	switch cmd {
+	case "up":
+		err = up(ctx, args)
+	case "down":
+		err = down(ctx, args)
+	case "redo":
+		err = redo(ctx, args)
+	case "status":
+		err = status(ctx, args)
+	case "new":
+		err = newMigration(args)
+	default:
+		err = fmt.Errorf("unknown command %q, usage: migrate <up|down|redo|status|new> [flags]", cmd)
+	}
+	if err != nil {
+		applogger.Fatal(err)
+	}
+}
+
+func up(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("up", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "print the migrations and their SQL without applying them")
+	_ = fs.Parse(args)
+
+	n, err := countArg(fs, 0)
+	if err != nil {
+		return err
+	}
+	migrator, err := newMigrator(*dryRun)
+	if err != nil {
+		return err
+	}
+	steps, err := migrator.Up(ctx, n)
+	report(steps, *dryRun)
+	return err
+}
+
+func down(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("down", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "print the migrations and their SQL without rolling them back")
+	_ = fs.Parse(args)
+
+	n, err := countArg(fs, 1)
+	if err != nil {
+		return err
+	}
+	migrator, err := newMigrator(*dryRun)
+	if err != nil {
+		return err
+	}
+	steps, err := migrator.Down(ctx, n)
+	report(steps, *dryRun)
+	return err
+}
+
+func redo(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("redo", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "print the migration and its SQL without running it")
+	_ = fs.Parse(args)
+
+	migrator, err := newMigrator(*dryRun)
 	if err != nil {
-		applogger.Fatalf("cannot load config: %v\n", err)
+		return err
 	}
+	steps, err := migrator.Redo(ctx)
+	report(steps, *dryRun)
+	return err
+}
+
+func status(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("status", flag.ExitOnError)
+	_ = fs.Parse(args)
 
-	db, err := postgres.NewConnection(postgres.Options{
-		DBName:   cfg.DB.Name,
-		DBUser:   cfg.DB.User,
-		Password: cfg.DB.Pass,
-		Host:     cfg.DB.Host,
-		Port:     strconv.Itoa(cfg.DB.Port),
-		SSLMode:  false,
-	})
+	migrator, err := newMigrator(false)
 	if err != nil {
-		applogger.Fatalf("cannot connecting to db: %v\n", err)
+		return err
 	}
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, s := range statuses {
+		switch {
+		case s.Unknown:
+			fmt.Printf("%-60s applied %s (no migration file)\n", s.ID, s.AppliedAt.Format(time.RFC3339))
+		case s.AppliedAt != nil:
+			fmt.Printf("%-60s applied %s\n", s.ID, s.AppliedAt.Format(time.RFC3339))
+		default:
+			pending++
+			fmt.Printf("%-60s pending\n", s.ID)
+		}
+	}
+	fmt.Printf("%d migrations, %d pending\n", len(statuses), pending)
+	return nil
+}
+
+// newMigration scaffolds an empty migration named after the current UTC time.
+func newMigration(args []string) error {
+	fs := flag.NewFlagSet("new", flag.ExitOnError)
+	_ = fs.Parse(args)
 
-	migrations := &migrate.FileMigrationSource{
-		Dir: "migrations",
+	name := strings.ToLower(strings.Join(fs.Args(), "_"))
+	name = strings.NewReplacer("-", "_", " ", "_").Replace(name)
+	if !migrationNameRegex.MatchString(name) {
+		return fmt.Errorf("migration name %q must only contain letters, digits and underscores", name)
 	}
-	sqlDB, err := db.DB()
+
+	path := filepath.Join(migrationsDir, time.Now().UTC().Format(migrationIDLayout)+"_"+name+".sql")
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
 	if err != nil {
-		applogger.Fatalf("cannot get underlying sql.DB: %v\n", err)
+		return err
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString("-- +migrate Up\n\n-- +migrate Down\n"); err != nil {
+		return err
 	}
+	fmt.Println("created", path)
+	return nil
+}
 
-	total, err := migrate.Exec(sqlDB, "postgres", migrations, migrate.Up)
+func newMigrator(dryRun bool) (*postgres.Migrator, error) {
+	// only the DB settings are used, the rest of the config is not validated
+	cfg, err := config.Load()
 	if err != nil {
-		applogger.Fatalf("cannot execute migration: %v\n", err)
+		return nil, err
 	}
+	db, err := postgres.NewConnection(postgres.ParseFromConfig(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to db: %w", err)
+	}
+	return postgres.NewMigrator(db, migrationsDir, dryRun)
+}
 
-	applogger.Infof("applied %d migrations\n", total)
+// countArg parses the optional number of migrations following the flags.
+func countArg(fs *flag.FlagSet, fallback int) (int, error) {
+	if fs.NArg() == 0 {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(fs.Arg(0))
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid number of migrations %q", fs.Arg(0))
+	}
+	return n, nil
+}
+
+// report prints the steps run, or with their SQL the steps planned.
+func report(steps []postgres.MigrationStep, dryRun bool) {
+	for _, s := range steps {
+		direction := "up"
+		if s.Direction == migrate.Down {
+			direction = "down"
+		}
+		if !dryRun {
+			fmt.Printf("%s %s\n", direction, s.ID)
+			continue
+		}
+		fmt.Printf("-- would run %s %s\n", direction, s.ID)
+		for _, q := range s.Queries {
+			fmt.Println(strings.TrimSpace(q))
+		}
+	}
+	verb := "applied"
+	if dryRun {
+		verb = "would apply"
+	}
+	fmt.Printf("%s %d migrations\n", verb, len(steps))
 }
@@ -0,0 +1,155 @@
+// Command worker runs the background jobs of the service: once at start and
+// then every WORKER_INTERVAL, it accrues the interest of flexible savings and
+// matures the fixed savings reaching their maturity date. Both only run on
+// business days of the calendar; the days the bank is closed are caught up on
+// the next one.
+//
+// It serves its Prometheus metrics on /metrics and health probes on /healthz
+// at WORKER_PORT: /healthz/ready is down when the database is unreachable or
+// no run completed for two intervals.
+//
+// Run a single instance: runs are idempotent within a day, not safe to race.
+package main
+
+import (
+	"context"
+	"log"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"e-wallet/internal/adapters/clock"
+	"e-wallet/internal/adapters/holidays"
+	"e-wallet/internal/adapters/metrics"
+	"e-wallet/internal/adapters/repository/postgres"
+	interestapp "e-wallet/internal/application/interest"
+	"e-wallet/internal/config"
+	"e-wallet/internal/domain/account"
+	"e-wallet/internal/health"
+	"e-wallet/internal/ports"
+	"e-wallet/pkg/logger"
+	"e-wallet/pkg/tracing"
+
+	"go.uber.org/zap"
+)
+
+func main() {
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+	applog, err := logger.NewAppLogger()
+	if err != nil {
+		log.Fatalf("cannot load config: %v\n", err)
+	}
+	defer logger.Sync(applog)
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		applog.Fatal(err)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		ServiceName:  cfg.Tracing.ServiceName + "-worker",
+		Environment:  cfg.AppEnv,
+		Exporter:     cfg.Tracing.Exporter,
+		OTLPEndpoint: cfg.Tracing.OTLPEndpoint,
+		OTLPInsecure: cfg.Tracing.OTLPInsecure,
+		SampleRatio:  cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		applog.Fatalf("cannot init tracing: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			applog.Errorw("cannot flush traces", "error", err)
+		}
+	}()
+
+	businessDays, err := holidays.NewCalendar(cfg.Calendar.Convention, cfg.Calendar.HolidayFiles...)
+	if err != nil {
+		applog.Fatalf("cannot load business calendar: %v", err)
+	}
+
+	dbOpts := postgres.ParseFromConfig(cfg)
+	dbOpts.Logger = applog
+	db, err := postgres.NewConnection(dbOpts)
+	if err != nil {
+		applog.Fatal(err)
+	}
+	defer func() {
+		if err := postgres.Close(db); err != nil {
+			applog.Errorw("cannot close database", "error", err)
+		}
+	}()
+	promMetrics := metrics.NewPrometheus()
+	if err := db.Use(promMetrics.GormPlugin()); err != nil {
+		applog.Fatalf("cannot register database metrics: %v", err)
+	}
+	if err := db.Use(postgres.NewTracingPlugin()); err != nil {
+		applog.Fatalf("cannot register database tracing: %v", err)
+	}
+
+	accountNumbers, err := account.NewNumberGenerator(cfg.AccountNumberPrefix)
+	if err != nil {
+		applog.Fatalf("cannot create account number generator: %v", err)
+	}
+	interestService := interestapp.WithTracing(interestapp.NewInterestService(
+		postgres.NewAccountRepository(db, accountNumbers),
+		postgres.NewSavingsAccountDetailRepository(db),
+		postgres.NewTransactionRepository(db),
+		postgres.NewInterestHistoryRepository(db),
+		postgres.NewTxManager(db),
+		promMetrics,
+		businessDays,
+	))
+
+	heartbeat := health.NewHeartbeat("worker", 2*cfg.Worker.Interval, clock.System)
+	registry := health.NewRegistry()
+	registry.Register(postgres.NewHealthChecker(db), cfg.HealthCheckTimeout)
+	registry.Register(heartbeat, cfg.HealthCheckTimeout)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	probesDone := make(chan struct{})
+	go func() {
+		defer close(probesDone)
+		serveProbes(ctx, applog, probes(cfg, promMetrics, registry), cfg.HTTP.ShutdownTimeout)
+	}()
+
+	applog.Infow("worker started", "interval", cfg.Worker.Interval, "port", cfg.Worker.Port)
+	ticker := time.NewTicker(cfg.Worker.Interval)
+	defer ticker.Stop()
+	for {
+		accrueInterest(ctx, applog, interestService, clock.System)
+		matureFixedSavings(ctx, applog, interestService, clock.System)
+		heartbeat.Beat()
+		select {
+		case <-ctx.Done():
+			<-probesDone
+			applog.Infow("worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func accrueInterest(ctx context.Context, log *zap.SugaredLogger, svc ports.InterestService, clock ports.Clock) {
+	asOf := clock.Now()
+	credited, err := svc.AccrueFlexibleInterest(ctx, asOf)
+	if err != nil {
+		log.Errorw("cannot accrue interest of every account", "as_of", asOf.Format(time.DateOnly), "credited", credited, "error", err)
+		return
+	}
+	log.Infow("interest accrued", "as_of", asOf.Format(time.DateOnly), "credited", credited)
+}
+
+func matureFixedSavings(ctx context.Context, log *zap.SugaredLogger, svc ports.InterestService, clock ports.Clock) {
+	asOf := clock.Now()
+	matured, err := svc.MatureFixedSavings(ctx, asOf)
+	if err != nil {
+		log.Errorw("cannot mature every fixed savings account", "as_of", asOf.Format(time.DateOnly), "matured", matured, "error", err)
+		return
+	}
+	log.Infow("fixed savings matured", "as_of", asOf.Format(time.DateOnly), "matured", matured)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"e-wallet/internal/adapters/metrics"
+	"e-wallet/internal/config"
+	"e-wallet/internal/health"
+
+	"go.uber.org/zap"
+)
+
+// probes serves the Prometheus metrics and the health probes of the worker,
+// the same paths as the API: /healthz/live answers while the process runs,
+// /healthz/ready runs the checks of registry, the heartbeat of the job loop
+// among them.
+func probes(cfg *config.Config, m *metrics.Prometheus, registry *health.Registry) *http.Server {
+	live := func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": string(health.StatusUp)})
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", m.Handler())
+	mux.HandleFunc("GET /healthz", live)
+	mux.HandleFunc("GET /healthz/live", live)
+	mux.HandleFunc("GET /healthz/ready", func(w http.ResponseWriter, r *http.Request) {
+		report := registry.Check(r.Context())
+		status := http.StatusOK
+		if report.Status != health.StatusUp {
+			status = http.StatusServiceUnavailable
+		}
+		// the detailed report is for operators only, as on the API
+		token := cfg.HealthReportToken
+		given := r.Header.Get("X-Health-Token")
+		if token == "" || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			writeJSON(w, status, map[string]string{"status": string(report.Status)})
+			return
+		}
+		writeJSON(w, status, report)
+	})
+
+	return &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.Worker.Port),
+		Handler:           mux,
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		ReadTimeout:       cfg.HTTP.ReadTimeout,
+		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
+	}
+}
+
+// serveProbes runs srv until ctx is done.
+func serveProbes(ctx context.Context, log *zap.SugaredLogger, srv *http.Server, shutdownTimeout time.Duration) {
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorw("cannot serve probes", "addr", srv.Addr, "error", err)
+		}
+	}()
+
+	<-ctx.Done()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Errorw("cannot stop serving probes", "error", err)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
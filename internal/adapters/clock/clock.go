@@ -0,0 +1,52 @@
+// Package clock provides the clocks behind ports.Clock.
+package clock
+
+import (
+	"sync"
+	"time"
+
+	"e-wallet/internal/ports"
+)
+
+type system struct{}
+
+// System is the wall clock.
+var System ports.Clock = system{}
+
+func (system) Now() time.Time { return time.Now() }
+
+// Fake is a clock that only moves when told to, for tests.
+type Fake struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Set moves the clock to now, which may be in the past.
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = now
+}
+
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+// AdvanceDate moves the clock as time.Time.AddDate does, e.g. by a month.
+func (f *Fake) AdvanceDate(years, months, days int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.AddDate(years, months, days)
+}
@@ -1,7 +1,10 @@
 package http
 
 import (
+	"context"
+
 	"e-wallet/internal/adapters/handler/http/dto"
+	"e-wallet/internal/domain/audit"
 	"e-wallet/internal/domain/account"
 
 	"github.com/labstack/echo/v4"
@@ -14,28 +17,31 @@ import (
 //	@Tags			accounts
 //	@Accept			json
 //	@Produce		json
-//	@Success		201		{object}	dto.AccountResponse
-//	@Failure		400		{object}	dto.Response
-//	@Failure		401		{object}	dto.Response
-//	@Failure		500		{object}	dto.Response
+//	@Success		201		{object}	dto.Response{data=dto.AccountResponse}
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		404		{object}	dto.ProblemDetails
+//	@Failure		422		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
 //	@Router			/api/accounts/payment [post]
 //	@Security		BearerAuth
 func (s *Server) CreatePaymentAccount(c echo.Context) error {
 	userID := c.Get(UserIDKey).(string)
 	if userID == "" {
-		return s.handleError(c, dto.UnauthorizedResponse)
+		return s.handleError(c, errUnauthorized)
 	}
 
 	acc, err := s.AccountService.CreatePaymentAccount(c.Request().Context(), userID)
 	if err != nil {
-		s.Logger.Error(err)
-		return s.handleError(c, dto.InternalErrorResponse)
+		return s.handleError(c, err)
 	}
 
+	s.recordAccountCreated(c, acc)
+
 	resp := dto.NewAccountResponse(acc)
 	return c.JSON(201, dto.Response{
 		Status:  201,
-		Message: "Payment account created successfully",
+		Message: s.translate(c, "response.payment_account_created"),
 		Data:    resp,
 	})
 }
@@ -48,43 +54,45 @@ func (s *Server) CreatePaymentAccount(c echo.Context) error {
 //	@Accept			json
 //	@Produce		json
 //	@Param			request	body		dto.CreateFixedSavingsAccountRequest	true	"Fixed savings account creation data"
-//	@Success		201		{object}	dto.AccountResponse
-//	@Failure		400		{object}	dto.Response
-//	@Failure		401		{object}	dto.Response
-//	@Failure		500		{object}	dto.Response
+//	@Success		201		{object}	dto.Response{data=dto.AccountResponse}
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		404		{object}	dto.ProblemDetails
+//	@Failure		422		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
 //	@Router			/api/accounts/savings/fixed [post]
 //	@Security		BearerAuth
 func (s *Server) CreateFixedSavingsAccount(c echo.Context) error {
 	userID := c.Get(UserIDKey).(string)
 	if userID == "" {
-		return s.handleError(c, dto.UnauthorizedResponse)
+		return s.handleError(c, errUnauthorized)
 	}
 
 	var req dto.CreateFixedSavingsAccountRequest
 	if err := c.Bind(&req); err != nil {
-		s.Logger.Error(err)
-		return s.handleError(c, dto.BadRequestResponse)
+		return s.handleError(c, errInvalidRequestBody.Wrap(err))
 	}
 
 	if err := c.Validate(&req); err != nil {
-		s.Logger.Error(err)
-		return s.handleError(c, dto.BadRequestResponse)
+		return s.handleError(c, validationError(err))
 	}
 
 	domainReq := &account.CreateFixedSavingsAccountRequest{
-		TermCode: req.TermCode,
+		TermCode:            req.TermCode,
+		MaturityInstruction: req.MaturityInstruction,
 	}
 
 	acc, err := s.AccountService.CreateFixedSavingsAccount(c.Request().Context(), userID, domainReq)
 	if err != nil {
-		s.Logger.Error(err)
-		return s.handleError(c, dto.InternalErrorResponse)
+		return s.handleError(c, err)
 	}
 
+	s.recordAccountCreated(c, acc)
+
 	resp := dto.NewAccountResponse(acc)
 	return c.JSON(201, dto.Response{
 		Status:  201,
-		Message: "Fixed savings account created successfully",
+		Message: s.translate(c, "response.fixed_savings_account_created"),
 		Data:    resp,
 	})
 }
@@ -96,28 +104,31 @@ func (s *Server) CreateFixedSavingsAccount(c echo.Context) error {
 //	@Tags			accounts
 //	@Accept			json
 //	@Produce		json
-//	@Success		201		{object}	dto.AccountResponse
-//	@Failure		400		{object}	dto.Response
-//	@Failure		401		{object}	dto.Response
-//	@Failure		500		{object}	dto.Response
+//	@Success		201		{object}	dto.Response{data=dto.AccountResponse}
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		404		{object}	dto.ProblemDetails
+//	@Failure		422		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
 //	@Router			/api/accounts/savings/flexible [post]
 //	@Security		BearerAuth
 func (s *Server) CreateFlexibleSavingsAccount(c echo.Context) error {
 	userID := c.Get(UserIDKey).(string)
 	if userID == "" {
-		return s.handleError(c, dto.UnauthorizedResponse)
+		return s.handleError(c, errUnauthorized)
 	}
 
 	acc, err := s.AccountService.CreateFlexibleSavingsAccount(c.Request().Context(), userID)
 	if err != nil {
-		s.Logger.Error(err)
-		return s.handleError(c, dto.InternalErrorResponse)
+		return s.handleError(c, err)
 	}
 
+	s.recordAccountCreated(c, acc)
+
 	resp := dto.NewAccountResponse(acc)
 	return c.JSON(201, dto.Response{
 		Status:  201,
-		Message: "Flexible savings account created successfully",
+		Message: s.translate(c, "response.flexible_savings_account_created"),
 		Data:    resp,
 	})
 }
@@ -125,30 +136,43 @@ func (s *Server) CreateFlexibleSavingsAccount(c echo.Context) error {
 // ListAccounts godoc
 //
 //	@Summary		List user accounts
-//	@Description	Get all accounts (payment and savings) for the authenticated user
+//	@Description	Get all accounts (payment and savings) for the authenticated user, or the one with the given account number
 //	@Tags			accounts
 //	@Accept			json
 //	@Produce		json
-//	@Success		200		{object}	dto.ListAccountsResponse
-//	@Failure		401		{object}	dto.Response
-//	@Failure		500		{object}	dto.Response
+//	@Param			account_number	query		string	false	"Only the account with this number"
+//	@Success		200		{object}	dto.Response{data=dto.ListAccountsResponse}
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
 //	@Router			/api/accounts [get]
 //	@Security		BearerAuth
 func (s *Server) ListAccounts(c echo.Context) error {
 	userID := c.Get(UserIDKey).(string)
 	if userID == "" {
-		return s.handleError(c, dto.UnauthorizedResponse)
+		return s.handleError(c, errUnauthorized)
+	}
+
+	var req dto.ListAccountsRequest
+	if err := c.Bind(&req); err != nil {
+		return s.handleError(c, errInvalidRequestBody.Wrap(err))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return s.handleError(c, validationError(err))
 	}
 
 	listResp, err := s.AccountService.ListAccounts(c.Request().Context(), userID)
 	if err != nil {
-		s.Logger.Error(err)
-		return s.handleError(c, dto.InternalErrorResponse)
+		return s.handleError(c, err)
 	}
 
 	// Convert domain response to DTO
-	var accounts []dto.AccountWithDetailsResponse
+	accounts := make([]dto.AccountWithDetailsResponse, 0, len(listResp.Accounts))
 	for _, acc := range listResp.Accounts {
+		if req.AccountNumber != "" && acc.AccountNumber != req.AccountNumber {
+			continue
+		}
 		dtoAcc := dto.AccountWithDetailsResponse{
 			AccountResponse: dto.AccountResponse{
 				ID:            acc.ID,
@@ -170,6 +194,7 @@ func (s *Server) ListAccounts(c echo.Context) error {
 				StartDate:             acc.SavingsDetail.StartDate,
 				MaturityDate:          acc.SavingsDetail.MaturityDate,
 				LastInterestCalcDate:  acc.SavingsDetail.LastInterestCalcDate,
+				MaturityInstruction:   acc.SavingsDetail.MaturityInstruction,
 			}
 		}
 		accounts = append(accounts, dtoAcc)
@@ -177,4 +202,155 @@ func (s *Server) ListAccounts(c echo.Context) error {
 
 	resp := dto.ListAccountsResponse{Accounts: accounts}
 	return s.handleSuccess(c, resp)
-}
\ No newline at end of file
+}
+
+// UpdateMaturityInstruction godoc
+//
+//	@Summary		Update maturity instruction
+//	@Description	Choose what is done with a fixed savings account when its term matures: pay everything out to the payment account, roll over the principal and pay out the interest, or roll over both. It can be changed until the day before the maturity date.
+//	@Tags			accounts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string									true	"Account ID"
+//	@Param			request	body		dto.UpdateMaturityInstructionRequest	true	"Maturity instruction"
+//	@Success		200		{object}	dto.Response{data=dto.SavingsAccountDetailResponse}
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		404		{object}	dto.ProblemDetails
+//	@Failure		422		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/api/accounts/savings/fixed/{id}/maturity-instruction [put]
+//	@Security		BearerAuth
+func (s *Server) UpdateMaturityInstruction(c echo.Context) error {
+	userID := c.Get(UserIDKey).(string)
+	if userID == "" {
+		return s.handleError(c, errUnauthorized)
+	}
+
+	var req dto.UpdateMaturityInstructionRequest
+	if err := c.Bind(&req); err != nil {
+		return s.handleError(c, errInvalidRequestBody.Wrap(err))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return s.handleError(c, validationError(err))
+	}
+
+	accountID := c.Param("id")
+	detail, err := s.AccountService.UpdateMaturityInstruction(c.Request().Context(), userID, accountID, req.MaturityInstruction)
+	if err != nil {
+		return s.handleError(c, err)
+	}
+
+	s.recordAudit(c, &audit.Entry{
+		Action:     audit.ActionMaturityInstructionUpdated,
+		TargetType: audit.TargetAccount,
+		TargetID:   accountID,
+		Metadata: map[string]string{
+			"maturity_instruction": detail.MaturityInstruction,
+		},
+	})
+
+	resp := dto.NewSavingsAccountDetailResponse(detail)
+	return c.JSON(200, dto.Response{
+		Status:  200,
+		Message: s.translate(c, "response.maturity_instruction_updated"),
+		Data:    resp,
+	})
+}
+
+// DepositToFlexibleSavings godoc
+//
+//	@Summary		Deposit to flexible savings
+//	@Description	Move money from the payment account of the authenticated user to one of their flexible savings accounts. The deposit earns interest from the end of the day.
+//	@Tags			accounts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string								true	"Account ID"
+//	@Param			request	body		dto.FlexibleSavingsTransferRequest	true	"Amount to deposit, at least 10000"
+//	@Success		200		{object}	dto.Response{data=dto.AccountResponse}
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		404		{object}	dto.ProblemDetails
+//	@Failure		422		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/api/accounts/savings/flexible/{id}/deposit [post]
+//	@Security		BearerAuth
+func (s *Server) DepositToFlexibleSavings(c echo.Context) error {
+	return s.moveFlexibleSavings(c, account.TransactionTypeDeposit, s.AccountService.DepositToFlexibleSavings, "response.flexible_savings_deposited")
+}
+
+// WithdrawFromFlexibleSavings godoc
+//
+//	@Summary		Withdraw from flexible savings
+//	@Description	Move money from one of the flexible savings accounts of the authenticated user to their payment account. The amount withdrawn stops earning interest on the day.
+//	@Tags			accounts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string								true	"Account ID"
+//	@Param			request	body		dto.FlexibleSavingsTransferRequest	true	"Amount to withdraw, at least 10000"
+//	@Success		200		{object}	dto.Response{data=dto.AccountResponse}
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		404		{object}	dto.ProblemDetails
+//	@Failure		422		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/api/accounts/savings/flexible/{id}/withdraw [post]
+//	@Security		BearerAuth
+func (s *Server) WithdrawFromFlexibleSavings(c echo.Context) error {
+	return s.moveFlexibleSavings(c, account.TransactionTypeWithdrawal, s.AccountService.WithdrawFromFlexibleSavings, "response.flexible_savings_withdrawn")
+}
+
+// moveFlexibleSavings handles a deposit to or a withdrawal from flexible
+// savings with move, recording it in the audit log as a transfer of
+// transactionType.
+func (s *Server) moveFlexibleSavings(c echo.Context, transactionType string, move func(ctx context.Context, userID, accountID string, amount float64) (*account.Account, error), message string) error {
+	userID := c.Get(UserIDKey).(string)
+	if userID == "" {
+		return s.handleError(c, errUnauthorized)
+	}
+
+	var req dto.FlexibleSavingsTransferRequest
+	if err := c.Bind(&req); err != nil {
+		return s.handleError(c, errInvalidRequestBody.Wrap(err))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return s.handleError(c, validationError(err))
+	}
+
+	accountID := c.Param("id")
+	acc, err := move(c.Request().Context(), userID, accountID, req.Amount)
+	if err != nil {
+		return s.handleError(c, err)
+	}
+
+	s.recordAudit(c, &audit.Entry{
+		Action:     audit.ActionTransfer,
+		TargetType: audit.TargetAccount,
+		TargetID:   accountID,
+		Metadata: map[string]string{
+			"transaction_type": transactionType,
+			"amount":           account.AmountFromFloat(req.Amount).String(),
+		},
+	})
+
+	resp := dto.NewAccountResponse(acc)
+	return c.JSON(200, dto.Response{
+		Status:  200,
+		Message: s.translate(c, message),
+		Data:    resp,
+	})
+}
+
+func (s *Server) recordAccountCreated(c echo.Context, acc *account.Account) {
+	s.recordAudit(c, &audit.Entry{
+		Action:     audit.ActionAccountCreated,
+		TargetType: audit.TargetAccount,
+		TargetID:   acc.ID,
+		Metadata: map[string]string{
+			"account_type":   acc.AccountType,
+			"account_number": acc.AccountNumber,
+		},
+	})
+}
@@ -0,0 +1,32 @@
+package http
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RequireAdmin only lets through users listed in Config.AdminUserIDs.
+func (s *Server) RequireAdmin() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userID, _ := c.Get(UserIDKey).(string)
+			if userID == "" {
+				return s.handleError(c, errUnauthorized)
+			}
+			if !s.isAdmin(userID) {
+				return s.handleError(c, errForbidden)
+			}
+			return next(c)
+		}
+	}
+}
+
+func (s *Server) isAdmin(userID string) bool {
+	for _, id := range strings.Split(s.Config.AdminUserIDs, ",") {
+		if strings.TrimSpace(id) == userID {
+			return true
+		}
+	}
+	return false
+}
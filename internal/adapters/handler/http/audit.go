@@ -0,0 +1,33 @@
+package http
+
+import (
+	"e-wallet/internal/domain/audit"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// recordAudit writes an audit entry for the current request. Failing to
+// write the audit log never fails the request itself.
+func (s *Server) recordAudit(c echo.Context, entry *audit.Entry) {
+	if s.AuditLogger == nil {
+		return
+	}
+
+	entry.IP = c.RealIP()
+	entry.RequestID = s.requestID(c)
+	entry.UserAgent = c.Request().UserAgent()
+	if entry.ActorID == "" {
+		if userID, ok := c.Get(UserIDKey).(string); ok {
+			entry.ActorID = userID
+		}
+	}
+
+	if err := s.AuditLogger.Log(c.Request().Context(), entry); err != nil {
+		s.log(c).Errorw(
+			"cannot write audit log",
+			zap.String("action", string(entry.Action)),
+			zap.Error(err),
+		)
+	}
+}
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"strconv"
+
+	"e-wallet/internal/adapters/handler/http/dto"
+	"e-wallet/internal/domain/audit"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ListAuditLogs godoc
+//
+//	@Summary		List audit logs
+//	@Description	Search the audit trail of security and money events (admin only)
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			actor_id	query		string	false	"Filter by actor user ID"
+//	@Param			target_type	query		string	false	"Filter by target type"	Enums(USER, PROFILE, ACCOUNT, AUDIT_LOG)
+//	@Param			target_id	query		string	false	"Filter by target ID"
+//	@Param			action		query		string	false	"Filter by action"
+//	@Param			ip			query		string	false	"Filter by client IP"
+//	@Param			request_id	query		string	false	"Filter by request ID"
+//	@Param			from		query		string	false	"Created at or after (RFC3339)"
+//	@Param			to			query		string	false	"Created before (RFC3339)"
+//	@Param			limit		query		int		false	"Page size (max 500)"
+//	@Param			offset		query		int		false	"Page offset"
+//	@Success		200			{object}	dto.Response{data=dto.ListAuditLogsResponse}
+//	@Failure		400			{object}	dto.ProblemDetails
+//	@Failure		401			{object}	dto.ProblemDetails
+//	@Failure		403			{object}	dto.ProblemDetails
+//	@Failure		500			{object}	dto.ProblemDetails
+//	@Router			/api/admin/audit-logs [get]
+//	@Security		BearerAuth
+func (s *Server) ListAuditLogs(c echo.Context) error {
+	var req dto.ListAuditLogsRequest
+	if err := c.Bind(&req); err != nil {
+		return s.handleError(c, errInvalidRequestBody.Wrap(err))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return s.handleError(c, validationError(err))
+	}
+
+	result, err := s.AuditLogger.Query(c.Request().Context(), &audit.Filter{
+		ActorID:    req.ActorID,
+		TargetType: req.TargetType,
+		TargetID:   req.TargetID,
+		Action:     audit.Action(req.Action),
+		IP:         req.IP,
+		RequestID:  req.RequestID,
+		From:       req.From,
+		To:         req.To,
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+	})
+	if err != nil {
+		return s.handleError(c, err)
+	}
+
+	s.recordAudit(c, &audit.Entry{
+		Action:     audit.ActionAdminAuditQuery,
+		TargetType: audit.TargetAudit,
+		Metadata:   map[string]string{"query": c.QueryString()},
+	})
+
+	resp := dto.NewListAuditLogsResponse(result)
+	return s.handleSuccess(c, resp)
+}
+
+// VerifyAuditLogs godoc
+//
+//	@Summary		Verify audit log integrity
+//	@Description	Walk the hash chain of the audit trail and report the first tampered entry (admin only)
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	dto.Response{data=dto.VerifyAuditLogsResponse}
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		403	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
+//	@Router			/api/admin/audit-logs/verify [get]
+//	@Security		BearerAuth
+func (s *Server) VerifyAuditLogs(c echo.Context) error {
+	result, err := s.AuditLogger.Verify(c.Request().Context())
+	if err != nil {
+		return s.handleError(c, err)
+	}
+
+	s.recordAudit(c, &audit.Entry{
+		Action:     audit.ActionAdminAuditCheck,
+		TargetType: audit.TargetAudit,
+		Metadata:   map[string]string{"valid": strconv.FormatBool(result.Valid)},
+	})
+
+	resp := dto.NewVerifyAuditLogsResponse(result)
+	return s.handleSuccess(c, resp)
+}
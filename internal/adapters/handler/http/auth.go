@@ -1,14 +1,16 @@
 package http
 
 import (
+	"context"
 	"errors"
-	"os"
 	"strings"
-	"time"
+
+	"e-wallet/internal/domain/user"
+	"e-wallet/internal/ports"
+	"e-wallet/pkg/logger"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
-	"github.com/sirupsen/logrus"
 )
 
 const (
@@ -21,13 +23,21 @@ type Authentication struct {
 	SkipperPath []string
 	KeyLookup   string
 	AuthScheme  string
+	SecretKey   string
+	Clock       ports.Clock
+	// ActiveUser looks up the user of a valid token, failing with
+	// ErrUserNotFound once their data was deleted
+	ActiveUser func(ctx context.Context, userID string) (*user.User, error)
 }
 
-func NewAuthentication(keyLookup string, authScheme string, skipperPath []string) *Authentication {
+func NewAuthentication(keyLookup string, authScheme string, secretKey string, skipperPath []string, clock ports.Clock, activeUser func(ctx context.Context, userID string) (*user.User, error)) *Authentication {
 	return &Authentication{
 		SkipperPath: skipperPath,
 		KeyLookup:   keyLookup,
 		AuthScheme:  authScheme,
+		SecretKey:   secretKey,
+		Clock:       clock,
+		ActiveUser:  activeUser,
 	}
 }
 
@@ -35,29 +45,56 @@ func (a *Authentication) Middleware() echo.MiddlewareFunc {
 	skipper := func(c echo.Context) bool {
 		return ContainFirst(a.SkipperPath, c.Path())
 	}
-	return middleware.KeyAuthWithConfig(middleware.KeyAuthConfig{
+	keyAuth := middleware.KeyAuthWithConfig(middleware.KeyAuthConfig{
 		Skipper:    skipper,
 		KeyLookup:  a.KeyLookup,
 		AuthScheme: a.AuthScheme,
 		Validator:  a.ValidateAccessToken,
+		// a missing token is a 401 as well, not the 400 of key-auth
+		ErrorHandler: func(err error, c echo.Context) error {
+			return errUnauthorized.Wrap(err)
+		},
 	})
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return keyAuth(a.requireActiveUser(next))
+	}
+}
+
+// requireActiveUser rejects the tokens of users whose data was deleted: they
+// stop working with the deletion, not when they expire.
+func (a *Authentication) requireActiveUser(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, ok := c.Get(UserIDKey).(string)
+		if !ok || userID == "" {
+			return next(c)
+		}
+		if _, err := a.ActiveUser(c.Request().Context(), userID); err != nil {
+			if errors.Is(err, user.ErrUserNotFound) {
+				logger.FromContext(c.Request().Context(), logger.NOOPLogger).Infow("access token user no longer exists")
+				return errUnauthorized.Wrap(err)
+			}
+			return err
+		}
+		return next(c)
+	}
 }
 
 func (a *Authentication) ValidateAccessToken(token string, c echo.Context) (bool, error) {
+	log := logger.FromContext(c.Request().Context(), logger.NOOPLogger)
 
 	if token == "" {
 		return false, errors.New("")
 	}
 
-	claims, err := ValidateToken(token, os.Getenv("JWT_SECRET_KEY"))
+	now := a.Clock.Now()
+	claims, err := ValidateToken(token, a.SecretKey, now)
 	if err != nil {
 		return false, err
 	}
 
 	// check expired time
-	now := time.Now().Local().Unix()
-	if int64(claims["exp"].(float64)) < now {
-		logrus.Error("Token is expired - ValidateAccessToken")
+	if int64(claims["exp"].(float64)) < now.Unix() {
+		log.Infow("access token expired")
 
 		return false, errors.New("token expired")
 	}
@@ -65,19 +102,20 @@ func (a *Authentication) ValidateAccessToken(token string, c echo.Context) (bool
 	// get user_id
 	payload, err := DecodeToken(claims)
 	if err != nil {
-		logrus.Error(err)
+		log.Infow("cannot decode access token", "error", err)
 
 		return false, err
 	}
 
 	if payload.UserID == "" {
-		logrus.Error("Unauthorized")
+		log.Infow("access token has no user id")
 
 		return false, errors.New("")
 	}
 
 	c.Set(UserClaimKey, payload)
 	c.Set(UserIDKey, payload.UserID)
+	setLogUser(c, payload.UserID, log)
 
 	return true, nil
 }
@@ -8,7 +8,7 @@ import (
 type AccountResponse struct {
 	ID            string    `json:"id" example:"acc-123"`
 	UserID        string    `json:"user_id" example:"user-123"`
-	AccountNumber string    `json:"account_number" example:"1234567890"`
+	AccountNumber string    `json:"account_number" example:"971045182377"`
 	AccountType   string    `json:"account_type" example:"payment"`
 	Balance       float64   `json:"balance" example:"1000.50"`
 	Status        string    `json:"status" example:"active"`
@@ -37,6 +37,20 @@ type SavingsAccountDetailResponse struct {
 	StartDate             time.Time  `json:"start_date" example:"2023-10-01T00:00:00Z"`
 	MaturityDate          *time.Time `json:"maturity_date,omitempty" example:"2024-10-01T00:00:00Z"`
 	LastInterestCalcDate  *time.Time `json:"last_interest_calc_date,omitempty" example:"2023-10-01T00:00:00Z"`
+	MaturityInstruction   string     `json:"maturity_instruction,omitempty" example:"ROLLOVER_PRINCIPAL_AND_INTEREST"`
+}
+
+func NewSavingsAccountDetailResponse(detail *account.SavingsAccountDetail) *SavingsAccountDetailResponse {
+	return &SavingsAccountDetailResponse{
+		AccountID:            detail.AccountID,
+		IsFixedTerm:          detail.IsFixedTerm,
+		TermMonths:           detail.TermMonths,
+		AnnualInterestRate:   detail.AnnualInterestRate,
+		StartDate:            detail.StartDate,
+		MaturityDate:         detail.MaturityDate,
+		LastInterestCalcDate: detail.LastInterestCalcDate,
+		MaturityInstruction:  detail.MaturityInstruction,
+	}
 }
 
 type AccountWithDetailsResponse struct {
@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+type ListAuditLogsRequest struct {
+	ActorID    string     `query:"actor_id"`
+	TargetType string     `query:"target_type" validate:"omitempty,oneof=USER PROFILE ACCOUNT AUDIT_LOG"`
+	TargetID   string     `query:"target_id"`
+	Action     string     `query:"action"`
+	IP         string     `query:"ip" validate:"omitempty,ip"`
+	RequestID  string     `query:"request_id"`
+	From       *time.Time `query:"from"`
+	To         *time.Time `query:"to"`
+	Limit      int        `query:"limit" validate:"omitempty,min=1,max=500"`
+	Offset     int        `query:"offset" validate:"omitempty,min=0"`
+}
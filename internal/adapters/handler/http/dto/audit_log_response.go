@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"e-wallet/internal/domain/audit"
+	"time"
+)
+
+type AuditLogResponse struct {
+	ID         string            `json:"id" example:"0199f0b4-8c1e-7a52-9d1f-0a2b3c4d5e6f"`
+	Sequence   int64             `json:"sequence" example:"42"`
+	Action     string            `json:"action" example:"LOGIN"`
+	ActorID    string            `json:"actor_id" example:"user-123"`
+	TargetType string            `json:"target_type" example:"USER"`
+	TargetID   string            `json:"target_id" example:"user-123"`
+	IP         string            `json:"ip" example:"127.0.0.1"`
+	RequestID  string            `json:"request_id" example:"b1c2d3"`
+	UserAgent  string            `json:"user_agent" example:"Mozilla/5.0"`
+	Metadata   map[string]string `json:"metadata" extensions:"x-nullable"`
+	PrevHash   string            `json:"prev_hash"`
+	Hash       string            `json:"hash"`
+	CreatedAt  time.Time         `json:"created_at" example:"2023-10-01T00:00:00Z"`
+}
+
+type ListAuditLogsResponse struct {
+	AuditLogs []*AuditLogResponse `json:"audit_logs"`
+	Total     int64               `json:"total" example:"1"`
+}
+
+type VerifyAuditLogsResponse struct {
+	Valid         bool   `json:"valid" example:"true"`
+	CheckedCount  int64  `json:"checked_count" example:"1000"`
+	BrokenEntryID string `json:"broken_entry_id,omitempty"`
+}
+
+func NewAuditLogResponse(e *audit.Entry) *AuditLogResponse {
+	return &AuditLogResponse{
+		ID:         e.ID,
+		Sequence:   e.Sequence,
+		Action:     string(e.Action),
+		ActorID:    e.ActorID,
+		TargetType: e.TargetType,
+		TargetID:   e.TargetID,
+		IP:         e.IP,
+		RequestID:  e.RequestID,
+		UserAgent:  e.UserAgent,
+		Metadata:   e.Metadata,
+		PrevHash:   e.PrevHash,
+		Hash:       e.Hash,
+		CreatedAt:  e.CreatedAt,
+	}
+}
+
+func NewListAuditLogsResponse(result *audit.QueryResult) *ListAuditLogsResponse {
+	logs := make([]*AuditLogResponse, 0, len(result.Entries))
+	for _, e := range result.Entries {
+		logs = append(logs, NewAuditLogResponse(e))
+	}
+	return &ListAuditLogsResponse{AuditLogs: logs, Total: result.Total}
+}
+
+func NewVerifyAuditLogsResponse(result *audit.VerifyResult) *VerifyAuditLogsResponse {
+	return &VerifyAuditLogsResponse{
+		Valid:         result.Valid,
+		CheckedCount:  result.CheckedCount,
+		BrokenEntryID: result.BrokenEntryID,
+	}
+}
@@ -14,19 +14,4 @@ var (
 		Message: http.StatusText(http.StatusOK),
 		Data:    nil,
 	}
-	InternalErrorResponse = Response{
-		Status:  http.StatusInternalServerError,
-		Message: http.StatusText(http.StatusInternalServerError),
-		Data:    nil,
-	}
-	BadRequestResponse = Response{
-		Status:  http.StatusBadRequest,
-		Message: http.StatusText(http.StatusBadRequest),
-		Data:    nil,
-	}
-	UnauthorizedResponse = Response{
-		Status:  http.StatusUnauthorized,
-		Message: http.StatusText(http.StatusUnauthorized),
-		Data:    nil,
-	}
 )
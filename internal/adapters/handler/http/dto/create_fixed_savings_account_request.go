@@ -1,5 +1,6 @@
 package dto
 
 type CreateFixedSavingsAccountRequest struct {
-	TermCode string `json:"term_code" validate:"required,oneof=1 3 6 8 12" example:"12"`
+	TermCode            string `json:"term_code" validate:"required,oneof=1 3 6 8 12" example:"12"`
+	MaturityInstruction string `json:"maturity_instruction" validate:"omitempty,oneof=PAYOUT ROLLOVER_PRINCIPAL ROLLOVER_PRINCIPAL_AND_INTEREST" example:"ROLLOVER_PRINCIPAL_AND_INTEREST"`
 }
\ No newline at end of file
@@ -0,0 +1,24 @@
+package dto
+
+import (
+	"e-wallet/internal/domain/privacy"
+	"time"
+)
+
+type DataExportResponse struct {
+	ID          string     `json:"id" example:"0199f0b4-8c1e-7a52-9d1f-0a2b3c4d5e6f"`
+	Status      string     `json:"status" example:"PENDING"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at" example:"2023-10-01T00:00:00Z"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" example:"2023-10-01T00:01:00Z"`
+}
+
+func NewDataExportResponse(e *privacy.DataExport) *DataExportResponse {
+	return &DataExportResponse{
+		ID:          e.ID,
+		Status:      string(e.Status),
+		Error:       e.Error,
+		CreatedAt:   e.CreatedAt,
+		CompletedAt: e.CompletedAt,
+	}
+}
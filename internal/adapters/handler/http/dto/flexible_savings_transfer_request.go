@@ -0,0 +1,5 @@
+package dto
+
+type FlexibleSavingsTransferRequest struct {
+	Amount float64 `json:"amount" validate:"required,gt=0" example:"100000"`
+}
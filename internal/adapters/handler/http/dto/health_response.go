@@ -0,0 +1,6 @@
+package dto
+
+// HealthResponse is the body of the liveness and readiness probes.
+type HealthResponse struct {
+	Status string `json:"status" example:"up"`
+}
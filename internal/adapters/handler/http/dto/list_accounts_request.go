@@ -0,0 +1,5 @@
+package dto
+
+type ListAccountsRequest struct {
+	AccountNumber string `query:"account_number" validate:"omitempty,account_number" example:"971045182377"`
+}
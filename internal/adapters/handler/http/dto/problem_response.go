@@ -0,0 +1,47 @@
+package dto
+
+import "e-wallet/internal/domain/apperror"
+
+// MIMEApplicationProblemJSON is the media type of error responses (RFC 7807).
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// ProblemTypePrefix prefixes the error code to build the problem type URI.
+const ProblemTypePrefix = "urn:e-wallet:problem:"
+
+// ProblemDetails is the body of every error response, following RFC 7807.
+// Code, RequestID, TraceID and Errors are extension members.
+type ProblemDetails struct {
+	Type      string                `json:"type" example:"urn:e-wallet:problem:savings_account_limit_exceeded"`
+	Title     string                `json:"title" example:"Unprocessable Entity"`
+	Status    int                   `json:"status" example:"422"`
+	Detail    string                `json:"detail,omitempty" example:"user can have at most 5 savings accounts"`
+	Instance  string                `json:"instance,omitempty" example:"/api/accounts/savings/flexible"`
+	Code      string                `json:"code" example:"savings_account_limit_exceeded"`
+	RequestID string                `json:"request_id,omitempty" example:"b1c2d3"`
+	TraceID   string                `json:"trace_id,omitempty" example:"4bf92f3577b34da6a3ce929d0e0e4736"`
+	Errors    []*FieldErrorResponse `json:"errors,omitempty"`
+}
+
+type FieldErrorResponse struct {
+	Field   string `json:"field" example:"term_code"`
+	Code    string `json:"code" example:"oneof"`
+	Message string `json:"message" example:"invalid term code"`
+}
+
+func NewProblemDetails(status int, title string, e *apperror.Error) *ProblemDetails {
+	p := &ProblemDetails{
+		Type:   ProblemTypePrefix + e.Code,
+		Title:  title,
+		Status: status,
+		Detail: e.Message,
+		Code:   e.Code,
+	}
+	for _, f := range e.Fields {
+		p.Errors = append(p.Errors, &FieldErrorResponse{
+			Field:   f.Field,
+			Code:    f.Code,
+			Message: f.Message,
+		})
+	}
+	return p
+}
@@ -0,0 +1,5 @@
+package dto
+
+type UpdateMaturityInstructionRequest struct {
+	MaturityInstruction string `json:"maturity_instruction" validate:"required,oneof=PAYOUT ROLLOVER_PRINCIPAL ROLLOVER_PRINCIPAL_AND_INTEREST" example:"PAYOUT"`
+}
@@ -14,7 +14,7 @@ type UserResponse struct {
 }
 
 type CreateUserResponse struct {
-	User  *UserResponse `json:"user"`
+	User  *UserResponse `json:"user" extensions:"x-nullable"`
 	Token string        `json:"token"`
 }
 
@@ -1,9 +1,12 @@
 package dto
 
 import (
+	"reflect"
 	"regexp"
 	"strings"
 
+	"e-wallet/internal/domain/account"
+
 	"github.com/go-playground/validator/v10"
 )
 
@@ -24,10 +27,11 @@ func ValidatePassword(fl validator.FieldLevel) bool {
 	return hasLower && hasUpper && hasDigit && hasSpecial
 }
 
+var ValidTeams = []string{"FRONT_END", "BACK_END", "QA", "ADMIN", "BRSE", "DESIGN", "OTHERS"}
+
 func ValidateTeam(fl validator.FieldLevel) bool {
 	team := fl.Field().String()
-	validTeams := []string{"FRONT_END", "BACK_END", "QA", "ADMIN", "BRSE", "DESIGN", "OTHERS"}
-	for _, validTeam := range validTeams {
+	for _, validTeam := range ValidTeams {
 		if team == validTeam {
 			return true
 		}
@@ -41,8 +45,31 @@ func ValidatePhone(fl validator.FieldLevel) bool {
 	return phoneRegex.MatchString(phone)
 }
 
+// ValidateAccountNumber rejects account numbers with a wrong length, product
+// segment or check digit, so that a mistyped number fails before any lookup.
+func ValidateAccountNumber(fl validator.FieldLevel) bool {
+	return account.ValidateNumber(fl.Field().String()) == nil
+}
+
+// FieldName reports fields by their json (or query) name, so validation
+// errors refer to the names clients actually send.
+func FieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "query", "param"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
 func RegisterCustomValidations(v *validator.Validate) {
+	v.RegisterTagNameFunc(FieldName)
 	v.RegisterValidation("password", ValidatePassword)
 	v.RegisterValidation("team", ValidateTeam)
 	v.RegisterValidation("phone", ValidatePhone)
+	v.RegisterValidation("account_number", ValidateAccountNumber)
 }
\ No newline at end of file
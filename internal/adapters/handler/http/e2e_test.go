@@ -0,0 +1,541 @@
+package http_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"e-wallet/internal/adapters/clock"
+	httpserver "e-wallet/internal/adapters/handler/http"
+	"e-wallet/internal/adapters/handler/http/dto"
+	"e-wallet/internal/adapters/metrics"
+	"e-wallet/internal/adapters/repository/memory"
+	"e-wallet/internal/adapters/service"
+	"e-wallet/internal/adapters/storage"
+	accountapp "e-wallet/internal/application/account"
+	privacyapp "e-wallet/internal/application/privacy"
+	profileapp "e-wallet/internal/application/profile"
+	userapp "e-wallet/internal/application/user"
+	"e-wallet/internal/config"
+	"e-wallet/internal/domain/account"
+	"e-wallet/internal/domain/calendar"
+	"e-wallet/internal/ports"
+)
+
+// The end-to-end tests drive the API over HTTP as a client would. The server
+// and services are wired as in cmd/api, on the in-memory repositories, and
+// every response is checked against the Swagger document.
+
+type e2e struct {
+	cfg   *config.Config
+	clock *clock.Fake
+	url   string
+	spec  *apiSpec
+	// accounts funds the PAYMENT accounts, which no endpoint tops up
+	accounts ports.AccountRepository
+}
+
+func newE2E(t *testing.T) *e2e {
+	t.Helper()
+
+	cfg := &config.Config{
+		AppEnv:          "local",
+		JWTSecret:       "e2e-secret",
+		ExportDir:       t.TempDir(),
+		DefaultLanguage: "en",
+	}
+	now := clock.NewFake(time.Date(2025, 1, 15, 9, 30, 0, 0, time.UTC))
+	server, err := httpserver.New(httpserver.WithConfig(cfg), httpserver.WithClock(now))
+	require.NoError(t, err)
+
+	store := memory.NewStore()
+	txManager := memory.NewTxManager(store)
+	userRepo := memory.NewUserRepository(store)
+	profileRepo := memory.NewProfileRepository(store)
+	accountNumbers, err := account.NewNumberGenerator("97")
+	require.NoError(t, err)
+	accountRepo := memory.NewAccountRepository(store, accountNumbers)
+	savingsRepo := memory.NewSavingsAccountDetailRepository(store)
+	transactionRepo := memory.NewTransactionRepository(store)
+
+	server.UserService = userapp.WithTracing(userapp.NewUserService(userRepo, service.NewPasswordService()))
+	server.ProfileService = profileapp.WithTracing(profileapp.NewProfileService(userRepo, profileRepo, now))
+	server.AccountService = accountapp.WithTracing(accountapp.NewAccountService(userRepo, accountRepo, savingsRepo, transactionRepo, txManager, metrics.Noop, now, calendar.New(calendar.ModifiedFollowing)))
+	server.PrivacyService = privacyapp.WithTracing(privacyapp.NewPrivacyService(
+		userRepo,
+		profileRepo,
+		accountRepo,
+		savingsRepo,
+		transactionRepo,
+		memory.NewInterestHistoryRepository(store),
+		memory.NewDataExportRepository(store),
+		storage.NewLocalExportStorage(cfg.ExportDir),
+		txManager,
+		now,
+	))
+	server.AuditLogger = memory.NewAuditLogger(store)
+	server.Health.Register(storage.NewLocalStorageHealthChecker(cfg.ExportDir), time.Second)
+
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+
+	return &e2e{cfg: cfg, clock: now, url: ts.URL, spec: loadAPISpec(t, ts.URL), accounts: accountRepo}
+}
+
+type apiResponse struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+// call sends body as JSON to route, the path as documented with its
+// {parameters} replaced by params in order and an optional query, and
+// validates the response.
+func (e *e2e) call(t *testing.T, method, route, token string, body any, params ...string) apiResponse {
+	t.Helper()
+
+	path := route
+	for _, p := range params {
+		start, end := strings.Index(path, "{"), strings.Index(path, "}")
+		require.True(t, start >= 0 && end > start, "%s has fewer parameters than given", route)
+		path = path[:start] + p + path[end+1:]
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		require.NoError(t, err)
+		reqBody = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, e.url+path, reqBody)
+	require.NoError(t, err)
+	req.Header.Set("Accept-Language", "en")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	resp := apiResponse{Status: res.StatusCode, Header: res.Header, Body: b}
+	documented, _, _ := strings.Cut(route, "?")
+	e.spec.validate(t, method, documented, resp)
+	return resp
+}
+
+// data decodes the data of a successful response.
+func data[T any](t *testing.T, res apiResponse) T {
+	t.Helper()
+	var body struct {
+		Data T `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(res.Body, &body), "%s", res.Body)
+	return body.Data
+}
+
+// problem decodes an error response.
+func problem(t *testing.T, res apiResponse) dto.ProblemDetails {
+	t.Helper()
+	assert.Equal(t, dto.MIMEApplicationProblemJSON, res.Header.Get("Content-Type"))
+	var p dto.ProblemDetails
+	require.NoError(t, json.Unmarshal(res.Body, &p), "%s", res.Body)
+	return p
+}
+
+// password is the password of every user signed up by the tests.
+const password = "Secret12345@!"
+
+// signUp registers a user and logs them in.
+func (e *e2e) signUp(t *testing.T, email string) (userID, token string) {
+	t.Helper()
+
+	res := e.call(t, http.MethodPost, "/api/auth/register", "", dto.CreateUserRequest{
+		Username: strings.Split(email, "@")[0],
+		Email:    email,
+		Password: password,
+	})
+	require.Equal(t, http.StatusOK, res.Status, "%s", res.Body)
+
+	return e.login(t, email)
+}
+
+func (e *e2e) login(t *testing.T, email string) (userID, token string) {
+	t.Helper()
+	res := e.call(t, http.MethodPost, "/api/auth/login", "", dto.LoginUserRequest{Email: email, Password: password})
+	require.Equal(t, http.StatusOK, res.Status, "%s", res.Body)
+	login := data[dto.LoginUserResponse](t, res)
+	require.NotEmpty(t, login.Token)
+	return login.User.ID, login.Token
+}
+
+func (e *e2e) completeProfile(t *testing.T, token, phone, nationalID string) {
+	t.Helper()
+	res := e.call(t, http.MethodPut, "/api/users/profile", token, dto.UpdateProfileRequest{
+		DisplayName: "Nguyen Van A",
+		PhoneNumber: phone,
+		NationalID:  nationalID,
+		BirthYear:   1995,
+		Gender:      "MALE",
+		Team:        "BACK_END",
+	})
+	require.Equal(t, http.StatusOK, res.Status, "%s", res.Body)
+}
+
+func TestE2E_OpenAccounts(t *testing.T) {
+	e := newE2E(t)
+	userID, token := e.signUp(t, "alice@example.com")
+
+	res := e.call(t, http.MethodGet, "/api/accounts", token, nil)
+	require.Equal(t, http.StatusOK, res.Status)
+	assert.Empty(t, data[dto.ListAccountsResponse](t, res).Accounts)
+
+	// a payment account needs a completed profile
+	res = e.call(t, http.MethodPost, "/api/accounts/payment", token, nil)
+	assert.Equal(t, http.StatusUnprocessableEntity, res.Status)
+	assert.Equal(t, "profile_not_completed", problem(t, res).Code)
+
+	e.completeProfile(t, token, "0912345678", "079095000001")
+	res = e.call(t, http.MethodGet, "/api/users/profile", token, nil)
+	require.Equal(t, http.StatusOK, res.Status)
+	assert.Equal(t, "0912345678", data[dto.ProfileResponse](t, res).PhoneNumber)
+
+	res = e.call(t, http.MethodPost, "/api/accounts/payment", token, nil)
+	require.Equal(t, http.StatusCreated, res.Status, "%s", res.Body)
+	payment := data[dto.AccountResponse](t, res)
+	assert.Equal(t, userID, payment.UserID)
+	assert.Equal(t, account.AccountTypePayment, payment.AccountType)
+	assert.NoError(t, account.ValidateNumber(payment.AccountNumber))
+
+	// only one payment account per user
+	res = e.call(t, http.MethodPost, "/api/accounts/payment", token, nil)
+	assert.Equal(t, http.StatusUnprocessableEntity, res.Status)
+	problem(t, res)
+
+	res = e.call(t, http.MethodPost, "/api/accounts/savings/fixed", token, dto.CreateFixedSavingsAccountRequest{TermCode: "12"})
+	require.Equal(t, http.StatusCreated, res.Status, "%s", res.Body)
+	fixed := data[dto.AccountResponse](t, res)
+
+	res = e.call(t, http.MethodPost, "/api/accounts/savings/fixed", token, dto.CreateFixedSavingsAccountRequest{TermCode: "7"})
+	assert.Equal(t, http.StatusBadRequest, res.Status)
+	assert.Equal(t, "term_code", problem(t, res).Errors[0].Field)
+
+	res = e.call(t, http.MethodPost, "/api/accounts/savings/flexible", token, nil)
+	require.Equal(t, http.StatusCreated, res.Status, "%s", res.Body)
+	flexible := data[dto.AccountResponse](t, res)
+
+	res = e.call(t, http.MethodGet, "/api/accounts", token, nil)
+	require.Equal(t, http.StatusOK, res.Status)
+	accounts := map[string]dto.AccountWithDetailsResponse{}
+	for _, acc := range data[dto.ListAccountsResponse](t, res).Accounts {
+		accounts[acc.ID] = acc
+	}
+	require.Len(t, accounts, 3)
+	assert.Nil(t, accounts[payment.ID].SavingsDetail)
+	if detail := accounts[fixed.ID].SavingsDetail; assert.NotNil(t, detail) {
+		assert.True(t, detail.IsFixedTerm)
+		assert.Equal(t, 12, *detail.TermMonths)
+		assert.NotNil(t, detail.MaturityDate)
+	}
+	if detail := accounts[flexible.ID].SavingsDetail; assert.NotNil(t, detail) {
+		assert.False(t, detail.IsFixedTerm)
+		assert.Nil(t, detail.MaturityDate)
+	}
+
+	res = e.call(t, http.MethodGet, "/api/accounts?account_number="+fixed.AccountNumber, token, nil)
+	require.Equal(t, http.StatusOK, res.Status)
+	if found := data[dto.ListAccountsResponse](t, res).Accounts; assert.Len(t, found, 1) {
+		assert.Equal(t, fixed.ID, found[0].ID)
+	}
+
+	// a mistyped account number fails its check digit
+	mistyped := fixed.AccountNumber[:11] + string('0'+(fixed.AccountNumber[11]-'0'+1)%10)
+	res = e.call(t, http.MethodGet, "/api/accounts?account_number="+mistyped, token, nil)
+	assert.Equal(t, http.StatusBadRequest, res.Status)
+	if p := problem(t, res); assert.Len(t, p.Errors, 1) {
+		assert.Equal(t, "account_number", p.Errors[0].Field)
+		assert.Equal(t, "account_number", p.Errors[0].Code)
+	}
+
+	// accounts are private to their owner
+	_, otherToken := e.signUp(t, "bob@example.com")
+	res = e.call(t, http.MethodGet, "/api/accounts", otherToken, nil)
+	require.Equal(t, http.StatusOK, res.Status)
+	assert.Empty(t, data[dto.ListAccountsResponse](t, res).Accounts)
+}
+
+func TestE2E_MaturityInstruction(t *testing.T) {
+	e := newE2E(t)
+	_, token := e.signUp(t, "alice@example.com")
+	e.completeProfile(t, token, "0912345678", "079095000001")
+	route := "/api/accounts/savings/fixed/{id}/maturity-instruction"
+
+	res := e.call(t, http.MethodPost, "/api/accounts/savings/fixed", token, dto.CreateFixedSavingsAccountRequest{TermCode: "6"})
+	require.Equal(t, http.StatusCreated, res.Status, "%s", res.Body)
+	fixed := data[dto.AccountResponse](t, res)
+
+	res = e.call(t, http.MethodGet, "/api/accounts", token, nil)
+	require.Equal(t, http.StatusOK, res.Status)
+	accounts := data[dto.ListAccountsResponse](t, res).Accounts
+	require.Len(t, accounts, 1)
+	assert.Equal(t, account.MaturityInstructionRolloverPrincipalAndInterest, accounts[0].SavingsDetail.MaturityInstruction)
+
+	// paying out needs somewhere to pay to
+	payout := dto.UpdateMaturityInstructionRequest{MaturityInstruction: account.MaturityInstructionPayout}
+	res = e.call(t, http.MethodPut, route, token, payout, fixed.ID)
+	assert.Equal(t, http.StatusUnprocessableEntity, res.Status)
+	assert.Equal(t, "payment_account_required", problem(t, res).Code)
+
+	res = e.call(t, http.MethodPost, "/api/accounts/payment", token, nil)
+	require.Equal(t, http.StatusCreated, res.Status, "%s", res.Body)
+	res = e.call(t, http.MethodPut, route, token, payout, fixed.ID)
+	require.Equal(t, http.StatusOK, res.Status, "%s", res.Body)
+	detail := data[dto.SavingsAccountDetailResponse](t, res)
+	assert.Equal(t, fixed.ID, detail.AccountID)
+	assert.Equal(t, account.MaturityInstructionPayout, detail.MaturityInstruction)
+
+	res = e.call(t, http.MethodPut, route, token, dto.UpdateMaturityInstructionRequest{MaturityInstruction: "REINVEST"}, fixed.ID)
+	assert.Equal(t, http.StatusBadRequest, res.Status)
+	assert.Equal(t, "maturity_instruction", problem(t, res).Errors[0].Field)
+
+	// someone else's deposit does not exist for them
+	_, otherToken := e.signUp(t, "bob@example.com")
+	res = e.call(t, http.MethodPut, route, otherToken, payout, fixed.ID)
+	assert.Equal(t, http.StatusNotFound, res.Status)
+	problem(t, res)
+
+	// too late once the term has matured
+	e.clock.AdvanceDate(0, 6, 0)
+	_, token = e.login(t, "alice@example.com")
+	res = e.call(t, http.MethodPut, route, token, payout, fixed.ID)
+	assert.Equal(t, http.StatusUnprocessableEntity, res.Status)
+	assert.Equal(t, "account_matured", problem(t, res).Code)
+}
+
+func TestE2E_FlexibleSavings(t *testing.T) {
+	e := newE2E(t)
+	_, token := e.signUp(t, "alice@example.com")
+	e.completeProfile(t, token, "0912345678", "079095000001")
+	deposit, withdraw := "/api/accounts/savings/flexible/{id}/deposit", "/api/accounts/savings/flexible/{id}/withdraw"
+
+	res := e.call(t, http.MethodPost, "/api/accounts/savings/flexible", token, nil)
+	require.Equal(t, http.StatusCreated, res.Status, "%s", res.Body)
+	flexible := data[dto.AccountResponse](t, res)
+
+	// deposits come from the payment account
+	res = e.call(t, http.MethodPost, deposit, token, dto.FlexibleSavingsTransferRequest{Amount: 50_000}, flexible.ID)
+	assert.Equal(t, http.StatusUnprocessableEntity, res.Status)
+	assert.Equal(t, "payment_account_required", problem(t, res).Code)
+
+	res = e.call(t, http.MethodPost, "/api/accounts/payment", token, nil)
+	require.Equal(t, http.StatusCreated, res.Status, "%s", res.Body)
+	payment := data[dto.AccountResponse](t, res)
+	res = e.call(t, http.MethodPost, deposit, token, dto.FlexibleSavingsTransferRequest{Amount: 50_000}, flexible.ID)
+	assert.Equal(t, http.StatusUnprocessableEntity, res.Status)
+	assert.Equal(t, "insufficient_funds", problem(t, res).Code)
+
+	require.NoError(t, e.accounts.UpdateAccountBalance(context.Background(), payment.ID, 100_000))
+	res = e.call(t, http.MethodPost, deposit, token, dto.FlexibleSavingsTransferRequest{Amount: 80_000}, flexible.ID)
+	require.Equal(t, http.StatusOK, res.Status, "%s", res.Body)
+	assert.Equal(t, 80_000.0, data[dto.AccountResponse](t, res).Balance)
+
+	res = e.call(t, http.MethodPost, withdraw, token, dto.FlexibleSavingsTransferRequest{Amount: 30_000}, flexible.ID)
+	require.Equal(t, http.StatusOK, res.Status, "%s", res.Body)
+	assert.Equal(t, 50_000.0, data[dto.AccountResponse](t, res).Balance)
+
+	res = e.call(t, http.MethodGet, "/api/accounts", token, nil)
+	require.Equal(t, http.StatusOK, res.Status)
+	balances := map[string]float64{}
+	for _, acc := range data[dto.ListAccountsResponse](t, res).Accounts {
+		balances[acc.ID] = acc.Balance
+	}
+	assert.Equal(t, map[string]float64{payment.ID: 50_000, flexible.ID: 50_000}, balances)
+
+	res = e.call(t, http.MethodPost, withdraw, token, dto.FlexibleSavingsTransferRequest{Amount: 9_999}, flexible.ID)
+	assert.Equal(t, http.StatusBadRequest, res.Status)
+	assert.Equal(t, "amount", problem(t, res).Errors[0].Field)
+
+	res = e.call(t, http.MethodPost, withdraw, token, dto.FlexibleSavingsTransferRequest{Amount: 60_000}, flexible.ID)
+	assert.Equal(t, http.StatusUnprocessableEntity, res.Status)
+	assert.Equal(t, "insufficient_funds", problem(t, res).Code)
+
+	// fixed savings take no deposits
+	res = e.call(t, http.MethodPost, "/api/accounts/savings/fixed", token, dto.CreateFixedSavingsAccountRequest{TermCode: "6"})
+	require.Equal(t, http.StatusCreated, res.Status, "%s", res.Body)
+	res = e.call(t, http.MethodPost, deposit, token, dto.FlexibleSavingsTransferRequest{Amount: 10_000}, data[dto.AccountResponse](t, res).ID)
+	assert.Equal(t, http.StatusBadRequest, res.Status)
+	assert.Equal(t, "not_flexible_savings", problem(t, res).Code)
+
+	// someone else's savings do not exist for them
+	_, otherToken := e.signUp(t, "bob@example.com")
+	res = e.call(t, http.MethodPost, withdraw, otherToken, dto.FlexibleSavingsTransferRequest{Amount: 10_000}, flexible.ID)
+	assert.Equal(t, http.StatusNotFound, res.Status)
+	problem(t, res)
+}
+
+func TestE2E_Auth(t *testing.T) {
+	e := newE2E(t)
+	_, token := e.signUp(t, "alice@example.com")
+
+	t.Run("duplicate email", func(t *testing.T) {
+		res := e.call(t, http.MethodPost, "/api/auth/register", "", dto.CreateUserRequest{
+			Username: "alice2",
+			Email:    "alice@example.com",
+			Password: "Secret12345@!",
+		})
+		assert.Equal(t, http.StatusConflict, res.Status)
+		problem(t, res)
+	})
+
+	t.Run("weak password", func(t *testing.T) {
+		res := e.call(t, http.MethodPost, "/api/auth/register", "", dto.CreateUserRequest{
+			Username: "carol",
+			Email:    "carol@example.com",
+			Password: "short",
+		})
+		assert.Equal(t, http.StatusBadRequest, res.Status)
+		assert.Equal(t, "password", problem(t, res).Errors[0].Field)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		res := e.call(t, http.MethodPost, "/api/auth/login", "", dto.LoginUserRequest{
+			Email:    "alice@example.com",
+			Password: "Wrong12345@!",
+		})
+		assert.Equal(t, http.StatusUnauthorized, res.Status)
+		problem(t, res)
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		res := e.call(t, http.MethodGet, "/api/accounts", "", nil)
+		assert.Equal(t, http.StatusUnauthorized, res.Status)
+		assert.Equal(t, "unauthorized", problem(t, res).Code)
+	})
+
+	t.Run("forged token", func(t *testing.T) {
+		token, err := httpserver.CreateAccessToken(e.clock.Now(), time.Hour, httpserver.TokenPayload{UserID: "someone"}, "another-secret")
+		require.NoError(t, err)
+		res := e.call(t, http.MethodGet, "/api/accounts", token, nil)
+		assert.Equal(t, http.StatusUnauthorized, res.Status)
+		assert.Equal(t, "unauthorized", problem(t, res).Code)
+	})
+
+	t.Run("token expiry", func(t *testing.T) {
+		start := e.clock.Now()
+		t.Cleanup(func() { e.clock.Set(start) })
+
+		e.clock.Advance(httpserver.DefaultExpiredTime - time.Second)
+		res := e.call(t, http.MethodGet, "/api/accounts", token, nil)
+		assert.Equal(t, http.StatusOK, res.Status, "%s", res.Body)
+
+		e.clock.Advance(2 * time.Second)
+		res = e.call(t, http.MethodGet, "/api/accounts", token, nil)
+		assert.Equal(t, http.StatusUnauthorized, res.Status)
+		assert.Equal(t, "unauthorized", problem(t, res).Code)
+	})
+}
+
+func TestE2E_ProfileConflict(t *testing.T) {
+	e := newE2E(t)
+	_, alice := e.signUp(t, "alice@example.com")
+	_, bob := e.signUp(t, "bob@example.com")
+
+	res := e.call(t, http.MethodGet, "/api/users/profile", alice, nil)
+	assert.Equal(t, http.StatusNotFound, res.Status)
+
+	e.completeProfile(t, alice, "0912345678", "079095000001")
+
+	res = e.call(t, http.MethodPut, "/api/users/profile", bob, dto.UpdateProfileRequest{
+		DisplayName: "Tran Thi B",
+		PhoneNumber: "0912345678",
+		NationalID:  "079095000002",
+		BirthYear:   1996,
+		Gender:      "FEMALE",
+		Team:        "QA",
+	})
+	assert.Equal(t, http.StatusConflict, res.Status)
+	problem(t, res)
+}
+
+func TestE2E_DataExportAndDeletion(t *testing.T) {
+	e := newE2E(t)
+	_, token := e.signUp(t, "alice@example.com")
+	e.completeProfile(t, token, "0912345678", "079095000001")
+
+	res := e.call(t, http.MethodPost, "/api/users/export", token, nil)
+	require.Equal(t, http.StatusAccepted, res.Status, "%s", res.Body)
+	export := data[dto.DataExportResponse](t, res)
+
+	// the export is built in the background
+	require.Eventually(t, func() bool {
+		res := e.call(t, http.MethodGet, "/api/users/export/{id}", token, nil, export.ID)
+		return res.Status == http.StatusOK && data[dto.DataExportResponse](t, res).Status == "COMPLETED"
+	}, 5*time.Second, 20*time.Millisecond)
+
+	res = e.call(t, http.MethodGet, "/api/users/export/{id}/download", token, nil, export.ID)
+	require.Equal(t, http.StatusOK, res.Status)
+	archive, err := zip.NewReader(bytes.NewReader(res.Body), int64(len(res.Body)))
+	require.NoError(t, err)
+	assert.NotEmpty(t, archive.File)
+
+	_, other := e.signUp(t, "bob@example.com")
+	res = e.call(t, http.MethodGet, "/api/users/export/{id}", other, nil, export.ID)
+	assert.Equal(t, http.StatusNotFound, res.Status)
+
+	res = e.call(t, http.MethodPost, "/api/users/deletion", token, nil)
+	require.Equal(t, http.StatusOK, res.Status, "%s", res.Body)
+
+	// the token of a deleted user stops working before it expires
+	for _, route := range []string{"/api/users/deletion", "/api/accounts/payment", "/api/accounts/savings/flexible"} {
+		res = e.call(t, http.MethodPost, route, token, nil)
+		assert.Equal(t, http.StatusUnauthorized, res.Status, route)
+		problem(t, res)
+	}
+	res = e.call(t, http.MethodGet, "/api/accounts", token, nil)
+	assert.Equal(t, http.StatusUnauthorized, res.Status)
+}
+
+func TestE2E_AuditLogs(t *testing.T) {
+	e := newE2E(t)
+	adminID, admin := e.signUp(t, "admin@example.com")
+	_, alice := e.signUp(t, "alice@example.com")
+	e.cfg.AdminUserIDs = adminID
+
+	res := e.call(t, http.MethodGet, "/api/admin/audit-logs", alice, nil)
+	assert.Equal(t, http.StatusForbidden, res.Status)
+
+	res = e.call(t, http.MethodGet, "/api/admin/audit-logs", admin, nil)
+	require.Equal(t, http.StatusOK, res.Status, "%s", res.Body)
+	logs := data[dto.ListAuditLogsResponse](t, res)
+	// both registrations and logins
+	assert.EqualValues(t, 4, logs.Total)
+
+	res = e.call(t, http.MethodGet, "/api/admin/audit-logs/verify", admin, nil)
+	require.Equal(t, http.StatusOK, res.Status)
+	assert.True(t, data[dto.VerifyAuditLogsResponse](t, res).Valid)
+}
+
+func TestE2E_Health(t *testing.T) {
+	e := newE2E(t)
+
+	res := e.call(t, http.MethodGet, "/healthz/live", "", nil)
+	assert.Equal(t, http.StatusOK, res.Status)
+
+	res = e.call(t, http.MethodGet, "/healthz/ready", "", nil)
+	assert.Equal(t, http.StatusOK, res.Status)
+}
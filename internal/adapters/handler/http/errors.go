@@ -0,0 +1,115 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"e-wallet/internal/adapters/handler/http/dto"
+	"e-wallet/internal/domain/apperror"
+	"e-wallet/pkg/tracing"
+
+	sentryecho "github.com/getsentry/sentry-go/echo"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+var (
+	errUnauthorized       = apperror.Unauthorized("unauthorized", "authentication required")
+	errForbidden          = apperror.Forbidden("forbidden", "you are not allowed to access this resource")
+	errInvalidRequestBody = apperror.Invalid("invalid_request_body", "request body is malformed")
+	errTooManyRequests    = apperror.RateLimited("too_many_requests", "too many requests, retry in {retry_after} seconds")
+	errInternal           = apperror.New(apperror.KindInternal, "internal_error", "an unexpected error occurred")
+)
+
+// kindStatus maps each error kind to its HTTP status. Business rules that
+// block a well-formed request (limits, preconditions) are 422.
+var kindStatus = map[apperror.Kind]int{
+	apperror.KindValidation:         http.StatusBadRequest,
+	apperror.KindUnauthorized:       http.StatusUnauthorized,
+	apperror.KindForbidden:          http.StatusForbidden,
+	apperror.KindNotFound:           http.StatusNotFound,
+	apperror.KindConflict:           http.StatusConflict,
+	apperror.KindPreconditionFailed: http.StatusUnprocessableEntity,
+	apperror.KindLimitExceeded:      http.StatusUnprocessableEntity,
+	apperror.KindRateLimited:        http.StatusTooManyRequests,
+	apperror.KindInternal:           http.StatusInternalServerError,
+}
+
+// handleError writes err as an application/problem+json response. Untyped
+// errors are logged and reported as 500 without leaking their message.
+func (s *Server) handleError(c echo.Context, err error) error {
+	appErr, ok := apperror.As(err)
+	if !ok {
+		appErr = errInternal.Wrap(err)
+	}
+
+	status, ok := kindStatus[appErr.Kind]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	return s.writeProblem(c, err, status, appErr)
+}
+
+// HTTPErrorHandler renders errors returned by handlers and middlewares
+// (routing, authentication, ...) in the same problem+json format.
+func (s *Server) HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	var he *echo.HTTPError
+	if _, ok := apperror.As(err); !ok && errors.As(err, &he) {
+		err = s.writeProblem(c, err, he.Code, httpErrorToAppError(he))
+	} else {
+		err = s.handleError(c, err)
+	}
+	if err != nil {
+		s.log(c).Errorw("cannot write error response", zap.Error(err))
+	}
+}
+
+func (s *Server) writeProblem(c echo.Context, err error, status int, appErr *apperror.Error) error {
+	log := s.log(c)
+	if status >= http.StatusInternalServerError {
+		log.Errorw(err.Error())
+		if hub := sentryecho.GetHubFromContext(c); hub != nil {
+			hub.CaptureException(err)
+		}
+	} else {
+		log.Infow(err.Error(), zap.String("code", appErr.Code))
+	}
+
+	// Don't leak internal error messages to clients
+	if status >= http.StatusInternalServerError {
+		appErr = errInternal
+	}
+
+	lang := s.language(c)
+	problem := dto.NewProblemDetails(status, statusText(lang, status), localizeError(lang, appErr))
+	problem.Instance = c.Request().URL.Path
+	problem.RequestID = s.requestID(c)
+	problem.TraceID = tracing.TraceID(c.Request().Context())
+
+	c.Response().Header().Set(echo.HeaderContentType, dto.MIMEApplicationProblemJSON)
+	if c.Request().Method == http.MethodHead {
+		return c.NoContent(status)
+	}
+	return c.JSON(status, problem)
+}
+
+// httpErrorToAppError converts errors raised by echo itself; their code is
+// derived from the status text, e.g. 405 becomes "method_not_allowed".
+func httpErrorToAppError(he *echo.HTTPError) *apperror.Error {
+	message := http.StatusText(he.Code)
+	if he.Message != nil {
+		message = fmt.Sprint(he.Message)
+	}
+
+	code := strings.ToLower(strings.ReplaceAll(http.StatusText(he.Code), " ", "_"))
+	e := apperror.New(apperror.KindInternal, code, message)
+	e.Err = he.Internal
+	return e
+}
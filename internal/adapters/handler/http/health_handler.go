@@ -0,0 +1,77 @@
+package http
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"e-wallet/internal/adapters/handler/http/dto"
+	"e-wallet/internal/health"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderHealthToken carries Config.HealthReportToken to get the detailed
+// readiness report.
+const HeaderHealthToken = "X-Health-Token"
+
+func (s *Server) RegisterHealthCheck(router *echo.Group) {
+	router.GET("/healthz", s.Live)
+	router.GET("/healthz/live", s.Live)
+	router.GET("/healthz/ready", s.Ready)
+}
+
+// Live godoc
+//
+//	@Summary		Liveness probe
+//	@Description	Reports that the process is running. It does not check dependencies.
+//	@Tags			health
+//	@Produce		json
+//	@Success		200	{object}	dto.HealthResponse
+//	@Router			/healthz/live [get]
+func (s *Server) Live(c echo.Context) error {
+	return c.JSON(http.StatusOK, dto.HealthResponse{Status: string(health.StatusUp)})
+}
+
+// Ready godoc
+//
+//	@Summary		Readiness probe
+//	@Description	Runs the registered dependency checks, and fails once the server is shutting down. The per-check report is only returned when the X-Health-Token header matches the configured token.
+//	@Tags			health
+//	@Produce		json
+//	@Param			X-Health-Token	header		string	false	"Operator token for the detailed report"
+//	@Success		200				{object}	health.Report
+//	@Failure		503				{object}	health.Report
+//	@Router			/healthz/ready [get]
+func (s *Server) Ready(c echo.Context) error {
+	if s.draining.Load() {
+		return c.JSON(http.StatusServiceUnavailable, dto.HealthResponse{Status: string(health.StatusDown)})
+	}
+
+	report := s.Health.Check(c.Request().Context())
+
+	status := http.StatusOK
+	if report.Status != health.StatusUp {
+		status = http.StatusServiceUnavailable
+		for _, res := range report.Checks {
+			if res.Status == health.StatusDown {
+				s.log(c).Warnw("health check failed", "check", res.Name, "error", res.Error, "latency", res.Latency)
+			}
+		}
+	}
+
+	if !s.canSeeHealthReport(c) {
+		return c.JSON(status, dto.HealthResponse{Status: string(report.Status)})
+	}
+	return c.JSON(status, report)
+}
+
+// canSeeHealthReport keeps dependency names and errors away from anonymous
+// callers; the detailed report is disabled when no token is configured.
+func (s *Server) canSeeHealthReport(c echo.Context) bool {
+	token := s.Config.HealthReportToken
+	if token == "" {
+		return false
+	}
+	given := c.Request().Header.Get(HeaderHealthToken)
+	return subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1
+}
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"e-wallet/internal/config"
+	"e-wallet/internal/health"
+)
+
+func TestServer_Ready(t *testing.T) {
+	tests := []struct {
+		name           string
+		checkErr       error
+		token          string
+		expectedStatus int
+		expectDetails  bool
+	}{
+		{name: "ready", expectedStatus: http.StatusOK},
+		{name: "dependency down", checkErr: errors.New("connection refused"), expectedStatus: http.StatusServiceUnavailable},
+		{name: "wrong token hides details", token: "guess", expectedStatus: http.StatusOK},
+		{name: "operator token shows details", token: "secret", expectedStatus: http.StatusOK, expectDetails: true},
+		{name: "operator token on failure", checkErr: errors.New("connection refused"), token: "secret", expectedStatus: http.StatusServiceUnavailable, expectDetails: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := new(config.Config)
+			cfg.HealthReportToken = "secret"
+			s, err := New(WithConfig(cfg))
+			require.NoError(t, err)
+			s.Health.Register(health.CheckFunc("postgres", func(ctx context.Context) error { return tt.checkErr }), 0)
+
+			req := httptest.NewRequest(http.MethodGet, "/healthz/ready", nil)
+			if tt.token != "" {
+				req.Header.Set(HeaderHealthToken, tt.token)
+			}
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			expectedStatus := string(health.StatusUp)
+			if tt.checkErr != nil {
+				expectedStatus = string(health.StatusDown)
+			}
+			assert.Equal(t, expectedStatus, body["status"])
+
+			checks, ok := body["checks"].([]any)
+			assert.Equal(t, tt.expectDetails, ok)
+			if tt.expectDetails {
+				require.Len(t, checks, 1)
+				check := checks[0].(map[string]any)
+				assert.Equal(t, "postgres", check["name"])
+				if tt.checkErr != nil {
+					assert.Equal(t, tt.checkErr.Error(), check["error"])
+				}
+			}
+		})
+	}
+}
+
+func TestServer_Live(t *testing.T) {
+	s, err := New(WithConfig(new(config.Config)))
+	require.NoError(t, err)
+	// liveness must not depend on dependencies
+	s.Health.Register(health.CheckFunc("postgres", func(ctx context.Context) error { return errors.New("down") }), 0)
+
+	for _, path := range []string{"/healthz", "/healthz/live"} {
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code, path)
+		assert.JSONEq(t, `{"status":"up"}`, rec.Body.String(), path)
+	}
+}
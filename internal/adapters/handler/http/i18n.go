@@ -0,0 +1,105 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"e-wallet/internal/domain/apperror"
+	"e-wallet/internal/i18n"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	LanguageKey = "Language"
+
+	headerAcceptLanguage  = "Accept-Language"
+	headerContentLanguage = "Content-Language"
+)
+
+// Localize picks the response language from the Accept-Language header and
+// makes it available to handlers (c.Get(LanguageKey)) and services (context).
+func (s *Server) Localize() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			lang := i18n.Negotiate(c.Request().Header.Get(headerAcceptLanguage), s.defaultLanguage())
+
+			c.Set(LanguageKey, lang)
+			c.SetRequest(c.Request().WithContext(i18n.WithLanguage(c.Request().Context(), lang)))
+			c.Response().Header().Set(headerContentLanguage, string(lang))
+			c.Response().Header().Add(echo.HeaderVary, headerAcceptLanguage)
+
+			return next(c)
+		}
+	}
+}
+
+func (s *Server) defaultLanguage() i18n.Language {
+	if s.Config != nil {
+		if lang, ok := i18n.Parse(s.Config.DefaultLanguage); ok {
+			return lang
+		}
+	}
+	return i18n.English
+}
+
+func (s *Server) language(c echo.Context) i18n.Language {
+	if lang, ok := c.Get(LanguageKey).(i18n.Language); ok {
+		return lang
+	}
+	return s.defaultLanguage()
+}
+
+func (s *Server) translate(c echo.Context, key string) string {
+	return i18n.T(s.language(c), key, nil)
+}
+
+// statusText returns the localised title of an HTTP status.
+func statusText(lang i18n.Language, status int) string {
+	if text, ok := i18n.Lookup(lang, "status."+strconv.Itoa(status), nil); ok {
+		return text
+	}
+	return http.StatusText(status)
+}
+
+// localizeError returns a copy of e with its messages in lang. Messages come
+// from "error.<code>" and "validation.<field code>"; e's own English message
+// is kept when the catalogue has no entry.
+func localizeError(lang i18n.Language, e *apperror.Error) *apperror.Error {
+	cp := *e
+	cp.Fields = make([]apperror.FieldError, len(e.Fields))
+	for i, f := range e.Fields {
+		f.Message = fieldMessage(lang, f)
+		cp.Fields[i] = f
+	}
+
+	switch {
+	case len(cp.Fields) > 0:
+		cp.Message = cp.Fields[0].Message
+	default:
+		if message, ok := i18n.Lookup(lang, "error."+e.Code, e.Params); ok {
+			cp.Message = message
+		}
+	}
+	return &cp
+}
+
+func fieldMessage(lang i18n.Language, f apperror.FieldError) string {
+	name, ok := i18n.Lookup(lang, "field."+f.Field, nil)
+	if !ok {
+		name = f.Field
+	}
+
+	params := map[string]string{
+		"field": name,
+		"param": strings.Join(strings.Fields(f.Param), ", "),
+	}
+	if message, ok := i18n.Lookup(lang, "validation."+f.Code, params); ok {
+		return message
+	}
+	if f.Message != "" {
+		return f.Message
+	}
+	return i18n.T(lang, "validation.invalid", params)
+}
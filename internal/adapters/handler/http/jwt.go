@@ -16,28 +16,29 @@ type TokenPayload struct {
 	UserID string `json:"user_id"`
 }
 
-func CreateAccessToken(ttl time.Duration, payload TokenPayload, secretJWTKey string) (string, error) {
+// CreateAccessToken signs a token for payload issued at now and valid for ttl.
+func CreateAccessToken(now time.Time, ttl time.Duration, payload TokenPayload, secretJWTKey string) (string, error) {
 	token := jwt.New(jwt.SigningMethodHS256)
 
-	now := time.Now().UTC()
 	claims := token.Claims.(jwt.MapClaims)
 
 	claims["sub"] = payload
-	claims["exp"] = now.Add(ttl).Unix()
+	claims["exp"] = now.UTC().Add(ttl).Unix()
 
 	tokenString, err := token.SignedString([]byte(secretJWTKey))
 
 	return tokenString, err
 }
 
-func ValidateToken(token string, secretJWTKey string) (jwt.MapClaims, error) {
+// ValidateToken checks the signature of token and that it hasn't expired at now.
+func ValidateToken(token string, secretJWTKey string, now time.Time) (jwt.MapClaims, error) {
 	parseToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, echo.NewHTTPError(http.StatusForbidden, "Unexpected signing method: %v", token.Header["alg"])
 		}
 		signature := []byte(secretJWTKey)
 		return signature, nil
-	})
+	}, jwt.WithTimeFunc(func() time.Time { return now }))
 
 	if err != nil {
 		return nil, err
@@ -0,0 +1,88 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// HTTPServer builds the net/http server for s using the timeouts from config.
+func (s *Server) HTTPServer() *http.Server {
+	return &http.Server{
+		Addr:              fmt.Sprintf(":%d", s.Config.Port),
+		Handler:           s,
+		ReadTimeout:       s.Config.HTTP.ReadTimeout,
+		ReadHeaderTimeout: s.Config.HTTP.ReadHeaderTimeout,
+		WriteTimeout:      s.Config.HTTP.WriteTimeout,
+		IdleTimeout:       s.Config.HTTP.IdleTimeout,
+	}
+}
+
+// Run listens on the configured port and serves until ctx is cancelled.
+func (s *Server) Run(ctx context.Context) error {
+	srv := s.HTTPServer()
+	ln, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, srv, ln)
+}
+
+// Serve serves srv on ln until ctx is cancelled, then reports unready for
+// HTTP.ShutdownDelay, stops accepting new connections and waits up to
+// HTTP.ShutdownTimeout for in-flight requests and the data exports they
+// started.
+// TLS is used when both TLS_CERT_FILE and TLS_KEY_FILE are set.
+func (s *Server) Serve(ctx context.Context, srv *http.Server, ln net.Listener) error {
+	errCh := make(chan error, 1)
+	go func() {
+		if s.tlsEnabled() {
+			s.Logger.Infow("server started", "addr", ln.Addr().String(), "tls", true)
+			errCh <- srv.ServeTLS(ln, s.Config.HTTP.TLSCertFile, s.Config.HTTP.TLSKeyFile)
+			return
+		}
+		s.Logger.Infow("server started", "addr", ln.Addr().String(), "tls", false)
+		errCh <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	// keep serving while the load balancer takes the instance out
+	s.draining.Store(true)
+	if delay := s.Config.HTTP.ShutdownDelay; delay > 0 {
+		s.Logger.Infow("reporting unready before shutting down", "delay", delay.String())
+		time.Sleep(delay)
+	}
+
+	s.Logger.Infow("shutting down server", "timeout", s.Config.HTTP.ShutdownTimeout.String())
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.Config.HTTP.ShutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		// deadline reached: drop the remaining connections
+		_ = srv.Close()
+		return fmt.Errorf("shutdown server: %w", err)
+	}
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	if s.PrivacyService != nil {
+		if err := s.PrivacyService.Wait(shutdownCtx); err != nil {
+			return fmt.Errorf("wait for data exports: %w", err)
+		}
+	}
+
+	s.Logger.Info("server stopped")
+	return nil
+}
+
+func (s *Server) tlsEnabled() bool {
+	return s.Config.HTTP.TLSCertFile != "" && s.Config.HTTP.TLSKeyFile != ""
+}
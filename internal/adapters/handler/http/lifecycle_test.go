@@ -0,0 +1,163 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"e-wallet/internal/config"
+	"e-wallet/mocks"
+)
+
+func newLifecycleServer(t *testing.T, shutdownTimeout time.Duration) (*Server, net.Listener) {
+	cfg := new(config.Config)
+	cfg.HTTP.ShutdownTimeout = shutdownTimeout
+
+	s, err := New(WithConfig(cfg))
+	require.NoError(t, err)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	return s, ln
+}
+
+func TestServer_Serve_DrainsInFlightRequests(t *testing.T) {
+	s, ln := newLifecycleServer(t, 5*time.Second)
+
+	started := make(chan struct{})
+	s.Router.GET("/healthz/slow", func(c echo.Context) error {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		return c.String(http.StatusOK, "done")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Serve(ctx, s.HTTPServer(), ln) }()
+
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	resCh := make(chan result, 1)
+	go func() {
+		res, err := http.Get("http://" + ln.Addr().String() + "/healthz/slow")
+		if err != nil {
+			resCh <- result{err: err}
+			return
+		}
+		defer res.Body.Close()
+		body, _ := io.ReadAll(res.Body)
+		resCh <- result{status: res.StatusCode, body: string(body)}
+	}()
+
+	<-started
+	cancel()
+
+	res := <-resCh
+	require.NoError(t, res.err)
+	assert.Equal(t, http.StatusOK, res.status)
+	assert.Equal(t, "done", res.body)
+	assert.NoError(t, <-done)
+
+	_, err := net.Dial("tcp", ln.Addr().String())
+	assert.Error(t, err, "listener should be closed after shutdown")
+}
+
+func TestServer_Serve_ShutdownDeadline(t *testing.T) {
+	s, ln := newLifecycleServer(t, 50*time.Millisecond)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	s.Router.GET("/healthz/stuck", func(c echo.Context) error {
+		close(started)
+		<-release
+		return c.NoContent(http.StatusOK)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Serve(ctx, s.HTTPServer(), ln) }()
+	go func() {
+		res, err := http.Get("http://" + ln.Addr().String() + "/healthz/stuck")
+		if err == nil {
+			res.Body.Close()
+		}
+	}()
+
+	<-started
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Serve did not return after the shutdown deadline")
+	}
+}
+
+func TestServer_Serve_WaitsForDataExports(t *testing.T) {
+	s, ln := newLifecycleServer(t, 5*time.Second)
+
+	waiting := make(chan struct{})
+	release := make(chan struct{})
+	privacyService := mocks.NewMockPrivacyService(t)
+	privacyService.EXPECT().Wait(mock.Anything).RunAndReturn(func(ctx context.Context) error {
+		close(waiting)
+		<-release
+		return nil
+	}).Once()
+	s.PrivacyService = privacyService
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Serve(ctx, s.HTTPServer(), ln) }()
+	cancel()
+
+	<-waiting
+	select {
+	case <-done:
+		t.Fatal("Serve returned before the data exports were done")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	assert.NoError(t, <-done)
+}
+
+func TestServer_Serve_UnreadyWhileShuttingDown(t *testing.T) {
+	s, ln := newLifecycleServer(t, 5*time.Second)
+	s.Config.HTTP.ShutdownDelay = 300 * time.Millisecond
+	ready := func() int {
+		res, err := http.Get("http://" + ln.Addr().String() + "/healthz/ready")
+		require.NoError(t, err)
+		defer res.Body.Close()
+		_, _ = io.Copy(io.Discard, res.Body)
+		return res.StatusCode
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Serve(ctx, s.HTTPServer(), ln) }()
+	assert.Equal(t, http.StatusOK, ready())
+
+	cancel()
+	assert.Eventually(t, func() bool { return ready() == http.StatusServiceUnavailable }, 200*time.Millisecond, 10*time.Millisecond)
+	// the other routes are still served
+	res, err := http.Get("http://" + ln.Addr().String() + "/healthz/live")
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	assert.NoError(t, <-done)
+}
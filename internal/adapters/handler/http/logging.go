@@ -0,0 +1,75 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"e-wallet/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// RequestLogger stores a logger tagged with the request id, route and trace
+// in the request context and writes one access log line per request.
+func (s *Server) RequestLogger() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			req := c.Request()
+
+			route := c.Path()
+			if route == "" {
+				route = unmatchedRoute
+			}
+			log := logger.WithTrace(req.Context(), s.Logger).With(
+				"request_id", s.requestID(c),
+				"method", req.Method,
+				"route", route,
+			)
+			c.SetRequest(req.WithContext(logger.WithContext(req.Context(), log)))
+
+			err := next(c)
+			if err != nil {
+				c.Error(err)
+			}
+
+			status := c.Response().Status
+			fields := []any{
+				"path", req.URL.Path,
+				"status", status,
+				"latency", time.Since(start).String(),
+				"latency_ms", time.Since(start).Milliseconds(),
+				"bytes_out", c.Response().Size,
+				"remote_ip", c.RealIP(),
+				"user_agent", req.UserAgent(),
+			}
+			if userID, ok := c.Get(UserIDKey).(string); ok && userID != "" {
+				fields = append(fields, "user_id", userID)
+			}
+
+			switch {
+			case status >= http.StatusInternalServerError:
+				log.Errorw("request completed", fields...)
+			case status >= http.StatusBadRequest:
+				log.Warnw("request completed", fields...)
+			default:
+				log.Infow("request completed", fields...)
+			}
+			return nil
+		}
+	}
+}
+
+// log returns the request-scoped logger, falling back to the server logger
+// outside of a request.
+func (s *Server) log(c echo.Context) *zap.SugaredLogger {
+	return logger.FromContext(c.Request().Context(), s.Logger)
+}
+
+// setLogUser adds the authenticated user to the request-scoped logger.
+func setLogUser(c echo.Context, userID string, fallback *zap.SugaredLogger) {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx, fallback).With("user_id", userID)
+	c.SetRequest(c.Request().WithContext(logger.WithContext(ctx, log)))
+}
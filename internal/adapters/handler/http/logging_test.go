@@ -0,0 +1,87 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"e-wallet/internal/config"
+	"e-wallet/mocks"
+	"e-wallet/pkg/logger"
+)
+
+func TestServer_RequestLogger(t *testing.T) {
+	token, err := CreateAccessToken(time.Now(), time.Hour, TokenPayload{UserID: "user-123"}, "test-secret")
+	require.NoError(t, err)
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	accountSvc := mocks.NewMockAccountService(t)
+	accountSvc.EXPECT().
+		ListAccounts(mock.Anything, "user-123").
+		Return(nil, errors.New("connection reset while reading 0912345678")).
+		Once()
+
+	s, err := New(WithConfig(&config.Config{JWTSecret: "test-secret"}))
+	require.NoError(t, err)
+	s.Logger = zap.New(logger.NewRedactingCore(core)).Sugar()
+	s.AccountService = accountSvc
+	s.UserService = activeUsers(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/accounts", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	req.Header.Set(echo.HeaderXRequestID, "req-1")
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+
+	// the error is logged with the request-scoped fields and scrubbed
+	errorLog := entries[0]
+	assert.Equal(t, zapcore.ErrorLevel, errorLog.Level)
+	assert.Equal(t, "connection reset while reading ******5678", errorLog.Message)
+	assert.Subset(t, errorLog.ContextMap(), map[string]any{
+		"request_id": "req-1",
+		"method":     http.MethodGet,
+		"route":      "/api/accounts",
+		"user_id":    "user-123",
+	})
+
+	accessLog := entries[1]
+	assert.Equal(t, "request completed", accessLog.Message)
+	assert.Equal(t, zapcore.ErrorLevel, accessLog.Level)
+	fields := accessLog.ContextMap()
+	assert.Equal(t, "req-1", fields["request_id"])
+	assert.Equal(t, "/api/accounts", fields["route"])
+	assert.Equal(t, int64(http.StatusInternalServerError), fields["status"])
+	assert.Equal(t, "user-123", fields["user_id"])
+	assert.Contains(t, fields, "latency_ms")
+	assert.NotContains(t, accessLog.Message+errorLog.Message, token)
+}
+
+func TestServer_RequestLogger_Anonymous(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	s, err := New(WithConfig(new(config.Config)))
+	require.NoError(t, err)
+	s.Logger = zap.New(core).Sugar()
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz/live", nil))
+
+	accessLogs := logs.FilterMessage("request completed").All()
+	require.Len(t, accessLogs, 1)
+	assert.Equal(t, zapcore.InfoLevel, accessLogs[0].Level)
+	assert.NotContains(t, accessLogs[0].ContextMap(), "user_id")
+	assert.NotEmpty(t, accessLogs[0].ContextMap()["request_id"])
+}
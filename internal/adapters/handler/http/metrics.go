@@ -0,0 +1,57 @@
+package http
+
+import (
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const unmatchedRoute = "unmatched"
+
+// RecordMetrics observes the latency and status code of every request,
+// labelled with the route template it matched.
+func (s *Server) RecordMetrics() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+			if err != nil {
+				// render the error now so the status code is known
+				c.Error(err)
+			}
+
+			route := c.Path()
+			if route == "" {
+				route = unmatchedRoute
+			}
+			s.Metrics.ObserveHTTPRequest(c.Request().Method, route, c.Response().Status, time.Since(start))
+			return nil
+		}
+	}
+}
+
+// RegisterMetrics exposes the Prometheus metrics on /metrics to the scrapers
+// sending Config.MetricsToken as their bearer token. The route is exempt from
+// the user authentication, and the API port is public, so it checks a token of
+// its own.
+func (s *Server) RegisterMetrics() {
+	handler := echo.WrapHandler(s.Metrics.Handler())
+	s.Router.GET("/metrics", func(c echo.Context) error {
+		if !s.canScrapeMetrics(c) {
+			c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer realm="metrics"`)
+			return s.handleError(c, errUnauthorized)
+		}
+		return handler(c)
+	})
+}
+
+func (s *Server) canScrapeMetrics(c echo.Context) bool {
+	token := s.Config.MetricsToken
+	if token == "" {
+		return false
+	}
+	given, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1
+}
@@ -0,0 +1,86 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"e-wallet/internal/adapters/metrics"
+	"e-wallet/internal/config"
+)
+
+func TestServer_RecordMetrics(t *testing.T) {
+	m := metrics.NewPrometheus()
+	s, err := New(WithConfig(&config.Config{MetricsToken: "scrape"}), WithMetrics(m))
+	require.NoError(t, err)
+
+	for _, path := range []string{"/healthz/live", "/healthz/live", "/api/users/export/exp-123", "/does-not-exist"} {
+		s.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	m.AccountCreated("PAYMENT")
+	m.TransferCompleted("WITHDRAWAL", 150000)
+	m.InterestAccrued("FIXED_SAVINGS", 1234.5)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer scrape")
+	s.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+
+	assert.Contains(t, body, `ewallet_http_requests_total{method="GET",route="/healthz/live",status="200"} 2`)
+	// the route template is used, not the raw path
+	assert.Contains(t, body, `ewallet_http_requests_total{method="GET",route="/api/users/export/:id",status="401"} 1`)
+	assert.Contains(t, body, `ewallet_http_request_duration_seconds_count{method="GET",route="/healthz/live"} 2`)
+	assert.NotContains(t, body, "exp-123")
+	assert.NotContains(t, body, "/does-not-exist")
+	assert.Contains(t, body, `ewallet_accounts_created_total{account_type="PAYMENT"} 1`)
+	assert.Contains(t, body, `ewallet_transfer_volume_total{transaction_type="WITHDRAWAL"} 150000`)
+	assert.Contains(t, body, `ewallet_interest_accrued_total{account_type="FIXED_SAVINGS"} 1234.5`)
+}
+
+func TestServer_Metrics_Disabled(t *testing.T) {
+	s, err := New(WithConfig(new(config.Config)))
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.NotEqual(t, http.StatusOK, rec.Code)
+}
+
+func TestServer_Metrics_Token(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+		wantStatus    int
+	}{
+		// unknown routes need a user token, as any other
+		{name: "no token configured", token: "", authorization: "Bearer ", wantStatus: http.StatusUnauthorized},
+		{name: "missing", token: "scrape", wantStatus: http.StatusUnauthorized},
+		{name: "wrong", token: "scrape", authorization: "Bearer guess", wantStatus: http.StatusUnauthorized},
+		{name: "not a bearer token", token: "scrape", authorization: "scrape", wantStatus: http.StatusUnauthorized},
+		{name: "right", token: "scrape", authorization: "Bearer scrape", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New(WithConfig(&config.Config{MetricsToken: tt.token}), WithMetrics(metrics.NewPrometheus()))
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if tt.authorization != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.authorization)
+			}
+			s.ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus != http.StatusOK {
+				assert.NotContains(t, rec.Body.String(), "ewallet_")
+			}
+		})
+	}
+}
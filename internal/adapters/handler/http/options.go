@@ -1,6 +1,10 @@
 package http
 
-import "e-wallet/internal/config"
+import (
+	"e-wallet/internal/adapters/metrics"
+	"e-wallet/internal/config"
+	"e-wallet/internal/ports"
+)
 
 
 type Options func(s *Server) error
@@ -11,3 +15,24 @@ func WithConfig(cfg *config.Config) Options {
 		return nil
 	}
 }
+
+func WithMetrics(m *metrics.Prometheus) Options {
+	return func(s *Server) error {
+		s.Metrics = m
+		return nil
+	}
+}
+
+func WithRateLimitStore(store ports.RateLimitStore) Options {
+	return func(s *Server) error {
+		s.RateLimitStore = store
+		return nil
+	}
+}
+
+func WithClock(c ports.Clock) Options {
+	return func(s *Server) error {
+		s.Clock = c
+		return nil
+	}
+}
@@ -0,0 +1,146 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"e-wallet/internal/adapters/handler/http/dto"
+	"e-wallet/internal/domain/audit"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RequestDataExport godoc
+//
+//	@Summary		Request personal data export
+//	@Description	Start assembling a ZIP archive of the authenticated user's data (user, profile, accounts, transactions, interest history)
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Success		202	{object}	dto.Response{data=dto.DataExportResponse}
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
+//	@Router			/api/users/export [post]
+//	@Security		BearerAuth
+func (s *Server) RequestDataExport(c echo.Context) error {
+	userID := c.Get(UserIDKey).(string)
+	if userID == "" {
+		return s.handleError(c, errUnauthorized)
+	}
+
+	export, err := s.PrivacyService.RequestExport(c.Request().Context(), userID)
+	if err != nil {
+		return s.handleError(c, err)
+	}
+
+	s.recordAudit(c, &audit.Entry{
+		Action:     audit.ActionDataExported,
+		TargetType: audit.TargetExport,
+		TargetID:   export.ID,
+	})
+
+	resp := dto.NewDataExportResponse(export)
+	return c.JSON(http.StatusAccepted, dto.Response{
+		Status:  http.StatusAccepted,
+		Message: s.translate(c, "response.data_export_requested"),
+		Data:    resp,
+	})
+}
+
+// GetDataExport godoc
+//
+//	@Summary		Get personal data export status
+//	@Description	Get the status of a personal data export requested by the authenticated user
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Export ID"
+//	@Success		200	{object}	dto.Response{data=dto.DataExportResponse}
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		404	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
+//	@Router			/api/users/export/{id} [get]
+//	@Security		BearerAuth
+func (s *Server) GetDataExport(c echo.Context) error {
+	userID := c.Get(UserIDKey).(string)
+	if userID == "" {
+		return s.handleError(c, errUnauthorized)
+	}
+
+	export, err := s.PrivacyService.GetExport(c.Request().Context(), userID, c.Param("id"))
+	if err != nil {
+		return s.handleError(c, err)
+	}
+
+	resp := dto.NewDataExportResponse(export)
+	return s.handleSuccess(c, resp)
+}
+
+// DownloadDataExport godoc
+//
+//	@Summary		Download personal data export
+//	@Description	Download the ZIP archive of a completed personal data export
+//	@Tags			users
+//	@Produce		application/zip
+//	@Param			id	path		string	true	"Export ID"
+//	@Success		200	{file}		file
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		404	{object}	dto.ProblemDetails
+//	@Failure		422	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
+//	@Router			/api/users/export/{id}/download [get]
+//	@Security		BearerAuth
+func (s *Server) DownloadDataExport(c echo.Context) error {
+	userID := c.Get(UserIDKey).(string)
+	if userID == "" {
+		return s.handleError(c, errUnauthorized)
+	}
+
+	file, export, err := s.PrivacyService.OpenExport(c.Request().Context(), userID, c.Param("id"))
+	if err != nil {
+		return s.handleError(c, err)
+	}
+	defer file.Close()
+
+	s.recordAudit(c, &audit.Entry{
+		Action:     audit.ActionDataDownloaded,
+		TargetType: audit.TargetExport,
+		TargetID:   export.ID,
+	})
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", "e-wallet-data-"+export.ID+".zip"))
+	return c.Stream(http.StatusOK, "application/zip", file)
+}
+
+// DeleteUserData godoc
+//
+//	@Summary		Delete personal data
+//	@Description	Anonymise the authenticated user's personal data and close their accounts. Financial records are retained for bookkeeping. Only allowed when all balances are zero.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	dto.Response
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		409	{object}	dto.ProblemDetails
+//	@Failure		422	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
+//	@Router			/api/users/deletion [post]
+//	@Security		BearerAuth
+func (s *Server) DeleteUserData(c echo.Context) error {
+	userID := c.Get(UserIDKey).(string)
+	if userID == "" {
+		return s.handleError(c, errUnauthorized)
+	}
+
+	if err := s.PrivacyService.DeleteUserData(c.Request().Context(), userID); err != nil {
+		return s.handleError(c, err)
+	}
+
+	s.recordAudit(c, &audit.Entry{
+		Action:     audit.ActionUserDataDeleted,
+		TargetType: audit.TargetUser,
+		TargetID:   userID,
+	})
+
+	return s.handleSuccess(c, nil)
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"e-wallet/internal/adapters/handler/http/dto"
+	"e-wallet/internal/domain/audit"
 	"e-wallet/internal/domain/profile"
 
 	"github.com/labstack/echo/v4"
@@ -15,45 +16,51 @@ import (
 //	@Accept			json
 //	@Produce		json
 //	@Param			request	body		dto.UpdateProfileRequest	true	"Profile update data"
-//	@Success		200		{object}	dto.ProfileResponse
-//	@Failure		400		{object}	dto.Response
-//	@Failure		401		{object}	dto.Response
-//	@Failure		500		{object}	dto.Response
+//	@Success		200		{object}	dto.Response{data=dto.ProfileResponse}
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		409		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
 //	@Router			/api/users/profile [put]
 //	@Security		BearerAuth
 func (s *Server) UpdateProfile(c echo.Context) error {
 	userID := c.Get(UserIDKey).(string)
 	if userID == "" {
-		return s.handleError(c, dto.UnauthorizedResponse)
+		return s.handleError(c, errUnauthorized)
 	}
 
 	var req dto.UpdateProfileRequest
 	if err := c.Bind(&req); err != nil {
-		s.Logger.Error(err)
-		return s.handleError(c, dto.BadRequestResponse)
+		return s.handleError(c, errInvalidRequestBody.Wrap(err))
 	}
 
 	if err := c.Validate(&req); err != nil {
-		s.Logger.Error(err)
-		return s.handleError(c, dto.BadRequestResponse)
+		return s.handleError(c, validationError(err))
 	}
 
 	profileReq := &profile.UpdateProfileRequest{
-		DisplayName: req.DisplayName,
-		AvatarURL:   req.AvatarURL,
-		PhoneNumber: req.PhoneNumber,
-		NationalID:  req.NationalID,
-		BirthYear:   req.BirthYear,
-		Gender:      req.Gender,
-		Team:        req.Team,
+		DisplayName:       req.DisplayName,
+		AvatarURL:         req.AvatarURL,
+		PhoneNumber:       req.PhoneNumber,
+		NationalID:        req.NationalID,
+		BirthYear:         req.BirthYear,
+		Gender:            req.Gender,
+		Team:              req.Team,
+		PreferredLanguage: req.PreferredLanguage,
 	}
 
 	updatedProfile, err := s.ProfileService.UpdateProfile(c.Request().Context(), userID, profileReq)
 	if err != nil {
-		s.Logger.Error(err)
-		return s.handleError(c, dto.InternalErrorResponse)
+		return s.handleError(c, err)
 	}
 
+	s.recordAudit(c, &audit.Entry{
+		Action:     audit.ActionProfileUpdated,
+		TargetType: audit.TargetProfile,
+		TargetID:   userID,
+		Metadata:   map[string]string{"display_name": req.DisplayName, "team": req.Team},
+	})
+
 	resp := dto.NewProfileResponse(updatedProfile)
 	return s.handleSuccess(c, resp)
 }
@@ -65,23 +72,23 @@ func (s *Server) UpdateProfile(c echo.Context) error {
 //	@Tags			users
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{object}	dto.ProfileResponse
-//	@Failure		401	{object}	dto.Response
-//	@Failure		500	{object}	dto.Response
+//	@Success		200	{object}	dto.Response{data=dto.ProfileResponse}
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		404	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
 //	@Router			/api/users/profile [get]
 //	@Security		BearerAuth
 func (s *Server) GetProfile(c echo.Context) error {
 	userID := c.Get(UserIDKey).(string)
 	if userID == "" {
-		return s.handleError(c, dto.UnauthorizedResponse)
+		return s.handleError(c, errUnauthorized)
 	}
 
 	profile, err := s.ProfileService.GetProfile(c.Request().Context(), userID)
 	if err != nil {
-		s.Logger.Error(err)
-		return s.handleError(c, dto.InternalErrorResponse)
+		return s.handleError(c, err)
 	}
 
 	resp := dto.NewProfileResponse(profile)
 	return s.handleSuccess(c, resp)
-}
\ No newline at end of file
+}
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"e-wallet/internal/ratelimit"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderRateLimitLimit     = "X-RateLimit-Limit"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	HeaderRateLimitReset     = "X-RateLimit-Reset"
+)
+
+// RateLimitRule limits the requests whose path starts with one of Prefixes
+// (all requests when empty, except the probes and docs), counted per Key.
+type RateLimitRule struct {
+	Name     string
+	Prefixes []string
+	// Key identifies the client; requests without a key are not limited.
+	Key   func(c echo.Context) (string, bool)
+	Limit ratelimit.Limit
+}
+
+// rateLimitExempt are never limited: probes must keep working when a client
+// is throttled. /metrics is limited, as its token could be guessed otherwise.
+var rateLimitExempt = []string{"/healthz", "/swagger/"}
+
+// ipExtractor reads the client address from the connection, or from
+// X-Forwarded-For when the request came through one of the trusted proxies.
+// The headers are ignored otherwise, so that a client can't choose the
+// address its requests are limited and audited by.
+func (s *Server) ipExtractor() (echo.IPExtractor, error) {
+	if len(s.Config.HTTP.TrustedProxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, cidr := range s.Config.HTTP.TrustedProxies {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("trusted proxy %q: %w", cidr, err)
+		}
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
+
+// ClientIP keys the bucket by the client address.
+func ClientIP(c echo.Context) (string, bool) {
+	return "ip:" + c.RealIP(), true
+}
+
+// AuthenticatedUser keys the bucket by the user from the access token.
+func AuthenticatedUser(c echo.Context) (string, bool) {
+	userID, ok := c.Get(UserIDKey).(string)
+	if !ok || userID == "" {
+		return "", false
+	}
+	return "user:" + userID, true
+}
+
+// RegisterIPRateLimit limits every client by IP. It runs before
+// authentication so that invalid tokens are throttled too.
+func (s *Server) RegisterIPRateLimit() {
+	if s.RateLimitStore == nil {
+		return
+	}
+	cfg := s.Config.RateLimit
+	s.Router.Use(s.RateLimit(RateLimitRule{
+		Name:  "ip",
+		Key:   ClientIP,
+		Limit: ratelimit.PerMinute(cfg.IPPerMinute, cfg.IPBurst),
+	}))
+}
+
+// RegisterRouteRateLimits limits the auth endpoints by IP and the rest of the
+// API by user. It runs after authentication.
+func (s *Server) RegisterRouteRateLimits() {
+	if s.RateLimitStore == nil {
+		return
+	}
+	cfg := s.Config.RateLimit
+	s.Router.Use(s.RateLimit(RateLimitRule{
+		Name:     "auth",
+		Prefixes: []string{"/api/auth"},
+		Key:      ClientIP,
+		Limit:    ratelimit.PerMinute(cfg.AuthPerMinute, cfg.AuthBurst),
+	}))
+	s.Router.Use(s.RateLimit(RateLimitRule{
+		Name:     "user",
+		Prefixes: []string{"/api/"},
+		Key:      AuthenticatedUser,
+		Limit:    ratelimit.PerMinute(cfg.UserPerMinute, cfg.UserBurst),
+	}))
+}
+
+// RateLimit applies rule with token bucket semantics and reports the bucket
+// state in the X-RateLimit-* headers. Store failures let the request through.
+func (s *Server) RateLimit(rule RateLimitRule) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			path := c.Request().URL.Path
+			if hasAnyPrefix(path, rateLimitExempt) || (len(rule.Prefixes) > 0 && !hasAnyPrefix(path, rule.Prefixes)) {
+				return next(c)
+			}
+			key, ok := rule.Key(c)
+			if !ok {
+				return next(c)
+			}
+
+			res, err := s.RateLimitStore.Take(c.Request().Context(), "rl:"+rule.Name+":"+key, rule.Limit, s.Clock.Now())
+			if err != nil {
+				s.log(c).Warnw("rate limit store unavailable, request let through", "rule", rule.Name, "error", err)
+				return next(c)
+			}
+
+			h := c.Response().Header()
+			h.Set(HeaderRateLimitLimit, strconv.Itoa(res.Limit))
+			h.Set(HeaderRateLimitRemaining, strconv.Itoa(res.Remaining))
+			h.Set(HeaderRateLimitReset, strconv.Itoa(seconds(res.ResetAfter)))
+			if !res.Allowed {
+				retryAfter := strconv.Itoa(seconds(res.RetryAfter))
+				h.Set(echo.HeaderRetryAfter, retryAfter)
+				s.log(c).Infow("rate limited", "rule", rule.Name)
+				return s.writeProblem(c, errTooManyRequests, http.StatusTooManyRequests, errTooManyRequests.With("retry_after", retryAfter))
+			}
+			return next(c)
+		}
+	}
+}
+
+func hasAnyPrefix(path string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(path, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// seconds rounds d up to whole seconds, as Retry-After takes no fractions.
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,157 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"e-wallet/internal/adapters/handler/http/dto"
+	"e-wallet/internal/adapters/repository/memory"
+	"e-wallet/internal/config"
+	"e-wallet/internal/domain/privacy"
+	"e-wallet/internal/ports"
+	"e-wallet/internal/ratelimit"
+	"e-wallet/mocks"
+)
+
+func newRateLimitedServer(t *testing.T, store ports.RateLimitStore) *Server {
+	cfg := &config.Config{JWTSecret: "test-secret"}
+	cfg.RateLimit.IPPerMinute, cfg.RateLimit.IPBurst = 600, 100
+	cfg.RateLimit.AuthPerMinute, cfg.RateLimit.AuthBurst = 1, 2
+	cfg.RateLimit.UserPerMinute, cfg.RateLimit.UserBurst = 1, 1
+
+	s, err := New(WithConfig(cfg), WithRateLimitStore(store))
+	require.NoError(t, err)
+	return s
+}
+
+func login(s *Server, ip string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/login", strings.NewReader("{"))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.RemoteAddr = ip + ":40000"
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_RateLimit_Auth(t *testing.T) {
+	s := newRateLimitedServer(t, memory.NewRateLimitStore())
+
+	rec := login(s, "10.0.0.1")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get(HeaderRateLimitLimit))
+	assert.Equal(t, "1", rec.Header().Get(HeaderRateLimitRemaining))
+	assert.Equal(t, "60", rec.Header().Get(HeaderRateLimitReset))
+
+	rec = login(s, "10.0.0.1")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "0", rec.Header().Get(HeaderRateLimitRemaining))
+
+	rec = login(s, "10.0.0.1")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "60", rec.Header().Get(echo.HeaderRetryAfter))
+	assert.Equal(t, dto.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+
+	var problem dto.ProblemDetails
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, "too_many_requests", problem.Code)
+	assert.Equal(t, "too many requests, retry in 60 seconds", problem.Detail)
+
+	// another client is not affected
+	rec = login(s, "10.0.0.2")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestServer_RateLimit_ForwardedFor(t *testing.T) {
+	loginVia := func(s *Server, peer, forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/login", strings.NewReader("{"))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+		req.Header.Set(echo.HeaderXRealIP, forwardedFor)
+		req.RemoteAddr = peer + ":40000"
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("ignored from clients", func(t *testing.T) {
+		s := newRateLimitedServer(t, memory.NewRateLimitStore())
+
+		// a new address in the headers on every try is still the same client
+		codes := []int{loginVia(s, "203.0.113.7", "10.0.0.1"), loginVia(s, "203.0.113.7", "10.0.0.2"), loginVia(s, "203.0.113.7", "10.0.0.3")}
+		assert.Equal(t, []int{http.StatusBadRequest, http.StatusBadRequest, http.StatusTooManyRequests}, codes)
+	})
+
+	t.Run("believed from trusted proxies", func(t *testing.T) {
+		s := newRateLimitedServer(t, memory.NewRateLimitStore())
+		s.Config.HTTP.TrustedProxies = []string{"10.1.0.0/16"}
+		extractor, err := s.ipExtractor()
+		require.NoError(t, err)
+		s.Router.IPExtractor = extractor
+
+		codes := []int{loginVia(s, "10.1.0.5", "203.0.113.7"), loginVia(s, "10.1.0.5", "203.0.113.7"), loginVia(s, "10.1.0.5", "203.0.113.8")}
+		assert.Equal(t, []int{http.StatusBadRequest, http.StatusBadRequest, http.StatusBadRequest}, codes)
+	})
+}
+
+func TestServer_RateLimit_User(t *testing.T) {
+	s := newRateLimitedServer(t, memory.NewRateLimitStore())
+	privacySvc := mocks.NewMockPrivacyService(t)
+	privacySvc.EXPECT().GetExport(mock.Anything, mock.Anything, "exp-1").Return(nil, privacy.ErrExportNotFound)
+	s.PrivacyService = privacySvc
+	s.UserService = activeUsers(t)
+
+	get := func(userID, ip string) *httptest.ResponseRecorder {
+		token, err := CreateAccessToken(time.Now(), time.Hour, TokenPayload{UserID: userID}, "test-secret")
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodGet, "/api/users/export/exp-1", nil)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		req.RemoteAddr = ip + ":40000"
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusNotFound, get("user-1", "10.0.0.1").Code)
+	// the same user from another IP shares the bucket
+	assert.Equal(t, http.StatusTooManyRequests, get("user-1", "10.0.0.2").Code)
+	assert.Equal(t, http.StatusNotFound, get("user-2", "10.0.0.1").Code)
+}
+
+func TestServer_RateLimit_Exempt(t *testing.T) {
+	s := newRateLimitedServer(t, memory.NewRateLimitStore())
+	s.Config.RateLimit.IPBurst = 1
+
+	for i := 0; i < 5; i++ {
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz/live", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get(HeaderRateLimitLimit))
+	}
+}
+
+type failingRateLimitStore struct{}
+
+func (failingRateLimitStore) Take(context.Context, string, ratelimit.Limit, time.Time) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("connection refused")
+}
+
+func TestServer_RateLimit_StoreFailureLetsThrough(t *testing.T) {
+	s := newRateLimitedServer(t, failingRateLimitStore{})
+
+	for i := 0; i < 5; i++ {
+		rec := login(s, "10.0.0.1")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Empty(t, rec.Header().Get(HeaderRateLimitLimit))
+	}
+}
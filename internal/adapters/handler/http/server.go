@@ -1,12 +1,18 @@
 package http
 
 import (
+	"context"
+	"e-wallet/internal/adapters/clock"
 	"e-wallet/internal/adapters/handler/http/dto"
+	"e-wallet/internal/adapters/metrics"
 	"e-wallet/internal/config"
+	"e-wallet/internal/domain/user"
+	"e-wallet/internal/health"
 	"e-wallet/internal/ports"
 	"e-wallet/pkg/logger"
 	"net/http"
 	"strings"
+	"sync/atomic"
 
 	sentryecho "github.com/getsentry/sentry-go/echo"
 	"github.com/go-playground/validator/v10"
@@ -27,6 +33,25 @@ type Server struct {
 	UserService    ports.UserService
 	ProfileService ports.ProfileService
 	AccountService ports.AccountService
+	PrivacyService ports.PrivacyService
+
+	AuditLogger ports.AuditLogger
+	// Encryptor is optional; it blind-indexes the emails of failed logins
+	Encryptor ports.FieldEncryptor
+
+	// Health holds the dependency checks behind /healthz/ready
+	Health *health.Registry
+	// Metrics is optional; /metrics is only served when it and
+	// Config.MetricsToken are set
+	Metrics *metrics.Prometheus
+	// RateLimitStore is optional; requests are not limited when it is nil
+	RateLimitStore ports.RateLimitStore
+	// Clock tells the time to tokens and rate limits
+	Clock ports.Clock
+
+	// draining is set when the server starts shutting down, so that
+	// /healthz/ready fails while in-flight requests drain
+	draining atomic.Bool
 }
 
 type CustomValidator struct {
@@ -66,11 +91,14 @@ func New(options ...Options) (*Server, error) {
 		Router: echo.New(),
 		Config: config.Empty,
 		Logger: logger.NOOPLogger,
+		Clock:  clock.System,
+		Health: health.NewRegistry(),
 	}
 
 	v := validator.New()
 	dto.RegisterCustomValidations(v)
 	s.Router.Validator = &CustomValidator{validator: v}
+	s.Router.HTTPErrorHandler = s.HTTPErrorHandler
 
 	for _, fn := range options {
 		if err := fn(&s); err != nil {
@@ -78,10 +106,19 @@ func New(options ...Options) (*Server, error) {
 		}
 	}
 
+	ipExtractor, err := s.ipExtractor()
+	if err != nil {
+		return nil, err
+	}
+	s.Router.IPExtractor = ipExtractor
+
 	s.RegisterGlobalMiddlewares()
 	s.RegisterAuthMiddlewares()
 	s.RegisterRoute()
 	s.RegisterSwagger()
+	if s.Metrics != nil && s.Config.MetricsToken != "" {
+		s.RegisterMetrics()
+	}
 
 	s.RegisterHealthCheck(s.Router.Group(""))
 
@@ -89,9 +126,15 @@ func New(options ...Options) (*Server, error) {
 }
 
 func (s *Server) RegisterGlobalMiddlewares() {
+	if s.Metrics != nil {
+		s.Router.Use(s.RecordMetrics())
+	}
+	s.Router.Use(s.Trace())
+	s.Router.Use(middleware.RequestID())
+	s.Router.Use(s.RequestLogger())
 	s.Router.Use(middleware.Recover())
 	s.Router.Use(middleware.Secure())
-	s.Router.Use(middleware.RequestID())
+	s.Router.Use(s.Localize())
 	s.Router.Use(middleware.Gzip())
 	s.Router.Use(sentryecho.New(sentryecho.Options{Repanic: true}))
 
@@ -102,55 +145,33 @@ func (s *Server) RegisterGlobalMiddlewares() {
 			AllowOrigins: aos,
 		}))
 	}
+
+	s.RegisterIPRateLimit()
 }
 
 func (s *Server) RegisterAuthMiddlewares() {
 	skipperPath := []string{
 		"/healthz",
+		"/metrics",
 		"/api/auth",
 		"/swagger/",
 	}
-	s.Router.Use(NewAuthentication("header:Authorization", "Bearer", skipperPath).Middleware())
+	// the user service is looked up per request, as it is set after New
+	activeUser := func(ctx context.Context, userID string) (*user.User, error) {
+		return s.UserService.GetActiveUser(ctx, userID)
+	}
+	s.Router.Use(NewAuthentication("header:Authorization", "Bearer", s.Config.JWTSecret, skipperPath, s.Clock, activeUser).Middleware())
+	s.RegisterRouteRateLimits()
 }
 
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.Router.ServeHTTP(w, r)
 }
 
-// RegisterHealthCheck godoc
-//
-//	@Summary		Health check
-//	@Description	Check if the service is up and running
-//	@Tags			health
-//	@Produce		json
-//	@Success		200	{object}	map[string]string
-//	@Router			/healthz [get]
-func (s *Server) RegisterHealthCheck(router *echo.Group) {
-	router.GET("/healthz", func(c echo.Context) error {
-		return c.JSON(http.StatusOK, map[string]string{
-			"status":  http.StatusText(http.StatusOK),
-			"message": "Service is up and running",
-		})
-	})
-}
-
-func (s *Server) handleError(c echo.Context, resErr dto.Response) error {
-	s.Logger.Errorw(
-		resErr.Message,
-		zap.String("request_id", s.requestID(c)),
-	)
-
-	return c.JSON(resErr.Status, dto.Response{
-		Status:  resErr.Status,
-		Message: resErr.Message,
-		Data:    nil,
-	})
-}
-
 func (s *Server) handleSuccess(c echo.Context, data any) error {
 	return c.JSON(http.StatusOK, dto.Response{
 		Status:  http.StatusOK,
-		Message: http.StatusText(http.StatusOK),
+		Message: s.translate(c, "response.ok"),
 		Data:    data,
 	})
 }
@@ -161,7 +182,7 @@ func (s *Server) requestID(c echo.Context) string {
 
 func (s *Server) RegisterRoutes(router *echo.Group) {
 	router.POST("/register", s.CreateUser)
-	router.POST("/login", s.Login)
+	router.POST("/login", s.LoginUser)
 }
 
 func (s *Server) RegisterRoute() {
@@ -173,12 +194,24 @@ func (s *Server) RegisterRoute() {
 	// users
 	apiGroup.PUT("/users/profile", s.UpdateProfile)
 	apiGroup.GET("/users/profile", s.GetProfile)
+	apiGroup.POST("/users/export", s.RequestDataExport)
+	apiGroup.GET("/users/export/:id", s.GetDataExport)
+	apiGroup.GET("/users/export/:id/download", s.DownloadDataExport)
+	apiGroup.POST("/users/deletion", s.DeleteUserData)
 
 	// accounts
 	apiGroup.POST("/accounts/payment", s.CreatePaymentAccount)
 	apiGroup.POST("/accounts/savings/fixed", s.CreateFixedSavingsAccount)
 	apiGroup.POST("/accounts/savings/flexible", s.CreateFlexibleSavingsAccount)
+	apiGroup.PUT("/accounts/savings/fixed/:id/maturity-instruction", s.UpdateMaturityInstruction)
+	apiGroup.POST("/accounts/savings/flexible/:id/deposit", s.DepositToFlexibleSavings)
+	apiGroup.POST("/accounts/savings/flexible/:id/withdraw", s.WithdrawFromFlexibleSavings)
 	apiGroup.GET("/accounts", s.ListAccounts)
+
+	// admin
+	adminGroup := apiGroup.Group("/admin", s.RequireAdmin())
+	adminGroup.GET("/audit-logs", s.ListAuditLogs)
+	adminGroup.GET("/audit-logs/verify", s.VerifyAuditLogs)
 }
 
 func (s *Server) RegisterSwagger() {
@@ -0,0 +1,130 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// apiSpec checks responses against the Swagger document the server serves,
+// so that the document can't drift from what the handlers return.
+//
+// The document is read more strictly than Swagger 2.0 does: objects may only
+// hold the properties it lists, and a property is only nullable when it is
+// marked x-nullable.
+type apiSpec struct {
+	definitions map[string]any
+	paths       map[string]map[string]struct {
+		Responses map[string]struct {
+			Schema map[string]any `json:"schema"`
+		} `json:"responses"`
+	}
+}
+
+func loadAPISpec(t *testing.T, baseURL string) *apiSpec {
+	t.Helper()
+
+	res, err := http.Get(baseURL + "/swagger/doc.json")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var doc struct {
+		Definitions map[string]any  `json:"definitions"`
+		Paths       json.RawMessage `json:"paths"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&doc))
+
+	spec := &apiSpec{definitions: map[string]any{}}
+	require.NoError(t, json.Unmarshal(doc.Paths, &spec.paths))
+	for name, schema := range doc.Definitions {
+		spec.definitions[name] = strictSchema(schema)
+	}
+	return spec
+}
+
+// validate fails the test unless the response to method route is documented
+// for its status code and its body matches the documented schema.
+func (s *apiSpec) validate(t *testing.T, method, route string, res apiResponse) {
+	t.Helper()
+
+	op, ok := s.paths[route][strings.ToLower(method)]
+	if !ok {
+		t.Errorf("%s %s is not documented", method, route)
+		return
+	}
+	documented, ok := op.Responses[strconv.Itoa(res.Status)]
+	if !ok {
+		t.Errorf("%s %s: status %d is not documented", method, route, res.Status)
+		return
+	}
+	if documented.Schema == nil || documented.Schema["type"] == "file" {
+		return
+	}
+
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(map[string]any{
+		"definitions": s.definitions,
+		"allOf":       []any{strictSchema(documented.Schema)},
+	}))
+	require.NoError(t, err)
+
+	result, err := schema.Validate(gojsonschema.NewBytesLoader(res.Body))
+	require.NoError(t, err, "%s %s: the body is not JSON: %s", method, route, res.Body)
+	for _, e := range result.Errors() {
+		t.Errorf("%s %s %d: %s\n%s", method, route, res.Status, e, res.Body)
+	}
+}
+
+// strictSchema returns a copy of schema whose objects reject properties they
+// don't list, and whose x-nullable members also accept null.
+func strictSchema(schema any) any {
+	switch s := schema.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(s)+1)
+		for k, v := range s {
+			switch k {
+			case "properties":
+				props := map[string]any{}
+				for name, prop := range v.(map[string]any) {
+					props[name] = strictSchema(prop)
+				}
+				out[k] = props
+			case "items", "additionalProperties":
+				out[k] = strictSchema(v)
+			case "allOf":
+				out[k] = openMembers(v.([]any))
+			case "x-nullable":
+			default:
+				out[k] = v
+			}
+		}
+		if _, ok := out["properties"]; ok && out["additionalProperties"] == nil {
+			out["additionalProperties"] = false
+		}
+		if nullable, _ := s["x-nullable"].(bool); nullable {
+			return map[string]any{"anyOf": []any{map[string]any{"type": "null"}, out}}
+		}
+		return out
+	default:
+		return schema
+	}
+}
+
+// openMembers makes the members of an allOf strict, except for their own
+// properties: each member only lists part of the properties of the whole.
+func openMembers(members []any) []any {
+	out := make([]any, len(members))
+	for i, m := range members {
+		strict := strictSchema(m)
+		if obj, ok := strict.(map[string]any); ok && m.(map[string]any)["additionalProperties"] == nil {
+			delete(obj, "additionalProperties")
+		}
+		out[i] = strict
+	}
+	return out
+}
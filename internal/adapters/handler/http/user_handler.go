@@ -1,7 +1,10 @@
 package http
 
 import (
+	"errors"
+
 	"e-wallet/internal/adapters/handler/http/dto"
+	"e-wallet/internal/domain/audit"
 	"e-wallet/internal/domain/user"
 
 	"github.com/labstack/echo/v4"
@@ -15,29 +18,37 @@ import (
 //	@Accept			json
 //	@Produce		json
 //	@Param			request	body		dto.CreateUserRequest	true	"User registration data"
-//	@Success		200		{object}	dto.CreateUserResponse
-//	@Failure		400		{object}	dto.Response
-//	@Failure		500		{object}	dto.Response
+//	@Success		200		{object}	dto.Response{data=dto.CreateUserResponse}
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		409		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
 //	@Router			/api/auth/register [post]
 func (s *Server) CreateUser(c echo.Context) error {
 	var req dto.CreateUserRequest
 	if err := c.Bind(&req); err != nil {
-		return s.handleError(c, dto.BadRequestResponse)
+		return s.handleError(c, errInvalidRequestBody.Wrap(err))
 	}
 
 	if err := c.Validate(&req); err != nil {
-		return s.handleError(c, dto.BadRequestResponse)
+		return s.handleError(c, validationError(err))
 	}
 
-	_, err := s.UserService.CreateUser(c.Request().Context(), &user.CreateUserRequest{
+	createdUser, err := s.UserService.CreateUser(c.Request().Context(), &user.CreateUserRequest{
 		Username: req.Username,
 		Email:    req.Email,
 		Password: req.Password,
 	})
 	if err != nil {
-		return s.handleError(c, dto.InternalErrorResponse)
+		return s.handleError(c, err)
 	}
 
+	s.recordAudit(c, &audit.Entry{
+		Action:     audit.ActionUserRegistered,
+		ActorID:    createdUser.ID,
+		TargetType: audit.TargetUser,
+		TargetID:   createdUser.ID,
+	})
+
 	resp := dto.NewCreateUserResponse()
 	return s.handleSuccess(c, resp)
 }
@@ -50,35 +61,69 @@ func (s *Server) CreateUser(c echo.Context) error {
 //	@Accept			json
 //	@Produce		json
 //	@Param			request	body		dto.LoginUserRequest	true	"User login data"
-//	@Success		200		{object}	dto.LoginUserResponse
-//	@Failure		400		{object}	dto.Response
-//	@Failure		401		{object}	dto.Response
-//	@Failure		500		{object}	dto.Response
+//	@Success		200		{object}	dto.Response{data=dto.LoginUserResponse}
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
 //	@Router			/api/auth/login [post]
 func (s *Server) LoginUser(c echo.Context) error {
 	var req dto.LoginUserRequest
 	if err := c.Bind(&req); err != nil {
-		return s.handleError(c, dto.BadRequestResponse)
+		return s.handleError(c, errInvalidRequestBody.Wrap(err))
 	}
 
 	if err := c.Validate(&req); err != nil {
-		return s.handleError(c, dto.BadRequestResponse)
+		return s.handleError(c, validationError(err))
 	}
 
-	user, err := s.UserService.LoginUser(c.Request().Context(), &user.LoginUserRequest{
+	u, err := s.UserService.LoginUser(c.Request().Context(), &user.LoginUserRequest{
 		Email:    req.Email,
 		Password: req.Password,
 	})
 	if err != nil {
-		return s.handleError(c, dto.UnauthorizedResponse)
+		s.recordAudit(c, s.loginFailedEntry(c, req.Email, err))
+		return s.handleError(c, err)
 	}
 
-	payload := TokenPayload{UserID: user.ID}
-	token, err := CreateAccessToken(DefaultExpiredTime, payload, s.Config.JWTSecret)
+	payload := TokenPayload{UserID: u.ID}
+	token, err := CreateAccessToken(s.Clock.Now(), DefaultExpiredTime, payload, s.Config.JWTSecret)
 	if err != nil {
-		return s.handleError(c, dto.InternalErrorResponse)
+		return s.handleError(c, err)
 	}
 
-	resp := dto.NewLoginUserResponse(user, token)
+	s.recordAudit(c, &audit.Entry{
+		Action:     audit.ActionLogin,
+		ActorID:    u.ID,
+		TargetType: audit.TargetUser,
+		TargetID:   u.ID,
+	})
+
+	resp := dto.NewLoginUserResponse(u, token)
 	return s.handleSuccess(c, resp)
 }
+
+// loginFailedEntry attributes a failed login to the account it was for. The
+// audit log is kept longer than the users it mentions, so an email matching no
+// account is only recorded as its blind index, and not at all without one.
+func (s *Server) loginFailedEntry(c echo.Context, email string, err error) *audit.Entry {
+	entry := &audit.Entry{
+		Action:     audit.ActionLoginFailed,
+		TargetType: audit.TargetUser,
+	}
+
+	var wrongPassword *user.WrongPasswordError
+	if errors.As(err, &wrongPassword) {
+		entry.TargetID = wrongPassword.UserID
+		return entry
+	}
+	if s.Encryptor == nil {
+		return entry
+	}
+	emailIndex, err := s.Encryptor.BlindIndex(email)
+	if err != nil {
+		s.log(c).Errorw("cannot index the email of a failed login", "error", err)
+		return entry
+	}
+	entry.Metadata = map[string]string{"email_bidx": emailIndex}
+	return entry
+}
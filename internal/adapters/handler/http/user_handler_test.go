@@ -2,6 +2,7 @@ package http
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -13,13 +14,16 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"e-wallet/internal/adapters/clock"
 	"e-wallet/internal/adapters/handler/http/dto"
 	"e-wallet/internal/config"
+	"e-wallet/internal/domain/apperror"
+	"e-wallet/internal/domain/audit"
 	"e-wallet/internal/domain/user"
 	"e-wallet/mocks"
 	"e-wallet/pkg/logger"
-	"strings"
 )
 
 func TestServer_CreateUser(t *testing.T) {
@@ -29,6 +33,7 @@ func TestServer_CreateUser(t *testing.T) {
 		mockSetup        func(*mocks.MockUserService)
 		expectedStatus   int
 		expectedResponse dto.Response
+		expectedCode     string
 	}{
 		{
 			name: "success - create user",
@@ -71,7 +76,7 @@ func TestServer_CreateUser(t *testing.T) {
 				// No mock setup needed as bind fails
 			},
 			expectedStatus: http.StatusBadRequest,
-			expectedResponse: dto.BadRequestResponse,
+			expectedCode:   "invalid_request_body",
 		},
 		{
 			name: "error - validation fails",
@@ -84,7 +89,7 @@ func TestServer_CreateUser(t *testing.T) {
 				// No mock setup needed as validation fails
 			},
 			expectedStatus: http.StatusBadRequest,
-			expectedResponse: dto.BadRequestResponse,
+			expectedCode:   apperror.CodeValidationFailed,
 		},
 		{
 			name: "error - service fails",
@@ -102,7 +107,23 @@ func TestServer_CreateUser(t *testing.T) {
 					Once()
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedResponse: dto.InternalErrorResponse,
+			expectedCode:   "internal_error",
+		},
+		{
+			name: "error - user already exists",
+			requestBody: dto.CreateUserRequest{
+				Username: "testuser",
+				Email:    "test@example.com",
+				Password: "TestPass123@!",
+			},
+			mockSetup: func(userSvc *mocks.MockUserService) {
+				userSvc.EXPECT().
+					CreateUser(mock.Anything, mock.Anything).
+					Return(nil, user.ErrUserAlreadyExists).
+					Once()
+			},
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "user_already_exists",
 		},
 	}
 
@@ -146,6 +167,11 @@ func TestServer_CreateUser(t *testing.T) {
 			// Assert
 			assert.NoError(t, err)
 			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedCode != "" {
+				assertProblem(t, rec, tt.expectedStatus, tt.expectedCode)
+				return
+			}
+
 			var actualResponse dto.Response
 			_ = json.Unmarshal(rec.Body.Bytes(), &actualResponse)
 			dataActualJson, _ := json.Marshal(actualResponse.Data)
@@ -162,9 +188,7 @@ func TestServer_CreateUser(t *testing.T) {
 				Status:  actualResponse.Status,
 				Message: actualResponse.Message,
 			})
-			if strings.Contains(tt.name, "success") {
-				assert.Equal(t, expectedData, dataActual)
-			}
+			assert.Equal(t, expectedData, dataActual)
 		})
 	}
 }
@@ -176,6 +200,7 @@ func TestServer_LoginUser(t *testing.T) {
 		mockSetup        func(*mocks.MockUserService)
 		expectedStatus   int
 		expectedResponse dto.Response
+		expectedCode     string
 	}{
 		{
 			name: "success - login user",
@@ -224,7 +249,7 @@ func TestServer_LoginUser(t *testing.T) {
 				// No mock setup needed as bind fails
 			},
 			expectedStatus: http.StatusBadRequest,
-			expectedResponse: dto.BadRequestResponse,
+			expectedCode:   "invalid_request_body",
 		},
 		{
 			name: "error - validation fails",
@@ -236,7 +261,7 @@ func TestServer_LoginUser(t *testing.T) {
 				// No mock setup needed as validation fails
 			},
 			expectedStatus: http.StatusBadRequest,
-			expectedResponse: dto.BadRequestResponse,
+			expectedCode:   apperror.CodeValidationFailed,
 		},
 		{
 			name: "error - service fails",
@@ -252,8 +277,23 @@ func TestServer_LoginUser(t *testing.T) {
 					Return(nil, errors.New("service error")).
 					Once()
 			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   "internal_error",
+		},
+		{
+			name: "error - invalid credentials",
+			requestBody: dto.LoginUserRequest{
+				Email:    "test@example.com",
+				Password: "TestPass123@!",
+			},
+			mockSetup: func(userSvc *mocks.MockUserService) {
+				userSvc.EXPECT().
+					LoginUser(mock.Anything, mock.Anything).
+					Return(nil, user.ErrInvalidCredentials).
+					Once()
+			},
 			expectedStatus: http.StatusUnauthorized,
-			expectedResponse: dto.UnauthorizedResponse,
+			expectedCode:   "invalid_credentials",
 		},
 	}
 
@@ -292,6 +332,7 @@ func TestServer_LoginUser(t *testing.T) {
 				Config: &config.Config{
 					JWTSecret: "test-secret",
 				},
+				Clock: clock.System,
 			}
 
 			// Execute
@@ -300,6 +341,11 @@ func TestServer_LoginUser(t *testing.T) {
 
 			// Assert
 			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedCode != "" {
+				assertProblem(t, rec, tt.expectedStatus, tt.expectedCode)
+				return
+			}
+
 			var actualResponse dto.Response
 			_ = json.Unmarshal(rec.Body.Bytes(), &actualResponse)
 			dataActualJson, _ := json.Marshal(actualResponse.Data)
@@ -316,9 +362,99 @@ func TestServer_LoginUser(t *testing.T) {
 				Status:  actualResponse.Status,
 				Message: actualResponse.Message,
 			})
-			if strings.Contains(tt.name, "success") {
-				assert.NotEmpty(t, dataActual.Token)
-			}
+			assert.NotEmpty(t, dataActual.Token)
+		})
+	}
+}
+
+func TestServer_LoginUser_AuditsFailure(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		expectedEntry audit.Entry
+	}{
+		{
+			name: "wrong password",
+			err:  user.ErrInvalidCredentials.Wrap(&user.WrongPasswordError{UserID: "user-123", Err: errors.New("mismatch")}),
+			expectedEntry: audit.Entry{
+				Action:     audit.ActionLoginFailed,
+				TargetType: audit.TargetUser,
+				TargetID:   "user-123",
+			},
+		},
+		{
+			name: "unknown email",
+			err:  user.ErrInvalidCredentials,
+			expectedEntry: audit.Entry{
+				Action:     audit.ActionLoginFailed,
+				TargetType: audit.TargetUser,
+				Metadata:   map[string]string{"email_bidx": "email-index"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userSvc := mocks.NewMockUserService(t)
+			userSvc.EXPECT().LoginUser(mock.Anything, mock.Anything).Return(nil, tt.err).Once()
+			encryptor := mocks.NewMockFieldEncryptor(t)
+			encryptor.EXPECT().BlindIndex("test@example.com").Return("email-index", nil).Maybe()
+
+			var logged *audit.Entry
+			auditLogger := mocks.NewMockAuditLogger(t)
+			auditLogger.EXPECT().Log(mock.Anything, mock.Anything).
+				RunAndReturn(func(_ context.Context, entry *audit.Entry) error {
+					logged = entry
+					return nil
+				}).
+				Once()
+
+			s, err := New(WithConfig(&config.Config{JWTSecret: "test-secret"}))
+			require.NoError(t, err)
+			s.UserService = userSvc
+			s.AuditLogger = auditLogger
+			s.Encryptor = encryptor
+
+			body, _ := json.Marshal(dto.LoginUserRequest{Email: "test@example.com", Password: "TestPass123@!"})
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/login", bytes.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, req)
+
+			assertProblem(t, rec, http.StatusUnauthorized, "invalid_credentials")
+			require.NotNil(t, logged)
+			assert.Equal(t, tt.expectedEntry.Action, logged.Action)
+			assert.Equal(t, tt.expectedEntry.TargetType, logged.TargetType)
+			assert.Equal(t, tt.expectedEntry.TargetID, logged.TargetID)
+			assert.Equal(t, tt.expectedEntry.Metadata, logged.Metadata)
 		})
 	}
 }
+
+// activeUsers is a user service to which every user is active, for the
+// tests of authenticated endpoints.
+func activeUsers(t *testing.T) *mocks.MockUserService {
+	userSvc := mocks.NewMockUserService(t)
+	userSvc.EXPECT().GetActiveUser(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, userID string) (*user.User, error) {
+			return &user.User{ID: userID}, nil
+		}).
+		Maybe()
+	return userSvc
+}
+
+func assertProblem(t *testing.T, rec *httptest.ResponseRecorder, status int, code string) {
+	t.Helper()
+
+	assert.Equal(t, dto.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+
+	var problem dto.ProblemDetails
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, status, problem.Status)
+	assert.Equal(t, http.StatusText(status), problem.Title)
+	assert.Equal(t, code, problem.Code)
+	assert.Equal(t, dto.ProblemTypePrefix+code, problem.Type)
+	if status == http.StatusInternalServerError {
+		assert.NotContains(t, problem.Detail, "service error")
+	}
+}
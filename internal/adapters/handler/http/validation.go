@@ -0,0 +1,53 @@
+package http
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"e-wallet/internal/adapters/handler/http/dto"
+	"e-wallet/internal/domain/apperror"
+	"e-wallet/internal/i18n"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// validationError converts the error returned by c.Validate into a
+// validation error listing every rejected field. Messages are English here
+// and get localised when the response is written.
+func validationError(err error) error {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return apperror.Validation().Wrap(err)
+	}
+
+	fields := make([]apperror.FieldError, 0, len(verrs))
+	for _, fe := range verrs {
+		f := apperror.FieldError{
+			Field: fieldPath(fe),
+			Code:  fe.Tag(),
+			Param: fe.Param(),
+		}
+		switch f.Code {
+		case "min", "max":
+			// Length of a string rather than a value
+			if fe.Kind() == reflect.String {
+				f.Code += "_length"
+			}
+		case "team":
+			f.Param = strings.Join(dto.ValidTeams, " ")
+		}
+		f.Message = fieldMessage(i18n.English, f)
+		fields = append(fields, f)
+	}
+	return apperror.Validation(fields...).Wrap(err)
+}
+
+// fieldPath returns the dotted path of the field without the request struct
+// name, e.g. "term_code" or "items[0].amount".
+func fieldPath(fe validator.FieldError) string {
+	if _, path, ok := strings.Cut(fe.Namespace(), "."); ok {
+		return path
+	}
+	return fe.Field()
+}
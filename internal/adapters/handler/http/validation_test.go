@@ -0,0 +1,149 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"e-wallet/internal/adapters/handler/http/dto"
+	"e-wallet/internal/domain/account"
+	"e-wallet/internal/domain/apperror"
+	"e-wallet/internal/domain/privacy"
+	"e-wallet/internal/i18n"
+)
+
+func TestValidationError(t *testing.T) {
+	v := validator.New()
+	dto.RegisterCustomValidations(v)
+
+	tests := []struct {
+		name     string
+		request  any
+		expected []apperror.FieldError
+	}{
+		{
+			name: "create user - custom password rule",
+			request: &dto.CreateUserRequest{
+				Username: "",
+				Email:    "invalid-email",
+				Password: "short",
+			},
+			expected: []apperror.FieldError{
+				{Field: "username", Code: "required", Message: "username is required"},
+				{Field: "email", Code: "email", Message: "email must be a valid email address"},
+				{Field: "password", Code: "password", Message: "password must be 12 to 50 characters long and contain a lowercase letter, an uppercase letter, a digit and one of @!$%^"},
+			},
+		},
+		{
+			name:    "fixed savings - oneof",
+			request: &dto.CreateFixedSavingsAccountRequest{TermCode: "7"},
+			expected: []apperror.FieldError{
+				{Field: "term_code", Code: "oneof", Param: "1 3 6 8 12", Message: "term_code must be one of 1, 3, 6, 8, 12"},
+			},
+		},
+		{
+			name: "update profile - phone, team and min",
+			request: &dto.UpdateProfileRequest{
+				DisplayName: "Alice",
+				PhoneNumber: "12ab",
+				NationalID:  "079201001234",
+				BirthYear:   1800,
+				Gender:      "MALE",
+				Team:        "SALES",
+			},
+			expected: []apperror.FieldError{
+				{Field: "phone_number", Code: "phone", Message: "phone_number must be a phone number of 10 to 15 digits, optionally starting with +"},
+				{Field: "birth_year", Code: "min", Param: "1900", Message: "birth_year must be at least 1900"},
+				{Field: "team", Code: "team", Param: "FRONT_END BACK_END QA ADMIN BRSE DESIGN OTHERS", Message: "team must be one of FRONT_END, BACK_END, QA, ADMIN, BRSE, DESIGN, OTHERS"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validationError(v.Struct(tt.request))
+
+			appErr, ok := apperror.As(err)
+			require.True(t, ok)
+			assert.Equal(t, apperror.KindValidation, appErr.Kind)
+			assert.Equal(t, apperror.CodeValidationFailed, appErr.Code)
+			assert.Equal(t, tt.expected, appErr.Fields)
+			assert.Equal(t, tt.expected[0].Message, appErr.Message)
+		})
+	}
+}
+
+func TestLocalizeError(t *testing.T) {
+	v := validator.New()
+	dto.RegisterCustomValidations(v)
+
+	tests := []struct {
+		name           string
+		lang           i18n.Language
+		err            *apperror.Error
+		expectedDetail string
+		expectedFields []string
+	}{
+		{
+			name:           "vi - domain error",
+			lang:           i18n.Vietnamese,
+			err:            account.ErrSavingsAccountLimit,
+			expectedDetail: "mỗi người dùng chỉ được có tối đa 5 tài khoản tiết kiệm",
+		},
+		{
+			name:           "vi - domain error with params",
+			lang:           i18n.Vietnamese,
+			err:            privacy.ErrNonZeroBalance.With("account_number", "1234567890"),
+			expectedDetail: "tài khoản 1234567890 vẫn còn số dư",
+		},
+		{
+			name:           "vi - validation error",
+			lang:           i18n.Vietnamese,
+			err:            mustAppError(t, validationError(v.Struct(&dto.CreateFixedSavingsAccountRequest{TermCode: "7"}))),
+			expectedDetail: "kỳ hạn phải là một trong các giá trị 1, 3, 6, 8, 12",
+			expectedFields: []string{"kỳ hạn phải là một trong các giá trị 1, 3, 6, 8, 12"},
+		},
+		{
+			name:           "en - domain validation error",
+			lang:           i18n.English,
+			err:            account.ErrInvalidTermCode.With("term_code", "7"),
+			expectedDetail: "term_code must be one of 1, 3, 6, 8, 12",
+			expectedFields: []string{"term_code must be one of 1, 3, 6, 8, 12"},
+		},
+		{
+			name:           "vi - invalid account number",
+			lang:           i18n.Vietnamese,
+			err:            account.ErrInvalidAccountNumber.With("account_number", "971045182370"),
+			expectedDetail: "số tài khoản phải là số tài khoản gồm 12 chữ số với chữ số kiểm tra hợp lệ",
+			expectedFields: []string{"số tài khoản phải là số tài khoản gồm 12 chữ số với chữ số kiểm tra hợp lệ"},
+		},
+		{
+			name:           "unknown code keeps the original message",
+			lang:           i18n.Vietnamese,
+			err:            apperror.NotFound("something_not_found", "something not found"),
+			expectedDetail: "something not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			localized := localizeError(tt.lang, tt.err)
+
+			assert.Equal(t, tt.expectedDetail, localized.Message)
+			var fields []string
+			for _, f := range localized.Fields {
+				fields = append(fields, f.Message)
+			}
+			assert.Equal(t, tt.expectedFields, fields)
+			assert.Equal(t, tt.err.Code, localized.Code)
+		})
+	}
+}
+
+func mustAppError(t *testing.T, err error) *apperror.Error {
+	appErr, ok := apperror.As(err)
+	require.True(t, ok)
+	return appErr
+}
@@ -0,0 +1,124 @@
+// Package holidays loads the holiday sets of the business calendar from JSON
+// files such as holidays/vn.json:
+//
+//	{
+//	  "name": "Vietnam public holidays",
+//	  "holidays": [
+//	    {"date": "2026-01-01", "name": "Tết Dương lịch"},
+//	    {"from": "2026-02-14", "to": "2026-02-22", "name": "Tết Nguyên Đán"}
+//	  ]
+//	}
+//
+// A holiday is a single date or a range of dates, both ends included. Lunar
+// holidays move from year to year, so the files are updated when the
+// government announces the days off of the next year.
+package holidays
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"e-wallet/internal/domain/calendar"
+)
+
+// maxRangeDays bounds a range of dates, the longest Tết break being 9 days.
+const maxRangeDays = 31
+
+type file struct {
+	Name     string  `json:"name"`
+	Holidays []entry `json:"holidays"`
+}
+
+type entry struct {
+	Name string `json:"name"`
+	Date string `json:"date"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// NewCalendar returns the calendar closed on the holidays of every file in
+// paths, adjusting dates by convention.
+func NewCalendar(convention string, paths ...string) (*calendar.Calendar, error) {
+	c, err := calendar.ParseConvention(convention)
+	if err != nil {
+		return nil, err
+	}
+	holidays, err := Load(paths...)
+	if err != nil {
+		return nil, err
+	}
+	return calendar.New(c, holidays...), nil
+}
+
+// Load reads the holidays of every file in paths, in one list.
+func Load(paths ...string) ([]calendar.Holiday, error) {
+	var holidays []calendar.Holiday
+	for _, path := range paths {
+		h, err := LoadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		holidays = append(holidays, h...)
+	}
+	return holidays, nil
+}
+
+// LoadFile reads the holidays of one file.
+func LoadFile(path string) ([]calendar.Holiday, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read holidays: %w", err)
+	}
+	var f file
+	if err := json.Unmarshal(raw, &f); err != nil {
+		return nil, fmt.Errorf("parse holidays %s: %w", path, err)
+	}
+
+	var holidays []calendar.Holiday
+	for i, e := range f.Holidays {
+		dates, err := e.dates()
+		if err != nil {
+			return nil, fmt.Errorf("holidays %s: entry %d: %w", path, i, err)
+		}
+		for _, d := range dates {
+			holidays = append(holidays, calendar.Holiday{Date: d, Name: e.Name})
+		}
+	}
+	return holidays, nil
+}
+
+func (e entry) dates() ([]time.Time, error) {
+	if e.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if e.Date != "" {
+		if e.From != "" || e.To != "" {
+			return nil, fmt.Errorf("%s has both a date and a range", e.Name)
+		}
+		d, err := time.Parse(time.DateOnly, e.Date)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Name, err)
+		}
+		return []time.Time{d}, nil
+	}
+
+	from, err := time.Parse(time.DateOnly, e.From)
+	if err != nil {
+		return nil, fmt.Errorf("%s: from: %w", e.Name, err)
+	}
+	to, err := time.Parse(time.DateOnly, e.To)
+	if err != nil {
+		return nil, fmt.Errorf("%s: to: %w", e.Name, err)
+	}
+	if to.Before(from) || to.Sub(from) >= maxRangeDays*24*time.Hour {
+		return nil, fmt.Errorf("%s: %s to %s is not a range of at most %d days", e.Name, e.From, e.To, maxRangeDays)
+	}
+
+	var dates []time.Time
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		dates = append(dates, d)
+	}
+	return dates, nil
+}
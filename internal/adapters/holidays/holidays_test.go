@@ -0,0 +1,76 @@
+package holidays
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"e-wallet/internal/domain/calendar"
+)
+
+func writeFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "holidays.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadFile(t *testing.T) {
+	path := writeFile(t, `{
+		"name": "test",
+		"holidays": [
+			{"date": "2026-01-01", "name": "New Year"},
+			{"from": "2026-02-14", "to": "2026-02-16", "name": "Tết"}
+		]
+	}`)
+
+	holidays, err := LoadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []calendar.Holiday{
+		{Date: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), Name: "New Year"},
+		{Date: time.Date(2026, time.February, 14, 0, 0, 0, 0, time.UTC), Name: "Tết"},
+		{Date: time.Date(2026, time.February, 15, 0, 0, 0, 0, time.UTC), Name: "Tết"},
+		{Date: time.Date(2026, time.February, 16, 0, 0, 0, 0, time.UTC), Name: "Tết"},
+	}, holidays)
+}
+
+func TestLoadFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"not json", `holidays`},
+		{"no name", `{"holidays": [{"date": "2026-01-01"}]}`},
+		{"bad date", `{"holidays": [{"date": "01/01/2026", "name": "New Year"}]}`},
+		{"date and range", `{"holidays": [{"date": "2026-01-01", "from": "2026-01-01", "to": "2026-01-02", "name": "New Year"}]}`},
+		{"half range", `{"holidays": [{"from": "2026-02-14", "name": "Tết"}]}`},
+		{"backwards range", `{"holidays": [{"from": "2026-02-22", "to": "2026-02-14", "name": "Tết"}]}`},
+		{"year-long range", `{"holidays": [{"from": "2026-01-01", "to": "2026-12-31", "name": "Tết"}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadFile(writeFile(t, tt.content))
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := LoadFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+// The holiday sets shipped with the service load, and Tết is not a business day.
+func TestLoad_Shipped(t *testing.T) {
+	paths, err := filepath.Glob("../../../holidays/*.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	holidays, err := Load(paths...)
+	require.NoError(t, err)
+
+	c := calendar.New(calendar.ModifiedFollowing, holidays...)
+	assert.False(t, c.IsBusinessDay(time.Date(2026, time.February, 17, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2026, time.February, 23, 0, 0, 0, 0, time.UTC), c.Adjust(time.Date(2026, time.February, 16, 0, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,155 @@
+// Package metrics exposes the service metrics in the Prometheus format.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"e-wallet/internal/ports"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "ewallet"
+
+// Prometheus collects HTTP, database and business metrics in its own registry
+// so tests can create as many as they need.
+type Prometheus struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	dbQueries       *prometheus.CounterVec
+	dbQueryDuration *prometheus.HistogramVec
+
+	accountsCreated *prometheus.CounterVec
+	transferVolume  *prometheus.CounterVec
+	transfers       *prometheus.CounterVec
+	interestAccrued *prometheus.CounterVec
+}
+
+var _ ports.Metrics = (*Prometheus)(nil)
+
+func NewPrometheus() *Prometheus {
+	p := &Prometheus{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "HTTP requests by method, route and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by method and route.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		dbQueries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      "queries_total",
+			Help:      "Database queries by operation, table and result.",
+		}, []string{"operation", "table", "result"}),
+		dbQueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      "query_duration_seconds",
+			Help:      "Database query latency by operation and table.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "table"}),
+		accountsCreated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "accounts_created_total",
+			Help:      "Accounts opened by account type.",
+		}, []string{"account_type"}),
+		transfers: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transfers_total",
+			Help:      "Completed transfers by transaction type.",
+		}, []string{"transaction_type"}),
+		transferVolume: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transfer_volume_total",
+			Help:      "Amount moved by completed transfers, by transaction type.",
+		}, []string{"transaction_type"}),
+		interestAccrued: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "interest_accrued_total",
+			Help:      "Interest accrued by account type.",
+		}, []string{"account_type"}),
+	}
+
+	p.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		p.httpRequests,
+		p.httpDuration,
+		p.dbQueries,
+		p.dbQueryDuration,
+		p.accountsCreated,
+		p.transfers,
+		p.transferVolume,
+		p.interestAccrued,
+	)
+	return p
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func (p *Prometheus) Handler() http.Handler {
+	return promhttp.HandlerFor(p.registry, promhttp.HandlerOpts{Registry: p.registry})
+}
+
+// MustRegister adds extra collectors, e.g. the connection pool stats.
+func (p *Prometheus) MustRegister(cs ...prometheus.Collector) {
+	p.registry.MustRegister(cs...)
+}
+
+// ObserveHTTPRequest records a served request. route is the route template
+// (e.g. /api/accounts/:id) so ids don't explode the label cardinality.
+func (p *Prometheus) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	p.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	p.httpDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+func (p *Prometheus) ObserveDBQuery(operation, table string, err error, duration time.Duration) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	p.dbQueries.WithLabelValues(operation, table, result).Inc()
+	p.dbQueryDuration.WithLabelValues(operation, table).Observe(duration.Seconds())
+}
+
+func (p *Prometheus) AccountCreated(accountType string) {
+	p.accountsCreated.WithLabelValues(accountType).Inc()
+}
+
+func (p *Prometheus) TransferCompleted(transactionType string, amount float64) {
+	p.transfers.WithLabelValues(transactionType).Inc()
+	if amount > 0 {
+		p.transferVolume.WithLabelValues(transactionType).Add(amount)
+	}
+}
+
+func (p *Prometheus) InterestAccrued(accountType string, amount float64) {
+	// counters can only increase
+	if amount > 0 {
+		p.interestAccrued.WithLabelValues(accountType).Add(amount)
+	}
+}
+
+type noop struct{}
+
+// Noop discards every business event; for tests and tools that don't export
+// metrics.
+var Noop ports.Metrics = noop{}
+
+func (noop) AccountCreated(string)             {}
+func (noop) TransferCompleted(string, float64) {}
+func (noop) InterestAccrued(string, float64)   {}
@@ -0,0 +1,314 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"e-wallet/internal/domain/account"
+	"e-wallet/internal/ports"
+	"e-wallet/pkg"
+)
+
+// maxAccountNumberAttempts bounds how many numbers are tried when the
+// generated ones are already taken.
+const maxAccountNumberAttempts = 5
+
+type accountRepository struct {
+	store   *Store
+	numbers ports.AccountNumberGenerator
+}
+
+type savingsAccountDetailRepository struct {
+	store *Store
+}
+
+func NewAccountRepository(store *Store, numbers ports.AccountNumberGenerator) ports.AccountRepository {
+	return &accountRepository{store: store, numbers: numbers}
+}
+
+func NewSavingsAccountDetailRepository(store *Store) ports.SavingsAccountDetailRepository {
+	return &savingsAccountDetailRepository{store: store}
+}
+
+func (r *accountRepository) CreatePaymentAccount(ctx context.Context, userID string) (*account.Account, error) {
+	return r.create(ctx, userID, account.AccountTypePayment)
+}
+
+func (r *accountRepository) CreateFixedSavingsAccount(ctx context.Context, userID string, req *account.CreateFixedSavingsAccountRequest) (*account.Account, error) {
+	return r.create(ctx, userID, account.AccountTypeFixedSavings)
+}
+
+func (r *accountRepository) CreateFlexibleSavingsAccount(ctx context.Context, userID string) (*account.Account, error) {
+	return r.create(ctx, userID, account.AccountTypeFlexibleSavings)
+}
+
+// create inserts an active account with a new number, drawing another one
+// when the number is already taken.
+func (r *accountRepository) create(ctx context.Context, userID string, accountType string) (*account.Account, error) {
+	defer r.store.lock(ctx)()
+
+	t := &r.store.tables
+	if !t.hasUser(userID) {
+		return nil, ErrForeignKeyViolation
+	}
+	for attempt := 0; attempt < maxAccountNumberAttempts; attempt++ {
+		accountNumber, err := r.numbers.Generate(accountType)
+		if err != nil {
+			return nil, err
+		}
+		if slices.ContainsFunc(t.accounts, func(a account.Account) bool { return a.AccountNumber == accountNumber }) {
+			continue
+		}
+
+		now := time.Now()
+		row := account.Account{
+			ID:            pkg.NewUUIDV7(),
+			UserID:        userID,
+			AccountNumber: accountNumber,
+			AccountType:   accountType,
+			Balance:       0,
+			Status:        "ACTIVE",
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		t.accounts = append(t.accounts, row)
+		return &row, nil
+	}
+	return nil, account.ErrAccountNumberUnavailable
+}
+
+func (r *accountRepository) GetAccountsByUserID(ctx context.Context, userID string) ([]*account.Account, error) {
+	defer r.store.lock(ctx)()
+
+	var accounts []*account.Account
+	for _, a := range r.store.tables.accounts {
+		if a.UserID == userID {
+			accounts = append(accounts, &a)
+		}
+	}
+	return accounts, nil
+}
+
+func (r *accountRepository) GetAccountByID(ctx context.Context, accountID string) (*account.Account, error) {
+	defer r.store.lock(ctx)()
+
+	i := slices.IndexFunc(r.store.tables.accounts, func(a account.Account) bool { return a.ID == accountID })
+	if i < 0 {
+		return nil, account.ErrAccountNotFound
+	}
+	a := r.store.tables.accounts[i]
+	return &a, nil
+}
+
+// GetAccountForUpdate reads an account as GetAccountByID does: transactions
+// of the store are serializable, so there is nothing more to lock.
+func (r *accountRepository) GetAccountForUpdate(ctx context.Context, accountID string) (*account.Account, error) {
+	return r.GetAccountByID(ctx, accountID)
+}
+
+func (r *accountRepository) CountPaymentAccountsByUserID(ctx context.Context, userID string) (int64, error) {
+	return r.count(ctx, userID, account.AccountTypePayment)
+}
+
+func (r *accountRepository) CountSavingsAccountsByUserID(ctx context.Context, userID string) (int64, error) {
+	return r.count(ctx, userID, account.AccountTypeFixedSavings, account.AccountTypeFlexibleSavings)
+}
+
+func (r *accountRepository) count(ctx context.Context, userID string, accountTypes ...string) (int64, error) {
+	defer r.store.lock(ctx)()
+
+	var count int64
+	for _, a := range r.store.tables.accounts {
+		if a.UserID == userID && slices.Contains(accountTypes, a.AccountType) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *accountRepository) UpdateAccountBalance(ctx context.Context, accountID string, newBalance float64) error {
+	return r.update(ctx, func(a *account.Account) bool { return a.ID == accountID }, func(a *account.Account) {
+		a.Balance = newBalance
+	})
+}
+
+func (r *accountRepository) UpdateAccountStatus(ctx context.Context, accountID string, status string) error {
+	return r.update(ctx, func(a *account.Account) bool { return a.ID == accountID }, func(a *account.Account) {
+		a.Status = status
+	})
+}
+
+func (r *accountRepository) CloseAccountsByUserID(ctx context.Context, userID string) error {
+	return r.update(ctx, func(a *account.Account) bool { return a.UserID == userID }, func(a *account.Account) {
+		a.Status = "CLOSED"
+	})
+}
+
+func (r *accountRepository) update(ctx context.Context, match func(*account.Account) bool, set func(*account.Account)) error {
+	defer r.store.lock(ctx)()
+
+	t := &r.store.tables
+	for i := range t.accounts {
+		if match(&t.accounts[i]) {
+			set(&t.accounts[i])
+			t.accounts[i].UpdatedAt = time.Now()
+		}
+	}
+	return nil
+}
+
+func (r *savingsAccountDetailRepository) CreateSavingsAccountDetail(ctx context.Context, detail *account.SavingsAccountDetail) error {
+	defer r.store.lock(ctx)()
+
+	t := &r.store.tables
+	if !t.hasAccount(detail.AccountID) {
+		return ErrForeignKeyViolation
+	}
+	if slices.ContainsFunc(t.savingsDetails, func(d account.SavingsAccountDetail) bool { return d.AccountID == detail.AccountID }) {
+		return ErrDuplicateKey
+	}
+
+	t.savingsDetails = append(t.savingsDetails, account.SavingsAccountDetail{
+		AccountID:            detail.AccountID,
+		IsFixedTerm:          detail.IsFixedTerm,
+		TermMonths:           clonePtr(detail.TermMonths),
+		AnnualInterestRate:   detail.AnnualInterestRate,
+		StartDate:            detail.StartDate,
+		MaturityDate:         clonePtr(detail.MaturityDate),
+		LastInterestCalcDate: clonePtr(detail.LastInterestCalcDate),
+		MaturityInstruction:  detail.MaturityInstruction,
+		InterestCarry:        detail.InterestCarry,
+	})
+	return nil
+}
+
+func (r *savingsAccountDetailRepository) GetSavingsAccountDetailByAccountID(ctx context.Context, accountID string) (*account.SavingsAccountDetail, error) {
+	defer r.store.lock(ctx)()
+
+	i := slices.IndexFunc(r.store.tables.savingsDetails, func(d account.SavingsAccountDetail) bool { return d.AccountID == accountID })
+	if i < 0 {
+		return nil, account.ErrSavingsAccountDetailNotFound
+	}
+	d := r.store.tables.savingsDetails[i]
+	d.TermMonths = clonePtr(d.TermMonths)
+	d.MaturityDate = clonePtr(d.MaturityDate)
+	d.LastInterestCalcDate = clonePtr(d.LastInterestCalcDate)
+	return &d, nil
+}
+
+func (r *savingsAccountDetailRepository) UpdateLastInterestCalcDate(ctx context.Context, accountID string, date *time.Time) error {
+	return r.update(ctx, accountID, func(d *account.SavingsAccountDetail) {
+		d.LastInterestCalcDate = clonePtr(date)
+	})
+}
+
+func (r *savingsAccountDetailRepository) UpdateInterestCarry(ctx context.Context, accountID string, carry int64) error {
+	return r.update(ctx, accountID, func(d *account.SavingsAccountDetail) {
+		d.InterestCarry = carry
+	})
+}
+
+func (r *savingsAccountDetailRepository) GetFlexibleSavingsDueForInterest(ctx context.Context, asOf time.Time) ([]*account.SavingsAccountDetail, error) {
+	defer r.store.lock(ctx)()
+
+	t := &r.store.tables
+	var details []*account.SavingsAccountDetail
+	for _, d := range t.savingsDetails {
+		if d.IsFixedTerm || d.LastInterestCalcDate == nil || account.DaysBetween(*d.LastInterestCalcDate, asOf) <= 0 {
+			continue
+		}
+		active := slices.ContainsFunc(t.accounts, func(a account.Account) bool { return a.ID == d.AccountID && a.Status == "ACTIVE" })
+		if !active {
+			continue
+		}
+		d.TermMonths = clonePtr(d.TermMonths)
+		d.MaturityDate = clonePtr(d.MaturityDate)
+		d.LastInterestCalcDate = clonePtr(d.LastInterestCalcDate)
+		details = append(details, &d)
+	}
+	return details, nil
+}
+
+func (r *savingsAccountDetailRepository) UpdateMaturityInstruction(ctx context.Context, accountID string, instruction string) error {
+	return r.update(ctx, accountID, func(d *account.SavingsAccountDetail) {
+		d.MaturityInstruction = instruction
+	})
+}
+
+func (r *savingsAccountDetailRepository) GetFixedSavingsDueForMaturity(ctx context.Context, asOf time.Time) ([]*account.SavingsAccountDetail, error) {
+	defer r.store.lock(ctx)()
+
+	t := &r.store.tables
+	var details []*account.SavingsAccountDetail
+	for _, d := range t.savingsDetails {
+		if !d.IsFixedTerm || d.MaturityDate == nil || account.DaysBetween(*d.MaturityDate, asOf) < 0 {
+			continue
+		}
+		active := slices.ContainsFunc(t.accounts, func(a account.Account) bool { return a.ID == d.AccountID && a.Status == "ACTIVE" })
+		if !active {
+			continue
+		}
+		d.TermMonths = clonePtr(d.TermMonths)
+		d.MaturityDate = clonePtr(d.MaturityDate)
+		d.LastInterestCalcDate = clonePtr(d.LastInterestCalcDate)
+		details = append(details, &d)
+	}
+	return details, nil
+}
+
+func (r *savingsAccountDetailRepository) RenewFixedTerm(ctx context.Context, accountID string, startDate, maturityDate time.Time, rate float64) error {
+	return r.update(ctx, accountID, func(d *account.SavingsAccountDetail) {
+		d.StartDate = startDate
+		d.MaturityDate = &maturityDate
+		d.AnnualInterestRate = rate
+	})
+}
+
+func (r *savingsAccountDetailRepository) update(ctx context.Context, accountID string, set func(*account.SavingsAccountDetail)) error {
+	defer r.store.lock(ctx)()
+
+	t := &r.store.tables
+	for i := range t.savingsDetails {
+		if t.savingsDetails[i].AccountID == accountID {
+			set(&t.savingsDetails[i])
+		}
+	}
+	return nil
+}
+
+// CreateRenewalCycle records a matured term, setting its ID when empty. An
+// account numbers each cycle once, as the unique key of the table does.
+func (r *savingsAccountDetailRepository) CreateRenewalCycle(ctx context.Context, cycle *account.RenewalCycle) error {
+	defer r.store.lock(ctx)()
+
+	t := &r.store.tables
+	if !t.hasAccount(cycle.AccountID) {
+		return ErrForeignKeyViolation
+	}
+	if cycle.ID == "" {
+		cycle.ID = pkg.NewUUIDV7()
+	}
+	if slices.ContainsFunc(t.renewalCycles, func(row account.RenewalCycle) bool {
+		return row.ID == cycle.ID || (row.AccountID == cycle.AccountID && row.Cycle == cycle.Cycle)
+	}) {
+		return ErrDuplicateKey
+	}
+	cycle.CreatedAt = time.Now()
+	t.renewalCycles = append(t.renewalCycles, *cycle)
+	return nil
+}
+
+func (r *savingsAccountDetailRepository) GetRenewalCyclesByAccountIDs(ctx context.Context, accountIDs []string) ([]*account.RenewalCycle, error) {
+	defer r.store.lock(ctx)()
+
+	cycles := byAccountIDs(r.store.tables.renewalCycles, accountIDs, func(c account.RenewalCycle) string { return c.AccountID })
+	slices.SortStableFunc(cycles, func(a, b *account.RenewalCycle) int {
+		if a.AccountID != b.AccountID {
+			return strings.Compare(a.AccountID, b.AccountID)
+		}
+		return a.Cycle - b.Cycle
+	})
+	return cycles, nil
+}
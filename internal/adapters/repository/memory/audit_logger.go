@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"e-wallet/internal/domain/audit"
+	"e-wallet/internal/ports"
+	"e-wallet/pkg"
+)
+
+const (
+	auditDefaultLimit = 50
+	auditMaxLimit     = 500
+)
+
+type auditLogger struct {
+	store *Store
+}
+
+func NewAuditLogger(store *Store) ports.AuditLogger {
+	return &auditLogger{store: store}
+}
+
+func (l *auditLogger) Log(ctx context.Context, entry *audit.Entry) error {
+	if entry.ID == "" {
+		entry.ID = pkg.NewUUIDV7()
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	entry.CreatedAt = entry.CreatedAt.UTC().Truncate(time.Microsecond)
+	if entry.Metadata == nil {
+		entry.Metadata = map[string]string{}
+	}
+
+	s := l.store
+	s.auditMu.Lock()
+	defer s.auditMu.Unlock()
+
+	prevHash := audit.GenesisHash
+	if n := len(s.auditLogs); n > 0 {
+		prevHash = s.auditLogs[n-1].Hash
+	}
+	entry.Seal(prevHash)
+	entry.Sequence = int64(len(s.auditLogs)) + 1
+
+	row := *entry
+	row.Metadata = maps.Clone(entry.Metadata)
+	s.auditLogs = append(s.auditLogs, row)
+	return nil
+}
+
+func (l *auditLogger) Query(ctx context.Context, filter *audit.Filter) (*audit.QueryResult, error) {
+	s := l.store
+	s.auditMu.Lock()
+	defer s.auditMu.Unlock()
+
+	var matched []*audit.Entry
+	// newest first
+	for i := len(s.auditLogs) - 1; i >= 0; i-- {
+		if e := s.auditLogs[i]; matches(&e, filter) {
+			e.Metadata = maps.Clone(e.Metadata)
+			matched = append(matched, &e)
+		}
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = auditDefaultLimit
+	}
+	if limit > auditMaxLimit {
+		limit = auditMaxLimit
+	}
+	offset := min(max(filter.Offset, 0), len(matched))
+	end := min(offset+limit, len(matched))
+
+	entries := make([]*audit.Entry, 0, end-offset)
+	entries = append(entries, matched[offset:end]...)
+	return &audit.QueryResult{Entries: entries, Total: int64(len(matched))}, nil
+}
+
+func (l *auditLogger) Verify(ctx context.Context) (*audit.VerifyResult, error) {
+	s := l.store
+	s.auditMu.Lock()
+	defer s.auditMu.Unlock()
+
+	entries := make([]*audit.Entry, len(s.auditLogs))
+	for i := range s.auditLogs {
+		entries[i] = &s.auditLogs[i]
+	}
+	if idx := audit.VerifyChain(entries, audit.GenesisHash); idx >= 0 {
+		return &audit.VerifyResult{Valid: false, CheckedCount: int64(idx) + 1, BrokenEntryID: entries[idx].ID}, nil
+	}
+	return &audit.VerifyResult{Valid: true, CheckedCount: int64(len(entries))}, nil
+}
+
+func matches(e *audit.Entry, f *audit.Filter) bool {
+	switch {
+	case f.ActorID != "" && e.ActorID != f.ActorID,
+		f.TargetType != "" && e.TargetType != f.TargetType,
+		f.TargetID != "" && e.TargetID != f.TargetID,
+		f.Action != "" && e.Action != f.Action,
+		f.IP != "" && e.IP != f.IP,
+		f.RequestID != "" && e.RequestID != f.RequestID,
+		f.From != nil && e.CreatedAt.Before(*f.From),
+		f.To != nil && !e.CreatedAt.Before(*f.To):
+		return false
+	}
+	return true
+}
@@ -0,0 +1,49 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"e-wallet/internal/domain/banklink"
+	"e-wallet/internal/ports"
+)
+
+type bankLinkRepository struct {
+	store *Store
+}
+
+// NewBankLinkRepository keeps bank tokens in clear, as they never leave the
+// process.
+func NewBankLinkRepository(store *Store) ports.BankLinkRepository {
+	return &bankLinkRepository{store: store}
+}
+
+func (r *bankLinkRepository) Create(ctx context.Context, link *banklink.BankLink) (*banklink.BankLink, error) {
+	defer r.store.lock(ctx)()
+
+	t := &r.store.tables
+	if !t.hasUser(link.UserID) {
+		return nil, ErrForeignKeyViolation
+	}
+	if slices.ContainsFunc(t.bankLinks, func(l banklink.BankLink) bool { return l.ID == link.ID }) {
+		return nil, ErrDuplicateKey
+	}
+
+	row := *link
+	row.CreatedAt = time.Now()
+	t.bankLinks = append(t.bankLinks, row)
+	return &row, nil
+}
+
+func (r *bankLinkRepository) GetByUserID(ctx context.Context, userID string) ([]*banklink.BankLink, error) {
+	defer r.store.lock(ctx)()
+
+	var links []*banklink.BankLink
+	for _, l := range r.store.tables.bankLinks {
+		if l.UserID == userID {
+			links = append(links, &l)
+		}
+	}
+	return links, nil
+}
@@ -0,0 +1,31 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"e-wallet/internal/adapters/repository/repotest"
+	"e-wallet/internal/domain/account"
+)
+
+func TestContract(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		numbers, err := account.NewNumberGenerator("97")
+		require.NoError(t, err)
+
+		store := NewStore()
+		return repotest.Repositories{
+			Users:           NewUserRepository(store),
+			Profiles:        NewProfileRepository(store),
+			Accounts:        NewAccountRepository(store, numbers),
+			SavingsDetails:  NewSavingsAccountDetailRepository(store),
+			Transactions:    NewTransactionRepository(store),
+			InterestHistory: NewInterestHistoryRepository(store),
+			DataExports:     NewDataExportRepository(store),
+			BankLinks:       NewBankLinkRepository(store),
+			AuditLogger:     NewAuditLogger(store),
+			TxManager:       NewTxManager(store),
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"e-wallet/internal/domain/privacy"
+	"e-wallet/internal/ports"
+)
+
+type dataExportRepository struct {
+	store *Store
+}
+
+func NewDataExportRepository(store *Store) ports.DataExportRepository {
+	return &dataExportRepository{store: store}
+}
+
+func (r *dataExportRepository) Create(ctx context.Context, export *privacy.DataExport) (*privacy.DataExport, error) {
+	defer r.store.lock(ctx)()
+
+	t := &r.store.tables
+	if !t.hasUser(export.UserID) {
+		return nil, ErrForeignKeyViolation
+	}
+	if slices.ContainsFunc(t.dataExports, func(e privacy.DataExport) bool { return e.ID == export.ID }) {
+		return nil, ErrDuplicateKey
+	}
+
+	row := privacy.DataExport{
+		ID:        export.ID,
+		UserID:    export.UserID,
+		Status:    export.Status,
+		CreatedAt: time.Now(),
+	}
+	t.dataExports = append(t.dataExports, row)
+	return &row, nil
+}
+
+func (r *dataExportRepository) Update(ctx context.Context, export *privacy.DataExport) error {
+	defer r.store.lock(ctx)()
+
+	t := &r.store.tables
+	for i := range t.dataExports {
+		if t.dataExports[i].ID == export.ID {
+			t.dataExports[i].Status = export.Status
+			t.dataExports[i].FileName = export.FileName
+			t.dataExports[i].Error = export.Error
+			t.dataExports[i].CompletedAt = clonePtr(export.CompletedAt)
+		}
+	}
+	return nil
+}
+
+func (r *dataExportRepository) GetByID(ctx context.Context, id string) (*privacy.DataExport, error) {
+	defer r.store.lock(ctx)()
+
+	i := slices.IndexFunc(r.store.tables.dataExports, func(e privacy.DataExport) bool { return e.ID == id })
+	if i < 0 {
+		return nil, privacy.ErrExportNotFound
+	}
+	e := r.store.tables.dataExports[i]
+	e.CompletedAt = clonePtr(e.CompletedAt)
+	return &e, nil
+}
+
+func (r *dataExportRepository) GetByUserID(ctx context.Context, userID string) ([]*privacy.DataExport, error) {
+	defer r.store.lock(ctx)()
+
+	var exports []*privacy.DataExport
+	for _, e := range r.store.tables.dataExports {
+		if e.UserID == userID {
+			e.CompletedAt = clonePtr(e.CompletedAt)
+			exports = append(exports, &e)
+		}
+	}
+	return exports, nil
+}
+
+func (r *dataExportRepository) FailUnfinished(ctx context.Context, createdBefore, completedAt time.Time, reason string) (int, error) {
+	defer r.store.lock(ctx)()
+
+	failed := 0
+	t := &r.store.tables
+	for i := range t.dataExports {
+		e := &t.dataExports[i]
+		if (e.Status == privacy.ExportStatusPending || e.Status == privacy.ExportStatusProcessing) && e.CreatedAt.Before(createdBefore) {
+			e.Status = privacy.ExportStatusFailed
+			e.Error = reason
+			e.CompletedAt = &completedAt
+			failed++
+		}
+	}
+	return failed, nil
+}
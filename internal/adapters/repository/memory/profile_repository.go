@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"e-wallet/internal/domain/profile"
+	"e-wallet/internal/ports"
+)
+
+type profileRepository struct {
+	store *Store
+}
+
+// NewProfileRepository keeps phone numbers and national IDs in clear, as
+// they never leave the process.
+func NewProfileRepository(store *Store) ports.ProfileRepository {
+	return &profileRepository{store: store}
+}
+
+func (r *profileRepository) GetByUserID(ctx context.Context, userID string) (*profile.Profile, error) {
+	defer r.store.lock(ctx)()
+
+	i := slices.IndexFunc(r.store.tables.profiles, func(p profile.Profile) bool { return p.UserID == userID })
+	if i < 0 {
+		return nil, profile.ErrProfileNotFound
+	}
+	p := r.store.tables.profiles[i]
+	p.AvatarURL = clonePtr(p.AvatarURL)
+	return &p, nil
+}
+
+func (r *profileRepository) Upsert(ctx context.Context, p *profile.Profile) (*profile.Profile, error) {
+	defer r.store.lock(ctx)()
+
+	t := &r.store.tables
+	if !t.hasUser(p.UserID) {
+		return nil, ErrForeignKeyViolation
+	}
+	if err := r.checkUnique(p.UserID, p.PhoneNumber, p.NationalID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	row := profile.Profile{
+		UserID:            p.UserID,
+		DisplayName:       p.DisplayName,
+		AvatarURL:         clonePtr(p.AvatarURL),
+		PhoneNumber:       p.PhoneNumber,
+		NationalID:        p.NationalID,
+		BirthYear:         p.BirthYear,
+		Gender:            p.Gender,
+		Team:              p.Team,
+		PreferredLanguage: p.PreferredLanguage,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if i := slices.IndexFunc(t.profiles, func(existing profile.Profile) bool { return existing.UserID == p.UserID }); i >= 0 {
+		row.CreatedAt = t.profiles[i].CreatedAt
+		t.profiles[i] = row
+	} else {
+		t.profiles = append(t.profiles, row)
+	}
+
+	row.AvatarURL = clonePtr(row.AvatarURL)
+	return &row, nil
+}
+
+func (r *profileRepository) Anonymize(ctx context.Context, userID string, placeholder string) error {
+	defer r.store.lock(ctx)()
+
+	if err := r.checkUnique(userID, placeholder, placeholder); err != nil {
+		return err
+	}
+	t := &r.store.tables
+	for i := range t.profiles {
+		if t.profiles[i].UserID == userID {
+			t.profiles[i].DisplayName = "Deleted user"
+			t.profiles[i].AvatarURL = nil
+			t.profiles[i].PhoneNumber = placeholder
+			t.profiles[i].NationalID = placeholder
+			t.profiles[i].UpdatedAt = time.Now()
+		}
+	}
+	return nil
+}
+
+func (r *profileRepository) CheckNationalIDExists(ctx context.Context, nationalID string, excludeUserID string) (bool, error) {
+	defer r.store.lock(ctx)()
+
+	return r.exists(func(p profile.Profile) bool { return p.NationalID == nationalID }, excludeUserID), nil
+}
+
+func (r *profileRepository) CheckPhoneNumberExists(ctx context.Context, phoneNumber string, excludeUserID string) (bool, error) {
+	defer r.store.lock(ctx)()
+
+	return r.exists(func(p profile.Profile) bool { return p.PhoneNumber == phoneNumber }, excludeUserID), nil
+}
+
+func (r *profileRepository) exists(match func(profile.Profile) bool, excludeUserID string) bool {
+	return slices.ContainsFunc(r.store.tables.profiles, func(p profile.Profile) bool {
+		return match(p) && (excludeUserID == "" || p.UserID != excludeUserID)
+	})
+}
+
+// checkUnique enforces the unique indexes on phone numbers and national IDs
+// for a write to the profile of userID.
+func (r *profileRepository) checkUnique(userID string, phoneNumber string, nationalID string) error {
+	if r.exists(func(p profile.Profile) bool { return p.PhoneNumber == phoneNumber }, userID) {
+		return profile.ErrPhoneNumberTaken
+	}
+	if r.exists(func(p profile.Profile) bool { return p.NationalID == nationalID }, userID) {
+		return profile.ErrNationalIDTaken
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"e-wallet/internal/ports"
+	"e-wallet/internal/ratelimit"
+)
+
+// sweepEvery is the number of Take calls between two sweeps of full buckets.
+const sweepEvery = 1024
+
+type rateLimitStore struct {
+	mu      sync.Mutex
+	buckets map[string]entry
+	calls   int
+}
+
+type entry struct {
+	bucket ratelimit.Bucket
+	limit  ratelimit.Limit
+}
+
+// NewRateLimitStore keeps buckets in process memory, so limits only hold for
+// a single instance.
+func NewRateLimitStore() ports.RateLimitStore {
+	return &rateLimitStore{buckets: map[string]entry{}}
+}
+
+func (s *rateLimitStore) Take(ctx context.Context, key string, limit ratelimit.Limit, now time.Time) (ratelimit.Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+	if s.calls%sweepEvery == 0 {
+		s.sweep(now)
+	}
+
+	e, ok := s.buckets[key]
+	if !ok {
+		e.bucket = ratelimit.NewBucket(limit, now)
+	}
+	bucket, res := e.bucket.Take(limit, now)
+	s.buckets[key] = entry{bucket: bucket, limit: limit}
+	return res, nil
+}
+
+// sweep forgets the buckets that have refilled; they are recreated full.
+func (s *rateLimitStore) sweep(now time.Time) {
+	for key, e := range s.buckets {
+		if e.bucket.Full(e.limit, now) {
+			delete(s.buckets, key)
+		}
+	}
+}
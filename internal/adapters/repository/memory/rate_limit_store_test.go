@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"e-wallet/internal/ratelimit"
+)
+
+func TestRateLimitStore_Take(t *testing.T) {
+	store := NewRateLimitStore()
+	limit := ratelimit.PerMinute(60, 2)
+	now := time.Now()
+
+	for _, allowed := range []bool{true, true, false} {
+		res, err := store.Take(context.Background(), "ip:10.0.0.1", limit, now)
+		require.NoError(t, err)
+		assert.Equal(t, allowed, res.Allowed)
+	}
+
+	// keys don't share buckets
+	res, err := store.Take(context.Background(), "ip:10.0.0.2", limit, now)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+}
+
+func TestRateLimitStore_Take_Concurrent(t *testing.T) {
+	store := NewRateLimitStore()
+	limit := ratelimit.PerMinute(1, 10)
+	now := time.Now()
+
+	var allowed atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := store.Take(context.Background(), "user:1", limit, now)
+			if err == nil && res.Allowed {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(10), allowed.Load())
+}
+
+func TestRateLimitStore_Sweep(t *testing.T) {
+	s := NewRateLimitStore().(*rateLimitStore)
+	limit := ratelimit.PerMinute(60, 1)
+	now := time.Now()
+
+	_, _ = s.Take(context.Background(), "idle", limit, now)
+	_, _ = s.Take(context.Background(), "busy", limit, now.Add(time.Minute))
+	s.sweep(now.Add(time.Minute))
+
+	assert.NotContains(t, s.buckets, "idle")
+	assert.Contains(t, s.buckets, "busy")
+}
@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	"e-wallet/internal/domain/account"
+	"e-wallet/internal/domain/audit"
+	"e-wallet/internal/domain/banklink"
+	"e-wallet/internal/domain/privacy"
+	"e-wallet/internal/domain/profile"
+	"e-wallet/internal/domain/user"
+)
+
+// The errors of constraints the domain has no error for, where postgres
+// reports a unique or foreign key violation.
+var (
+	ErrDuplicateKey        = errors.New("memory: duplicate key")
+	ErrForeignKeyViolation = errors.New("memory: referenced row does not exist")
+)
+
+// Store holds the tables of the in-memory repositories. Repositories sharing
+// a Store see each other's rows, as they would in one database.
+//
+// Every query outside a transaction waits for the running transaction to end,
+// so transactions are serializable. A rollback restores the tables as they
+// were when the transaction began.
+type Store struct {
+	txMu sync.Mutex
+	mu   sync.Mutex
+
+	tables tables
+
+	// The audit log is written outside of transactions, as in postgres
+	auditMu   sync.Mutex
+	auditLogs []audit.Entry
+}
+
+type tables struct {
+	users            []user.User
+	profiles         []profile.Profile
+	accounts         []account.Account
+	savingsDetails   []account.SavingsAccountDetail
+	transactions     []account.Transaction
+	flexibleInterest []account.FlexibleInterestRecord
+	fixedInterest    []account.FixedInterestRecord
+	renewalCycles    []account.RenewalCycle
+	dataExports      []privacy.DataExport
+	bankLinks        []banklink.BankLink
+}
+
+func NewStore() *Store {
+	return &Store{}
+}
+
+// txKey marks a context running in a transaction of the store it holds.
+type txKey struct{}
+
+func (s *Store) inTx(ctx context.Context) bool {
+	return ctx.Value(txKey{}) == s
+}
+
+// lock locks the tables for one query, after waiting for the running
+// transaction unless ctx belongs to it.
+func (s *Store) lock(ctx context.Context) (unlock func()) {
+	inTx := s.inTx(ctx)
+	if !inTx {
+		s.txMu.Lock()
+	}
+	s.mu.Lock()
+	return func() {
+		s.mu.Unlock()
+		if !inTx {
+			s.txMu.Unlock()
+		}
+	}
+}
+
+// Rows are stored by value and their pointer fields are never modified in
+// place, so copying the slices is enough to snapshot the tables.
+func (t *tables) clone() tables {
+	return tables{
+		users:            slices.Clone(t.users),
+		profiles:         slices.Clone(t.profiles),
+		accounts:         slices.Clone(t.accounts),
+		savingsDetails:   slices.Clone(t.savingsDetails),
+		transactions:     slices.Clone(t.transactions),
+		flexibleInterest: slices.Clone(t.flexibleInterest),
+		fixedInterest:    slices.Clone(t.fixedInterest),
+		renewalCycles:    slices.Clone(t.renewalCycles),
+		dataExports:      slices.Clone(t.dataExports),
+		bankLinks:        slices.Clone(t.bankLinks),
+	}
+}
+
+func (t *tables) hasUser(id string) bool {
+	return slices.ContainsFunc(t.users, func(u user.User) bool { return u.ID == id })
+}
+
+func (t *tables) hasAccount(id string) bool {
+	return slices.ContainsFunc(t.accounts, func(a account.Account) bool { return a.ID == id })
+}
+
+// clonePtr copies the value p points to, so that the caller and the store
+// don't share it.
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
@@ -0,0 +1,148 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"e-wallet/internal/domain/account"
+	"e-wallet/internal/ports"
+	"e-wallet/pkg"
+)
+
+type transactionRepository struct {
+	store *Store
+}
+
+type interestHistoryRepository struct {
+	store *Store
+}
+
+func NewTransactionRepository(store *Store) ports.TransactionRepository {
+	return &transactionRepository{store: store}
+}
+
+func NewInterestHistoryRepository(store *Store) ports.InterestHistoryRepository {
+	return &interestHistoryRepository{store: store}
+}
+
+// CreateTransaction inserts tx, setting its ID when empty. TransactionDate
+// and CreatedAt default as in the transactions table.
+func (r *transactionRepository) CreateTransaction(ctx context.Context, tx *account.Transaction) error {
+	defer r.store.lock(ctx)()
+
+	t := &r.store.tables
+	if !t.hasAccount(tx.AccountID) {
+		return ErrForeignKeyViolation
+	}
+	if tx.ID == "" {
+		tx.ID = pkg.NewUUIDV7()
+	}
+	if slices.ContainsFunc(t.transactions, func(row account.Transaction) bool { return row.ID == tx.ID }) {
+		return ErrDuplicateKey
+	}
+	now := time.Now()
+	if tx.TransactionDate.IsZero() {
+		tx.TransactionDate = now
+	}
+	tx.CreatedAt = now
+	t.transactions = append(t.transactions, *tx)
+	return nil
+}
+
+func (r *transactionRepository) GetTransactionsByAccountIDs(ctx context.Context, accountIDs []string) ([]*account.Transaction, error) {
+	defer r.store.lock(ctx)()
+
+	transactions := byAccountIDs(r.store.tables.transactions, accountIDs, func(t account.Transaction) string { return t.AccountID })
+	slices.SortStableFunc(transactions, func(a, b *account.Transaction) int {
+		return a.TransactionDate.Compare(b.TransactionDate)
+	})
+	return transactions, nil
+}
+
+func (r *transactionRepository) GetAccountTransactionsSince(ctx context.Context, accountID string, since time.Time) ([]*account.Transaction, error) {
+	defer r.store.lock(ctx)()
+
+	var transactions []*account.Transaction
+	for _, t := range r.store.tables.transactions {
+		if t.AccountID == accountID && !t.TransactionDate.Before(since) {
+			transactions = append(transactions, &t)
+		}
+	}
+	slices.SortStableFunc(transactions, func(a, b *account.Transaction) int {
+		return a.TransactionDate.Compare(b.TransactionDate)
+	})
+	return transactions, nil
+}
+
+// CreateFlexibleInterestRecord inserts a daily interest record of a flexible
+// savings account, setting its ID when empty.
+func (r *interestHistoryRepository) CreateFlexibleInterestRecord(ctx context.Context, record *account.FlexibleInterestRecord) error {
+	defer r.store.lock(ctx)()
+
+	t := &r.store.tables
+	if !t.hasAccount(record.AccountID) {
+		return ErrForeignKeyViolation
+	}
+	if record.ID == "" {
+		record.ID = pkg.NewUUIDV7()
+	}
+	if slices.ContainsFunc(t.flexibleInterest, func(row account.FlexibleInterestRecord) bool { return row.ID == record.ID }) {
+		return ErrDuplicateKey
+	}
+	record.CreatedAt = time.Now()
+	t.flexibleInterest = append(t.flexibleInterest, *record)
+	return nil
+}
+
+// CreateFixedInterestRecord inserts the interest paid on a fixed savings
+// account, setting its ID when empty.
+func (r *interestHistoryRepository) CreateFixedInterestRecord(ctx context.Context, record *account.FixedInterestRecord) error {
+	defer r.store.lock(ctx)()
+
+	t := &r.store.tables
+	if !t.hasAccount(record.AccountID) {
+		return ErrForeignKeyViolation
+	}
+	if record.ID == "" {
+		record.ID = pkg.NewUUIDV7()
+	}
+	if slices.ContainsFunc(t.fixedInterest, func(row account.FixedInterestRecord) bool { return row.ID == record.ID }) {
+		return ErrDuplicateKey
+	}
+	record.CreatedAt = time.Now()
+	t.fixedInterest = append(t.fixedInterest, *record)
+	return nil
+}
+
+func (r *interestHistoryRepository) GetFlexibleInterestHistoryByAccountIDs(ctx context.Context, accountIDs []string) ([]*account.FlexibleInterestRecord, error) {
+	defer r.store.lock(ctx)()
+
+	records := byAccountIDs(r.store.tables.flexibleInterest, accountIDs, func(r account.FlexibleInterestRecord) string { return r.AccountID })
+	slices.SortStableFunc(records, func(a, b *account.FlexibleInterestRecord) int {
+		return a.CalculationDate.Compare(b.CalculationDate)
+	})
+	return records, nil
+}
+
+func (r *interestHistoryRepository) GetFixedInterestHistoryByAccountIDs(ctx context.Context, accountIDs []string) ([]*account.FixedInterestRecord, error) {
+	defer r.store.lock(ctx)()
+
+	records := byAccountIDs(r.store.tables.fixedInterest, accountIDs, func(r account.FixedInterestRecord) string { return r.AccountID })
+	slices.SortStableFunc(records, func(a, b *account.FixedInterestRecord) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return records, nil
+}
+
+// byAccountIDs returns copies of the rows belonging to one of accountIDs,
+// nil when there are none.
+func byAccountIDs[T any](rows []T, accountIDs []string, accountID func(T) string) []*T {
+	var matched []*T
+	for _, row := range rows {
+		if slices.Contains(accountIDs, accountID(row)) {
+			matched = append(matched, &row)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+
+	"e-wallet/internal/ports"
+)
+
+type txManager struct {
+	store *Store
+}
+
+func NewTxManager(store *Store) ports.TxManager {
+	return &txManager{store: store}
+}
+
+// WithinTransaction runs fn in a transaction, or a savepoint when ctx already
+// carries one. The tables are restored when fn fails or panics.
+func (m *txManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	s := m.store
+	if !s.inTx(ctx) {
+		s.txMu.Lock()
+		defer s.txMu.Unlock()
+		ctx = context.WithValue(ctx, txKey{}, s)
+	}
+
+	s.mu.Lock()
+	savepoint := s.tables.clone()
+	s.mu.Unlock()
+
+	rollback := func() {
+		s.mu.Lock()
+		s.tables = savepoint
+		s.mu.Unlock()
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(ctx); err != nil {
+		rollback()
+		return err
+	}
+	return nil
+}
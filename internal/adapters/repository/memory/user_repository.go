@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"e-wallet/internal/domain/user"
+	"e-wallet/internal/ports"
+)
+
+type userRepository struct {
+	store *Store
+}
+
+func NewUserRepository(store *Store) ports.UserRepository {
+	return &userRepository{store: store}
+}
+
+func (r *userRepository) Create(ctx context.Context, u *user.User) (*user.User, error) {
+	defer r.store.lock(ctx)()
+
+	t := &r.store.tables
+	if slices.ContainsFunc(t.users, func(existing user.User) bool {
+		return existing.ID == u.ID || existing.Username == u.Username || existing.Email == u.Email
+	}) {
+		return nil, user.ErrUserAlreadyExists
+	}
+
+	now := time.Now()
+	row := user.User{
+		ID:                 u.ID,
+		Username:           u.Username,
+		Email:              u.Email,
+		PasswordHash:       u.PasswordHash,
+		IsEmailVerified:    u.IsEmailVerified,
+		IsProfileCompleted: u.IsProfileCompleted,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	t.users = append(t.users, row)
+	return &row, nil
+}
+
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*user.User, error) {
+	return r.find(ctx, func(u user.User) bool { return u.Email == email })
+}
+
+func (r *userRepository) GetByID(ctx context.Context, id string) (*user.User, error) {
+	return r.find(ctx, func(u user.User) bool { return u.ID == id })
+}
+
+func (r *userRepository) find(ctx context.Context, match func(user.User) bool) (*user.User, error) {
+	defer r.store.lock(ctx)()
+
+	i := slices.IndexFunc(r.store.tables.users, match)
+	if i < 0 {
+		return nil, user.ErrUserNotFound
+	}
+	u := r.store.tables.users[i]
+	u.DeletedAt = clonePtr(u.DeletedAt)
+	return &u, nil
+}
+
+func (r *userRepository) Anonymize(ctx context.Context, id string, username string, email string) error {
+	defer r.store.lock(ctx)()
+
+	t := &r.store.tables
+	if slices.ContainsFunc(t.users, func(u user.User) bool {
+		return u.ID != id && (u.Username == username || u.Email == email)
+	}) {
+		return ErrDuplicateKey
+	}
+	now := time.Now()
+	for i := range t.users {
+		if t.users[i].ID == id {
+			t.users[i].Username = username
+			t.users[i].Email = email
+			t.users[i].PasswordHash = ""
+			t.users[i].DeletedAt = &now
+			t.users[i].UpdatedAt = now
+		}
+	}
+	return nil
+}
+
+func (r *userRepository) UpdateProfileCompleted(ctx context.Context, id string, completed bool) error {
+	defer r.store.lock(ctx)()
+
+	t := &r.store.tables
+	for i := range t.users {
+		if t.users[i].ID == id {
+			t.users[i].IsProfileCompleted = completed
+			t.users[i].UpdatedAt = time.Now()
+		}
+	}
+	return nil
+}
@@ -3,8 +3,6 @@ package postgres
 import (
 	"context"
 	"errors"
-	"fmt"
-	"math/rand"
 	"time"
 
 	"e-wallet/internal/domain/account"
@@ -12,19 +10,24 @@ import (
 	"e-wallet/pkg"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// maxAccountNumberAttempts bounds how many numbers are tried when the
+// generated ones are already taken.
+const maxAccountNumberAttempts = 5
 
 type accountRepository struct {
-	db *gorm.DB
+	db      *gorm.DB
+	numbers ports.AccountNumberGenerator
 }
 
 type savingsAccountDetailRepository struct {
 	db *gorm.DB
 }
 
-func NewAccountRepository(db *gorm.DB) ports.AccountRepository {
-	return &accountRepository{db: db}
+func NewAccountRepository(db *gorm.DB, numbers ports.AccountNumberGenerator) ports.AccountRepository {
+	return &accountRepository{db: db, numbers: numbers}
 }
 
 func NewSavingsAccountDetailRepository(db *gorm.DB) ports.SavingsAccountDetailRepository {
@@ -65,11 +68,17 @@ type SavingsAccountDetail struct {
 	StartDate             time.Time  `gorm:"column:start_date;not null"`
 	MaturityDate          *time.Time `gorm:"column:maturity_date"`
 	LastInterestCalcDate  *time.Time `gorm:"column:last_interest_calc_date"`
+	MaturityInstruction   *string    `gorm:"column:maturity_instruction"`
+	InterestCarry         int64      `gorm:"column:interest_carry;not null"`
 	CreatedAt             time.Time  `gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt             time.Time  `gorm:"column:updated_at;autoUpdateTime"`
 }
 
 func (s *SavingsAccountDetail) ToDomain() *account.SavingsAccountDetail {
+	var maturityInstruction string
+	if s.MaturityInstruction != nil {
+		maturityInstruction = *s.MaturityInstruction
+	}
 	return &account.SavingsAccountDetail{
 		AccountID:             s.AccountID,
 		IsFixedTerm:           s.IsFixedTerm,
@@ -78,66 +87,90 @@ func (s *SavingsAccountDetail) ToDomain() *account.SavingsAccountDetail {
 		StartDate:             s.StartDate,
 		MaturityDate:          s.MaturityDate,
 		LastInterestCalcDate:  s.LastInterestCalcDate,
+		MaturityInstruction:   maturityInstruction,
+		InterestCarry:         s.InterestCarry,
 	}
 }
 
-func (r *accountRepository) CreatePaymentAccount(ctx context.Context, userID string) (*account.Account, error) {
-	accountNumber := generateAccountNumber()
-	schema := &Account{
-		ID:            pkg.NewUUIDV7(),
-		UserID:        userID,
-		AccountNumber: accountNumber,
-		AccountType:   "PAYMENT",
-		Balance:       0,
-		Status:        "ACTIVE",
-	}
+// RenewalCycle schema
+type RenewalCycle struct {
+	ID                 string    `gorm:"column:id;primaryKey"`
+	AccountID          string    `gorm:"column:account_id;not null"`
+	Cycle              int       `gorm:"column:cycle;not null"`
+	StartDate          time.Time `gorm:"column:start_date;not null"`
+	MaturityDate       time.Time `gorm:"column:maturity_date;not null"`
+	Principal          float64   `gorm:"column:principal;not null"`
+	Interest           float64   `gorm:"column:interest;not null"`
+	AnnualInterestRate float64   `gorm:"column:annual_interest_rate;not null"`
+	Instruction        string    `gorm:"column:instruction;not null"`
+	RenewedPrincipal   float64   `gorm:"column:renewed_principal;not null"`
+	CreatedAt          time.Time `gorm:"column:created_at;autoCreateTime"`
+}
 
-	if err := r.db.WithContext(ctx).Table(AccountsTableName).Create(schema).Error; err != nil {
-		return nil, err
+func (c *RenewalCycle) ToDomain() *account.RenewalCycle {
+	return &account.RenewalCycle{
+		ID:                 c.ID,
+		AccountID:          c.AccountID,
+		Cycle:              c.Cycle,
+		StartDate:          c.StartDate,
+		MaturityDate:       c.MaturityDate,
+		Principal:          c.Principal,
+		Interest:           c.Interest,
+		AnnualInterestRate: c.AnnualInterestRate,
+		Instruction:        c.Instruction,
+		RenewedPrincipal:   c.RenewedPrincipal,
+		CreatedAt:          c.CreatedAt,
 	}
+}
 
-	return schema.ToDomain(), nil
+func (r *accountRepository) CreatePaymentAccount(ctx context.Context, userID string) (*account.Account, error) {
+	return r.create(ctx, userID, account.AccountTypePayment)
 }
 
 func (r *accountRepository) CreateFixedSavingsAccount(ctx context.Context, userID string, req *account.CreateFixedSavingsAccountRequest) (*account.Account, error) {
-	accountNumber := generateAccountNumber()
-	schema := &Account{
-		ID:            pkg.NewUUIDV7(),
-		UserID:        userID,
-		AccountNumber: accountNumber,
-		AccountType:   "FIXED_SAVINGS",
-		Balance:       0,
-		Status:        "ACTIVE",
-	}
-
-	if err := r.db.WithContext(ctx).Table(AccountsTableName).Create(schema).Error; err != nil {
-		return nil, err
-	}
-
-	return schema.ToDomain(), nil
+	return r.create(ctx, userID, account.AccountTypeFixedSavings)
 }
 
 func (r *accountRepository) CreateFlexibleSavingsAccount(ctx context.Context, userID string) (*account.Account, error) {
-	accountNumber := generateAccountNumber()
-	schema := &Account{
-		ID:            pkg.NewUUIDV7(),
-		UserID:        userID,
-		AccountNumber: accountNumber,
-		AccountType:   "FLEXIBLE_SAVINGS",
-		Balance:       0,
-		Status:        "ACTIVE",
-	}
+	return r.create(ctx, userID, account.AccountTypeFlexibleSavings)
+}
 
-	if err := r.db.WithContext(ctx).Table(AccountsTableName).Create(schema).Error; err != nil {
-		return nil, err
-	}
+// create inserts an active account with a new number, drawing another one
+// when the number is already taken.
+func (r *accountRepository) create(ctx context.Context, userID string, accountType string) (*account.Account, error) {
+	for attempt := 0; attempt < maxAccountNumberAttempts; attempt++ {
+		accountNumber, err := r.numbers.Generate(accountType)
+		if err != nil {
+			return nil, err
+		}
+		schema := &Account{
+			ID:            pkg.NewUUIDV7(),
+			UserID:        userID,
+			AccountNumber: accountNumber,
+			AccountType:   accountType,
+			Balance:       0,
+			Status:        "ACTIVE",
+		}
 
-	return schema.ToDomain(), nil
+		// A failed insert aborts the surrounding transaction, the savepoint
+		// confines a collision to this attempt
+		err = conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+			return tx.Table(AccountsTableName).Create(schema).Error
+		})
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return schema.ToDomain(), nil
+	}
+	return nil, ErrAccountNumberUnavailable
 }
 
 func (r *accountRepository) GetAccountsByUserID(ctx context.Context, userID string) ([]*account.Account, error) {
 	var schemas []Account
-	if err := r.db.WithContext(ctx).Table(AccountsTableName).Where("user_id = ?", userID).Find(&schemas).Error; err != nil {
+	if err := conn(ctx, r.db).Table(AccountsTableName).Where("user_id = ?", userID).Find(&schemas).Error; err != nil {
 		return nil, err
 	}
 
@@ -151,9 +184,25 @@ func (r *accountRepository) GetAccountsByUserID(ctx context.Context, userID stri
 
 func (r *accountRepository) GetAccountByID(ctx context.Context, accountID string) (*account.Account, error) {
 	var schema Account
-	if err := r.db.WithContext(ctx).Table(AccountsTableName).Where("id = ?", accountID).First(&schema).Error; err != nil {
+	if err := conn(ctx, r.db).Table(AccountsTableName).Where("id = ?", accountID).First(&schema).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAccountNotFound
+		}
+		return nil, err
+	}
+
+	return schema.ToDomain(), nil
+}
+
+func (r *accountRepository) GetAccountForUpdate(ctx context.Context, accountID string) (*account.Account, error) {
+	var schema Account
+	err := conn(ctx, r.db).Table(AccountsTableName).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", accountID).
+		Take(&schema).Error
+	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, ErrUserNotFound // Reuse existing error
+			return nil, ErrAccountNotFound
 		}
 		return nil, err
 	}
@@ -163,7 +212,7 @@ func (r *accountRepository) GetAccountByID(ctx context.Context, accountID string
 
 func (r *accountRepository) CountPaymentAccountsByUserID(ctx context.Context, userID string) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Table(AccountsTableName).
+	err := conn(ctx, r.db).Table(AccountsTableName).
 		Where("user_id = ? AND account_type = ?", userID, "PAYMENT").
 		Count(&count).Error
 	return count, err
@@ -171,19 +220,36 @@ func (r *accountRepository) CountPaymentAccountsByUserID(ctx context.Context, us
 
 func (r *accountRepository) CountSavingsAccountsByUserID(ctx context.Context, userID string) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Table(AccountsTableName).
+	err := conn(ctx, r.db).Table(AccountsTableName).
 		Where("user_id = ? AND account_type IN (?, ?)", userID, "FIXED_SAVINGS", "FLEXIBLE_SAVINGS").
 		Count(&count).Error
 	return count, err
 }
 
 func (r *accountRepository) UpdateAccountBalance(ctx context.Context, accountID string, newBalance float64) error {
-	return r.db.WithContext(ctx).Table(AccountsTableName).
+	return conn(ctx, r.db).Table(AccountsTableName).
 		Where("id = ?", accountID).
 		Update("balance", newBalance).Error
 }
 
+func (r *accountRepository) UpdateAccountStatus(ctx context.Context, accountID string, status string) error {
+	return conn(ctx, r.db).Table(AccountsTableName).
+		Where("id = ?", accountID).
+		Update("status", status).Error
+}
+
+func (r *accountRepository) CloseAccountsByUserID(ctx context.Context, userID string) error {
+	return conn(ctx, r.db).Table(AccountsTableName).
+		Where("user_id = ?", userID).
+		Update("status", "CLOSED").Error
+}
+
 func (r *savingsAccountDetailRepository) CreateSavingsAccountDetail(ctx context.Context, detail *account.SavingsAccountDetail) error {
+	// Flexible savings have no maturity, nor an instruction for it
+	var maturityInstruction *string
+	if detail.MaturityInstruction != "" {
+		maturityInstruction = &detail.MaturityInstruction
+	}
 	schema := &SavingsAccountDetail{
 		AccountID:             detail.AccountID,
 		IsFixedTerm:           detail.IsFixedTerm,
@@ -192,16 +258,18 @@ func (r *savingsAccountDetailRepository) CreateSavingsAccountDetail(ctx context.
 		StartDate:             detail.StartDate,
 		MaturityDate:          detail.MaturityDate,
 		LastInterestCalcDate:  detail.LastInterestCalcDate,
+		MaturityInstruction:   maturityInstruction,
+		InterestCarry:         detail.InterestCarry,
 	}
 
-	return r.db.WithContext(ctx).Table(SavingsAccountDetailsTableName).Create(schema).Error
+	return conn(ctx, r.db).Table(SavingsAccountDetailsTableName).Create(schema).Error
 }
 
 func (r *savingsAccountDetailRepository) GetSavingsAccountDetailByAccountID(ctx context.Context, accountID string) (*account.SavingsAccountDetail, error) {
 	var schema SavingsAccountDetail
-	if err := r.db.WithContext(ctx).Table(SavingsAccountDetailsTableName).Where("account_id = ?", accountID).First(&schema).Error; err != nil {
+	if err := conn(ctx, r.db).Table(SavingsAccountDetailsTableName).Where("account_id = ?", accountID).First(&schema).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, ErrUserNotFound // Reuse existing error
+			return nil, ErrSavingsAccountDetailNotFound
 		}
 		return nil, err
 	}
@@ -210,13 +278,112 @@ func (r *savingsAccountDetailRepository) GetSavingsAccountDetailByAccountID(ctx
 }
 
 func (r *savingsAccountDetailRepository) UpdateLastInterestCalcDate(ctx context.Context, accountID string, date *time.Time) error {
-	return r.db.WithContext(ctx).Table(SavingsAccountDetailsTableName).
+	return conn(ctx, r.db).Table(SavingsAccountDetailsTableName).
 		Where("account_id = ?", accountID).
 		Update("last_interest_calc_date", date).Error
 }
 
-// generateAccountNumber generates a unique 10-digit account number
-func generateAccountNumber() string {
-	rand.Seed(time.Now().UnixNano())
-	return fmt.Sprintf("%010d", rand.Intn(10000000000))
-}
\ No newline at end of file
+func (r *savingsAccountDetailRepository) UpdateInterestCarry(ctx context.Context, accountID string, carry int64) error {
+	return conn(ctx, r.db).Table(SavingsAccountDetailsTableName).
+		Where("account_id = ?", accountID).
+		Update("interest_carry", carry).Error
+}
+
+func (r *savingsAccountDetailRepository) GetFlexibleSavingsDueForInterest(ctx context.Context, asOf time.Time) ([]*account.SavingsAccountDetail, error) {
+	var schemas []SavingsAccountDetail
+	if err := conn(ctx, r.db).Table(SavingsAccountDetailsTableName+" AS d").
+		Select("d.*").
+		Joins("JOIN "+AccountsTableName+" AS a ON a.id = d.account_id").
+		Where("d.is_fixed_term = ? AND a.status = ? AND d.last_interest_calc_date < ?", false, "ACTIVE", asOf.Format(time.DateOnly)).
+		Order("d.account_id").
+		Find(&schemas).Error; err != nil {
+		return nil, err
+	}
+
+	var details []*account.SavingsAccountDetail
+	for _, schema := range schemas {
+		details = append(details, schema.ToDomain())
+	}
+
+	return details, nil
+}
+
+func (r *savingsAccountDetailRepository) UpdateMaturityInstruction(ctx context.Context, accountID string, instruction string) error {
+	return conn(ctx, r.db).Table(SavingsAccountDetailsTableName).
+		Where("account_id = ?", accountID).
+		Update("maturity_instruction", instruction).Error
+}
+
+func (r *savingsAccountDetailRepository) GetFixedSavingsDueForMaturity(ctx context.Context, asOf time.Time) ([]*account.SavingsAccountDetail, error) {
+	var schemas []SavingsAccountDetail
+	if err := conn(ctx, r.db).Table(SavingsAccountDetailsTableName+" AS d").
+		Select("d.*").
+		Joins("JOIN "+AccountsTableName+" AS a ON a.id = d.account_id").
+		Where("d.is_fixed_term = ? AND a.status = ? AND d.maturity_date <= ?", true, "ACTIVE", asOf.Format(time.DateOnly)).
+		Order("d.account_id").
+		Find(&schemas).Error; err != nil {
+		return nil, err
+	}
+
+	var details []*account.SavingsAccountDetail
+	for _, schema := range schemas {
+		details = append(details, schema.ToDomain())
+	}
+
+	return details, nil
+}
+
+func (r *savingsAccountDetailRepository) RenewFixedTerm(ctx context.Context, accountID string, startDate, maturityDate time.Time, rate float64) error {
+	return conn(ctx, r.db).Table(SavingsAccountDetailsTableName).
+		Where("account_id = ?", accountID).
+		Updates(map[string]any{
+			"start_date":           startDate,
+			"maturity_date":        maturityDate,
+			"annual_interest_rate": rate,
+		}).Error
+}
+
+// CreateRenewalCycle records a matured term, setting its ID when empty.
+func (r *savingsAccountDetailRepository) CreateRenewalCycle(ctx context.Context, cycle *account.RenewalCycle) error {
+	if cycle.ID == "" {
+		cycle.ID = pkg.NewUUIDV7()
+	}
+	schema := &RenewalCycle{
+		ID:                 cycle.ID,
+		AccountID:          cycle.AccountID,
+		Cycle:              cycle.Cycle,
+		StartDate:          cycle.StartDate,
+		MaturityDate:       cycle.MaturityDate,
+		Principal:          cycle.Principal,
+		Interest:           cycle.Interest,
+		AnnualInterestRate: cycle.AnnualInterestRate,
+		Instruction:        cycle.Instruction,
+		RenewedPrincipal:   cycle.RenewedPrincipal,
+	}
+	if err := conn(ctx, r.db).Table(RenewalCyclesTableName).Create(schema).Error; err != nil {
+		return err
+	}
+	cycle.CreatedAt = schema.CreatedAt
+	return nil
+}
+
+func (r *savingsAccountDetailRepository) GetRenewalCyclesByAccountIDs(ctx context.Context, accountIDs []string) ([]*account.RenewalCycle, error) {
+	if len(accountIDs) == 0 {
+		return nil, nil
+	}
+
+	var schemas []RenewalCycle
+	if err := conn(ctx, r.db).Table(RenewalCyclesTableName).
+		Where("account_id IN ?", accountIDs).
+		Order("account_id, cycle").
+		Find(&schemas).Error; err != nil {
+		return nil, err
+	}
+
+	var cycles []*account.RenewalCycle
+	for _, schema := range schemas {
+		cycles = append(cycles, schema.ToDomain())
+	}
+
+	return cycles, nil
+}
@@ -9,15 +9,22 @@ import (
 	"github.com/stretchr/testify/require"
 	"e-wallet/internal/domain/account"
 	"e-wallet/internal/domain/user"
+	"e-wallet/internal/ports"
+	"e-wallet/mocks"
 	"e-wallet/pkg"
 
 	_ "github.com/lib/pq"
 )
 
+func testAccountNumbers(t *testing.T) ports.AccountNumberGenerator {
+	numbers, err := account.NewNumberGenerator("97")
+	require.NoError(t, err)
+	return numbers
+}
 
 func TestAccountRepository_CreatePaymentAccount(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewAccountRepository(db)
+	repo := NewAccountRepository(db, testAccountNumbers(t))
 
 	// Create a test user first
 	userRepo := NewUserRepository(db)
@@ -41,12 +48,12 @@ func TestAccountRepository_CreatePaymentAccount(t *testing.T) {
 	assert.NotZero(t, result.CreatedAt)
 	assert.NotZero(t, result.UpdatedAt)
 	assert.NotEmpty(t, result.AccountNumber)
-	assert.Len(t, result.AccountNumber, 10)
+	assert.NoError(t, account.ValidateNumber(result.AccountNumber))
 }
 
 func TestAccountRepository_CreatePaymentAccount_DBError(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewAccountRepository(db)
+	repo := NewAccountRepository(db, testAccountNumbers(t))
 
 	// Close the database connection to simulate DB error
 	sqlDB, _ := db.DB()
@@ -60,9 +67,55 @@ func TestAccountRepository_CreatePaymentAccount_DBError(t *testing.T) {
 	assert.Nil(t, result)
 }
 
+func TestAccountRepository_Create_NumberCollision(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+	const taken, free = "971000000002", "971000000010"
+
+	userRepo := NewUserRepository(db)
+	testUser := &user.User{
+		ID:           pkg.NewUUIDV7(),
+		Username:     "collision",
+		Email:        "collision@example.com",
+		PasswordHash: "hashedpassword",
+	}
+	_, err := userRepo.Create(ctx, testUser)
+	require.NoError(t, err)
+
+	numbers := mocks.NewMockAccountNumberGenerator(t)
+	numbers.EXPECT().Generate(account.AccountTypePayment).Return(taken, nil).Once()
+	_, err = NewAccountRepository(db, numbers).CreatePaymentAccount(ctx, testUser.ID)
+	require.NoError(t, err)
+
+	t.Run("retries with another number", func(t *testing.T) {
+		numbers := mocks.NewMockAccountNumberGenerator(t)
+		numbers.EXPECT().Generate(account.AccountTypeFlexibleSavings).Return(taken, nil).Once()
+		numbers.EXPECT().Generate(account.AccountTypeFlexibleSavings).Return(free, nil).Once()
+		repo := NewAccountRepository(db, numbers)
+
+		// the collision must not abort the caller's transaction
+		var result *account.Account
+		err := NewTxManager(db).WithinTransaction(ctx, func(ctx context.Context) (err error) {
+			result, err = repo.CreateFlexibleSavingsAccount(ctx, testUser.ID)
+			return err
+		})
+		require.NoError(t, err)
+		assert.Equal(t, free, result.AccountNumber)
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		numbers := mocks.NewMockAccountNumberGenerator(t)
+		numbers.EXPECT().Generate(account.AccountTypeFixedSavings).Return(taken, nil).Times(maxAccountNumberAttempts)
+
+		result, err := NewAccountRepository(db, numbers).CreateFixedSavingsAccount(ctx, testUser.ID, &account.CreateFixedSavingsAccountRequest{TermCode: "1"})
+		assert.ErrorIs(t, err, ErrAccountNumberUnavailable)
+		assert.Nil(t, result)
+	})
+}
+
 func TestAccountRepository_CreateFixedSavingsAccount(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewAccountRepository(db)
+	repo := NewAccountRepository(db, testAccountNumbers(t))
 
 	// Create a test user first
 	userRepo := NewUserRepository(db)
@@ -90,12 +143,12 @@ func TestAccountRepository_CreateFixedSavingsAccount(t *testing.T) {
 	assert.NotZero(t, result.CreatedAt)
 	assert.NotZero(t, result.UpdatedAt)
 	assert.NotEmpty(t, result.AccountNumber)
-	assert.Len(t, result.AccountNumber, 10)
+	assert.NoError(t, account.ValidateNumber(result.AccountNumber))
 }
 
 func TestAccountRepository_CreateFixedSavingsAccount_DBError(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewAccountRepository(db)
+	repo := NewAccountRepository(db, testAccountNumbers(t))
 
 	// Close the database connection to simulate DB error
 	sqlDB, _ := db.DB()
@@ -114,7 +167,7 @@ func TestAccountRepository_CreateFixedSavingsAccount_DBError(t *testing.T) {
 
 func TestAccountRepository_CreateFlexibleSavingsAccount(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewAccountRepository(db)
+	repo := NewAccountRepository(db, testAccountNumbers(t))
 
 	// Create a test user first
 	userRepo := NewUserRepository(db)
@@ -138,12 +191,12 @@ func TestAccountRepository_CreateFlexibleSavingsAccount(t *testing.T) {
 	assert.NotZero(t, result.CreatedAt)
 	assert.NotZero(t, result.UpdatedAt)
 	assert.NotEmpty(t, result.AccountNumber)
-	assert.Len(t, result.AccountNumber, 10)
+	assert.NoError(t, account.ValidateNumber(result.AccountNumber))
 }
 
 func TestAccountRepository_CreateFlexibleSavingsAccount_DBError(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewAccountRepository(db)
+	repo := NewAccountRepository(db, testAccountNumbers(t))
 
 	// Close the database connection to simulate DB error
 	sqlDB, _ := db.DB()
@@ -159,7 +212,7 @@ func TestAccountRepository_CreateFlexibleSavingsAccount_DBError(t *testing.T) {
 
 func TestAccountRepository_GetAccountsByUserID(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewAccountRepository(db)
+	repo := NewAccountRepository(db, testAccountNumbers(t))
 
 	// Create a test user first
 	userRepo := NewUserRepository(db)
@@ -212,7 +265,7 @@ func TestAccountRepository_GetAccountsByUserID(t *testing.T) {
 
 func TestAccountRepository_GetAccountsByUserID_DBError(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewAccountRepository(db)
+	repo := NewAccountRepository(db, testAccountNumbers(t))
 
 	// Close the database connection to simulate DB error
 	sqlDB, _ := db.DB()
@@ -226,7 +279,7 @@ func TestAccountRepository_GetAccountsByUserID_DBError(t *testing.T) {
 
 func TestAccountRepository_GetAccountByID(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewAccountRepository(db)
+	repo := NewAccountRepository(db, testAccountNumbers(t))
 
 	// Create a test user first
 	userRepo := NewUserRepository(db)
@@ -258,7 +311,7 @@ func TestAccountRepository_GetAccountByID(t *testing.T) {
 			name:        "error - account not found",
 			accountID:   pkg.NewUUIDV7(),
 			expectError: true,
-			expectedErr: ErrUserNotFound,
+			expectedErr: ErrAccountNotFound,
 		},
 	}
 
@@ -288,7 +341,7 @@ func TestAccountRepository_GetAccountByID(t *testing.T) {
 
 func TestAccountRepository_GetAccountByID_DBError(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewAccountRepository(db)
+	repo := NewAccountRepository(db, testAccountNumbers(t))
 
 	// Close the database connection to simulate DB error
 	sqlDB, _ := db.DB()
@@ -298,12 +351,12 @@ func TestAccountRepository_GetAccountByID_DBError(t *testing.T) {
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
-	assert.NotEqual(t, ErrUserNotFound, err)
+	assert.NotEqual(t, ErrAccountNotFound, err)
 }
 
 func TestAccountRepository_CountPaymentAccountsByUserID(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewAccountRepository(db)
+	repo := NewAccountRepository(db, testAccountNumbers(t))
 
 	// Create test users first
 	userRepo := NewUserRepository(db)
@@ -362,7 +415,7 @@ func TestAccountRepository_CountPaymentAccountsByUserID(t *testing.T) {
 
 func TestAccountRepository_CountPaymentAccountsByUserID_DBError(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewAccountRepository(db)
+	repo := NewAccountRepository(db, testAccountNumbers(t))
 
 	// Close the database connection to simulate DB error
 	sqlDB, _ := db.DB()
@@ -376,7 +429,7 @@ func TestAccountRepository_CountPaymentAccountsByUserID_DBError(t *testing.T) {
 
 func TestAccountRepository_CountSavingsAccountsByUserID(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewAccountRepository(db)
+	repo := NewAccountRepository(db, testAccountNumbers(t))
 
 	// Create test users first
 	userRepo := NewUserRepository(db)
@@ -435,7 +488,7 @@ func TestAccountRepository_CountSavingsAccountsByUserID(t *testing.T) {
 
 func TestAccountRepository_CountSavingsAccountsByUserID_DBError(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewAccountRepository(db)
+	repo := NewAccountRepository(db, testAccountNumbers(t))
 
 	// Close the database connection to simulate DB error
 	sqlDB, _ := db.DB()
@@ -449,7 +502,7 @@ func TestAccountRepository_CountSavingsAccountsByUserID_DBError(t *testing.T) {
 
 func TestAccountRepository_UpdateAccountBalance(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewAccountRepository(db)
+	repo := NewAccountRepository(db, testAccountNumbers(t))
 
 	// Create a test user first
 	userRepo := NewUserRepository(db)
@@ -480,7 +533,7 @@ func TestAccountRepository_UpdateAccountBalance(t *testing.T) {
 
 func TestAccountRepository_UpdateAccountBalance_DBError(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewAccountRepository(db)
+	repo := NewAccountRepository(db, testAccountNumbers(t))
 
 	// Close the database connection to simulate DB error
 	sqlDB, _ := db.DB()
@@ -493,7 +546,7 @@ func TestAccountRepository_UpdateAccountBalance_DBError(t *testing.T) {
 
 func TestSavingsAccountDetailRepository_CreateSavingsAccountDetail(t *testing.T) {
 	db := setupTestDB(t)
-	accountRepo := NewAccountRepository(db)
+	accountRepo := NewAccountRepository(db, testAccountNumbers(t))
 	detailRepo := NewSavingsAccountDetailRepository(db)
 
 	// Create a test user first
@@ -553,7 +606,7 @@ func TestSavingsAccountDetailRepository_CreateSavingsAccountDetail_DBError(t *te
 
 func TestSavingsAccountDetailRepository_GetSavingsAccountDetailByAccountID(t *testing.T) {
 	db := setupTestDB(t)
-	accountRepo := NewAccountRepository(db)
+	accountRepo := NewAccountRepository(db, testAccountNumbers(t))
 	detailRepo := NewSavingsAccountDetailRepository(db)
 
 	// Create a test user first
@@ -598,7 +651,7 @@ func TestSavingsAccountDetailRepository_GetSavingsAccountDetailByAccountID(t *te
 			name:        "error - detail not found",
 			accountID:   pkg.NewUUIDV7(),
 			expectError: true,
-			expectedErr: ErrUserNotFound,
+			expectedErr: ErrSavingsAccountDetailNotFound,
 		},
 	}
 
@@ -635,12 +688,12 @@ func TestSavingsAccountDetailRepository_GetSavingsAccountDetailByAccountID_DBErr
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
-	assert.NotEqual(t, ErrUserNotFound, err)
+	assert.NotEqual(t, ErrSavingsAccountDetailNotFound, err)
 }
 
 func TestSavingsAccountDetailRepository_UpdateLastInterestCalcDate(t *testing.T) {
 	db := setupTestDB(t)
-	accountRepo := NewAccountRepository(db)
+	accountRepo := NewAccountRepository(db, testAccountNumbers(t))
 	detailRepo := NewSavingsAccountDetailRepository(db)
 
 	// Create a test user first
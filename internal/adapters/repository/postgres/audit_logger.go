@@ -0,0 +1,209 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"e-wallet/internal/domain/audit"
+	"e-wallet/internal/ports"
+	"e-wallet/pkg"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// auditChainLockKey serialises writers so every entry is chained to the latest one
+	auditChainLockKey = 7_263_001
+	auditDefaultLimit = 50
+	auditMaxLimit     = 500
+	auditVerifyBatch  = 1000
+)
+
+type auditLogger struct {
+	db *gorm.DB
+}
+
+func NewAuditLogger(db *gorm.DB) ports.AuditLogger {
+	return &auditLogger{db: db}
+}
+
+// AuditLog schema
+type AuditLog struct {
+	ID         string    `gorm:"column:id;primaryKey"`
+	Seq        int64     `gorm:"column:seq;autoIncrement;<-:false"`
+	Action     string    `gorm:"column:action;not null"`
+	ActorID    string    `gorm:"column:actor_id"`
+	TargetType string    `gorm:"column:target_type"`
+	TargetID   string    `gorm:"column:target_id"`
+	IP         string    `gorm:"column:ip"`
+	RequestID  string    `gorm:"column:request_id"`
+	UserAgent  string    `gorm:"column:user_agent"`
+	Metadata   string    `gorm:"column:metadata;not null"`
+	PrevHash   string    `gorm:"column:prev_hash;not null"`
+	Hash       string    `gorm:"column:hash;not null"`
+	CreatedAt  time.Time `gorm:"column:created_at;not null"`
+}
+
+func (a *AuditLog) ToDomain() *audit.Entry {
+	metadata := map[string]string{}
+	_ = json.Unmarshal([]byte(a.Metadata), &metadata)
+
+	return &audit.Entry{
+		ID:         a.ID,
+		Sequence:   a.Seq,
+		Action:     audit.Action(a.Action),
+		ActorID:    a.ActorID,
+		TargetType: a.TargetType,
+		TargetID:   a.TargetID,
+		IP:         a.IP,
+		RequestID:  a.RequestID,
+		UserAgent:  a.UserAgent,
+		Metadata:   metadata,
+		PrevHash:   a.PrevHash,
+		Hash:       a.Hash,
+		CreatedAt:  a.CreatedAt,
+	}
+}
+
+func (l *auditLogger) Log(ctx context.Context, entry *audit.Entry) error {
+	if entry.ID == "" {
+		entry.ID = pkg.NewUUIDV7()
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	entry.CreatedAt = entry.CreatedAt.UTC().Truncate(time.Microsecond)
+	if entry.Metadata == nil {
+		entry.Metadata = map[string]string{}
+	}
+
+	metadata, err := json.Marshal(entry.Metadata)
+	if err != nil {
+		return err
+	}
+
+	return l.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", auditChainLockKey).Error; err != nil {
+			return err
+		}
+
+		prevHash := audit.GenesisHash
+		var last AuditLog
+		err := tx.Table(AuditLogsTableName).Order("seq DESC").Limit(1).First(&last).Error
+		switch {
+		case err == nil:
+			prevHash = last.Hash
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+
+		entry.Seal(prevHash)
+		schema := &AuditLog{
+			ID:         entry.ID,
+			Action:     string(entry.Action),
+			ActorID:    entry.ActorID,
+			TargetType: entry.TargetType,
+			TargetID:   entry.TargetID,
+			IP:         entry.IP,
+			RequestID:  entry.RequestID,
+			UserAgent:  entry.UserAgent,
+			Metadata:   string(metadata),
+			PrevHash:   entry.PrevHash,
+			Hash:       entry.Hash,
+			CreatedAt:  entry.CreatedAt,
+		}
+		return tx.Table(AuditLogsTableName).Create(schema).Error
+	})
+}
+
+func (l *auditLogger) Query(ctx context.Context, filter *audit.Filter) (*audit.QueryResult, error) {
+	query := l.db.WithContext(ctx).Table(AuditLogsTableName)
+	if filter.ActorID != "" {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID != "" {
+		query = query.Where("target_id = ?", filter.TargetID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", string(filter.Action))
+	}
+	if filter.IP != "" {
+		query = query.Where("ip = ?", filter.IP)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = auditDefaultLimit
+	}
+	if limit > auditMaxLimit {
+		limit = auditMaxLimit
+	}
+
+	var schemas []AuditLog
+	if err := query.Order("seq DESC").Limit(limit).Offset(filter.Offset).Find(&schemas).Error; err != nil {
+		return nil, err
+	}
+
+	entries := make([]*audit.Entry, 0, len(schemas))
+	for _, schema := range schemas {
+		entries = append(entries, schema.ToDomain())
+	}
+
+	return &audit.QueryResult{Entries: entries, Total: total}, nil
+}
+
+func (l *auditLogger) Verify(ctx context.Context) (*audit.VerifyResult, error) {
+	result := &audit.VerifyResult{Valid: true}
+	prevHash := audit.GenesisHash
+	var lastSeq int64
+
+	for {
+		var schemas []AuditLog
+		err := l.db.WithContext(ctx).Table(AuditLogsTableName).
+			Where("seq > ?", lastSeq).
+			Order("seq ASC").
+			Limit(auditVerifyBatch).
+			Find(&schemas).Error
+		if err != nil {
+			return nil, err
+		}
+		if len(schemas) == 0 {
+			return result, nil
+		}
+
+		entries := make([]*audit.Entry, 0, len(schemas))
+		for _, schema := range schemas {
+			entries = append(entries, schema.ToDomain())
+		}
+
+		if idx := audit.VerifyChain(entries, prevHash); idx >= 0 {
+			result.Valid = false
+			result.CheckedCount += int64(idx) + 1
+			result.BrokenEntryID = entries[idx].ID
+			return result, nil
+		}
+
+		result.CheckedCount += int64(len(entries))
+		prevHash = entries[len(entries)-1].Hash
+		lastSeq = entries[len(entries)-1].Sequence
+	}
+}
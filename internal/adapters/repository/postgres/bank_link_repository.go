@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"e-wallet/internal/domain/banklink"
+	"e-wallet/internal/ports"
+
+	"gorm.io/gorm"
+)
+
+type bankLinkRepository struct {
+	db        *gorm.DB
+	encryptor ports.FieldEncryptor
+}
+
+// NewBankLinkRepository stores bank access and refresh tokens encrypted.
+func NewBankLinkRepository(db *gorm.DB, encryptor ports.FieldEncryptor) ports.BankLinkRepository {
+	return &bankLinkRepository{db: db, encryptor: encryptor}
+}
+
+// BankLink schema
+type BankLink struct {
+	ID           string    `gorm:"column:id;primaryKey"`
+	UserID       string    `gorm:"column:user_id;not null"`
+	BankCode     string    `gorm:"column:bank_code;not null"`
+	AccountType  string    `gorm:"column:account_type;not null"`
+	AccessToken  string    `gorm:"column:access_token"`
+	RefreshToken string    `gorm:"column:refresh_token"`
+	ExpiresIn    int       `gorm:"column:expires_in"`
+	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (r *bankLinkRepository) Create(ctx context.Context, link *banklink.BankLink) (*banklink.BankLink, error) {
+	schema := &BankLink{
+		ID:          link.ID,
+		UserID:      link.UserID,
+		BankCode:    link.BankCode,
+		AccountType: link.AccountType,
+		ExpiresIn:   link.ExpiresIn,
+	}
+
+	var err error
+	if schema.AccessToken, err = r.encryptor.Encrypt(link.AccessToken); err != nil {
+		return nil, err
+	}
+	if schema.RefreshToken, err = r.encryptor.Encrypt(link.RefreshToken); err != nil {
+		return nil, err
+	}
+
+	if err := conn(ctx, r.db).Table(BankLinksTableName).Create(schema).Error; err != nil {
+		return nil, err
+	}
+
+	return r.toDomain(schema)
+}
+
+func (r *bankLinkRepository) GetByUserID(ctx context.Context, userID string) ([]*banklink.BankLink, error) {
+	var schemas []BankLink
+	if err := conn(ctx, r.db).Table(BankLinksTableName).Where("user_id = ?", userID).Find(&schemas).Error; err != nil {
+		return nil, err
+	}
+
+	var links []*banklink.BankLink
+	for i := range schemas {
+		link, err := r.toDomain(&schemas[i])
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+
+	return links, nil
+}
+
+func (r *bankLinkRepository) toDomain(schema *BankLink) (*banklink.BankLink, error) {
+	link := &banklink.BankLink{
+		ID:          schema.ID,
+		UserID:      schema.UserID,
+		BankCode:    schema.BankCode,
+		AccountType: schema.AccountType,
+		ExpiresIn:   schema.ExpiresIn,
+		CreatedAt:   schema.CreatedAt,
+	}
+
+	var err error
+	if link.AccessToken, err = r.encryptor.Decrypt(schema.AccessToken); err != nil {
+		return nil, err
+	}
+	if link.RefreshToken, err = r.encryptor.Decrypt(schema.RefreshToken); err != nil {
+		return nil, err
+	}
+
+	return link, nil
+}
@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"e-wallet/internal/adapters/repository/repotest"
+	"e-wallet/internal/adapters/service"
+	"e-wallet/internal/ports"
+)
+
+func TestContract(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		db := setupTestDB(t)
+		encryptor := newTestEncryptor(t)
+		return repotest.Repositories{
+			Users:           NewUserRepository(db),
+			Profiles:        NewProfileRepository(db, encryptor),
+			Accounts:        NewAccountRepository(db, testAccountNumbers(t)),
+			SavingsDetails:  NewSavingsAccountDetailRepository(db),
+			Transactions:    NewTransactionRepository(db),
+			InterestHistory: NewInterestHistoryRepository(db),
+			DataExports:     NewDataExportRepository(db),
+			BankLinks:       NewBankLinkRepository(db, encryptor),
+			AuditLogger:     NewAuditLogger(db),
+			TxManager:       NewTxManager(db),
+		}
+	})
+}
+
+func newTestEncryptor(t *testing.T) ports.FieldEncryptor {
+	kf := service.KeyFile{ActiveKeyID: "k1", Keys: map[string]string{}}
+	key, err := service.NewRandomKey()
+	require.NoError(t, err)
+	kf.Keys["k1"] = key
+	kf.IndexKey, err = service.NewRandomKey()
+	require.NoError(t, err)
+
+	raw, err := json.Marshal(kf)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "keys.json")
+	require.NoError(t, os.WriteFile(path, raw, 0o600))
+
+	keys, err := service.NewLocalKeyProvider(path)
+	require.NoError(t, err)
+	return service.NewEnvelopeEncryptor(keys)
+}
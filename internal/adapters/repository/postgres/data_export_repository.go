@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"e-wallet/internal/domain/privacy"
+	"e-wallet/internal/ports"
+
+	"gorm.io/gorm"
+)
+
+type dataExportRepository struct {
+	db *gorm.DB
+}
+
+func NewDataExportRepository(db *gorm.DB) ports.DataExportRepository {
+	return &dataExportRepository{db: db}
+}
+
+// DataExport schema
+type DataExport struct {
+	ID          string     `gorm:"column:id;primaryKey"`
+	UserID      string     `gorm:"column:user_id;not null"`
+	Status      string     `gorm:"column:status;not null"`
+	FileName    string     `gorm:"column:file_name"`
+	Error       string     `gorm:"column:error"`
+	CreatedAt   time.Time  `gorm:"column:created_at;autoCreateTime"`
+	CompletedAt *time.Time `gorm:"column:completed_at"`
+}
+
+func (d *DataExport) ToDomain() *privacy.DataExport {
+	return &privacy.DataExport{
+		ID:          d.ID,
+		UserID:      d.UserID,
+		Status:      privacy.ExportStatus(d.Status),
+		FileName:    d.FileName,
+		Error:       d.Error,
+		CreatedAt:   d.CreatedAt,
+		CompletedAt: d.CompletedAt,
+	}
+}
+
+func (r *dataExportRepository) Create(ctx context.Context, export *privacy.DataExport) (*privacy.DataExport, error) {
+	schema := &DataExport{
+		ID:     export.ID,
+		UserID: export.UserID,
+		Status: string(export.Status),
+	}
+
+	if err := conn(ctx, r.db).Table(DataExportsTableName).Create(schema).Error; err != nil {
+		return nil, err
+	}
+
+	return schema.ToDomain(), nil
+}
+
+func (r *dataExportRepository) Update(ctx context.Context, export *privacy.DataExport) error {
+	return conn(ctx, r.db).Table(DataExportsTableName).Where("id = ?", export.ID).Updates(map[string]any{
+		"status":       string(export.Status),
+		"file_name":    export.FileName,
+		"error":        export.Error,
+		"completed_at": export.CompletedAt,
+	}).Error
+}
+
+func (r *dataExportRepository) GetByID(ctx context.Context, id string) (*privacy.DataExport, error) {
+	var schema DataExport
+	if err := conn(ctx, r.db).Table(DataExportsTableName).Where("id = ?", id).First(&schema).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDataExportNotFound
+		}
+		return nil, err
+	}
+
+	return schema.ToDomain(), nil
+}
+
+func (r *dataExportRepository) GetByUserID(ctx context.Context, userID string) ([]*privacy.DataExport, error) {
+	var schemas []DataExport
+	if err := conn(ctx, r.db).Table(DataExportsTableName).Where("user_id = ?", userID).Order("created_at").Find(&schemas).Error; err != nil {
+		return nil, err
+	}
+
+	var exports []*privacy.DataExport
+	for i := range schemas {
+		exports = append(exports, schemas[i].ToDomain())
+	}
+	return exports, nil
+}
+
+func (r *dataExportRepository) FailUnfinished(ctx context.Context, createdBefore, completedAt time.Time, reason string) (int, error) {
+	res := conn(ctx, r.db).Table(DataExportsTableName).
+		Where("status IN ? AND created_at < ?", []string{string(privacy.ExportStatusPending), string(privacy.ExportStatusProcessing)}, createdBefore).
+		Updates(map[string]any{
+			"status":       string(privacy.ExportStatusFailed),
+			"error":        reason,
+			"completed_at": completedAt,
+		})
+	return int(res.RowsAffected), res.Error
+}
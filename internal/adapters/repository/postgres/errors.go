@@ -1,7 +1,20 @@
 package postgres
 
-import "errors"
+import (
+	"e-wallet/internal/domain/account"
+	"e-wallet/internal/domain/privacy"
+	"e-wallet/internal/domain/profile"
+	"e-wallet/internal/domain/user"
+)
 
+// Repositories return the domain's typed errors so callers don't depend on
+// this package to recognise them.
 var (
-	ErrUserNotFound = errors.New("user not found")
-)
\ No newline at end of file
+	ErrUserNotFound                 = user.ErrUserNotFound
+	ErrUserAlreadyExists            = user.ErrUserAlreadyExists
+	ErrProfileNotFound              = profile.ErrProfileNotFound
+	ErrAccountNotFound              = account.ErrAccountNotFound
+	ErrSavingsAccountDetailNotFound = account.ErrSavingsAccountDetailNotFound
+	ErrAccountNumberUnavailable     = account.ErrAccountNumberUnavailable
+	ErrDataExportNotFound           = privacy.ErrExportNotFound
+)
@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"e-wallet/pkg"
+	"e-wallet/pkg/logger"
+)
+
+func TestGormLogger_NoValues(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	// statements are built but not run, no server is needed
+	db, err := gorm.Open(postgres.Open("host=localhost dbname=ewallet sslmode=disable"), &gorm.Config{
+		Logger:                 NewGormLogger(logger.NOOPLogger, gormlogger.Info),
+		DryRun:                 true,
+		SkipDefaultTransaction: true,
+		DisableAutomaticPing:   true,
+	})
+	require.NoError(t, err)
+
+	hash := "$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy"
+	ctx := logger.WithContext(context.Background(), zap.New(core).Sugar())
+	err = db.WithContext(ctx).Table(UsersTableName).Create(&User{
+		ID:           pkg.NewUUIDV7(),
+		Username:     "alice",
+		Email:        "alice@example.com",
+		PasswordHash: hash,
+	}).Error
+	require.NoError(t, err)
+
+	entries := logs.FilterMessage("database query").All()
+	require.Len(t, entries, 1)
+	assert.Contains(t, entries[0].ContextMap()["sql"], "INSERT INTO")
+	for _, entry := range logs.All() {
+		line := fmt.Sprint(entry.Message, entry.ContextMap())
+		assert.NotContains(t, line, hash)
+		assert.NotContains(t, line, "alice@example.com")
+	}
+}
@@ -0,0 +1,30 @@
+package postgres
+
+import (
+	"context"
+
+	"e-wallet/internal/ports"
+
+	"gorm.io/gorm"
+)
+
+type healthChecker struct {
+	db *gorm.DB
+}
+
+// NewHealthChecker pings the database behind db.
+func NewHealthChecker(db *gorm.DB) ports.HealthChecker {
+	return &healthChecker{db: db}
+}
+
+func (h *healthChecker) Name() string {
+	return "postgres"
+}
+
+func (h *healthChecker) Check(ctx context.Context) error {
+	sqlDB, err := h.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
@@ -0,0 +1,177 @@
+package postgres
+
+import (
+	"context"
+
+	"e-wallet/internal/ports"
+
+	"gorm.io/gorm"
+)
+
+// KeyRotator re-encrypts sensitive columns with the active key. Plaintext
+// rows written before encryption was introduced are encrypted as well. A row
+// the application changes while it is being re-encrypted is left as the
+// application wrote it; if that is still not with the active key, the next
+// run picks it up.
+type KeyRotator struct {
+	db        *gorm.DB
+	encryptor ports.FieldEncryptor
+	batchSize int
+	dryRun    bool
+}
+
+func NewKeyRotator(db *gorm.DB, encryptor ports.FieldEncryptor, batchSize int, dryRun bool) *KeyRotator {
+	return &KeyRotator{db: db, encryptor: encryptor, batchSize: batchSize, dryRun: dryRun}
+}
+
+// RotateUserProfiles returns the number of rows that were (or, in dry-run
+// mode, would be) rewritten.
+func (k *KeyRotator) RotateUserProfiles(ctx context.Context) (int, error) {
+	return k.rotateUserProfiles(ctx, "TRUE")
+}
+
+// IndexUserProfiles encrypts and indexes the profiles without blind indexes,
+// written before encryption was introduced: until then the uniqueness checks
+// of phone numbers and national ids don't see them. It returns the number of
+// rows that were (or, in dry-run mode, would be) rewritten.
+func (k *KeyRotator) IndexUserProfiles(ctx context.Context) (int, error) {
+	return k.rotateUserProfiles(ctx, "(phone_number_bidx IS NULL OR national_id_bidx IS NULL)")
+}
+
+// rotateUserProfiles rewrites the profiles matching condition that are not
+// encrypted with the active key or not indexed.
+func (k *KeyRotator) rotateUserProfiles(ctx context.Context, condition string) (int, error) {
+	rotated := 0
+	lastUserID := ""
+
+	for {
+		var schemas []UserProfile
+		err := k.db.WithContext(ctx).Table(UserProfilesTableName).
+			Where(condition).
+			Where("user_id::text > ?", lastUserID).
+			Order("user_id::text ASC").
+			Limit(k.batchSize).
+			Find(&schemas).Error
+		if err != nil {
+			return rotated, err
+		}
+		if len(schemas) == 0 {
+			return rotated, nil
+		}
+
+		for _, schema := range schemas {
+			lastUserID = schema.UserID
+			if !k.encryptor.NeedsRotation(schema.PhoneNumber) &&
+				!k.encryptor.NeedsRotation(schema.NationalID) &&
+				schema.PhoneNumberBidx != "" && schema.NationalIDBidx != "" {
+				continue
+			}
+
+			phoneNumber, err := k.encryptor.Decrypt(schema.PhoneNumber)
+			if err != nil {
+				return rotated, err
+			}
+			nationalID, err := k.encryptor.Decrypt(schema.NationalID)
+			if err != nil {
+				return rotated, err
+			}
+
+			updates, err := k.encrypt(map[string]string{
+				"phone_number": phoneNumber,
+				"national_id":  nationalID,
+			})
+			if err != nil {
+				return rotated, err
+			}
+			if updates["phone_number_bidx"], err = k.encryptor.BlindIndex(phoneNumber); err != nil {
+				return rotated, err
+			}
+			if updates["national_id_bidx"], err = k.encryptor.BlindIndex(nationalID); err != nil {
+				return rotated, err
+			}
+
+			if k.dryRun {
+				rotated++
+				continue
+			}
+			res := k.db.WithContext(ctx).Table(UserProfilesTableName).
+				Where("user_id = ?", schema.UserID).
+				Where("phone_number = ? AND national_id = ?", schema.PhoneNumber, schema.NationalID).
+				Where("COALESCE(phone_number_bidx, '') = ? AND COALESCE(national_id_bidx, '') = ?", schema.PhoneNumberBidx, schema.NationalIDBidx).
+				Updates(updates)
+			if res.Error != nil {
+				return rotated, res.Error
+			}
+			rotated += int(res.RowsAffected)
+		}
+	}
+}
+
+func (k *KeyRotator) RotateBankLinks(ctx context.Context) (int, error) {
+	rotated := 0
+	lastID := ""
+
+	for {
+		var schemas []BankLink
+		err := k.db.WithContext(ctx).Table(BankLinksTableName).
+			Where("id::text > ?", lastID).
+			Order("id::text ASC").
+			Limit(k.batchSize).
+			Find(&schemas).Error
+		if err != nil {
+			return rotated, err
+		}
+		if len(schemas) == 0 {
+			return rotated, nil
+		}
+
+		for _, schema := range schemas {
+			lastID = schema.ID
+			if !k.encryptor.NeedsRotation(schema.AccessToken) && !k.encryptor.NeedsRotation(schema.RefreshToken) {
+				continue
+			}
+
+			accessToken, err := k.encryptor.Decrypt(schema.AccessToken)
+			if err != nil {
+				return rotated, err
+			}
+			refreshToken, err := k.encryptor.Decrypt(schema.RefreshToken)
+			if err != nil {
+				return rotated, err
+			}
+
+			updates, err := k.encrypt(map[string]string{
+				"access_token":  accessToken,
+				"refresh_token": refreshToken,
+			})
+			if err != nil {
+				return rotated, err
+			}
+
+			if k.dryRun {
+				rotated++
+				continue
+			}
+			res := k.db.WithContext(ctx).Table(BankLinksTableName).
+				Where("id = ?", schema.ID).
+				Where("COALESCE(access_token, '') = ? AND COALESCE(refresh_token, '') = ?", schema.AccessToken, schema.RefreshToken).
+				Updates(updates)
+			if res.Error != nil {
+				return rotated, res.Error
+			}
+			rotated += int(res.RowsAffected)
+		}
+	}
+}
+
+func (k *KeyRotator) encrypt(columns map[string]string) (map[string]any, error) {
+	updates := make(map[string]any, len(columns)+2)
+	for column, plaintext := range columns {
+		ciphertext, err := k.encryptor.Encrypt(plaintext)
+		if err != nil {
+			return nil, err
+		}
+		updates[column] = ciphertext
+	}
+	return updates, nil
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"e-wallet/internal/domain/profile"
+	"e-wallet/internal/domain/user"
+	"e-wallet/internal/ports"
+	"e-wallet/pkg"
+)
+
+func TestKeyRotator_IndexUserProfiles(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+	encryptor := newTestEncryptor(t)
+	profiles := NewProfileRepository(db, encryptor)
+
+	u, err := NewUserRepository(db).Create(ctx, &user.User{ID: pkg.NewUUIDV7(), Username: "alice", Email: "alice@example.com", PasswordHash: "hash"})
+	require.NoError(t, err)
+	// a profile written before encryption: in plaintext, without blind indexes
+	require.NoError(t, db.Exec(`INSERT INTO user_profiles (user_id, display_name, phone_number, national_id, birth_year, gender, team)
+		VALUES (?, 'Alice', '0912345678', '079095000001', 1995, 'FEMALE', 'QA')`, u.ID).Error)
+
+	exists, err := profiles.CheckPhoneNumberExists(ctx, "0912345678", "")
+	require.NoError(t, err)
+	assert.False(t, exists, "an unindexed profile escapes the uniqueness check")
+
+	rotator := NewKeyRotator(db, encryptor, 10, false)
+	indexed, err := rotator.IndexUserProfiles(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, indexed)
+
+	exists, err = profiles.CheckPhoneNumberExists(ctx, "0912345678", "")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = profiles.CheckNationalIDExists(ctx, "079095000001", "")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	got, err := profiles.GetByUserID(ctx, u.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "0912345678", got.PhoneNumber)
+	assert.Equal(t, "079095000001", got.NationalID)
+
+	// indexed profiles are left alone
+	indexed, err = rotator.IndexUserProfiles(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, indexed)
+}
+
+// interruptingEncryptor runs interrupt before its first decryption, as if the
+// application wrote the row the rotator is re-encrypting.
+type interruptingEncryptor struct {
+	ports.FieldEncryptor
+	interrupt func()
+}
+
+func (e *interruptingEncryptor) Decrypt(value string) (string, error) {
+	if e.interrupt != nil {
+		e.interrupt()
+		e.interrupt = nil
+	}
+	return e.FieldEncryptor.Decrypt(value)
+}
+
+func TestKeyRotator_RotateUserProfiles_KeepsConcurrentUpdates(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+	encryptor := newTestEncryptor(t)
+	profiles := NewProfileRepository(db, encryptor)
+
+	u, err := NewUserRepository(db).Create(ctx, &user.User{ID: pkg.NewUUIDV7(), Username: "alice", Email: "alice@example.com", PasswordHash: "hash"})
+	require.NoError(t, err)
+	require.NoError(t, db.Exec(`INSERT INTO user_profiles (user_id, display_name, phone_number, national_id, birth_year, gender, team)
+		VALUES (?, 'Alice', '0912345678', '079095000001', 1995, 'FEMALE', 'QA')`, u.ID).Error)
+
+	rotator := NewKeyRotator(db, &interruptingEncryptor{
+		FieldEncryptor: encryptor,
+		interrupt: func() {
+			_, err := profiles.Upsert(ctx, &profile.Profile{
+				UserID:      u.ID,
+				DisplayName: "Alice",
+				PhoneNumber: "0987654321",
+				NationalID:  "079095000001",
+				BirthYear:   1995,
+				Gender:      "FEMALE",
+				Team:        "QA",
+			})
+			require.NoError(t, err)
+		},
+	}, 10, false)
+
+	rotated, err := rotator.RotateUserProfiles(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, rotated, "the profile changed after it was read")
+
+	got, err := profiles.GetByUserID(ctx, u.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "0987654321", got.PhoneNumber, "the concurrent update was overwritten")
+}
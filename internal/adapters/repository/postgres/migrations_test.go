@@ -0,0 +1,286 @@
+package postgres
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"e-wallet/internal/adapters/repository/postgres/pgtest"
+	"e-wallet/internal/domain/account"
+)
+
+// schemaModels maps every table the migrations create to the GORM schema
+// that reads and writes it.
+var schemaModels = map[string]any{
+	UsersTableName:                 &User{},
+	UserProfilesTableName:          &UserProfile{},
+	AccountsTableName:              &Account{},
+	SavingsAccountDetailsTableName: &SavingsAccountDetail{},
+	TransactionsTableName:          &Transaction{},
+	FlexibleInterestTableName:      &FlexibleInterestHistory{},
+	FixedInterestTableName:         &FixedInterestHistory{},
+	RenewalCyclesTableName:         &RenewalCycle{},
+	AuditLogsTableName:             &AuditLog{},
+	DataExportsTableName:           &DataExport{},
+	BankLinksTableName:             &BankLink{},
+	RateLimitBucketsTableName:      &RateLimitBucket{},
+}
+
+// TestMigrations_MatchSchemas applies every migration to an empty database
+// and checks the resulting tables against the GORM schemas: each field must
+// have a column, and each required column without a default must have a field.
+func TestMigrations_MatchSchemas(t *testing.T) {
+	db := setupTestDB(t)
+
+	tables, err := db.Migrator().GetTables()
+	require.NoError(t, err)
+	for _, table := range tables {
+		if table == "gorp_migrations" {
+			continue
+		}
+		assert.Contains(t, schemaModels, table, "table %s has no GORM schema, is it a leftover?", table)
+	}
+
+	for table, model := range schemaModels {
+		t.Run(table, func(t *testing.T) {
+			stmt := &gorm.Statement{DB: db}
+			require.NoError(t, stmt.Parse(model))
+
+			columnTypes, err := db.Migrator().ColumnTypes(table)
+			require.NoError(t, err)
+			require.NotEmpty(t, columnTypes, "table %s does not exist", table)
+
+			columns := make(map[string]gorm.ColumnType, len(columnTypes))
+			for _, c := range columnTypes {
+				columns[c.Name()] = c
+			}
+
+			for _, field := range stmt.Schema.Fields {
+				if field.DBName == "" {
+					continue
+				}
+				assert.Contains(t, columns, field.DBName, "field %s has no column", field.Name)
+			}
+
+			for name, c := range columns {
+				nullable, _ := c.Nullable()
+				_, hasDefault := c.DefaultValue()
+				if nullable || hasDefault {
+					continue
+				}
+				assert.NotNil(t, stmt.Schema.LookUpField(name), "required column %s has no field", name)
+			}
+		})
+	}
+}
+
+const retireLegacyTablesMigration = "20251028000000_retire_legacy_tables.sql"
+
+// migrateToLegacy returns a migrator for an empty database that has applied
+// the migrations up to the legacy schema, the one before the legacy tables
+// were retired.
+func migrateToLegacy(t *testing.T) (*gorm.DB, *Migrator) {
+	db := connectTestDB(t, pgtest.NewEmptyDatabase(t))
+	ctx := context.Background()
+
+	m, err := NewMigrator(db, testMigrationsDir, false)
+	require.NoError(t, err)
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	legacy := slices.IndexFunc(statuses, func(s MigrationStatus) bool { return s.ID == retireLegacyTablesMigration })
+	require.Positive(t, legacy)
+
+	_, err = m.Up(ctx, legacy)
+	require.NoError(t, err)
+	assert.Equal(t, retireLegacyTablesMigration, pendingMigrations(t, m)[0])
+	return db, m
+}
+
+// TestMigrations_LegacyData seeds a database of the legacy schema and checks
+// what the remaining migrations make of its data.
+func TestMigrations_LegacyData(t *testing.T) {
+	db, m := migrateToLegacy(t)
+	ctx := context.Background()
+
+	const (
+		alice    = "0199a000-0000-7000-8000-000000000001"
+		bob      = "0199a000-0000-7000-8000-000000000002"
+		payment  = "0199a000-0000-7000-8000-000000000011"
+		flexible = "0199a000-0000-7000-8000-000000000012"
+		idle     = "0199a000-0000-7000-8000-000000000013"
+		fixed    = "0199a000-0000-7000-8000-000000000014"
+	)
+	for _, q := range []string{
+		`INSERT INTO users (id, username, email, password_hash) VALUES
+			('` + alice + `', 'alice', 'alice@example.com', 'hash'),
+			('` + bob + `', 'bob', 'bob@example.com', 'hash')`,
+		`INSERT INTO accounts (id, user_id, account_type, account_number, account_name, balance, interest_rate, fixed_term_months, created_at) VALUES
+			('` + payment + `', '` + alice + `', 'payment', '9700000001', 'Main', 70.00, NULL, NULL, '2025-09-30 09:00:00+00'),
+			('` + flexible + `', '` + alice + `', 'savings', '9700000002', 'Rainy day', 200.50, 0.80, NULL, '2025-09-30 09:00:00+00'),
+			('` + idle + `', '` + bob + `', 'FLEXIBLE_SAVINGS', '9700000003', NULL, 0, 0.80, NULL, '2025-10-05 09:00:00+00'),
+			('` + fixed + `', '` + alice + `', 'savings', '9700000004', 'Term', 1000.00, 5.00, 6, '2025-09-30 09:00:00+00')`,
+		`INSERT INTO transactions (id, account_id, transaction_type, amount, status, balance_after, related_account_id) VALUES
+			('0199a000-0000-7000-8000-000000000021', '` + payment + `', 'DEPOSIT', 100.00, 'COMPLETED', 100.00, NULL),
+			('0199a000-0000-7000-8000-000000000022', '` + payment + `', 'TRANSFER_OUT', 50.00, 'COMPLETED', 50.00, '` + flexible + `'),
+			('0199a000-0000-7000-8000-000000000023', '` + payment + `', 'transfer_in', 20.00, 'completed', 70.00, '` + fixed + `'),
+			('0199a000-0000-7000-8000-000000000024', '` + flexible + `', 'TRANSFER_IN', 200.00, 'COMPLETED', 200.00, '` + payment + `'),
+			('0199a000-0000-7000-8000-000000000025', '` + flexible + `', 'INTEREST', 0.50, 'COMPLETED', 200.50, NULL),
+			('0199a000-0000-7000-8000-000000000026', '` + fixed + `', 'TOP_UP', 1020.00, 'COMPLETED', 1020.00, NULL),
+			('0199a000-0000-7000-8000-000000000027', '` + fixed + `', 'WITHDRAW', 20.00, 'COMPLETED', 1000.00, '` + payment + `')`,
+		`INSERT INTO interest_history (id, account_id, date, interest_amount) VALUES
+			('0199a000-0000-7000-8000-000000000031', '` + flexible + `', '2025-10-01', 0.25),
+			('0199a000-0000-7000-8000-000000000032', '` + flexible + `', '2025-10-02', 0.25),
+			('0199a000-0000-7000-8000-000000000033', '` + fixed + `', '2025-10-15', 4.11)`,
+		`INSERT INTO profiles (id, user_id, display_name, phone_number, national_id, birth_year, gender, team) VALUES
+			('0199a000-0000-7000-8000-000000000041', '` + alice + `', 'Alice', '0900000001', '001234567890', 1990, 'F', 'Core'),
+			('0199a000-0000-7000-8000-000000000042', '` + bob + `', 'Bob', '0900000002', NULL, 1991, 'M', 'Core')`,
+	} {
+		require.NoError(t, db.Exec(q).Error)
+	}
+
+	_, err := m.Up(ctx, 0)
+	require.NoError(t, err)
+	assert.Empty(t, pendingMigrations(t, m))
+
+	for _, table := range []string{"legacy_accounts", "legacy_transactions", "legacy_interest_history", "profiles"} {
+		assert.False(t, db.Migrator().HasTable(table), "%s was not dropped", table)
+	}
+
+	accounts := NewAccountRepository(db, testAccountNumbers(t))
+	transactions := NewTransactionRepository(db)
+
+	t.Run("accounts", func(t *testing.T) {
+		for id, want := range map[string]struct {
+			accountType string
+			balance     float64
+		}{
+			payment:  {"PAYMENT", 70},
+			flexible: {"FLEXIBLE_SAVINGS", 200.50},
+			idle:     {"FLEXIBLE_SAVINGS", 0},
+			fixed:    {"FIXED_SAVINGS", 1000},
+		} {
+			acc, err := accounts.GetAccountByID(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, want.accountType, acc.AccountType, id)
+			assert.Equal(t, want.balance, acc.Balance, id)
+			assert.Equal(t, "ACTIVE", acc.Status, id)
+		}
+	})
+
+	t.Run("transactions", func(t *testing.T) {
+		txs, err := transactions.GetTransactionsByAccountIDs(ctx, []string{payment, flexible, idle, fixed})
+		require.NoError(t, err)
+
+		types := make(map[string]string, len(txs))
+		balances := make(map[string]account.Amount)
+		for _, tx := range txs {
+			types[tx.ID] = tx.TransactionType
+			balances[tx.AccountID] += tx.BalanceChange()
+		}
+		assert.Equal(t, map[string]string{
+			"0199a000-0000-7000-8000-000000000021": account.TransactionTypeDeposit,
+			"0199a000-0000-7000-8000-000000000022": account.TransactionTypePaymentInitiation,
+			"0199a000-0000-7000-8000-000000000023": account.TransactionTypeDeposit,
+			"0199a000-0000-7000-8000-000000000024": account.TransactionTypeDeposit,
+			"0199a000-0000-7000-8000-000000000025": account.TransactionTypeInterestCredit,
+			"0199a000-0000-7000-8000-000000000026": account.TransactionTypeDeposit,
+			"0199a000-0000-7000-8000-000000000027": account.TransactionTypeWithdrawal,
+		}, types)
+
+		// the migrated entries add up to the migrated balances
+		assert.Equal(t, map[string]account.Amount{
+			payment:  account.AmountFromFloat(70),
+			flexible: account.AmountFromFloat(200.50),
+			fixed:    account.AmountFromFloat(1000),
+		}, balances)
+	})
+
+	t.Run("savings details", func(t *testing.T) {
+		details := NewSavingsAccountDetailRepository(db)
+		day := func(s string) time.Time {
+			d, err := time.Parse(time.DateOnly, s)
+			require.NoError(t, err)
+			return d
+		}
+
+		flex, err := details.GetSavingsAccountDetailByAccountID(ctx, flexible)
+		require.NoError(t, err)
+		assert.False(t, flex.IsFixedTerm)
+		assert.InDelta(t, 0.008, flex.AnnualInterestRate, 1e-9)
+		// accrues from the day after the last legacy interest
+		require.NotNil(t, flex.LastInterestCalcDate)
+		assert.True(t, day("2025-10-02").Equal(*flex.LastInterestCalcDate), "last calculated %s", flex.LastInterestCalcDate)
+
+		never, err := details.GetSavingsAccountDetailByAccountID(ctx, idle)
+		require.NoError(t, err)
+		// accrues from its start date, like a new account
+		require.NotNil(t, never.LastInterestCalcDate)
+		assert.True(t, day("2025-10-05").Equal(*never.LastInterestCalcDate), "last calculated %s", never.LastInterestCalcDate)
+
+		term, err := details.GetSavingsAccountDetailByAccountID(ctx, fixed)
+		require.NoError(t, err)
+		assert.True(t, term.IsFixedTerm)
+		assert.InDelta(t, 0.05, term.AnnualInterestRate, 1e-9)
+		require.NotNil(t, term.TermMonths)
+		assert.Equal(t, 6, *term.TermMonths)
+		require.NotNil(t, term.MaturityDate)
+		assert.True(t, day("2026-03-30").Equal(*term.MaturityDate), "matures %s", term.MaturityDate)
+		assert.Nil(t, term.LastInterestCalcDate)
+	})
+
+	t.Run("interest history", func(t *testing.T) {
+		history := NewInterestHistoryRepository(db)
+		flex, err := history.GetFlexibleInterestHistoryByAccountIDs(ctx, []string{flexible})
+		require.NoError(t, err)
+		assert.Len(t, flex, 2)
+
+		term, err := history.GetFixedInterestHistoryByAccountIDs(ctx, []string{fixed})
+		require.NoError(t, err)
+		require.Len(t, term, 1)
+		assert.Equal(t, "2025-10-15", term[0].CalculationPeriod)
+		assert.Equal(t, 4.11, term[0].TotalInterestAmount)
+	})
+
+	t.Run("profiles", func(t *testing.T) {
+		var profiles []string
+		require.NoError(t, db.Table(UserProfilesTableName).Pluck("user_id", &profiles).Error)
+		// bob's legacy profile has no national id
+		assert.Equal(t, []string{alice}, profiles)
+
+		var completed []string
+		require.NoError(t, db.Table(UsersTableName).Where("is_profile_completed").Pluck("id", &completed).Error)
+		assert.Equal(t, []string{alice}, completed)
+	})
+}
+
+func TestMigrations_LegacyData_Unmigratable(t *testing.T) {
+	for name, tx := range map[string]struct{ transactionType, status string }{
+		"unknown type":    {"TRANSFER", "COMPLETED"},
+		"pending deposit": {"DEPOSIT", "PENDING"},
+		"failed payment":  {"PAYMENT", "FAILED"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			db, m := migrateToLegacy(t)
+			ctx := context.Background()
+
+			const (
+				alice   = "0199a000-0000-7000-8000-000000000001"
+				payment = "0199a000-0000-7000-8000-000000000011"
+			)
+			require.NoError(t, db.Exec(`INSERT INTO users (id, username, email, password_hash) VALUES ('`+alice+`', 'alice', 'alice@example.com', 'hash')`).Error)
+			require.NoError(t, db.Exec(`INSERT INTO accounts (id, user_id, account_type, account_number, balance) VALUES ('`+payment+`', '`+alice+`', 'PAYMENT', '9700000001', 0)`).Error)
+			require.NoError(t, db.Exec(`INSERT INTO transactions (id, account_id, transaction_type, amount, status, balance_after) VALUES
+				('0199a000-0000-7000-8000-000000000021', '`+payment+`', ?, 10.00, ?, 0)`, tx.transactionType, tx.status).Error)
+
+			_, err := m.Up(ctx, 0)
+			assert.ErrorContains(t, err, "legacy transactions cannot be migrated: "+tx.transactionType+" ("+tx.status+")")
+			assert.Contains(t, pendingMigrations(t, m), "20251029000005_migrate_legacy_data.sql")
+			assert.True(t, db.Migrator().HasTable("legacy_transactions"), "the legacy data is kept")
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	migrate "github.com/rubenv/sql-migrate"
+	"gorm.io/gorm"
+)
+
+const (
+	migrationDialect = "postgres"
+	// migrationLockID keys the advisory lock held while migrating, so that two
+	// deploys starting together apply the migrations one after the other.
+	migrationLockID int64 = 7_245_114_930_017
+)
+
+// ErrPendingMigrations is returned by Redo when migrations are pending, as
+// re-applying the latest one would then apply another migration instead.
+var ErrPendingMigrations = errors.New("migrations are pending, run up first")
+
+// MigrationStep is a migration applied, or in dry-run mode planned, in one
+// direction.
+type MigrationStep struct {
+	ID        string
+	Direction migrate.MigrationDirection
+	Queries   []string
+}
+
+// MigrationStatus tells whether a migration has been applied. Unknown
+// migrations are recorded in the database but have no file.
+type MigrationStatus struct {
+	ID        string
+	AppliedAt *time.Time
+	Unknown   bool
+}
+
+// Migrator applies the SQL migrations of a directory with sql-migrate.
+type Migrator struct {
+	db     *sql.DB
+	source migrate.MigrationSource
+	dryRun bool
+}
+
+func NewMigrator(db *gorm.DB, dir string, dryRun bool) (*Migrator, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: sqlDB, source: &migrate.FileMigrationSource{Dir: dir}, dryRun: dryRun}, nil
+}
+
+// Up applies at most max pending migrations, all of them when max is 0.
+func (m *Migrator) Up(ctx context.Context, max int) ([]MigrationStep, error) {
+	var steps []MigrationStep
+	err := m.withLock(ctx, func() (err error) {
+		steps, err = m.exec(ctx, migrate.Up, max)
+		return err
+	})
+	return steps, err
+}
+
+// Down rolls back the last max applied migrations, all of them when max is 0.
+func (m *Migrator) Down(ctx context.Context, max int) ([]MigrationStep, error) {
+	var steps []MigrationStep
+	err := m.withLock(ctx, func() (err error) {
+		steps, err = m.exec(ctx, migrate.Down, max)
+		return err
+	})
+	return steps, err
+}
+
+// Redo rolls back the last applied migration and applies it again.
+func (m *Migrator) Redo(ctx context.Context) ([]MigrationStep, error) {
+	var steps []MigrationStep
+	err := m.withLock(ctx, func() error {
+		pending, _, err := migrate.PlanMigration(m.db, migrationDialect, m.source, migrate.Up, 0)
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			return ErrPendingMigrations
+		}
+
+		last, _, err := migrate.PlanMigration(m.db, migrationDialect, m.source, migrate.Down, 1)
+		if err != nil || len(last) == 0 {
+			return err
+		}
+
+		down, err := m.exec(ctx, migrate.Down, 1)
+		steps = append(steps, down...)
+		if err != nil {
+			return err
+		}
+		if m.dryRun {
+			steps = append(steps, MigrationStep{ID: last[0].Id, Direction: migrate.Up, Queries: last[0].Up})
+			return nil
+		}
+		up, err := m.exec(ctx, migrate.Up, 1)
+		steps = append(steps, up...)
+		return err
+	})
+	return steps, err
+}
+
+// Status lists every migration in ID order with the time it was applied.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	migrations, err := m.source.FindMigrations()
+	if err != nil {
+		return nil, err
+	}
+	records, err := migrate.GetMigrationRecords(m.db, migrationDialect)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[string]time.Time, len(records))
+	for _, r := range records {
+		applied[r.Id] = r.AppliedAt
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, mig := range migrations {
+		status := MigrationStatus{ID: mig.Id}
+		if at, ok := applied[mig.Id]; ok {
+			status.AppliedAt = &at
+			delete(applied, mig.Id)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, r := range records {
+		if _, ok := applied[r.Id]; ok {
+			at := r.AppliedAt
+			statuses = append(statuses, MigrationStatus{ID: r.Id, AppliedAt: &at, Unknown: true})
+		}
+	}
+	return statuses, nil
+}
+
+// exec plans the migrations of one direction and, unless in dry-run mode,
+// applies them. On failure the steps applied before the error are returned.
+func (m *Migrator) exec(ctx context.Context, dir migrate.MigrationDirection, max int) ([]MigrationStep, error) {
+	planned, _, err := migrate.PlanMigration(m.db, migrationDialect, m.source, dir, max)
+	if err != nil {
+		return nil, err
+	}
+	steps := make([]MigrationStep, len(planned))
+	for i, p := range planned {
+		steps[i] = MigrationStep{ID: p.Id, Direction: dir, Queries: p.Queries}
+	}
+	if m.dryRun {
+		return steps, nil
+	}
+
+	n, err := migrate.ExecMaxContext(ctx, m.db, migrationDialect, m.source, dir, max)
+	if n < len(steps) {
+		steps = steps[:n]
+	}
+	return steps, err
+}
+
+// withLock runs fn while holding the migration advisory lock, waiting for
+// another migrator to finish or ctx to end. The lock belongs to a session, so
+// it is taken on a dedicated connection and released with it.
+func (m *Migrator) withLock(ctx context.Context, fn func() error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer func() {
+		_, _ = conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID)
+	}()
+
+	return fn()
+}
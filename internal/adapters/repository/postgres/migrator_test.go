@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"e-wallet/internal/domain/account"
+	"e-wallet/internal/domain/user"
+	"e-wallet/pkg"
+)
+
+const testMigrationsDir = "../../../../migrations"
+
+func pendingMigrations(t *testing.T, m *Migrator) []string {
+	statuses, err := m.Status(context.Background())
+	require.NoError(t, err)
+	var pending []string
+	for _, s := range statuses {
+		if s.AppliedAt == nil {
+			pending = append(pending, s.ID)
+		}
+	}
+	return pending
+}
+
+func TestMigrator(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	m, err := NewMigrator(db, testMigrationsDir, false)
+	require.NoError(t, err)
+	dryRun, err := NewMigrator(db, testMigrationsDir, true)
+	require.NoError(t, err)
+
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, statuses)
+	latest := statuses[len(statuses)-1].ID
+	assert.Empty(t, pendingMigrations(t, m))
+
+	t.Run("dry-run down changes nothing", func(t *testing.T) {
+		steps, err := dryRun.Down(ctx, 2)
+		require.NoError(t, err)
+		require.Len(t, steps, 2)
+		assert.Equal(t, latest, steps[0].ID)
+		assert.Equal(t, migrate.Down, steps[0].Direction)
+		assert.NotEmpty(t, steps[0].Queries)
+		assert.Empty(t, pendingMigrations(t, m))
+	})
+
+	t.Run("down then redo refuses pending migrations", func(t *testing.T) {
+		steps, err := m.Down(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, steps, 1)
+		assert.Equal(t, []string{latest}, pendingMigrations(t, m))
+
+		_, err = m.Redo(ctx)
+		assert.ErrorIs(t, err, ErrPendingMigrations)
+	})
+
+	t.Run("up applies the pending migration", func(t *testing.T) {
+		steps, err := m.Up(ctx, 0)
+		require.NoError(t, err)
+		require.Len(t, steps, 1)
+		assert.Equal(t, latest, steps[0].ID)
+		assert.Empty(t, pendingMigrations(t, m))
+	})
+
+	t.Run("redo", func(t *testing.T) {
+		steps, err := m.Redo(ctx)
+		require.NoError(t, err)
+		require.Len(t, steps, 2)
+		assert.Equal(t, migrate.Down, steps[0].Direction)
+		assert.Equal(t, migrate.Up, steps[1].Direction)
+		assert.Equal(t, latest, steps[1].ID)
+		assert.Empty(t, pendingMigrations(t, m))
+	})
+
+	t.Run("concurrent runs apply each migration once", func(t *testing.T) {
+		_, err := m.Down(ctx, 3)
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		applied := make([]int, 2)
+		for i := range applied {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				steps, err := m.Up(ctx, 0)
+				assert.NoError(t, err)
+				applied[i] = len(steps)
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, 3, applied[0]+applied[1])
+		assert.Empty(t, pendingMigrations(t, m))
+	})
+
+	t.Run("down keeps deposits", func(t *testing.T) {
+		u, err := NewUserRepository(db).Create(ctx, &user.User{ID: pkg.NewUUIDV7(), Username: "alice", Email: "alice@example.com", PasswordHash: "hash"})
+		require.NoError(t, err)
+		acc, err := NewAccountRepository(db, testAccountNumbers(t)).CreatePaymentAccount(ctx, u.ID)
+		require.NoError(t, err)
+		require.NoError(t, NewTransactionRepository(db).CreateTransaction(ctx, &account.Transaction{
+			AccountID:       acc.ID,
+			TransactionType: account.TransactionTypeDeposit,
+			Amount:          100,
+			TransactionDate: time.Now(),
+		}))
+
+		// DEPOSIT is allowed since the legacy data migration, so rolling
+		// back the one restating it keeps the deposit
+		steps, err := m.Down(ctx, 3)
+		require.NoError(t, err)
+		assert.Equal(t, "20261018000006_add_deposit_transaction_type.sql", steps[2].ID)
+
+		var deposits int64
+		require.NoError(t, db.Table(TransactionsTableName).Where("transaction_type = ?", account.TransactionTypeDeposit).Count(&deposits).Error)
+		assert.Equal(t, int64(1), deposits)
+
+		_, err = m.Up(ctx, 0)
+		require.NoError(t, err)
+		assert.Empty(t, pendingMigrations(t, m))
+	})
+}
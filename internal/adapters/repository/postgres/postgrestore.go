@@ -5,10 +5,10 @@ import (
 	"fmt"
 	"strconv"
 
+	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
-	// "gorm.io/gorm/logger"
 )
 
 type Options struct {
@@ -18,6 +18,9 @@ type Options struct {
 	Host     string
 	Port     string
 	SSLMode  bool
+	// Logger logs the failed and slow queries of requests without a logger
+	// of their own; nothing is logged when nil
+	Logger *zap.SugaredLogger
 }
 
 func ParseFromConfig(c *config.Config) Options {
@@ -34,7 +37,7 @@ func ParseFromConfig(c *config.Config) Options {
 func NewConnection(opts Options) (*gorm.DB, error) {
 	sslmode := "disable"
 	if opts.SSLMode {
-		sslmode = "enable"
+		sslmode = "require"
 	}
 
 	datasource := fmt.Sprintf(
@@ -43,12 +46,20 @@ func NewConnection(opts Options) (*gorm.DB, error) {
 	)
 
 	db, err := gorm.Open(postgres.Open(datasource), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger:         NewGormLogger(opts.Logger, logger.Warn),
 		TranslateError: true,
-		Logger: logger.Default.LogMode(logger.Info),
 	})
 	if err != nil {
 		return nil, err
 	}
 	return db, nil
-}
\ No newline at end of file
+}
+
+// Close releases the connection pool behind db.
+func Close(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
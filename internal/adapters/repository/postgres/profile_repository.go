@@ -12,56 +12,119 @@ import (
 )
 
 type profileRepository struct {
-	db *gorm.DB
+	db        *gorm.DB
+	encryptor ports.FieldEncryptor
 }
 
-func NewProfileRepository(db *gorm.DB) ports.ProfileRepository {
-	return &profileRepository{db: db}
+// NewProfileRepository stores phone numbers and national IDs encrypted, with
+// blind indexes for uniqueness checks.
+func NewProfileRepository(db *gorm.DB, encryptor ports.FieldEncryptor) ports.ProfileRepository {
+	return &profileRepository{db: db, encryptor: encryptor}
 }
 
 func (r *profileRepository) GetByUserID(ctx context.Context, userID string) (*profile.Profile, error) {
 	var schema UserProfile
-	if err := r.db.WithContext(ctx).Table(UserProfilesTableName).Where("user_id = ?", userID).First(&schema).Error; err != nil {
+	if err := conn(ctx, r.db).Table(UserProfilesTableName).Where("user_id = ?", userID).First(&schema).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, ErrUserNotFound
+			return nil, ErrProfileNotFound
 		}
 		return nil, err
 	}
 
-	return schema.ToDomain(), nil
+	return r.toDomain(&schema)
 }
 
 func (r *profileRepository) Upsert(ctx context.Context, profile *profile.Profile) (*profile.Profile, error) {
 	schema := &UserProfile{
-		UserID:      profile.UserID,
-		DisplayName: profile.DisplayName,
-		AvatarURL:   profile.AvatarURL,
-		PhoneNumber: profile.PhoneNumber,
-		NationalID:  profile.NationalID,
-		BirthYear:   profile.BirthYear,
-		Gender:      profile.Gender,
-		Team:        profile.Team,
+		UserID:            profile.UserID,
+		DisplayName:       profile.DisplayName,
+		AvatarURL:         profile.AvatarURL,
+		BirthYear:         profile.BirthYear,
+		Gender:            profile.Gender,
+		Team:              profile.Team,
+		PreferredLanguage: profile.PreferredLanguage,
 	}
 
-	if err := r.db.WithContext(ctx).Table(UserProfilesTableName).
+	var err error
+	if schema.PhoneNumber, err = r.encryptor.Encrypt(profile.PhoneNumber); err != nil {
+		return nil, err
+	}
+	if schema.NationalID, err = r.encryptor.Encrypt(profile.NationalID); err != nil {
+		return nil, err
+	}
+	if schema.PhoneNumberBidx, err = r.encryptor.BlindIndex(profile.PhoneNumber); err != nil {
+		return nil, err
+	}
+	if schema.NationalIDBidx, err = r.encryptor.BlindIndex(profile.NationalID); err != nil {
+		return nil, err
+	}
+
+	if err := conn(ctx, r.db).Table(UserProfilesTableName).
 		Clauses(clause.OnConflict{
 			Columns:   []clause.Column{{Name: "user_id"}},
-			DoUpdates: clause.AssignmentColumns([]string{"display_name", "avatar_url", "phone_number", "national_id", "birth_year", "gender", "team", "updated_at"}),
+			DoUpdates: clause.AssignmentColumns([]string{"display_name", "avatar_url", "phone_number", "national_id", "phone_number_bidx", "national_id_bidx", "birth_year", "gender", "team", "preferred_language", "updated_at"}),
 		}).
 		Clauses(clause.Returning{}).
 		Create(schema).Error; err != nil {
 		return nil, err
 	}
 
-	return schema.ToDomain(), nil
+	return r.toDomain(schema)
+}
+
+func (r *profileRepository) Anonymize(ctx context.Context, userID string, placeholder string) error {
+	ciphertext, err := r.encryptor.Encrypt(placeholder)
+	if err != nil {
+		return err
+	}
+	bidx, err := r.encryptor.BlindIndex(placeholder)
+	if err != nil {
+		return err
+	}
+
+	return conn(ctx, r.db).Table(UserProfilesTableName).Where("user_id = ?", userID).Updates(map[string]any{
+		"display_name":      "Deleted user",
+		"avatar_url":        nil,
+		"phone_number":      ciphertext,
+		"national_id":       ciphertext,
+		"phone_number_bidx": bidx,
+		"national_id_bidx":  bidx,
+	}).Error
 }
 
 func (r *profileRepository) CheckNationalIDExists(ctx context.Context, nationalID string, excludeUserID string) (bool, error) {
+	return r.checkBlindIndexExists(ctx, "national_id_bidx", nationalID, excludeUserID)
+}
+
+func (r *profileRepository) CheckPhoneNumberExists(ctx context.Context, phoneNumber string, excludeUserID string) (bool, error) {
+	return r.checkBlindIndexExists(ctx, "phone_number_bidx", phoneNumber, excludeUserID)
+}
+
+func (r *profileRepository) checkBlindIndexExists(ctx context.Context, column string, value string, excludeUserID string) (bool, error) {
+	bidx, err := r.encryptor.BlindIndex(value)
+	if err != nil {
+		return false, err
+	}
+
 	var count int64
-	query := r.db.WithContext(ctx).Table(UserProfilesTableName).Where("national_id = ?", nationalID)
+	query := conn(ctx, r.db).Table(UserProfilesTableName).Where(column+" = ?", bidx)
 	if excludeUserID != "" {
 		query = query.Where("user_id != ?", excludeUserID)
 	}
-	err := query.Count(&count).Error
+	err = query.Count(&count).Error
 	return count > 0, err
 }
+
+func (r *profileRepository) toDomain(schema *UserProfile) (*profile.Profile, error) {
+	p := schema.ToDomain()
+
+	var err error
+	if p.PhoneNumber, err = r.encryptor.Decrypt(schema.PhoneNumber); err != nil {
+		return nil, err
+	}
+	if p.NationalID, err = r.encryptor.Decrypt(schema.NationalID); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"e-wallet/internal/ports"
+	"e-wallet/internal/ratelimit"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// rateLimitSweepEvery is the number of Take calls between two deletions
+	// of idle buckets by an instance.
+	rateLimitSweepEvery = 1000
+	// rateLimitIdleAfter is long enough for any configured bucket to refill.
+	rateLimitIdleAfter = 24 * time.Hour
+)
+
+type rateLimitStore struct {
+	db    *gorm.DB
+	calls atomic.Int64
+}
+
+// NewRateLimitStore shares the token buckets between every instance through
+// the rate_limit_buckets table.
+func NewRateLimitStore(db *gorm.DB) ports.RateLimitStore {
+	return &rateLimitStore{db: db}
+}
+
+// RateLimitBucket schema
+type RateLimitBucket struct {
+	Key       string    `gorm:"column:key;primaryKey"`
+	Tokens    float64   `gorm:"column:tokens;not null"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null"`
+}
+
+// Take times the bucket with the clock of the database, not with now: the
+// instances sharing a bucket would refill it by the drift of their clocks
+// otherwise.
+func (s *rateLimitStore) Take(ctx context.Context, key string, limit ratelimit.Limit, now time.Time) (ratelimit.Result, error) {
+	var res ratelimit.Result
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Table(RateLimitBucketsTableName).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(map[string]any{"key": key, "tokens": float64(limit.Burst), "updated_at": gorm.Expr("clock_timestamp()")}).Error
+		if err != nil {
+			return err
+		}
+
+		// lock the row so concurrent requests take tokens one after another,
+		// and read the time once the lock is held
+		var row struct {
+			Tokens    float64
+			UpdatedAt time.Time
+			Now       time.Time
+		}
+		err = tx.Table(RateLimitBucketsTableName).
+			Select("tokens, updated_at, clock_timestamp() AS now").
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("key = ?", key).
+			Take(&row).Error
+		if err != nil {
+			return err
+		}
+
+		var bucket ratelimit.Bucket
+		bucket, res = ratelimit.Bucket{Tokens: row.Tokens, UpdatedAt: row.UpdatedAt}.Take(limit, row.Now)
+
+		return tx.Table(RateLimitBucketsTableName).
+			Where("key = ?", key).
+			Updates(map[string]any{"tokens": bucket.Tokens, "updated_at": bucket.UpdatedAt}).Error
+	})
+	if err != nil {
+		return res, err
+	}
+
+	if s.calls.Add(1)%rateLimitSweepEvery == 0 {
+		// best effort: a failed sweep is retried on the next round
+		_ = s.db.WithContext(ctx).Table(RateLimitBucketsTableName).
+			Where("updated_at < now() - make_interval(secs => ?)", rateLimitIdleAfter.Seconds()).
+			Delete(&RateLimitBucket{}).Error
+	}
+	return res, nil
+}
@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"e-wallet/internal/ratelimit"
+)
+
+func TestRateLimitStore_Take(t *testing.T) {
+	db := setupTestDB(t)
+	store := NewRateLimitStore(db)
+	limit := ratelimit.PerMinute(60, 2)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	for _, allowed := range []bool{true, true, false} {
+		res, err := store.Take(context.Background(), "ip:10.0.0.1", limit, now)
+		require.NoError(t, err)
+		assert.Equal(t, allowed, res.Allowed)
+	}
+
+	// the clock of the instance is not trusted, that of the database is
+	res, err := store.Take(context.Background(), "ip:10.0.0.1", limit, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+
+	// refilled one second later
+	require.NoError(t, db.Table(RateLimitBucketsTableName).
+		Where("key = ?", "ip:10.0.0.1").
+		Update("updated_at", gorm.Expr("updated_at - interval '1 second'")).Error)
+	res, err = store.Take(context.Background(), "ip:10.0.0.1", limit, now)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+}
+
+func TestRateLimitStore_Take_Concurrent(t *testing.T) {
+	db := setupTestDB(t)
+	store := NewRateLimitStore(db)
+	limit := ratelimit.PerMinute(1, 5)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	var allowed atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := store.Take(context.Background(), "user:1", limit, now)
+			if err == nil && res.Allowed {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(5), allowed.Load())
+}
@@ -7,34 +7,46 @@ import (
 )
 
 const (
-	UsersTableName                = "users"
-	UserProfilesTableName         = "user_profiles"
-	AccountsTableName             = "accounts"
+	UsersTableName                 = "users"
+	UserProfilesTableName          = "user_profiles"
+	AccountsTableName              = "accounts"
 	SavingsAccountDetailsTableName = "savings_account_details"
+	AuditLogsTableName             = "audit_logs"
+	TransactionsTableName          = "transactions"
+	FlexibleInterestTableName      = "flexible_savings_interest_history"
+	FixedInterestTableName         = "fixed_savings_interest_history"
+	RenewalCyclesTableName         = "fixed_savings_renewal_cycles"
+	DataExportsTableName           = "data_exports"
+	BankLinksTableName             = "bank_links"
+	RateLimitBucketsTableName      = "rate_limit_buckets"
 )
 
 type User struct {
-	ID                  string
-	Username            string
-	Email               string
-	PasswordHash        string
-	IsEmailVerified     bool
-	IsProfileCompleted  bool
-	CreatedAt           time.Time `gorm:"autoCreateTime"`
-	UpdatedAt           time.Time `gorm:"autoUpdateTime"`
+	ID                 string
+	Username           string
+	Email              string
+	PasswordHash       string
+	IsEmailVerified    bool
+	IsProfileCompleted bool
+	CreatedAt          time.Time `gorm:"autoCreateTime"`
+	UpdatedAt          time.Time `gorm:"autoUpdateTime"`
+	DeletedAt          *time.Time
 }
 
 type UserProfile struct {
-	UserID      string
-	DisplayName string
-	AvatarURL   *string
-	PhoneNumber string
-	NationalID  string
-	BirthYear   int
-	Gender      string
-	Team        string
-	CreatedAt   time.Time `gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+	UserID            string
+	DisplayName       string
+	AvatarURL         *string
+	PhoneNumber       string
+	NationalID        string
+	PhoneNumberBidx   string
+	NationalIDBidx    string
+	BirthYear         int
+	Gender            string
+	Team              string
+	PreferredLanguage string
+	CreatedAt         time.Time `gorm:"autoCreateTime"`
+	UpdatedAt         time.Time `gorm:"autoUpdateTime"`
 }
 
 func (u *User) ToDomain() *user.User {
@@ -47,20 +59,22 @@ func (u *User) ToDomain() *user.User {
 		IsProfileCompleted: u.IsProfileCompleted,
 		CreatedAt:          u.CreatedAt,
 		UpdatedAt:          u.UpdatedAt,
+		DeletedAt:          u.DeletedAt,
 	}
 }
 
 func (up *UserProfile) ToDomain() *profile.Profile {
 	return &profile.Profile{
-		UserID:      up.UserID,
-		DisplayName: up.DisplayName,
-		AvatarURL:   up.AvatarURL,
-		PhoneNumber: up.PhoneNumber,
-		NationalID:  up.NationalID,
-		BirthYear:   up.BirthYear,
-		Gender:      up.Gender,
-		Team:        up.Team,
-		CreatedAt:   up.CreatedAt,
-		UpdatedAt:   up.UpdatedAt,
+		UserID:            up.UserID,
+		DisplayName:       up.DisplayName,
+		AvatarURL:         up.AvatarURL,
+		PhoneNumber:       up.PhoneNumber,
+		NationalID:        up.NationalID,
+		BirthYear:         up.BirthYear,
+		Gender:            up.Gender,
+		Team:              up.Team,
+		PreferredLanguage: up.PreferredLanguage,
+		CreatedAt:         up.CreatedAt,
+		UpdatedAt:         up.UpdatedAt,
 	}
 }
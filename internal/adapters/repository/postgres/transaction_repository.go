@@ -0,0 +1,245 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"e-wallet/internal/domain/account"
+	"e-wallet/internal/ports"
+	"e-wallet/pkg"
+
+	"gorm.io/gorm"
+)
+
+type transactionRepository struct {
+	db *gorm.DB
+}
+
+type interestHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewTransactionRepository(db *gorm.DB) ports.TransactionRepository {
+	return &transactionRepository{db: db}
+}
+
+func NewInterestHistoryRepository(db *gorm.DB) ports.InterestHistoryRepository {
+	return &interestHistoryRepository{db: db}
+}
+
+// Transaction schema
+type Transaction struct {
+	ID              string    `gorm:"column:id;primaryKey"`
+	AccountID       string    `gorm:"column:account_id;not null"`
+	TransactionType string    `gorm:"column:transaction_type;not null"`
+	Amount          float64   `gorm:"column:amount;not null"`
+	TransactionDate time.Time `gorm:"column:transaction_date"`
+	Description     string    `gorm:"column:description"`
+	IsPenalty       bool      `gorm:"column:is_penalty"`
+	CreatedAt       time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (t *Transaction) ToDomain() *account.Transaction {
+	return &account.Transaction{
+		ID:              t.ID,
+		AccountID:       t.AccountID,
+		TransactionType: t.TransactionType,
+		Amount:          t.Amount,
+		TransactionDate: t.TransactionDate,
+		Description:     t.Description,
+		IsPenalty:       t.IsPenalty,
+		CreatedAt:       t.CreatedAt,
+	}
+}
+
+// FlexibleInterestHistory schema
+type FlexibleInterestHistory struct {
+	ID                  string    `gorm:"column:id;primaryKey"`
+	AccountID           string    `gorm:"column:account_id;not null"`
+	CalculationDate     time.Time `gorm:"column:calculation_date;not null"`
+	EODBalance          float64   `gorm:"column:eod_balance;not null"`
+	AnnualRateApplied   float64   `gorm:"column:annual_rate_applied;not null"`
+	DailyInterestAmount float64   `gorm:"column:daily_interest_amount;not null"`
+	IsPromotionalRate   bool      `gorm:"column:is_promotional_rate"`
+	CreatedAt           time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (h *FlexibleInterestHistory) ToDomain() *account.FlexibleInterestRecord {
+	return &account.FlexibleInterestRecord{
+		ID:                  h.ID,
+		AccountID:           h.AccountID,
+		CalculationDate:     h.CalculationDate,
+		EODBalance:          h.EODBalance,
+		AnnualRateApplied:   h.AnnualRateApplied,
+		DailyInterestAmount: h.DailyInterestAmount,
+		IsPromotionalRate:   h.IsPromotionalRate,
+		CreatedAt:           h.CreatedAt,
+	}
+}
+
+// FixedInterestHistory schema
+type FixedInterestHistory struct {
+	ID                  string    `gorm:"column:id;primaryKey"`
+	AccountID           string    `gorm:"column:account_id;not null"`
+	CalculationPeriod   string    `gorm:"column:calculation_period;not null"`
+	TotalInterestAmount float64   `gorm:"column:total_interest_amount;not null"`
+	IsEarlyWithdrawal   bool      `gorm:"column:is_early_withdrawal"`
+	CreatedAt           time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (h *FixedInterestHistory) ToDomain() *account.FixedInterestRecord {
+	return &account.FixedInterestRecord{
+		ID:                  h.ID,
+		AccountID:           h.AccountID,
+		CalculationPeriod:   h.CalculationPeriod,
+		TotalInterestAmount: h.TotalInterestAmount,
+		IsEarlyWithdrawal:   h.IsEarlyWithdrawal,
+		CreatedAt:           h.CreatedAt,
+	}
+}
+
+// CreateTransaction inserts tx, setting its ID when empty.
+func (r *transactionRepository) CreateTransaction(ctx context.Context, tx *account.Transaction) error {
+	if tx.ID == "" {
+		tx.ID = pkg.NewUUIDV7()
+	}
+	if tx.TransactionDate.IsZero() {
+		tx.TransactionDate = time.Now()
+	}
+	schema := &Transaction{
+		ID:              tx.ID,
+		AccountID:       tx.AccountID,
+		TransactionType: tx.TransactionType,
+		Amount:          tx.Amount,
+		TransactionDate: tx.TransactionDate,
+		Description:     tx.Description,
+		IsPenalty:       tx.IsPenalty,
+	}
+	if err := conn(ctx, r.db).Table(TransactionsTableName).Create(schema).Error; err != nil {
+		return err
+	}
+	tx.CreatedAt = schema.CreatedAt
+	return nil
+}
+
+func (r *transactionRepository) GetTransactionsByAccountIDs(ctx context.Context, accountIDs []string) ([]*account.Transaction, error) {
+	if len(accountIDs) == 0 {
+		return nil, nil
+	}
+
+	var schemas []Transaction
+	if err := conn(ctx, r.db).Table(TransactionsTableName).
+		Where("account_id IN ?", accountIDs).
+		Order("transaction_date ASC").
+		Find(&schemas).Error; err != nil {
+		return nil, err
+	}
+
+	var transactions []*account.Transaction
+	for _, schema := range schemas {
+		transactions = append(transactions, schema.ToDomain())
+	}
+
+	return transactions, nil
+}
+
+func (r *transactionRepository) GetAccountTransactionsSince(ctx context.Context, accountID string, since time.Time) ([]*account.Transaction, error) {
+	var schemas []Transaction
+	if err := conn(ctx, r.db).Table(TransactionsTableName).
+		Where("account_id = ? AND transaction_date >= ?", accountID, since).
+		Order("transaction_date ASC").
+		Find(&schemas).Error; err != nil {
+		return nil, err
+	}
+
+	var transactions []*account.Transaction
+	for _, schema := range schemas {
+		transactions = append(transactions, schema.ToDomain())
+	}
+
+	return transactions, nil
+}
+
+// CreateFlexibleInterestRecord inserts a daily interest record of a flexible
+// savings account, setting its ID when empty.
+func (r *interestHistoryRepository) CreateFlexibleInterestRecord(ctx context.Context, record *account.FlexibleInterestRecord) error {
+	if record.ID == "" {
+		record.ID = pkg.NewUUIDV7()
+	}
+	schema := &FlexibleInterestHistory{
+		ID:                  record.ID,
+		AccountID:           record.AccountID,
+		CalculationDate:     record.CalculationDate,
+		EODBalance:          record.EODBalance,
+		AnnualRateApplied:   record.AnnualRateApplied,
+		DailyInterestAmount: record.DailyInterestAmount,
+		IsPromotionalRate:   record.IsPromotionalRate,
+	}
+	if err := conn(ctx, r.db).Table(FlexibleInterestTableName).Create(schema).Error; err != nil {
+		return err
+	}
+	record.CreatedAt = schema.CreatedAt
+	return nil
+}
+
+// CreateFixedInterestRecord inserts the interest paid on a fixed savings
+// account, setting its ID when empty.
+func (r *interestHistoryRepository) CreateFixedInterestRecord(ctx context.Context, record *account.FixedInterestRecord) error {
+	if record.ID == "" {
+		record.ID = pkg.NewUUIDV7()
+	}
+	schema := &FixedInterestHistory{
+		ID:                  record.ID,
+		AccountID:           record.AccountID,
+		CalculationPeriod:   record.CalculationPeriod,
+		TotalInterestAmount: record.TotalInterestAmount,
+		IsEarlyWithdrawal:   record.IsEarlyWithdrawal,
+	}
+	if err := conn(ctx, r.db).Table(FixedInterestTableName).Create(schema).Error; err != nil {
+		return err
+	}
+	record.CreatedAt = schema.CreatedAt
+	return nil
+}
+
+func (r *interestHistoryRepository) GetFlexibleInterestHistoryByAccountIDs(ctx context.Context, accountIDs []string) ([]*account.FlexibleInterestRecord, error) {
+	if len(accountIDs) == 0 {
+		return nil, nil
+	}
+
+	var schemas []FlexibleInterestHistory
+	if err := conn(ctx, r.db).Table(FlexibleInterestTableName).
+		Where("account_id IN ?", accountIDs).
+		Order("calculation_date ASC").
+		Find(&schemas).Error; err != nil {
+		return nil, err
+	}
+
+	var records []*account.FlexibleInterestRecord
+	for _, schema := range schemas {
+		records = append(records, schema.ToDomain())
+	}
+
+	return records, nil
+}
+
+func (r *interestHistoryRepository) GetFixedInterestHistoryByAccountIDs(ctx context.Context, accountIDs []string) ([]*account.FixedInterestRecord, error) {
+	if len(accountIDs) == 0 {
+		return nil, nil
+	}
+
+	var schemas []FixedInterestHistory
+	if err := conn(ctx, r.db).Table(FixedInterestTableName).
+		Where("account_id IN ?", accountIDs).
+		Order("created_at ASC").
+		Find(&schemas).Error; err != nil {
+		return nil, err
+	}
+
+	var records []*account.FixedInterestRecord
+	for _, schema := range schemas {
+		records = append(records, schema.ToDomain())
+	}
+
+	return records, nil
+}
@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"context"
+
+	"e-wallet/internal/ports"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+type txManager struct {
+	db *gorm.DB
+}
+
+func NewTxManager(db *gorm.DB) ports.TxManager {
+	return &txManager{db: db}
+}
+
+// WithinTransaction starts a transaction, or a savepoint when ctx already
+// carries one, and passes it to fn through the context.
+func (m *txManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// on a transaction, gorm's Transaction uses a savepoint
+	return conn(ctx, m.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// conn returns the transaction carried by ctx, or db outside of one.
+// Repositories use it for every query so that they join the unit of work.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
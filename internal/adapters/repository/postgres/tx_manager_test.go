@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"e-wallet/internal/domain/user"
+	"e-wallet/pkg"
+)
+
+func newTestUser(name string) *user.User {
+	return &user.User{
+		ID:           pkg.NewUUIDV7(),
+		Username:     name,
+		Email:        name + "@example.com",
+		PasswordHash: "hashedpassword",
+	}
+}
+
+func TestTxManager(t *testing.T) {
+	db := setupTestDB(t)
+	txManager := NewTxManager(db)
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+
+	t.Run("commit", func(t *testing.T) {
+		u := newTestUser("committed")
+		err := txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			_, err := repo.Create(ctx, u)
+			return err
+		})
+		require.NoError(t, err)
+
+		_, err = repo.GetByID(ctx, u.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		u := newTestUser("rolledback")
+		err := txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			if _, err := repo.Create(ctx, u); err != nil {
+				return err
+			}
+			// visible inside the transaction
+			if _, err := repo.GetByID(ctx, u.ID); err != nil {
+				return err
+			}
+			return errBoom
+		})
+		assert.ErrorIs(t, err, errBoom)
+
+		_, err = repo.GetByID(ctx, u.ID)
+		assert.ErrorIs(t, err, ErrUserNotFound)
+	})
+
+	t.Run("nested savepoint", func(t *testing.T) {
+		outer, inner := newTestUser("outer"), newTestUser("inner")
+		err := txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			if _, err := repo.Create(ctx, outer); err != nil {
+				return err
+			}
+			err := txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+				if _, err := repo.Create(ctx, inner); err != nil {
+					return err
+				}
+				return errBoom
+			})
+			assert.ErrorIs(t, err, errBoom)
+			// the outer transaction goes on after the savepoint rollback
+			return nil
+		})
+		require.NoError(t, err)
+
+		_, err = repo.GetByID(ctx, outer.ID)
+		assert.NoError(t, err)
+		_, err = repo.GetByID(ctx, inner.ID)
+		assert.ErrorIs(t, err, ErrUserNotFound)
+	})
+}
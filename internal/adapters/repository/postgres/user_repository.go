@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"errors"
+	"time"
 
 	"e-wallet/internal/domain/user"
 	"e-wallet/internal/ports"
@@ -28,7 +29,10 @@ func (r *userRepository) Create(ctx context.Context, user *user.User) (*user.Use
 		IsProfileCompleted: user.IsProfileCompleted,
 	}
 
-	if err := r.db.WithContext(ctx).Table(UsersTableName).Create(schema).Error; err != nil {
+	if err := conn(ctx, r.db).Table(UsersTableName).Create(schema).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrUserAlreadyExists
+		}
 		return nil, err
 	}
 
@@ -37,7 +41,7 @@ func (r *userRepository) Create(ctx context.Context, user *user.User) (*user.Use
 
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*user.User, error) {
 	var schema User
-	if err := r.db.WithContext(ctx).Table(UsersTableName).Where("email = ?", email).First(&schema).Error; err != nil {
+	if err := conn(ctx, r.db).Table(UsersTableName).Where("email = ?", email).First(&schema).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrUserNotFound
 		}
@@ -49,7 +53,7 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*user.Us
 
 func (r *userRepository) GetByID(ctx context.Context, id string) (*user.User, error) {
 	var schema User
-	if err := r.db.WithContext(ctx).Table(UsersTableName).Where("id = ?", id).First(&schema).Error; err != nil {
+	if err := conn(ctx, r.db).Table(UsersTableName).Where("id = ?", id).First(&schema).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrUserNotFound
 		}
@@ -59,6 +63,15 @@ func (r *userRepository) GetByID(ctx context.Context, id string) (*user.User, er
 	return schema.ToDomain(), nil
 }
 
+func (r *userRepository) Anonymize(ctx context.Context, id string, username string, email string) error {
+	return conn(ctx, r.db).Table(UsersTableName).Where("id = ?", id).Updates(map[string]any{
+		"username":      username,
+		"email":         email,
+		"password_hash": "",
+		"deleted_at":    time.Now(),
+	}).Error
+}
+
 func (r *userRepository) UpdateProfileCompleted(ctx context.Context, id string, completed bool) error {
-	return r.db.WithContext(ctx).Table(UsersTableName).Where("id = ?", id).Update("is_profile_completed", completed).Error
+	return conn(ctx, r.db).Table(UsersTableName).Where("id = ?", id).Update("is_profile_completed", completed).Error
 }
\ No newline at end of file
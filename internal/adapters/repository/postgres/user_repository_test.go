@@ -2,94 +2,38 @@ package postgres
 
 import (
 	"context"
-	"database/sql"
-	"fmt"
-	"os"
 	"testing"
-	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 
-	"e-wallet/internal/config"
+	"e-wallet/internal/adapters/repository/postgres/pgtest"
 	"e-wallet/internal/domain/user"
 	"e-wallet/pkg"
-
-	_ "github.com/lib/pq"
-	migrate "github.com/rubenv/sql-migrate"
 )
 
 func TestMain(m *testing.M) {
-	// Set up test database environment variables
-	os.Setenv("DB_HOST", "pi.local")
-	os.Setenv("DB_PORT", "54321")
-	os.Setenv("DB_USER", "postgres")
-	os.Setenv("DB_PASS", "123456")
-	os.Setenv("DB_NAME", "e_wallet_test")
-	os.Setenv("ENABLE_SSL", "false")
-
-	code := m.Run()
-
-	os.Exit(code)
+	pgtest.Main(m)
 }
 
+// setupTestDB connects to a new, migrated database of its own, so tests may
+// commit and run concurrently without seeing each other's rows.
 func setupTestDB(t *testing.T) *gorm.DB {
-	cfg, err := config.LoadConfig()
-	require.NoError(t, err)
-
-	opts := ParseFromConfig(cfg)
-
-	// Generate unique database name
-	uniqueDBName := fmt.Sprintf("test_db_%d", time.Now().UnixNano())
-
-	// Create admin DSN to connect to postgres database
-	sslmode := "disable"
-	if opts.SSLMode {
-		sslmode = "enable"
-	}
-	adminDSN := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=postgres sslmode=%s",
-		opts.Host, opts.Port, opts.DBUser, opts.Password, sslmode,
-	)
-
-	// Connect to postgres database to create new database
-	adminDB, err := sql.Open("postgres", adminDSN)
-	require.NoError(t, err)
-	defer adminDB.Close()
-
-	// Create the unique database
-	_, err = adminDB.Exec("CREATE DATABASE " + uniqueDBName)
-	require.NoError(t, err)
-
-	// Set the database name to the unique one
-	opts.DBName = uniqueDBName
-
-	// Connect to the new database
-	db, err := NewConnection(opts)
-	require.NoError(t, err)
-
-	dbTest,err :=db.DB()
-	require.NoError(t, err)
-	migrations := &migrate.FileMigrationSource{
-		Dir: "../../../../migrations",
-	}
-	_, err = migrate.Exec(dbTest, "postgres", migrations, migrate.Up)
-	require.NoError(t, err)
+	return connectTestDB(t, pgtest.NewDatabase(t))
+}
 
-	// Cleanup: drop the database after test
-	t.Cleanup(func() {
-		// Close the GORM connection
-		sqlDB, _ := db.DB()
-		sqlDB.Close()
-
-		// Connect again to postgres to drop the database
-		adminDB2, err := sql.Open("postgres", adminDSN)
-		if err == nil {
-			defer adminDB2.Close()
-			adminDB2.Exec("DROP DATABASE IF EXISTS " + uniqueDBName)
-		}
+func connectTestDB(t *testing.T, testDB pgtest.Database) *gorm.DB {
+	db, err := NewConnection(Options{
+		DBName:   testDB.Name,
+		DBUser:   testDB.User,
+		Password: testDB.Password,
+		Host:     testDB.Host,
+		Port:     testDB.Port,
+		SSLMode:  testDB.SSLMode != "disable",
 	})
+	require.NoError(t, err)
+	t.Cleanup(func() { Close(db) })
 
 	return db
 }
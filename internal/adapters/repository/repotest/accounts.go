@@ -0,0 +1,346 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"e-wallet/internal/domain/account"
+	"e-wallet/pkg"
+)
+
+// date returns midnight UTC of the day, as read back from a DATE column.
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func testAccountRepository(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	alice := newUser(t, r, "alice")
+	bob := newUser(t, r, "bob")
+
+	payment, err := r.Accounts.CreatePaymentAccount(ctx, alice.ID)
+	require.NoError(t, err)
+	fixed, err := r.Accounts.CreateFixedSavingsAccount(ctx, alice.ID, &account.CreateFixedSavingsAccountRequest{TermCode: "6"})
+	require.NoError(t, err)
+	flexible, err := r.Accounts.CreateFlexibleSavingsAccount(ctx, alice.ID)
+	require.NoError(t, err)
+
+	t.Run("create", func(t *testing.T) {
+		for accountType, a := range map[string]*account.Account{
+			account.AccountTypePayment:         payment,
+			account.AccountTypeFixedSavings:    fixed,
+			account.AccountTypeFlexibleSavings: flexible,
+		} {
+			assert.Equal(t, accountType, a.AccountType)
+			assert.Equal(t, alice.ID, a.UserID)
+			assert.Equal(t, 0.0, a.Balance)
+			assert.Equal(t, "ACTIVE", a.Status)
+			assert.NoError(t, account.ValidateNumber(a.AccountNumber))
+			assert.NotZero(t, a.CreatedAt)
+		}
+		assert.NotEqual(t, payment.AccountNumber, fixed.AccountNumber)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, err := r.Accounts.CreatePaymentAccount(ctx, pkg.NewUUIDV7())
+		assert.Error(t, err)
+	})
+
+	t.Run("get by id", func(t *testing.T) {
+		a, err := r.Accounts.GetAccountByID(ctx, fixed.ID)
+		require.NoError(t, err)
+		assert.Equal(t, fixed.AccountNumber, a.AccountNumber)
+
+		_, err = r.Accounts.GetAccountByID(ctx, pkg.NewUUIDV7())
+		assert.ErrorIs(t, err, account.ErrAccountNotFound)
+	})
+
+	t.Run("get for update", func(t *testing.T) {
+		err := r.TxManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			a, err := r.Accounts.GetAccountForUpdate(ctx, flexible.ID)
+			require.NoError(t, err)
+			assert.Equal(t, flexible.AccountNumber, a.AccountNumber)
+
+			_, err = r.Accounts.GetAccountForUpdate(ctx, pkg.NewUUIDV7())
+			assert.ErrorIs(t, err, account.ErrAccountNotFound)
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("get by user id", func(t *testing.T) {
+		accounts, err := r.Accounts.GetAccountsByUserID(ctx, alice.ID)
+		require.NoError(t, err)
+		ids := make([]string, 0, len(accounts))
+		for _, a := range accounts {
+			ids = append(ids, a.ID)
+		}
+		assert.ElementsMatch(t, []string{payment.ID, fixed.ID, flexible.ID}, ids)
+
+		accounts, err = r.Accounts.GetAccountsByUserID(ctx, bob.ID)
+		require.NoError(t, err)
+		assert.Empty(t, accounts)
+	})
+
+	t.Run("count", func(t *testing.T) {
+		n, err := r.Accounts.CountPaymentAccountsByUserID(ctx, alice.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+
+		n, err = r.Accounts.CountSavingsAccountsByUserID(ctx, alice.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), n)
+
+		n, err = r.Accounts.CountSavingsAccountsByUserID(ctx, bob.ID)
+		require.NoError(t, err)
+		assert.Zero(t, n)
+	})
+
+	t.Run("update balance", func(t *testing.T) {
+		require.NoError(t, r.Accounts.UpdateAccountBalance(ctx, payment.ID, 1500.25))
+
+		a, err := r.Accounts.GetAccountByID(ctx, payment.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1500.25, a.Balance)
+
+		// like an UPDATE matching no row
+		assert.NoError(t, r.Accounts.UpdateAccountBalance(ctx, pkg.NewUUIDV7(), 1))
+	})
+
+	t.Run("update status", func(t *testing.T) {
+		require.NoError(t, r.Accounts.UpdateAccountStatus(ctx, flexible.ID, "CLOSED"))
+
+		a, err := r.Accounts.GetAccountByID(ctx, flexible.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "CLOSED", a.Status)
+		a, err = r.Accounts.GetAccountByID(ctx, fixed.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "ACTIVE", a.Status)
+	})
+
+	t.Run("close by user id", func(t *testing.T) {
+		bobs, err := r.Accounts.CreatePaymentAccount(ctx, bob.ID)
+		require.NoError(t, err)
+		require.NoError(t, r.Accounts.CloseAccountsByUserID(ctx, alice.ID))
+
+		accounts, err := r.Accounts.GetAccountsByUserID(ctx, alice.ID)
+		require.NoError(t, err)
+		for _, a := range accounts {
+			assert.Equal(t, "CLOSED", a.Status)
+		}
+		a, err := r.Accounts.GetAccountByID(ctx, bobs.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "ACTIVE", a.Status)
+	})
+}
+
+func testSavingsAccountDetailRepository(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	alice := newUser(t, r, "alice")
+	fixed, err := r.Accounts.CreateFixedSavingsAccount(ctx, alice.ID, &account.CreateFixedSavingsAccountRequest{TermCode: "6"})
+	require.NoError(t, err)
+
+	termMonths := 6
+	maturity := date(2026, time.April, 18)
+	detail := &account.SavingsAccountDetail{
+		AccountID:           fixed.ID,
+		IsFixedTerm:         true,
+		TermMonths:          &termMonths,
+		AnnualInterestRate:  0.036,
+		StartDate:           date(2025, time.October, 18),
+		MaturityDate:        &maturity,
+		MaturityInstruction: account.MaturityInstructionPayout,
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := r.SavingsDetails.GetSavingsAccountDetailByAccountID(ctx, fixed.ID)
+		assert.ErrorIs(t, err, account.ErrSavingsAccountDetailNotFound)
+	})
+
+	t.Run("create and get", func(t *testing.T) {
+		require.NoError(t, r.SavingsDetails.CreateSavingsAccountDetail(ctx, detail))
+
+		got, err := r.SavingsDetails.GetSavingsAccountDetailByAccountID(ctx, fixed.ID)
+		require.NoError(t, err)
+		assert.True(t, got.IsFixedTerm)
+		require.NotNil(t, got.TermMonths)
+		assert.Equal(t, 6, *got.TermMonths)
+		assert.Equal(t, 0.036, got.AnnualInterestRate)
+		assert.True(t, detail.StartDate.Equal(got.StartDate), "start date %s", got.StartDate)
+		require.NotNil(t, got.MaturityDate)
+		assert.True(t, maturity.Equal(*got.MaturityDate), "maturity date %s", got.MaturityDate)
+		assert.Nil(t, got.LastInterestCalcDate)
+		assert.Equal(t, account.MaturityInstructionPayout, got.MaturityInstruction)
+		assert.Zero(t, got.InterestCarry)
+	})
+
+	t.Run("one detail per account", func(t *testing.T) {
+		assert.Error(t, r.SavingsDetails.CreateSavingsAccountDetail(ctx, detail))
+	})
+
+	t.Run("unknown account", func(t *testing.T) {
+		orphan := *detail
+		orphan.AccountID = pkg.NewUUIDV7()
+		assert.Error(t, r.SavingsDetails.CreateSavingsAccountDetail(ctx, &orphan))
+	})
+
+	t.Run("update last interest calc date", func(t *testing.T) {
+		calcDate := date(2025, time.November, 18)
+		require.NoError(t, r.SavingsDetails.UpdateLastInterestCalcDate(ctx, fixed.ID, &calcDate))
+
+		got, err := r.SavingsDetails.GetSavingsAccountDetailByAccountID(ctx, fixed.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.LastInterestCalcDate)
+		assert.True(t, calcDate.Equal(*got.LastInterestCalcDate), "last interest calc date %s", got.LastInterestCalcDate)
+	})
+
+	t.Run("update interest carry", func(t *testing.T) {
+		require.NoError(t, r.SavingsDetails.UpdateInterestCarry(ctx, fixed.ID, 1_234_567))
+
+		got, err := r.SavingsDetails.GetSavingsAccountDetailByAccountID(ctx, fixed.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1_234_567), got.InterestCarry)
+	})
+
+	t.Run("update maturity instruction", func(t *testing.T) {
+		require.NoError(t, r.SavingsDetails.UpdateMaturityInstruction(ctx, fixed.ID, account.MaturityInstructionRolloverPrincipal))
+
+		got, err := r.SavingsDetails.GetSavingsAccountDetailByAccountID(ctx, fixed.ID)
+		require.NoError(t, err)
+		assert.Equal(t, account.MaturityInstructionRolloverPrincipal, got.MaturityInstruction)
+	})
+
+	t.Run("flexible due for interest", func(t *testing.T) {
+		bob := newUser(t, r, "bob")
+		newFlexible := func(userID string, lastCalc time.Time) string {
+			a, err := r.Accounts.CreateFlexibleSavingsAccount(ctx, userID)
+			require.NoError(t, err)
+			require.NoError(t, r.SavingsDetails.CreateSavingsAccountDetail(ctx, &account.SavingsAccountDetail{
+				AccountID:            a.ID,
+				AnnualInterestRate:   0.008,
+				StartDate:            lastCalc,
+				LastInterestCalcDate: &lastCalc,
+			}))
+			return a.ID
+		}
+		due := newFlexible(alice.ID, date(2025, time.October, 17))
+		newFlexible(alice.ID, date(2025, time.October, 18))
+		newFlexible(bob.ID, date(2025, time.October, 1))
+		require.NoError(t, r.Accounts.CloseAccountsByUserID(ctx, bob.ID))
+
+		// the morning of the 18th in Hanoi, still the 17th in UTC
+		ict := time.FixedZone("ICT", 7*60*60)
+		details, err := r.SavingsDetails.GetFlexibleSavingsDueForInterest(ctx, time.Date(2025, time.October, 18, 6, 0, 0, 0, ict))
+		require.NoError(t, err)
+		require.Len(t, details, 1)
+		assert.Equal(t, due, details[0].AccountID)
+		assert.False(t, details[0].IsFixedTerm)
+		require.NotNil(t, details[0].LastInterestCalcDate)
+		assert.True(t, date(2025, time.October, 17).Equal(*details[0].LastInterestCalcDate))
+	})
+}
+
+func testFixedSavingsMaturity(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	alice := newUser(t, r, "alice")
+	bob := newUser(t, r, "bob")
+
+	newFixed := func(userID string, maturity time.Time) string {
+		a, err := r.Accounts.CreateFixedSavingsAccount(ctx, userID, &account.CreateFixedSavingsAccountRequest{TermCode: "1"})
+		require.NoError(t, err)
+		termMonths := 1
+		require.NoError(t, r.SavingsDetails.CreateSavingsAccountDetail(ctx, &account.SavingsAccountDetail{
+			AccountID:           a.ID,
+			IsFixedTerm:         true,
+			TermMonths:          &termMonths,
+			AnnualInterestRate:  0.006,
+			StartDate:           maturity.AddDate(0, -1, 0),
+			MaturityDate:        &maturity,
+			MaturityInstruction: account.MaturityInstructionRolloverPrincipalAndInterest,
+		}))
+		return a.ID
+	}
+	due := newFixed(alice.ID, date(2025, time.October, 17))
+	dueToday := newFixed(alice.ID, date(2025, time.October, 18))
+	newFixed(alice.ID, date(2025, time.October, 19))
+	newFixed(bob.ID, date(2025, time.October, 1))
+	require.NoError(t, r.Accounts.CloseAccountsByUserID(ctx, bob.ID))
+	flexible, err := r.Accounts.CreateFlexibleSavingsAccount(ctx, alice.ID)
+	require.NoError(t, err)
+	lastCalc := date(2025, time.October, 1)
+	require.NoError(t, r.SavingsDetails.CreateSavingsAccountDetail(ctx, &account.SavingsAccountDetail{
+		AccountID:            flexible.ID,
+		AnnualInterestRate:   0.008,
+		StartDate:            lastCalc,
+		LastInterestCalcDate: &lastCalc,
+	}))
+
+	t.Run("due for maturity", func(t *testing.T) {
+		details, err := r.SavingsDetails.GetFixedSavingsDueForMaturity(ctx, time.Date(2025, time.October, 18, 9, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		ids := make([]string, 0, len(details))
+		for _, d := range details {
+			assert.True(t, d.IsFixedTerm)
+			assert.Equal(t, account.MaturityInstructionRolloverPrincipalAndInterest, d.MaturityInstruction)
+			ids = append(ids, d.AccountID)
+		}
+		assert.ElementsMatch(t, []string{due, dueToday}, ids)
+	})
+
+	t.Run("flexible have no maturity instruction", func(t *testing.T) {
+		got, err := r.SavingsDetails.GetSavingsAccountDetailByAccountID(ctx, flexible.ID)
+		require.NoError(t, err)
+		assert.Empty(t, got.MaturityInstruction)
+	})
+
+	t.Run("renew", func(t *testing.T) {
+		start, maturity := date(2025, time.October, 17), date(2025, time.November, 17)
+		require.NoError(t, r.SavingsDetails.RenewFixedTerm(ctx, due, start, maturity, 0.007))
+
+		got, err := r.SavingsDetails.GetSavingsAccountDetailByAccountID(ctx, due)
+		require.NoError(t, err)
+		assert.True(t, start.Equal(got.StartDate), "start date %s", got.StartDate)
+		require.NotNil(t, got.MaturityDate)
+		assert.True(t, maturity.Equal(*got.MaturityDate), "maturity date %s", got.MaturityDate)
+		assert.Equal(t, 0.007, got.AnnualInterestRate)
+
+		details, err := r.SavingsDetails.GetFixedSavingsDueForMaturity(ctx, date(2025, time.October, 18))
+		require.NoError(t, err)
+		require.Len(t, details, 1)
+		assert.Equal(t, dueToday, details[0].AccountID)
+	})
+
+	t.Run("renewal cycles", func(t *testing.T) {
+		// inserted out of order
+		for _, c := range []*account.RenewalCycle{
+			{AccountID: due, Cycle: 2, StartDate: date(2025, time.September, 17), MaturityDate: date(2025, time.October, 17), Principal: 1000.05, Interest: 0.5, AnnualInterestRate: 0.006, Instruction: account.MaturityInstructionRolloverPrincipalAndInterest, RenewedPrincipal: 1000.55},
+			{AccountID: dueToday, Cycle: 1, StartDate: date(2025, time.September, 18), MaturityDate: date(2025, time.October, 18), Principal: 10, Instruction: account.MaturityInstructionPayout},
+			{AccountID: due, Cycle: 1, StartDate: date(2025, time.August, 17), MaturityDate: date(2025, time.September, 17), Principal: 1000, Interest: 0.05, AnnualInterestRate: 0.006, Instruction: account.MaturityInstructionRolloverPrincipalAndInterest, RenewedPrincipal: 1000.05},
+		} {
+			require.NoError(t, r.SavingsDetails.CreateRenewalCycle(ctx, c))
+			assert.NotEmpty(t, c.ID)
+		}
+
+		cycles, err := r.SavingsDetails.GetRenewalCyclesByAccountIDs(ctx, []string{due})
+		require.NoError(t, err)
+		require.Len(t, cycles, 2)
+		assert.Equal(t, []int{1, 2}, []int{cycles[0].Cycle, cycles[1].Cycle})
+		assert.Equal(t, 1000.05, cycles[1].Principal)
+		assert.Equal(t, 0.5, cycles[1].Interest)
+		assert.Equal(t, 1000.55, cycles[1].RenewedPrincipal)
+		assert.True(t, date(2025, time.October, 17).Equal(cycles[1].MaturityDate), "maturity date %s", cycles[1].MaturityDate)
+
+		cycles, err = r.SavingsDetails.GetRenewalCyclesByAccountIDs(ctx, nil)
+		require.NoError(t, err)
+		assert.Empty(t, cycles)
+	})
+
+	t.Run("a cycle is recorded once", func(t *testing.T) {
+		err := r.SavingsDetails.CreateRenewalCycle(ctx, &account.RenewalCycle{AccountID: due, Cycle: 1, StartDate: date(2025, time.August, 17), MaturityDate: date(2025, time.September, 17), Instruction: account.MaturityInstructionPayout})
+		assert.Error(t, err)
+	})
+}
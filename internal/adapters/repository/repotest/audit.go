@@ -0,0 +1,64 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"e-wallet/internal/domain/audit"
+)
+
+func testAuditLogger(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	start := time.Date(2025, time.October, 18, 9, 0, 0, 0, time.UTC)
+
+	entries := []*audit.Entry{
+		{Action: audit.ActionLogin, ActorID: "user-1", TargetType: audit.TargetUser, TargetID: "user-1", IP: "10.0.0.1", CreatedAt: start},
+		{Action: audit.ActionAccountCreated, ActorID: "user-1", TargetType: audit.TargetAccount, TargetID: "account-1", Metadata: map[string]string{"type": "PAYMENT"}, CreatedAt: start.Add(time.Minute)},
+		{Action: audit.ActionLogin, ActorID: "user-2", TargetType: audit.TargetUser, TargetID: "user-2", IP: "10.0.0.2", CreatedAt: start.Add(2 * time.Minute)},
+	}
+	for _, e := range entries {
+		require.NoError(t, r.AuditLogger.Log(ctx, e))
+	}
+
+	t.Run("log seals the chain", func(t *testing.T) {
+		assert.NotEmpty(t, entries[0].ID)
+		assert.Equal(t, audit.GenesisHash, entries[0].PrevHash)
+		assert.Equal(t, entries[0].Hash, entries[1].PrevHash)
+		assert.Equal(t, entries[1].Hash, entries[2].PrevHash)
+	})
+
+	t.Run("query newest first", func(t *testing.T) {
+		result, err := r.AuditLogger.Query(ctx, &audit.Filter{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), result.Total)
+		require.Len(t, result.Entries, 3)
+		assert.Equal(t, entries[2].ID, result.Entries[0].ID)
+		assert.Equal(t, entries[0].ID, result.Entries[2].ID)
+		assert.Equal(t, map[string]string{"type": "PAYMENT"}, result.Entries[1].Metadata)
+	})
+
+	t.Run("query filters and pages", func(t *testing.T) {
+		result, err := r.AuditLogger.Query(ctx, &audit.Filter{Action: audit.ActionLogin, Limit: 1, Offset: 1})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), result.Total)
+		require.Len(t, result.Entries, 1)
+		assert.Equal(t, entries[0].ID, result.Entries[0].ID)
+
+		from, to := start.Add(time.Minute), start.Add(2*time.Minute)
+		result, err = r.AuditLogger.Query(ctx, &audit.Filter{ActorID: "user-1", From: &from, To: &to})
+		require.NoError(t, err)
+		require.Len(t, result.Entries, 1)
+		assert.Equal(t, entries[1].ID, result.Entries[0].ID)
+	})
+
+	t.Run("verify", func(t *testing.T) {
+		result, err := r.AuditLogger.Verify(ctx)
+		require.NoError(t, err)
+		assert.True(t, result.Valid)
+		assert.Equal(t, int64(3), result.CheckedCount)
+	})
+}
@@ -0,0 +1,136 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"e-wallet/internal/domain/account"
+	"e-wallet/pkg"
+)
+
+func testTransactionRepository(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	alice := newUser(t, r, "alice")
+	payment, err := r.Accounts.CreatePaymentAccount(ctx, alice.ID)
+	require.NoError(t, err)
+	flexible, err := r.Accounts.CreateFlexibleSavingsAccount(ctx, alice.ID)
+	require.NoError(t, err)
+
+	day := time.Date(2025, time.October, 18, 9, 0, 0, 0, time.UTC)
+	// inserted out of order
+	for _, tx := range []*account.Transaction{
+		{AccountID: payment.ID, TransactionType: account.TransactionTypeWithdrawal, Amount: 20, TransactionDate: day.Add(2 * time.Hour)},
+		{AccountID: flexible.ID, TransactionType: account.TransactionTypeInterestCredit, Amount: 0.5, TransactionDate: day.Add(time.Hour)},
+		{AccountID: payment.ID, TransactionType: account.TransactionTypePaymentInitiation, Amount: 100, TransactionDate: day, Description: "top up"},
+	} {
+		require.NoError(t, r.Transactions.CreateTransaction(ctx, tx))
+	}
+
+	t.Run("ordered by transaction date", func(t *testing.T) {
+		txs, err := r.Transactions.GetTransactionsByAccountIDs(ctx, []string{payment.ID, flexible.ID})
+		require.NoError(t, err)
+		require.Len(t, txs, 3)
+		assert.Equal(t, []float64{100, 0.5, 20}, []float64{txs[0].Amount, txs[1].Amount, txs[2].Amount})
+		assert.Equal(t, "top up", txs[0].Description)
+		assert.True(t, day.Equal(txs[0].TransactionDate), "transaction date %s", txs[0].TransactionDate)
+	})
+
+	t.Run("only the given accounts", func(t *testing.T) {
+		txs, err := r.Transactions.GetTransactionsByAccountIDs(ctx, []string{flexible.ID})
+		require.NoError(t, err)
+		require.Len(t, txs, 1)
+		assert.Equal(t, account.TransactionTypeInterestCredit, txs[0].TransactionType)
+
+		txs, err = r.Transactions.GetTransactionsByAccountIDs(ctx, []string{pkg.NewUUIDV7()})
+		require.NoError(t, err)
+		assert.Empty(t, txs)
+	})
+
+	t.Run("no accounts", func(t *testing.T) {
+		txs, err := r.Transactions.GetTransactionsByAccountIDs(ctx, nil)
+		require.NoError(t, err)
+		assert.Empty(t, txs)
+	})
+
+	t.Run("since", func(t *testing.T) {
+		deposit := &account.Transaction{AccountID: flexible.ID, TransactionType: account.TransactionTypeDeposit, Amount: 50, TransactionDate: day.Add(3 * time.Hour)}
+		require.NoError(t, r.Transactions.CreateTransaction(ctx, deposit))
+
+		txs, err := r.Transactions.GetAccountTransactionsSince(ctx, flexible.ID, day.Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, txs, 2)
+		assert.Equal(t, account.TransactionTypeInterestCredit, txs[0].TransactionType)
+		assert.Equal(t, account.TransactionTypeDeposit, txs[1].TransactionType)
+		assert.Equal(t, deposit.ID, txs[1].ID)
+
+		txs, err = r.Transactions.GetAccountTransactionsSince(ctx, flexible.ID, day.Add(3*time.Hour+time.Second))
+		require.NoError(t, err)
+		assert.Empty(t, txs)
+	})
+
+	t.Run("create sets the id", func(t *testing.T) {
+		tx := &account.Transaction{AccountID: payment.ID, TransactionType: account.TransactionTypeInterestCredit, Amount: 1}
+		require.NoError(t, r.Transactions.CreateTransaction(ctx, tx))
+		assert.NotEmpty(t, tx.ID)
+		assert.NotZero(t, tx.TransactionDate)
+
+		record := &account.FlexibleInterestRecord{AccountID: flexible.ID, CalculationDate: day, EODBalance: 1, AnnualRateApplied: 0.008}
+		require.NoError(t, r.InterestHistory.CreateFlexibleInterestRecord(ctx, record))
+		assert.NotEmpty(t, record.ID)
+	})
+
+	t.Run("unknown account", func(t *testing.T) {
+		err := r.Transactions.CreateTransaction(ctx, &account.Transaction{AccountID: pkg.NewUUIDV7(), TransactionType: account.TransactionTypeWithdrawal, Amount: 1})
+		assert.Error(t, err)
+	})
+}
+
+func testInterestHistoryRepository(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	alice := newUser(t, r, "alice")
+	flexible, err := r.Accounts.CreateFlexibleSavingsAccount(ctx, alice.ID)
+	require.NoError(t, err)
+	fixed, err := r.Accounts.CreateFixedSavingsAccount(ctx, alice.ID, &account.CreateFixedSavingsAccountRequest{TermCode: "1"})
+	require.NoError(t, err)
+
+	for _, record := range []*account.FlexibleInterestRecord{
+		{AccountID: flexible.ID, CalculationDate: date(2025, time.October, 19), EODBalance: 1000, AnnualRateApplied: 0.002, DailyInterestAmount: 0.01},
+		{AccountID: flexible.ID, CalculationDate: date(2025, time.October, 18), EODBalance: 900, AnnualRateApplied: 0.002, DailyInterestAmount: 0.01},
+	} {
+		require.NoError(t, r.InterestHistory.CreateFlexibleInterestRecord(ctx, record))
+	}
+	for _, period := range []string{"2025-10", "2025-11"} {
+		require.NoError(t, r.InterestHistory.CreateFixedInterestRecord(ctx, &account.FixedInterestRecord{AccountID: fixed.ID, CalculationPeriod: period, TotalInterestAmount: 6}))
+	}
+
+	t.Run("flexible ordered by calculation date", func(t *testing.T) {
+		records, err := r.InterestHistory.GetFlexibleInterestHistoryByAccountIDs(ctx, []string{flexible.ID})
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, 900.0, records[0].EODBalance)
+		assert.True(t, date(2025, time.October, 18).Equal(records[0].CalculationDate), "calculation date %s", records[0].CalculationDate)
+		assert.Equal(t, 1000.0, records[1].EODBalance)
+	})
+
+	t.Run("fixed ordered by creation", func(t *testing.T) {
+		records, err := r.InterestHistory.GetFixedInterestHistoryByAccountIDs(ctx, []string{fixed.ID})
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, "2025-10", records[0].CalculationPeriod)
+		assert.Equal(t, "2025-11", records[1].CalculationPeriod)
+	})
+
+	t.Run("only the given accounts", func(t *testing.T) {
+		flexibleRecords, err := r.InterestHistory.GetFlexibleInterestHistoryByAccountIDs(ctx, []string{fixed.ID})
+		require.NoError(t, err)
+		assert.Empty(t, flexibleRecords)
+
+		fixedRecords, err := r.InterestHistory.GetFixedInterestHistoryByAccountIDs(ctx, nil)
+		require.NoError(t, err)
+		assert.Empty(t, fixedRecords)
+	})
+}
@@ -0,0 +1,141 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"e-wallet/internal/domain/banklink"
+	"e-wallet/internal/domain/privacy"
+	"e-wallet/pkg"
+)
+
+func testDataExportRepository(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	alice := newUser(t, r, "alice")
+
+	export, err := r.DataExports.Create(ctx, privacy.NewDataExport(alice.ID))
+	require.NoError(t, err)
+
+	t.Run("create", func(t *testing.T) {
+		assert.Equal(t, alice.ID, export.UserID)
+		assert.Equal(t, privacy.ExportStatusPending, export.Status)
+		assert.NotZero(t, export.CreatedAt)
+		assert.Nil(t, export.CompletedAt)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		completedAt := time.Now().UTC().Truncate(time.Microsecond)
+		export.Status = privacy.ExportStatusCompleted
+		export.FileName = export.ID + ".zip"
+		export.CompletedAt = &completedAt
+		require.NoError(t, r.DataExports.Update(ctx, export))
+
+		got, err := r.DataExports.GetByID(ctx, export.ID)
+		require.NoError(t, err)
+		assert.Equal(t, privacy.ExportStatusCompleted, got.Status)
+		assert.Equal(t, export.FileName, got.FileName)
+		require.NotNil(t, got.CompletedAt)
+		assert.True(t, completedAt.Equal(*got.CompletedAt), "completed at %s", got.CompletedAt)
+	})
+
+	t.Run("by user", func(t *testing.T) {
+		bob := newUser(t, r, "bob")
+		_, err := r.DataExports.Create(ctx, privacy.NewDataExport(bob.ID))
+		require.NoError(t, err)
+
+		exports, err := r.DataExports.GetByUserID(ctx, alice.ID)
+		require.NoError(t, err)
+		require.Len(t, exports, 1)
+		assert.Equal(t, export.ID, exports[0].ID)
+
+		exports, err = r.DataExports.GetByUserID(ctx, pkg.NewUUIDV7())
+		require.NoError(t, err)
+		assert.Empty(t, exports)
+	})
+
+	t.Run("fail unfinished", func(t *testing.T) {
+		carol := newUser(t, r, "carol")
+		pending, err := r.DataExports.Create(ctx, privacy.NewDataExport(carol.ID))
+		require.NoError(t, err)
+		processing, err := r.DataExports.Create(ctx, privacy.NewDataExport(carol.ID))
+		require.NoError(t, err)
+		processing.Status = privacy.ExportStatusProcessing
+		require.NoError(t, r.DataExports.Update(ctx, processing))
+
+		// nothing was created before the first export
+		failed, err := r.DataExports.FailUnfinished(ctx, export.CreatedAt.Add(-time.Second), time.Now(), "interrupted")
+		require.NoError(t, err)
+		assert.Zero(t, failed)
+
+		completedAt := time.Now().UTC().Truncate(time.Microsecond)
+		failed, err = r.DataExports.FailUnfinished(ctx, time.Now().Add(time.Minute), completedAt, "interrupted")
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, failed, 2)
+
+		got, err := r.DataExports.GetByID(ctx, export.ID)
+		require.NoError(t, err)
+		assert.Equal(t, privacy.ExportStatusCompleted, got.Status, "finished exports are left alone")
+
+		for _, id := range []string{pending.ID, processing.ID} {
+			got, err := r.DataExports.GetByID(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, privacy.ExportStatusFailed, got.Status)
+			assert.Equal(t, "interrupted", got.Error)
+			require.NotNil(t, got.CompletedAt)
+			assert.True(t, completedAt.Equal(*got.CompletedAt), "completed at %s", got.CompletedAt)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := r.DataExports.GetByID(ctx, pkg.NewUUIDV7())
+		assert.ErrorIs(t, err, privacy.ErrExportNotFound)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, err := r.DataExports.Create(ctx, privacy.NewDataExport(pkg.NewUUIDV7()))
+		assert.Error(t, err)
+	})
+}
+
+func testBankLinkRepository(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	alice := newUser(t, r, "alice")
+	bob := newUser(t, r, "bob")
+
+	link, err := r.BankLinks.Create(ctx, &banklink.BankLink{
+		ID:           pkg.NewUUIDV7(),
+		UserID:       alice.ID,
+		BankCode:     "VCB",
+		AccountType:  "PAYMENT",
+		AccessToken:  "access-token",
+		RefreshToken: "refresh-token",
+		ExpiresIn:    3600,
+	})
+	require.NoError(t, err)
+
+	t.Run("tokens round trip", func(t *testing.T) {
+		links, err := r.BankLinks.GetByUserID(ctx, alice.ID)
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Equal(t, link.ID, links[0].ID)
+		assert.Equal(t, "access-token", links[0].AccessToken)
+		assert.Equal(t, "refresh-token", links[0].RefreshToken)
+		assert.Equal(t, 3600, links[0].ExpiresIn)
+		assert.NotZero(t, links[0].CreatedAt)
+	})
+
+	t.Run("none", func(t *testing.T) {
+		links, err := r.BankLinks.GetByUserID(ctx, bob.ID)
+		require.NoError(t, err)
+		assert.Empty(t, links)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, err := r.BankLinks.Create(ctx, &banklink.BankLink{ID: pkg.NewUUIDV7(), UserID: pkg.NewUUIDV7(), BankCode: "VCB", AccountType: "PAYMENT"})
+		assert.Error(t, err)
+	})
+}
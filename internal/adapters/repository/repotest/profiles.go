@@ -0,0 +1,104 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"e-wallet/internal/domain/profile"
+	"e-wallet/pkg"
+)
+
+func newProfile(userID string, phoneNumber string, nationalID string) *profile.Profile {
+	return &profile.Profile{
+		UserID:            userID,
+		DisplayName:       "Alice",
+		PhoneNumber:       phoneNumber,
+		NationalID:        nationalID,
+		BirthYear:         1990,
+		Gender:            "FEMALE",
+		Team:              "BACK_END",
+		PreferredLanguage: "en",
+	}
+}
+
+func testProfileRepository(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	alice := newUser(t, r, "alice")
+	bob := newUser(t, r, "bob")
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := r.Profiles.GetByUserID(ctx, alice.ID)
+		assert.ErrorIs(t, err, profile.ErrProfileNotFound)
+	})
+
+	t.Run("insert then update", func(t *testing.T) {
+		created, err := r.Profiles.Upsert(ctx, newProfile(alice.ID, "+84901234567", "079201001234"))
+		require.NoError(t, err)
+		assert.Equal(t, "+84901234567", created.PhoneNumber)
+
+		avatar := "https://example.com/alice.png"
+		update := newProfile(alice.ID, "+84901234568", "079201001234")
+		update.AvatarURL = &avatar
+		_, err = r.Profiles.Upsert(ctx, update)
+		require.NoError(t, err)
+
+		p, err := r.Profiles.GetByUserID(ctx, alice.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "+84901234568", p.PhoneNumber)
+		assert.Equal(t, "079201001234", p.NationalID)
+		require.NotNil(t, p.AvatarURL)
+		assert.Equal(t, avatar, *p.AvatarURL)
+	})
+
+	t.Run("exists checks exclude the user", func(t *testing.T) {
+		exists, err := r.Profiles.CheckPhoneNumberExists(ctx, "+84901234568", "")
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		exists, err = r.Profiles.CheckPhoneNumberExists(ctx, "+84901234568", alice.ID)
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		exists, err = r.Profiles.CheckNationalIDExists(ctx, "079201001234", bob.ID)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		exists, err = r.Profiles.CheckNationalIDExists(ctx, "000000000000", "")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("phone number and national id are unique", func(t *testing.T) {
+		_, err := r.Profiles.Upsert(ctx, newProfile(bob.ID, "+84901234568", "079201009999"))
+		assert.Error(t, err)
+
+		_, err = r.Profiles.Upsert(ctx, newProfile(bob.ID, "+84909999999", "079201001234"))
+		assert.Error(t, err)
+
+		_, err = r.Profiles.GetByUserID(ctx, bob.ID)
+		assert.ErrorIs(t, err, profile.ErrProfileNotFound)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, err := r.Profiles.Upsert(ctx, newProfile(pkg.NewUUIDV7(), "+84905555555", "079201005555"))
+		assert.Error(t, err)
+	})
+
+	t.Run("anonymize", func(t *testing.T) {
+		require.NoError(t, r.Profiles.Anonymize(ctx, alice.ID, "anonymized-alice"))
+
+		p, err := r.Profiles.GetByUserID(ctx, alice.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Deleted user", p.DisplayName)
+		assert.Nil(t, p.AvatarURL)
+		assert.Equal(t, "anonymized-alice", p.PhoneNumber)
+		assert.Equal(t, "anonymized-alice", p.NationalID)
+
+		exists, err := r.Profiles.CheckPhoneNumberExists(ctx, "+84901234568", "")
+		require.NoError(t, err)
+		assert.False(t, exists, "the phone number is free again")
+	})
+}
@@ -0,0 +1,45 @@
+// Package repotest is the contract every implementation of the repository
+// ports must pass, so that services behave the same on each of them.
+//
+// An adapter runs the suite from its tests:
+//
+//	func TestContract(t *testing.T) {
+//		repotest.Run(t, func(t *testing.T) repotest.Repositories { ... })
+//	}
+package repotest
+
+import (
+	"testing"
+
+	"e-wallet/internal/ports"
+)
+
+// Repositories are the adapters under test, all sharing one empty database.
+type Repositories struct {
+	Users           ports.UserRepository
+	Profiles        ports.ProfileRepository
+	Accounts        ports.AccountRepository
+	SavingsDetails  ports.SavingsAccountDetailRepository
+	Transactions    ports.TransactionRepository
+	InterestHistory ports.InterestHistoryRepository
+	DataExports     ports.DataExportRepository
+	BankLinks       ports.BankLinkRepository
+	AuditLogger     ports.AuditLogger
+	TxManager       ports.TxManager
+}
+
+// Run runs the contract against the repositories returned by newRepos, which
+// is called once per test with a new, empty database.
+func Run(t *testing.T, newRepos func(t *testing.T) Repositories) {
+	t.Run("UserRepository", func(t *testing.T) { testUserRepository(t, newRepos(t)) })
+	t.Run("ProfileRepository", func(t *testing.T) { testProfileRepository(t, newRepos(t)) })
+	t.Run("AccountRepository", func(t *testing.T) { testAccountRepository(t, newRepos(t)) })
+	t.Run("SavingsAccountDetailRepository", func(t *testing.T) { testSavingsAccountDetailRepository(t, newRepos(t)) })
+	t.Run("FixedSavingsMaturity", func(t *testing.T) { testFixedSavingsMaturity(t, newRepos(t)) })
+	t.Run("TransactionRepository", func(t *testing.T) { testTransactionRepository(t, newRepos(t)) })
+	t.Run("InterestHistoryRepository", func(t *testing.T) { testInterestHistoryRepository(t, newRepos(t)) })
+	t.Run("DataExportRepository", func(t *testing.T) { testDataExportRepository(t, newRepos(t)) })
+	t.Run("BankLinkRepository", func(t *testing.T) { testBankLinkRepository(t, newRepos(t)) })
+	t.Run("AuditLogger", func(t *testing.T) { testAuditLogger(t, newRepos(t)) })
+	t.Run("TxManager", func(t *testing.T) { testTxManager(t, newRepos(t)) })
+}
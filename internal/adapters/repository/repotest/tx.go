@@ -0,0 +1,91 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"e-wallet/internal/domain/user"
+	"e-wallet/pkg"
+)
+
+func testTxManager(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+	create := func(ctx context.Context, name string) (*user.User, error) {
+		return r.Users.Create(ctx, &user.User{ID: pkg.NewUUIDV7(), Username: name, Email: name + "@example.com", PasswordHash: "x"})
+	}
+
+	t.Run("commit", func(t *testing.T) {
+		var u *user.User
+		err := r.TxManager.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+			u, err = create(ctx, "committed")
+			return err
+		})
+		require.NoError(t, err)
+
+		_, err = r.Users.GetByID(ctx, u.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		var u *user.User
+		err := r.TxManager.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+			if u, err = create(ctx, "rolledback"); err != nil {
+				return err
+			}
+			// visible inside the transaction
+			if _, err := r.Users.GetByID(ctx, u.ID); err != nil {
+				return err
+			}
+			return errBoom
+		})
+		assert.ErrorIs(t, err, errBoom)
+
+		_, err = r.Users.GetByID(ctx, u.ID)
+		assert.ErrorIs(t, err, user.ErrUserNotFound)
+	})
+
+	t.Run("nested savepoint", func(t *testing.T) {
+		var outer, inner *user.User
+		err := r.TxManager.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+			if outer, err = create(ctx, "outer"); err != nil {
+				return err
+			}
+			err = r.TxManager.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+				if inner, err = create(ctx, "inner"); err != nil {
+					return err
+				}
+				return errBoom
+			})
+			assert.ErrorIs(t, err, errBoom)
+			// the outer transaction goes on after the savepoint rollback
+			return nil
+		})
+		require.NoError(t, err)
+
+		_, err = r.Users.GetByID(ctx, outer.ID)
+		assert.NoError(t, err)
+		_, err = r.Users.GetByID(ctx, inner.ID)
+		assert.ErrorIs(t, err, user.ErrUserNotFound)
+	})
+
+	t.Run("rollback on panic", func(t *testing.T) {
+		var u *user.User
+		assert.PanicsWithValue(t, "boom", func() {
+			_ = r.TxManager.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+				if u, err = create(ctx, "panicked"); err != nil {
+					return err
+				}
+				panic("boom")
+			})
+		})
+		require.NotNil(t, u)
+
+		_, err := r.Users.GetByID(ctx, u.ID)
+		assert.ErrorIs(t, err, user.ErrUserNotFound)
+	})
+}
@@ -0,0 +1,87 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"e-wallet/internal/domain/user"
+	"e-wallet/pkg"
+)
+
+// newUser creates a user whose username and email derive from name.
+func newUser(t *testing.T, r Repositories, name string) *user.User {
+	t.Helper()
+	u, err := r.Users.Create(context.Background(), &user.User{
+		ID:           pkg.NewUUIDV7(),
+		Username:     name,
+		Email:        name + "@example.com",
+		PasswordHash: "hashedpassword",
+	})
+	require.NoError(t, err)
+	return u
+}
+
+func testUserRepository(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	alice := newUser(t, r, "alice")
+
+	t.Run("create", func(t *testing.T) {
+		assert.Equal(t, "alice", alice.Username)
+		assert.Equal(t, "alice@example.com", alice.Email)
+		assert.False(t, alice.IsProfileCompleted)
+		assert.NotZero(t, alice.CreatedAt)
+		assert.Nil(t, alice.DeletedAt)
+	})
+
+	t.Run("get by id and email", func(t *testing.T) {
+		byID, err := r.Users.GetByID(ctx, alice.ID)
+		require.NoError(t, err)
+		assert.Equal(t, alice.Email, byID.Email)
+
+		byEmail, err := r.Users.GetByEmail(ctx, alice.Email)
+		require.NoError(t, err)
+		assert.Equal(t, alice.ID, byEmail.ID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := r.Users.GetByID(ctx, pkg.NewUUIDV7())
+		assert.ErrorIs(t, err, user.ErrUserNotFound)
+
+		_, err = r.Users.GetByEmail(ctx, "nobody@example.com")
+		assert.ErrorIs(t, err, user.ErrUserNotFound)
+	})
+
+	t.Run("username and email are unique", func(t *testing.T) {
+		_, err := r.Users.Create(ctx, &user.User{ID: pkg.NewUUIDV7(), Username: "alice", Email: "other@example.com", PasswordHash: "x"})
+		assert.ErrorIs(t, err, user.ErrUserAlreadyExists)
+
+		_, err = r.Users.Create(ctx, &user.User{ID: pkg.NewUUIDV7(), Username: "other", Email: "alice@example.com", PasswordHash: "x"})
+		assert.ErrorIs(t, err, user.ErrUserAlreadyExists)
+	})
+
+	t.Run("update profile completed", func(t *testing.T) {
+		require.NoError(t, r.Users.UpdateProfileCompleted(ctx, alice.ID, true))
+
+		u, err := r.Users.GetByID(ctx, alice.ID)
+		require.NoError(t, err)
+		assert.True(t, u.IsProfileCompleted)
+	})
+
+	t.Run("anonymize", func(t *testing.T) {
+		bob := newUser(t, r, "bob")
+		require.NoError(t, r.Users.Anonymize(ctx, bob.ID, "deleted-bob", "deleted-bob@invalid"))
+
+		u, err := r.Users.GetByID(ctx, bob.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "deleted-bob", u.Username)
+		assert.Equal(t, "deleted-bob@invalid", u.Email)
+		assert.Empty(t, u.PasswordHash)
+		assert.NotNil(t, u.DeletedAt)
+
+		_, err = r.Users.GetByEmail(ctx, "bob@example.com")
+		assert.ErrorIs(t, err, user.ErrUserNotFound)
+	})
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"e-wallet/internal/ports"
+)
+
+// ciphertextPrefix marks values produced by the envelope encryptor. Values
+// without it are legacy plaintext and are returned unchanged by Decrypt.
+const ciphertextPrefix = "enc:v1:"
+
+type envelopeEncryptor struct {
+	keys ports.KeyProvider
+}
+
+// NewEnvelopeEncryptor encrypts every value with its own random data key,
+// which is itself encrypted ("wrapped") with the provider's active key.
+//
+// Stored format: enc:v1:<key id>:<wrapped data key>:<ciphertext>
+func NewEnvelopeEncryptor(keys ports.KeyProvider) ports.FieldEncryptor {
+	return &envelopeEncryptor{keys: keys}
+}
+
+func (e *envelopeEncryptor) Encrypt(plaintext string) (string, error) {
+	keyID, kek, err := e.keys.ActiveKey()
+	if err != nil {
+		return "", err
+	}
+
+	dek := make([]byte, keySize)
+	if _, err := rand.Read(dek); err != nil {
+		return "", err
+	}
+
+	wrapped, err := seal(kek, dek)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := seal(dek, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+
+	return ciphertextPrefix + keyID + ":" +
+		base64.RawStdEncoding.EncodeToString(wrapped) + ":" +
+		base64.RawStdEncoding.EncodeToString(ciphertext), nil
+}
+
+func (e *envelopeEncryptor) Decrypt(value string) (string, error) {
+	if !strings.HasPrefix(value, ciphertextPrefix) {
+		return value, nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(value, ciphertextPrefix), ":")
+	if len(parts) != 3 {
+		return "", errors.New("malformed ciphertext")
+	}
+
+	kek, err := e.keys.Key(parts[0])
+	if err != nil {
+		return "", err
+	}
+	wrapped, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("malformed data key: %w", err)
+	}
+	ciphertext, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("malformed ciphertext: %w", err)
+	}
+
+	dek, err := open(kek, wrapped)
+	if err != nil {
+		return "", fmt.Errorf("unwrap data key: %w", err)
+	}
+	plaintext, err := open(dek, ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("decrypt value: %w", err)
+	}
+
+	return string(plaintext), nil
+}
+
+// BlindIndex returns a deterministic keyed hash of the normalised value, so
+// equality lookups and unique constraints work on encrypted columns.
+func (e *envelopeEncryptor) BlindIndex(value string) (string, error) {
+	key, err := e.keys.IndexKey()
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(strings.ToUpper(strings.TrimSpace(value))))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+func (e *envelopeEncryptor) NeedsRotation(value string) bool {
+	if !strings.HasPrefix(value, ciphertextPrefix) {
+		return true
+	}
+
+	activeKeyID, _, err := e.keys.ActiveKey()
+	if err != nil {
+		return false
+	}
+	return !strings.HasPrefix(value, ciphertextPrefix+activeKeyID+":")
+}
+
+// seal encrypts with AES-256-GCM and prepends the random nonce.
+func seal(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(key, sealed []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"e-wallet/internal/ports"
+)
+
+func writeKeyFile(t *testing.T, kf KeyFile) string {
+	raw, err := json.Marshal(kf)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "keys.json")
+	require.NoError(t, os.WriteFile(path, raw, 0o600))
+	return path
+}
+
+func newTestKeyFile(t *testing.T, keyIDs ...string) KeyFile {
+	kf := KeyFile{Keys: map[string]string{}}
+	for _, id := range keyIDs {
+		key, err := NewRandomKey()
+		require.NoError(t, err)
+		kf.Keys[id] = key
+		kf.ActiveKeyID = id
+	}
+	indexKey, err := NewRandomKey()
+	require.NoError(t, err)
+	kf.IndexKey = indexKey
+	return kf
+}
+
+func newTestEncryptor(t *testing.T, kf KeyFile) ports.FieldEncryptor {
+	keys, err := NewLocalKeyProvider(writeKeyFile(t, kf))
+	require.NoError(t, err)
+	return NewEnvelopeEncryptor(keys)
+}
+
+func TestEnvelopeEncryptor_EncryptDecrypt(t *testing.T) {
+	enc := newTestEncryptor(t, newTestKeyFile(t, "k1"))
+
+	tests := []struct {
+		name      string
+		plaintext string
+	}{
+		{name: "success - national id", plaintext: "079201001234"},
+		{name: "success - phone number", plaintext: "+84901234567"},
+		{name: "success - empty value", plaintext: ""},
+		{name: "success - unicode", plaintext: "Nguyễn Văn A"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ciphertext, err := enc.Encrypt(tt.plaintext)
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(ciphertext, "enc:v1:k1:"))
+			if tt.plaintext != "" {
+				assert.NotContains(t, ciphertext, tt.plaintext)
+			}
+
+			plaintext, err := enc.Decrypt(ciphertext)
+			require.NoError(t, err)
+			assert.Equal(t, tt.plaintext, plaintext)
+		})
+	}
+
+	// Same plaintext encrypts differently every time
+	c1, _ := enc.Encrypt("079201001234")
+	c2, _ := enc.Encrypt("079201001234")
+	assert.NotEqual(t, c1, c2)
+
+	// Legacy plaintext passes through
+	plaintext, err := enc.Decrypt("079201001234")
+	require.NoError(t, err)
+	assert.Equal(t, "079201001234", plaintext)
+
+	// Tampered ciphertext is rejected
+	tampered := []byte(c1)
+	tampered[len(tampered)-1] ^= 0x01
+	_, err = enc.Decrypt(string(tampered))
+	assert.Error(t, err)
+}
+
+func TestEnvelopeEncryptor_Rotation(t *testing.T) {
+	kf := newTestKeyFile(t, "k1")
+	oldEnc := newTestEncryptor(t, kf)
+	ciphertext, err := oldEnc.Encrypt("079201001234")
+	require.NoError(t, err)
+	assert.False(t, oldEnc.NeedsRotation(ciphertext))
+	assert.True(t, oldEnc.NeedsRotation("079201001234"))
+
+	// Add a new active key, keeping the old one for decryption
+	newKey, err := NewRandomKey()
+	require.NoError(t, err)
+	kf.Keys["k2"] = newKey
+	kf.ActiveKeyID = "k2"
+	newEnc := newTestEncryptor(t, kf)
+
+	assert.True(t, newEnc.NeedsRotation(ciphertext))
+	plaintext, err := newEnc.Decrypt(ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "079201001234", plaintext)
+
+	rotated, err := newEnc.Encrypt(plaintext)
+	require.NoError(t, err)
+	assert.False(t, newEnc.NeedsRotation(rotated))
+}
+
+func TestEnvelopeEncryptor_BlindIndex(t *testing.T) {
+	kf := newTestKeyFile(t, "k1")
+	enc := newTestEncryptor(t, kf)
+
+	idx1, err := enc.BlindIndex("079201001234")
+	require.NoError(t, err)
+	idx2, err := enc.BlindIndex(" 079201001234 ")
+	require.NoError(t, err)
+	idx3, err := enc.BlindIndex("079201001235")
+	require.NoError(t, err)
+
+	assert.Equal(t, idx1, idx2)
+	assert.NotEqual(t, idx1, idx3)
+	assert.Len(t, idx1, 64)
+
+	// Rotating the data keys keeps blind indexes stable
+	newKey, err := NewRandomKey()
+	require.NoError(t, err)
+	kf.Keys["k2"] = newKey
+	kf.ActiveKeyID = "k2"
+	idx4, err := newTestEncryptor(t, kf).BlindIndex("079201001234")
+	require.NoError(t, err)
+	assert.Equal(t, idx1, idx4)
+}
+
+func TestNewLocalKeyProvider_Errors(t *testing.T) {
+	kf := newTestKeyFile(t, "k1")
+	kf.ActiveKeyID = "missing"
+	_, err := NewLocalKeyProvider(writeKeyFile(t, kf))
+	assert.EqualError(t, err, `active key "missing" not found in key file`)
+
+	kf = newTestKeyFile(t, "k1")
+	kf.Keys["short"] = "c2hvcnQ="
+	_, err = NewLocalKeyProvider(writeKeyFile(t, kf))
+	assert.EqualError(t, err, `key "short": key must be 32 bytes, got 5`)
+
+	_, err = NewLocalKeyProvider("")
+	assert.EqualError(t, err, "encryption key file is not configured")
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"e-wallet/internal/ports"
+)
+
+const keySize = 32 // AES-256
+
+// KeyFile is the on-disk format of the local key provider.
+type KeyFile struct {
+	ActiveKeyID string            `json:"active_key_id"`
+	Keys        map[string]string `json:"keys"`
+	IndexKey    string            `json:"index_key"`
+}
+
+type localKeyProvider struct {
+	activeKeyID string
+	keys        map[string][]byte
+	indexKey    []byte
+}
+
+// NewLocalKeyProvider loads base64 encoded keys from a JSON key file.
+func NewLocalKeyProvider(path string) (ports.KeyProvider, error) {
+	if path == "" {
+		return nil, errors.New("encryption key file is not configured")
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read key file: %w", err)
+	}
+
+	var kf KeyFile
+	if err := json.Unmarshal(raw, &kf); err != nil {
+		return nil, fmt.Errorf("parse key file: %w", err)
+	}
+
+	p := &localKeyProvider{
+		activeKeyID: kf.ActiveKeyID,
+		keys:        make(map[string][]byte, len(kf.Keys)),
+	}
+	for id, encoded := range kf.Keys {
+		key, err := decodeKey(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", id, err)
+		}
+		p.keys[id] = key
+	}
+	if _, ok := p.keys[p.activeKeyID]; !ok {
+		return nil, fmt.Errorf("active key %q not found in key file", p.activeKeyID)
+	}
+
+	p.indexKey, err = decodeKey(kf.IndexKey)
+	if err != nil {
+		return nil, fmt.Errorf("index key: %w", err)
+	}
+
+	return p, nil
+}
+
+func (p *localKeyProvider) ActiveKey() (string, []byte, error) {
+	return p.activeKeyID, p.keys[p.activeKeyID], nil
+}
+
+func (p *localKeyProvider) Key(id string) ([]byte, error) {
+	key, ok := p.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", id)
+	}
+	return key, nil
+}
+
+func (p *localKeyProvider) IndexKey() ([]byte, error) {
+	return p.indexKey, nil
+}
+
+// NewRandomKey returns a base64 encoded random 256-bit key.
+func NewRandomKey() (string, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+func decodeKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", keySize, len(key))
+	}
+	return key, nil
+}
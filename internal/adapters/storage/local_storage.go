@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"e-wallet/internal/ports"
+)
+
+type localExportStorage struct {
+	dir string
+}
+
+// NewLocalExportStorage stores export archives as files under dir.
+func NewLocalExportStorage(dir string) ports.ExportStorage {
+	return &localExportStorage{dir: dir}
+}
+
+func (s *localExportStorage) Save(ctx context.Context, name string, content io.Reader) error {
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".export-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path(name))
+}
+
+func (s *localExportStorage) Open(ctx context.Context, name string) (io.ReadCloser, error) {
+	return os.Open(s.path(name))
+}
+
+func (s *localExportStorage) Delete(ctx context.Context, name string) error {
+	if err := os.Remove(s.path(name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path keeps files inside the storage directory whatever name is given.
+func (s *localExportStorage) path(name string) string {
+	return filepath.Join(s.dir, filepath.Base(name))
+}
+
+type localStorageHealthChecker struct {
+	dir string
+}
+
+// NewLocalStorageHealthChecker checks that dir exists and is writable.
+func NewLocalStorageHealthChecker(dir string) ports.HealthChecker {
+	return &localStorageHealthChecker{dir: dir}
+}
+
+func (h *localStorageHealthChecker) Name() string {
+	return "export_storage"
+}
+
+func (h *localStorageHealthChecker) Check(ctx context.Context) error {
+	if err := os.MkdirAll(h.dir, 0o700); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(h.dir, ".healthcheck-*")
+	if err != nil {
+		return err
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
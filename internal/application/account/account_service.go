@@ -2,26 +2,35 @@ package account
 
 import (
 	"context"
-	"errors"
-	"fmt"
-	"math/rand"
-	"time"
+	"slices"
+	"strconv"
 
 	"e-wallet/internal/domain/account"
+	"e-wallet/internal/domain/calendar"
 	"e-wallet/internal/ports"
 )
 
 type accountService struct {
-	userRepo    ports.UserRepository
-	accountRepo ports.AccountRepository
-	savingsRepo ports.SavingsAccountDetailRepository
+	userRepo        ports.UserRepository
+	accountRepo     ports.AccountRepository
+	savingsRepo     ports.SavingsAccountDetailRepository
+	transactionRepo ports.TransactionRepository
+	txManager       ports.TxManager
+	metrics         ports.Metrics
+	clock           ports.Clock
+	calendar        ports.BusinessCalendar
 }
 
-func NewAccountService(userRepo ports.UserRepository, accountRepo ports.AccountRepository, savingsRepo ports.SavingsAccountDetailRepository) ports.AccountService {
+func NewAccountService(userRepo ports.UserRepository, accountRepo ports.AccountRepository, savingsRepo ports.SavingsAccountDetailRepository, transactionRepo ports.TransactionRepository, txManager ports.TxManager, metrics ports.Metrics, clock ports.Clock, businessDays ports.BusinessCalendar) ports.AccountService {
 	return &accountService{
-		userRepo:    userRepo,
-		accountRepo: accountRepo,
-		savingsRepo: savingsRepo,
+		userRepo:        userRepo,
+		accountRepo:     accountRepo,
+		savingsRepo:     savingsRepo,
+		transactionRepo: transactionRepo,
+		txManager:       txManager,
+		metrics:         metrics,
+		clock:           clock,
+		calendar:        businessDays,
 	}
 }
 
@@ -32,7 +41,7 @@ func (s *accountService) CreatePaymentAccount(ctx context.Context, userID string
 		return nil, err
 	}
 	if !user.IsProfileCompleted {
-		return nil, errors.New("user profile must be completed before creating accounts")
+		return nil, account.ErrProfileNotCompleted
 	}
 
 	// Check limit: max 1 payment account per user
@@ -41,7 +50,7 @@ func (s *accountService) CreatePaymentAccount(ctx context.Context, userID string
 		return nil, err
 	}
 	if count >= 1 {
-		return nil, errors.New("user can have at most 1 payment account")
+		return nil, account.ErrPaymentAccountLimit
 	}
 
 	// Create account
@@ -49,6 +58,7 @@ func (s *accountService) CreatePaymentAccount(ctx context.Context, userID string
 	if err != nil {
 		return nil, err
 	}
+	s.metrics.AccountCreated(account.AccountTypePayment)
 
 	return acc, nil
 }
@@ -60,7 +70,7 @@ func (s *accountService) CreateFixedSavingsAccount(ctx context.Context, userID s
 		return nil, err
 	}
 	if !user.IsProfileCompleted {
-		return nil, errors.New("user profile must be completed before creating accounts")
+		return nil, account.ErrProfileNotCompleted
 	}
 
 	// Check limit: max 5 savings accounts per user
@@ -69,7 +79,7 @@ func (s *accountService) CreateFixedSavingsAccount(ctx context.Context, userID s
 		return nil, err
 	}
 	if count >= 5 {
-		return nil, errors.New("user can have at most 5 savings accounts")
+		return nil, account.ErrSavingsAccountLimit
 	}
 
 	// Validate term code and get interest rate
@@ -78,27 +88,42 @@ func (s *accountService) CreateFixedSavingsAccount(ctx context.Context, userID s
 		return nil, err
 	}
 
-	// Create account
-	acc, err := s.accountRepo.CreateFixedSavingsAccount(ctx, userID, req)
-	if err != nil {
+	maturityInstruction := req.MaturityInstruction
+	if maturityInstruction == "" {
+		maturityInstruction = account.DefaultMaturityInstruction
+	}
+	if err := s.checkMaturityInstruction(ctx, userID, maturityInstruction); err != nil {
 		return nil, err
 	}
 
-	// Create savings detail
-	maturityDate := acc.CreatedAt.AddDate(0, termMonths, 0)
-	detail := &account.SavingsAccountDetail{
-		AccountID:             acc.ID,
-		IsFixedTerm:           true,
-		TermMonths:            &termMonths,
-		AnnualInterestRate:    interestRate,
-		StartDate:             acc.CreatedAt,
-		MaturityDate:          &maturityDate,
-		LastInterestCalcDate:  nil, // Will be set on first interest calculation
-	}
+	// Create the account and its savings detail together, so that a failed
+	// detail insert leaves no orphan account behind
+	var acc *account.Account
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		acc, err = s.accountRepo.CreateFixedSavingsAccount(ctx, userID, req)
+		if err != nil {
+			return err
+		}
 
-	if err := s.savingsRepo.CreateSavingsAccountDetail(ctx, detail); err != nil {
+		// A deposit matures on a business day, so that it can be paid out
+		startDate := s.clock.Now()
+		maturityDate := s.calendar.Adjust(calendar.AddMonths(startDate, termMonths))
+		detail := &account.SavingsAccountDetail{
+			AccountID:             acc.ID,
+			IsFixedTerm:           true,
+			TermMonths:            &termMonths,
+			AnnualInterestRate:    interestRate,
+			StartDate:             startDate,
+			MaturityDate:          &maturityDate,
+			LastInterestCalcDate:  nil, // Will be set on first interest calculation
+			MaturityInstruction:   maturityInstruction,
+		}
+		return s.savingsRepo.CreateSavingsAccountDetail(ctx, detail)
+	})
+	if err != nil {
 		return nil, err
 	}
+	s.metrics.AccountCreated(account.AccountTypeFixedSavings)
 
 	return acc, nil
 }
@@ -110,7 +135,7 @@ func (s *accountService) CreateFlexibleSavingsAccount(ctx context.Context, userI
 		return nil, err
 	}
 	if !user.IsProfileCompleted {
-		return nil, errors.New("user profile must be completed before creating accounts")
+		return nil, account.ErrProfileNotCompleted
 	}
 
 	// Check limit: max 5 savings accounts per user
@@ -119,29 +144,34 @@ func (s *accountService) CreateFlexibleSavingsAccount(ctx context.Context, userI
 		return nil, err
 	}
 	if count >= 5 {
-		return nil, errors.New("user can have at most 5 savings accounts")
+		return nil, account.ErrSavingsAccountLimit
 	}
 
-	// Create account
-	acc, err := s.accountRepo.CreateFlexibleSavingsAccount(ctx, userID)
-	if err != nil {
-		return nil, err
-	}
-
-	// Create savings detail with promotional rate (0.8%)
-	detail := &account.SavingsAccountDetail{
-		AccountID:             acc.ID,
-		IsFixedTerm:           false,
-		TermMonths:            nil,
-		AnnualInterestRate:    0.008, // 0.8% promotional rate
-		StartDate:             acc.CreatedAt,
-		MaturityDate:          nil,
-		LastInterestCalcDate:  &acc.CreatedAt,
-	}
+	// Create the account and its savings detail together
+	var acc *account.Account
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		acc, err = s.accountRepo.CreateFlexibleSavingsAccount(ctx, userID)
+		if err != nil {
+			return err
+		}
 
-	if err := s.savingsRepo.CreateSavingsAccountDetail(ctx, detail); err != nil {
+		// Create savings detail with promotional rate (0.8%)
+		startDate := s.clock.Now()
+		detail := &account.SavingsAccountDetail{
+			AccountID:             acc.ID,
+			IsFixedTerm:           false,
+			TermMonths:            nil,
+			AnnualInterestRate:    0.008, // 0.8% promotional rate
+			StartDate:             startDate,
+			MaturityDate:          nil,
+			LastInterestCalcDate:  &startDate,
+		}
+		return s.savingsRepo.CreateSavingsAccountDetail(ctx, detail)
+	})
+	if err != nil {
 		return nil, err
 	}
+	s.metrics.AccountCreated(account.AccountTypeFlexibleSavings)
 
 	return acc, nil
 }
@@ -179,6 +209,7 @@ func (s *accountService) ListAccounts(ctx context.Context, userID string) (*acco
 					StartDate:             detail.StartDate,
 					MaturityDate:          detail.MaturityDate,
 					LastInterestCalcDate:  detail.LastInterestCalcDate,
+					MaturityInstruction:   detail.MaturityInstruction,
 				}
 			}
 		}
@@ -189,25 +220,207 @@ func (s *accountService) ListAccounts(ctx context.Context, userID string) (*acco
 	return &account.ListAccountsResponse{Accounts: response}, nil
 }
 
-func (s *accountService) getFixedSavingsTermDetails(termCode string) (int, float64, error) {
-	switch termCode {
-	case "1":
-		return 1, 0.006, nil // 0.6%
-	case "3":
-		return 3, 0.018, nil // 1.8%
-	case "6":
-		return 6, 0.036, nil // 3.6%
-	case "8":
-		return 8, 0.048, nil // 4.8%
-	case "12":
-		return 12, 0.072, nil // 7.2%
-	default:
-		return 0, 0, fmt.Errorf("invalid term code: %s", termCode)
+// UpdateMaturityInstruction changes what is done with a fixed savings
+// account of the user when its term matures. It can only be changed before
+// the maturity date, the worker maturing the deposits on that day.
+func (s *accountService) UpdateMaturityInstruction(ctx context.Context, userID, accountID, instruction string) (*account.SavingsAccountDetail, error) {
+	var detail *account.SavingsAccountDetail
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		acc, err := s.accountRepo.GetAccountByID(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		// Someone else's account is reported as missing, not as forbidden
+		if acc.UserID != userID {
+			return account.ErrAccountNotFound
+		}
+		if acc.AccountType != account.AccountTypeFixedSavings {
+			return account.ErrNotFixedSavings.With("account_number", acc.AccountNumber)
+		}
+		if acc.Status != "ACTIVE" {
+			return account.ErrAccountNotActive.With("account_number", acc.AccountNumber)
+		}
+
+		detail, err = s.savingsRepo.GetSavingsAccountDetailByAccountID(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		if detail.MaturityDate != nil && account.DaysBetween(s.clock.Now(), *detail.MaturityDate) <= 0 {
+			return account.ErrAccountMatured.With("account_number", acc.AccountNumber)
+		}
+		if err := s.checkMaturityInstruction(ctx, userID, instruction); err != nil {
+			return err
+		}
+
+		if err := s.savingsRepo.UpdateMaturityInstruction(ctx, accountID, instruction); err != nil {
+			return err
+		}
+		detail.MaturityInstruction = instruction
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+
+	return detail, nil
 }
 
-// generateAccountNumber generates a unique 10-digit account number
-func generateAccountNumber() string {
-	rand.Seed(time.Now().UnixNano())
-	return fmt.Sprintf("%010d", rand.Intn(10000000000))
-}
\ No newline at end of file
+// DepositToFlexibleSavings moves amount from the PAYMENT account of the user
+// to one of their flexible savings accounts. The deposit earns interest from
+// the end of the day it is made.
+func (s *accountService) DepositToFlexibleSavings(ctx context.Context, userID, accountID string, amount float64) (*account.Account, error) {
+	minor := account.AmountFromFloat(amount)
+	if minor < account.MinFlexibleSavingsDeposit {
+		return nil, account.ErrDepositBelowMinimum
+	}
+
+	savings, err := s.moveFlexibleSavings(ctx, userID, accountID, minor, true)
+	if err != nil {
+		return nil, err
+	}
+	s.metrics.TransferCompleted(account.TransactionTypeDeposit, minor.Float64())
+
+	return savings, nil
+}
+
+// WithdrawFromFlexibleSavings moves amount from a flexible savings account of
+// the user to their PAYMENT account. The amount withdrawn stops earning
+// interest from the end of the day before.
+func (s *accountService) WithdrawFromFlexibleSavings(ctx context.Context, userID, accountID string, amount float64) (*account.Account, error) {
+	minor := account.AmountFromFloat(amount)
+	if minor < account.MinFlexibleSavingsWithdrawal {
+		return nil, account.ErrWithdrawalBelowMinimum
+	}
+
+	savings, err := s.moveFlexibleSavings(ctx, userID, accountID, minor, false)
+	if err != nil {
+		return nil, err
+	}
+	s.metrics.TransferCompleted(account.TransactionTypeWithdrawal, minor.Float64())
+
+	return savings, nil
+}
+
+// moveFlexibleSavings moves amount between a flexible savings account of the
+// user and their PAYMENT account, into savings when deposit is true and out
+// of it otherwise. Both balances change and both transactions are recorded,
+// or nothing is.
+func (s *accountService) moveFlexibleSavings(ctx context.Context, userID, accountID string, amount account.Amount, deposit bool) (*account.Account, error) {
+	var savings *account.Account
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		acc, err := s.accountRepo.GetAccountByID(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		// Someone else's account is reported as missing, not as forbidden
+		if acc.UserID != userID {
+			return account.ErrAccountNotFound
+		}
+		if acc.AccountType != account.AccountTypeFlexibleSavings {
+			return account.ErrNotFlexibleSavings.With("account_number", acc.AccountNumber)
+		}
+
+		accounts, err := s.accountRepo.GetAccountsByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		i := slices.IndexFunc(accounts, func(a *account.Account) bool {
+			return a.AccountType == account.AccountTypePayment && a.Status == "ACTIVE"
+		})
+		if i < 0 {
+			return account.ErrPaymentAccountRequired
+		}
+
+		// Lock both accounts, always in the same order so that two
+		// transfers between them can't deadlock, and read their balances
+		// again once locked
+		ids := []string{accountID, accounts[i].ID}
+		slices.Sort(ids)
+		locked := make(map[string]*account.Account, len(ids))
+		for _, id := range ids {
+			if locked[id], err = s.accountRepo.GetAccountForUpdate(ctx, id); err != nil {
+				return err
+			}
+		}
+		savings = locked[accountID]
+		payment := locked[accounts[i].ID]
+		for _, acc := range []*account.Account{savings, payment} {
+			if acc.Status != "ACTIVE" {
+				return account.ErrAccountNotActive.With("account_number", acc.AccountNumber)
+			}
+		}
+
+		from, to := savings, payment
+		if deposit {
+			from, to = payment, savings
+		}
+		if err := account.Transfer(from, to, amount); err != nil {
+			return err
+		}
+		if err := s.accountRepo.UpdateAccountBalance(ctx, from.ID, from.Balance); err != nil {
+			return err
+		}
+		if err := s.accountRepo.UpdateAccountBalance(ctx, to.ID, to.Balance); err != nil {
+			return err
+		}
+
+		description := "Withdrawal"
+		if deposit {
+			description = "Deposit"
+		}
+		now := s.clock.Now()
+		err = s.transactionRepo.CreateTransaction(ctx, &account.Transaction{
+			AccountID:       from.ID,
+			TransactionType: account.TransactionTypeWithdrawal,
+			Amount:          amount.Float64(),
+			TransactionDate: now,
+			Description:     description + " to " + to.AccountNumber,
+		})
+		if err != nil {
+			return err
+		}
+		return s.transactionRepo.CreateTransaction(ctx, &account.Transaction{
+			AccountID:       to.ID,
+			TransactionType: account.TransactionTypeDeposit,
+			Amount:          amount.Float64(),
+			TransactionDate: now,
+			Description:     description + " from " + from.AccountNumber,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return savings, nil
+}
+
+// checkMaturityInstruction checks that instruction is known and, when it
+// pays out, that the user has a PAYMENT account to pay to.
+func (s *accountService) checkMaturityInstruction(ctx context.Context, userID, instruction string) error {
+	if !account.ValidMaturityInstruction(instruction) {
+		return account.ErrInvalidMaturityInstruction.With("maturity_instruction", instruction)
+	}
+	if !account.PaysOut(instruction) {
+		return nil
+	}
+	count, err := s.accountRepo.CountPaymentAccountsByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return account.ErrPaymentAccountRequired
+	}
+	return nil
+}
+
+func (s *accountService) getFixedSavingsTermDetails(termCode string) (int, float64, error) {
+	termMonths, err := strconv.Atoi(termCode)
+	if err != nil || strconv.Itoa(termMonths) != termCode {
+		return 0, 0, account.ErrInvalidTermCode.With("term_code", termCode)
+	}
+	rate, ok := account.FixedSavingsRate(termMonths)
+	if !ok {
+		return 0, 0, account.ErrInvalidTermCode.With("term_code", termCode)
+	}
+	return termMonths, rate, nil
+}
@@ -0,0 +1,433 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"e-wallet/internal/adapters/clock"
+	"e-wallet/internal/adapters/metrics"
+	"e-wallet/internal/adapters/repository/memory"
+	"e-wallet/internal/domain/account"
+	"e-wallet/internal/domain/calendar"
+	"e-wallet/internal/domain/user"
+	"e-wallet/internal/ports"
+	"e-wallet/mocks"
+	"e-wallet/pkg"
+)
+
+type testEnv struct {
+	users    ports.UserRepository
+	accounts ports.AccountRepository
+	savings  ports.SavingsAccountDetailRepository
+	txs      ports.TransactionRepository
+	store    *memory.Store
+	clock    *clock.Fake
+	calendar *calendar.Calendar
+}
+
+// newTestEnv backs the service with the in-memory repositories.
+func newTestEnv(t *testing.T) *testEnv {
+	numbers, err := account.NewNumberGenerator("97")
+	require.NoError(t, err)
+
+	store := memory.NewStore()
+	return &testEnv{
+		users:    memory.NewUserRepository(store),
+		accounts: memory.NewAccountRepository(store, numbers),
+		savings:  memory.NewSavingsAccountDetailRepository(store),
+		txs:      memory.NewTransactionRepository(store),
+		store:    store,
+		clock:    clock.NewFake(time.Date(2025, 1, 15, 9, 30, 0, 0, time.UTC)),
+		calendar: calendar.New(calendar.ModifiedFollowing, tet2026()...),
+	}
+}
+
+// tet2026 is the Tết break of 2026, Saturday February 14th to Sunday the 22nd.
+func tet2026() []calendar.Holiday {
+	var holidays []calendar.Holiday
+	for d := time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC); d.Day() <= 22; d = d.AddDate(0, 0, 1) {
+		holidays = append(holidays, calendar.Holiday{Date: d, Name: "Tết Nguyên Đán"})
+	}
+	return holidays
+}
+
+func (e *testEnv) service() ports.AccountService {
+	return NewAccountService(e.users, e.accounts, e.savings, e.txs, memory.NewTxManager(e.store), metrics.Noop, e.clock, e.calendar)
+}
+
+func (e *testEnv) newUser(t *testing.T, profileCompleted bool) *user.User {
+	id := pkg.NewUUIDV7()
+	u, err := e.users.Create(context.Background(), &user.User{
+		ID:                 id,
+		Username:           "alice-" + id,
+		Email:              "alice-" + id + "@example.com",
+		PasswordHash:       "hashedpassword",
+		IsProfileCompleted: profileCompleted,
+	})
+	require.NoError(t, err)
+	return u
+}
+
+func TestAccountService_CreatePaymentAccount(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("profile not completed", func(t *testing.T) {
+		env := newTestEnv(t)
+		u := env.newUser(t, false)
+
+		_, err := env.service().CreatePaymentAccount(ctx, u.ID)
+		assert.ErrorIs(t, err, account.ErrProfileNotCompleted)
+	})
+
+	t.Run("one payment account per user", func(t *testing.T) {
+		env := newTestEnv(t)
+		u := env.newUser(t, true)
+		svc := env.service()
+
+		acc, err := svc.CreatePaymentAccount(ctx, u.ID)
+		require.NoError(t, err)
+		assert.Equal(t, account.AccountTypePayment, acc.AccountType)
+
+		_, err = svc.CreatePaymentAccount(ctx, u.ID)
+		assert.ErrorIs(t, err, account.ErrPaymentAccountLimit)
+	})
+}
+
+func TestAccountService_CreateFixedSavingsAccount(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creates the savings detail", func(t *testing.T) {
+		env := newTestEnv(t)
+		u := env.newUser(t, true)
+
+		acc, err := env.service().CreateFixedSavingsAccount(ctx, u.ID, &account.CreateFixedSavingsAccountRequest{TermCode: "6"})
+		require.NoError(t, err)
+
+		detail, err := env.savings.GetSavingsAccountDetailByAccountID(ctx, acc.ID)
+		require.NoError(t, err)
+		assert.True(t, detail.IsFixedTerm)
+		assert.Equal(t, 6, *detail.TermMonths)
+		assert.Equal(t, 0.036, detail.AnnualInterestRate)
+		assert.Equal(t, time.Date(2025, 1, 15, 9, 30, 0, 0, time.UTC), detail.StartDate)
+		assert.Equal(t, time.Date(2025, 7, 15, 9, 30, 0, 0, time.UTC), *detail.MaturityDate)
+	})
+
+	t.Run("matures a leap day later across february", func(t *testing.T) {
+		env := newTestEnv(t)
+		u := env.newUser(t, true)
+		env.clock.Set(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC))
+
+		acc, err := env.service().CreateFixedSavingsAccount(ctx, u.ID, &account.CreateFixedSavingsAccountRequest{TermCode: "3"})
+		require.NoError(t, err)
+
+		detail, err := env.savings.GetSavingsAccountDetailByAccountID(ctx, acc.ID)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC), *detail.MaturityDate)
+		assert.Equal(t, 91, account.DaysBetween(detail.StartDate, *detail.MaturityDate))
+	})
+
+	// the maturity date is moved to a business day, modified following
+	maturities := []struct {
+		name  string
+		start time.Time
+		term  string
+		want  time.Time
+	}{
+		{"after tết", time.Date(2025, 11, 16, 9, 0, 0, 0, time.UTC), "3", time.Date(2026, 2, 23, 9, 0, 0, 0, time.UTC)},
+		{"back to friday at the end of the month", time.Date(2025, 11, 30, 9, 0, 0, 0, time.UTC), "6", time.Date(2026, 5, 29, 9, 0, 0, 0, time.UTC)},
+		{"from the end of a longer month", time.Date(2025, 8, 31, 9, 0, 0, 0, time.UTC), "6", time.Date(2026, 2, 27, 9, 0, 0, 0, time.UTC)},
+		{"on a business day", time.Date(2025, 12, 7, 9, 0, 0, 0, time.UTC), "1", time.Date(2026, 1, 7, 9, 0, 0, 0, time.UTC)},
+		{"on a saturday", time.Date(2025, 12, 10, 9, 0, 0, 0, time.UTC), "1", time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range maturities {
+		t.Run("matures "+tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			u := env.newUser(t, true)
+			env.clock.Set(tt.start)
+
+			acc, err := env.service().CreateFixedSavingsAccount(ctx, u.ID, &account.CreateFixedSavingsAccountRequest{TermCode: tt.term})
+			require.NoError(t, err)
+
+			detail, err := env.savings.GetSavingsAccountDetailByAccountID(ctx, acc.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, *detail.MaturityDate)
+			assert.True(t, env.calendar.IsBusinessDay(*detail.MaturityDate))
+		})
+	}
+
+	t.Run("invalid term code", func(t *testing.T) {
+		env := newTestEnv(t)
+		u := env.newUser(t, true)
+
+		_, err := env.service().CreateFixedSavingsAccount(ctx, u.ID, &account.CreateFixedSavingsAccountRequest{TermCode: "7"})
+		assert.ErrorIs(t, err, account.ErrInvalidTermCode)
+	})
+
+	t.Run("five savings accounts per user", func(t *testing.T) {
+		env := newTestEnv(t)
+		u := env.newUser(t, true)
+		svc := env.service()
+
+		for i := 0; i < 5; i++ {
+			_, err := svc.CreateFlexibleSavingsAccount(ctx, u.ID)
+			require.NoError(t, err)
+		}
+		_, err := svc.CreateFixedSavingsAccount(ctx, u.ID, &account.CreateFixedSavingsAccountRequest{TermCode: "1"})
+		assert.ErrorIs(t, err, account.ErrSavingsAccountLimit)
+	})
+
+	t.Run("no account without its detail", func(t *testing.T) {
+		env := newTestEnv(t)
+		u := env.newUser(t, true)
+		errBoom := errors.New("boom")
+		savings := mocks.NewMockSavingsAccountDetailRepository(t)
+		savings.EXPECT().CreateSavingsAccountDetail(mock.Anything, mock.Anything).Return(errBoom).Once()
+		env.savings = savings
+
+		_, err := env.service().CreateFixedSavingsAccount(ctx, u.ID, &account.CreateFixedSavingsAccountRequest{TermCode: "1"})
+		assert.ErrorIs(t, err, errBoom)
+
+		accounts, err := env.accounts.GetAccountsByUserID(ctx, u.ID)
+		require.NoError(t, err)
+		assert.Empty(t, accounts, "the account insert is rolled back")
+	})
+}
+
+func TestAccountService_CreateFlexibleSavingsAccount(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	u := env.newUser(t, true)
+
+	acc, err := env.service().CreateFlexibleSavingsAccount(ctx, u.ID)
+	require.NoError(t, err)
+
+	detail, err := env.savings.GetSavingsAccountDetailByAccountID(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.False(t, detail.IsFixedTerm)
+	assert.Nil(t, detail.MaturityDate)
+	assert.Equal(t, env.clock.Now(), detail.StartDate)
+	// interest accrues from the opening day
+	assert.Equal(t, env.clock.Now(), *detail.LastInterestCalcDate)
+}
+
+func TestAccountService_MaturityInstruction(t *testing.T) {
+	ctx := context.Background()
+
+	// newFixed opens a six-month deposit on January 15th 2025, maturing on
+	// July 15th
+	newFixed := func(t *testing.T, env *testEnv, req *account.CreateFixedSavingsAccountRequest) (*user.User, *account.Account) {
+		u := env.newUser(t, true)
+		req.TermCode = "6"
+		acc, err := env.service().CreateFixedSavingsAccount(ctx, u.ID, req)
+		require.NoError(t, err)
+		return u, acc
+	}
+
+	t.Run("rolls over by default", func(t *testing.T) {
+		env := newTestEnv(t)
+		_, acc := newFixed(t, env, &account.CreateFixedSavingsAccountRequest{})
+
+		detail, err := env.savings.GetSavingsAccountDetailByAccountID(ctx, acc.ID)
+		require.NoError(t, err)
+		assert.Equal(t, account.MaturityInstructionRolloverPrincipalAndInterest, detail.MaturityInstruction)
+	})
+
+	t.Run("paying out needs a payment account", func(t *testing.T) {
+		env := newTestEnv(t)
+		u := env.newUser(t, true)
+		svc := env.service()
+
+		req := &account.CreateFixedSavingsAccountRequest{TermCode: "6", MaturityInstruction: account.MaturityInstructionPayout}
+		_, err := svc.CreateFixedSavingsAccount(ctx, u.ID, req)
+		assert.ErrorIs(t, err, account.ErrPaymentAccountRequired)
+
+		_, err = svc.CreatePaymentAccount(ctx, u.ID)
+		require.NoError(t, err)
+		acc, err := svc.CreateFixedSavingsAccount(ctx, u.ID, req)
+		require.NoError(t, err)
+		detail, err := env.savings.GetSavingsAccountDetailByAccountID(ctx, acc.ID)
+		require.NoError(t, err)
+		assert.Equal(t, account.MaturityInstructionPayout, detail.MaturityInstruction)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		env := newTestEnv(t)
+		u, acc := newFixed(t, env, &account.CreateFixedSavingsAccountRequest{})
+		svc := env.service()
+		_, err := svc.CreatePaymentAccount(ctx, u.ID)
+		require.NoError(t, err)
+
+		// the day before maturity
+		env.clock.Set(time.Date(2025, 7, 14, 23, 0, 0, 0, time.UTC))
+		detail, err := svc.UpdateMaturityInstruction(ctx, u.ID, acc.ID, account.MaturityInstructionRolloverPrincipal)
+		require.NoError(t, err)
+		assert.Equal(t, account.MaturityInstructionRolloverPrincipal, detail.MaturityInstruction)
+
+		stored, err := env.savings.GetSavingsAccountDetailByAccountID(ctx, acc.ID)
+		require.NoError(t, err)
+		assert.Equal(t, account.MaturityInstructionRolloverPrincipal, stored.MaturityInstruction)
+	})
+
+	t.Run("not after maturity", func(t *testing.T) {
+		env := newTestEnv(t)
+		u, acc := newFixed(t, env, &account.CreateFixedSavingsAccountRequest{})
+
+		env.clock.Set(time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC))
+		_, err := env.service().UpdateMaturityInstruction(ctx, u.ID, acc.ID, account.MaturityInstructionRolloverPrincipalAndInterest)
+		assert.ErrorIs(t, err, account.ErrAccountMatured)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		env := newTestEnv(t)
+		u, acc := newFixed(t, env, &account.CreateFixedSavingsAccountRequest{})
+		svc := env.service()
+		flexible, err := svc.CreateFlexibleSavingsAccount(ctx, u.ID)
+		require.NoError(t, err)
+		other := env.newUser(t, true)
+
+		tests := []struct {
+			name        string
+			userID      string
+			accountID   string
+			instruction string
+			want        error
+		}{
+			{"unknown instruction", u.ID, acc.ID, "REINVEST", account.ErrInvalidMaturityInstruction},
+			{"no payment account", u.ID, acc.ID, account.MaturityInstructionPayout, account.ErrPaymentAccountRequired},
+			{"flexible savings", u.ID, flexible.ID, account.MaturityInstructionRolloverPrincipalAndInterest, account.ErrNotFixedSavings},
+			{"someone else's account", other.ID, acc.ID, account.MaturityInstructionRolloverPrincipalAndInterest, account.ErrAccountNotFound},
+			{"unknown account", u.ID, pkg.NewUUIDV7(), account.MaturityInstructionRolloverPrincipalAndInterest, account.ErrAccountNotFound},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := svc.UpdateMaturityInstruction(ctx, tt.userID, tt.accountID, tt.instruction)
+				assert.ErrorIs(t, err, tt.want)
+			})
+		}
+
+		require.NoError(t, env.accounts.UpdateAccountStatus(ctx, acc.ID, "CLOSED"))
+		_, err = svc.UpdateMaturityInstruction(ctx, u.ID, acc.ID, account.MaturityInstructionRolloverPrincipalAndInterest)
+		assert.ErrorIs(t, err, account.ErrAccountNotActive)
+	})
+}
+
+func TestAccountService_FlexibleSavingsTransfers(t *testing.T) {
+	ctx := context.Background()
+
+	// newFunded opens a PAYMENT account holding 1,000,000 and flexible
+	// savings for a new user
+	newFunded := func(t *testing.T, env *testEnv) (u *user.User, payment, flexible *account.Account) {
+		u = env.newUser(t, true)
+		svc := env.service()
+		payment, err := svc.CreatePaymentAccount(ctx, u.ID)
+		require.NoError(t, err)
+		require.NoError(t, env.accounts.UpdateAccountBalance(ctx, payment.ID, 1_000_000))
+		flexible, err = svc.CreateFlexibleSavingsAccount(ctx, u.ID)
+		require.NoError(t, err)
+		return u, payment, flexible
+	}
+	balance := func(t *testing.T, env *testEnv, accountID string) float64 {
+		acc, err := env.accounts.GetAccountByID(ctx, accountID)
+		require.NoError(t, err)
+		return acc.Balance
+	}
+
+	t.Run("deposit and withdraw", func(t *testing.T) {
+		env := newTestEnv(t)
+		u, payment, flexible := newFunded(t, env)
+		svc := env.service()
+
+		acc, err := svc.DepositToFlexibleSavings(ctx, u.ID, flexible.ID, 300_000.25)
+		require.NoError(t, err)
+		assert.Equal(t, 300_000.25, acc.Balance)
+		assert.Equal(t, 699_999.75, balance(t, env, payment.ID))
+
+		env.clock.Advance(time.Hour)
+		acc, err = svc.WithdrawFromFlexibleSavings(ctx, u.ID, flexible.ID, 100_000)
+		require.NoError(t, err)
+		assert.Equal(t, 200_000.25, acc.Balance)
+		assert.Equal(t, 200_000.25, balance(t, env, flexible.ID))
+		assert.Equal(t, 799_999.75, balance(t, env, payment.ID))
+
+		txs, err := env.txs.GetTransactionsByAccountIDs(ctx, []string{flexible.ID})
+		require.NoError(t, err)
+		require.Len(t, txs, 2)
+		assert.Equal(t, account.TransactionTypeDeposit, txs[0].TransactionType)
+		assert.Equal(t, 300_000.25, txs[0].Amount)
+		assert.Equal(t, "Deposit from "+payment.AccountNumber, txs[0].Description)
+		assert.True(t, env.clock.Now().Add(-time.Hour).Equal(txs[0].TransactionDate))
+		assert.Equal(t, account.TransactionTypeWithdrawal, txs[1].TransactionType)
+		assert.Equal(t, "Withdrawal to "+payment.AccountNumber, txs[1].Description)
+		assert.True(t, env.clock.Now().Equal(txs[1].TransactionDate))
+
+		txs, err = env.txs.GetTransactionsByAccountIDs(ctx, []string{payment.ID})
+		require.NoError(t, err)
+		require.Len(t, txs, 2)
+		assert.Equal(t, account.TransactionTypeWithdrawal, txs[0].TransactionType)
+		assert.Equal(t, "Deposit to "+flexible.AccountNumber, txs[0].Description)
+		assert.Equal(t, account.TransactionTypeDeposit, txs[1].TransactionType)
+		assert.Equal(t, 100_000.0, txs[1].Amount)
+		assert.Equal(t, "Withdrawal from "+flexible.AccountNumber, txs[1].Description)
+	})
+
+	t.Run("insufficient funds moves nothing", func(t *testing.T) {
+		env := newTestEnv(t)
+		u, payment, flexible := newFunded(t, env)
+		svc := env.service()
+
+		_, err := svc.DepositToFlexibleSavings(ctx, u.ID, flexible.ID, 1_000_000.01)
+		assert.ErrorIs(t, err, account.ErrInsufficientFunds)
+		_, err = svc.WithdrawFromFlexibleSavings(ctx, u.ID, flexible.ID, 10_000)
+		assert.ErrorIs(t, err, account.ErrInsufficientFunds)
+
+		assert.Equal(t, 1_000_000.0, balance(t, env, payment.ID))
+		assert.Equal(t, 0.0, balance(t, env, flexible.ID))
+		txs, err := env.txs.GetTransactionsByAccountIDs(ctx, []string{payment.ID, flexible.ID})
+		require.NoError(t, err)
+		assert.Empty(t, txs)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		env := newTestEnv(t)
+		u, _, flexible := newFunded(t, env)
+		svc := env.service()
+		fixed, err := svc.CreateFixedSavingsAccount(ctx, u.ID, &account.CreateFixedSavingsAccountRequest{TermCode: "6"})
+		require.NoError(t, err)
+		other := env.newUser(t, true)
+		unfunded, err := svc.CreateFlexibleSavingsAccount(ctx, other.ID)
+		require.NoError(t, err)
+
+		tests := []struct {
+			name      string
+			userID    string
+			accountID string
+			amount    float64
+			want      error
+		}{
+			{"below the minimum", u.ID, flexible.ID, 9_999.99, account.ErrDepositBelowMinimum},
+			{"fixed savings", u.ID, fixed.ID, 10_000, account.ErrNotFlexibleSavings},
+			{"someone else's account", other.ID, flexible.ID, 10_000, account.ErrAccountNotFound},
+			{"unknown account", u.ID, pkg.NewUUIDV7(), 10_000, account.ErrAccountNotFound},
+			{"no payment account", other.ID, unfunded.ID, 10_000, account.ErrPaymentAccountRequired},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := svc.DepositToFlexibleSavings(ctx, tt.userID, tt.accountID, tt.amount)
+				assert.ErrorIs(t, err, tt.want)
+			})
+		}
+
+		_, err = svc.WithdrawFromFlexibleSavings(ctx, u.ID, flexible.ID, 9_999.99)
+		assert.ErrorIs(t, err, account.ErrWithdrawalBelowMinimum)
+
+		require.NoError(t, env.accounts.UpdateAccountStatus(ctx, flexible.ID, "CLOSED"))
+		_, err = svc.DepositToFlexibleSavings(ctx, u.ID, flexible.ID, 10_000)
+		assert.ErrorIs(t, err, account.ErrAccountNotActive)
+	})
+}
@@ -0,0 +1,78 @@
+package account
+
+import (
+	"context"
+
+	"e-wallet/internal/domain/account"
+	"e-wallet/internal/ports"
+	"e-wallet/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var tracer = tracing.Tracer("e-wallet/internal/application/account")
+
+type tracedAccountService struct {
+	next ports.AccountService
+}
+
+// WithTracing wraps svc so every call runs in its own span.
+func WithTracing(svc ports.AccountService) ports.AccountService {
+	return &tracedAccountService{next: svc}
+}
+
+func (t *tracedAccountService) CreatePaymentAccount(ctx context.Context, userID string) (*account.Account, error) {
+	ctx, span := tracer.Start(ctx, "AccountService.CreatePaymentAccount")
+	span.SetAttributes(tracing.UserID(userID))
+	acc, err := t.next.CreatePaymentAccount(ctx, userID)
+	tracing.End(span, err)
+	return acc, err
+}
+
+func (t *tracedAccountService) CreateFixedSavingsAccount(ctx context.Context, userID string, req *account.CreateFixedSavingsAccountRequest) (*account.Account, error) {
+	ctx, span := tracer.Start(ctx, "AccountService.CreateFixedSavingsAccount")
+	span.SetAttributes(tracing.UserID(userID))
+	acc, err := t.next.CreateFixedSavingsAccount(ctx, userID, req)
+	tracing.End(span, err)
+	return acc, err
+}
+
+func (t *tracedAccountService) CreateFlexibleSavingsAccount(ctx context.Context, userID string) (*account.Account, error) {
+	ctx, span := tracer.Start(ctx, "AccountService.CreateFlexibleSavingsAccount")
+	span.SetAttributes(tracing.UserID(userID))
+	acc, err := t.next.CreateFlexibleSavingsAccount(ctx, userID)
+	tracing.End(span, err)
+	return acc, err
+}
+
+func (t *tracedAccountService) ListAccounts(ctx context.Context, userID string) (*account.ListAccountsResponse, error) {
+	ctx, span := tracer.Start(ctx, "AccountService.ListAccounts")
+	span.SetAttributes(tracing.UserID(userID))
+	res, err := t.next.ListAccounts(ctx, userID)
+	tracing.End(span, err)
+	return res, err
+}
+
+func (t *tracedAccountService) UpdateMaturityInstruction(ctx context.Context, userID, accountID, instruction string) (*account.SavingsAccountDetail, error) {
+	ctx, span := tracer.Start(ctx, "AccountService.UpdateMaturityInstruction")
+	span.SetAttributes(tracing.UserID(userID), attribute.String("account.id", accountID))
+	detail, err := t.next.UpdateMaturityInstruction(ctx, userID, accountID, instruction)
+	tracing.End(span, err)
+	return detail, err
+}
+
+func (t *tracedAccountService) DepositToFlexibleSavings(ctx context.Context, userID, accountID string, amount float64) (*account.Account, error) {
+	ctx, span := tracer.Start(ctx, "AccountService.DepositToFlexibleSavings")
+	span.SetAttributes(tracing.UserID(userID), attribute.String("account.id", accountID))
+	acc, err := t.next.DepositToFlexibleSavings(ctx, userID, accountID, amount)
+	tracing.End(span, err)
+	return acc, err
+}
+
+func (t *tracedAccountService) WithdrawFromFlexibleSavings(ctx context.Context, userID, accountID string, amount float64) (*account.Account, error) {
+	ctx, span := tracer.Start(ctx, "AccountService.WithdrawFromFlexibleSavings")
+	span.SetAttributes(tracing.UserID(userID), attribute.String("account.id", accountID))
+	acc, err := t.next.WithdrawFromFlexibleSavings(ctx, userID, accountID, amount)
+	tracing.End(span, err)
+	return acc, err
+}
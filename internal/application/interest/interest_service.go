@@ -0,0 +1,366 @@
+package interest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"e-wallet/internal/domain/account"
+	"e-wallet/internal/domain/calendar"
+	"e-wallet/internal/ports"
+)
+
+type interestService struct {
+	accountRepo     ports.AccountRepository
+	savingsRepo     ports.SavingsAccountDetailRepository
+	transactionRepo ports.TransactionRepository
+	interestRepo    ports.InterestHistoryRepository
+	txManager       ports.TxManager
+	metrics         ports.Metrics
+	calendar        ports.BusinessCalendar
+}
+
+func NewInterestService(accountRepo ports.AccountRepository, savingsRepo ports.SavingsAccountDetailRepository, transactionRepo ports.TransactionRepository, interestRepo ports.InterestHistoryRepository, txManager ports.TxManager, metrics ports.Metrics, businessDays ports.BusinessCalendar) ports.InterestService {
+	return &interestService{
+		accountRepo:     accountRepo,
+		savingsRepo:     savingsRepo,
+		transactionRepo: transactionRepo,
+		interestRepo:    interestRepo,
+		txManager:       txManager,
+		metrics:         metrics,
+		calendar:        businessDays,
+	}
+}
+
+// AccrueFlexibleInterest records the daily interest of every day since the
+// last calculation and credits it to the account, on business days only: the
+// days the bank is closed are accrued on the next business day, each on its
+// own end-of-day balance. An account that fails is left for the next run
+// without stopping the others.
+func (s *interestService) AccrueFlexibleInterest(ctx context.Context, asOf time.Time) (int, error) {
+	if !s.calendar.IsBusinessDay(asOf) {
+		return 0, nil
+	}
+
+	details, err := s.savingsRepo.GetFlexibleSavingsDueForInterest(ctx, asOf)
+	if err != nil {
+		return 0, err
+	}
+
+	var credited int
+	var errs []error
+	for _, detail := range details {
+		interest, err := s.accrue(ctx, detail.AccountID, asOf)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("accrue interest of account %s: %w", detail.AccountID, err))
+			continue
+		}
+		if interest > 0 {
+			credited++
+			s.metrics.InterestAccrued(account.AccountTypeFlexibleSavings, interest.Float64())
+		}
+	}
+	return credited, errors.Join(errs...)
+}
+
+// accrue accrues the interest of one account up to the date of asOf, and
+// returns the interest credited.
+func (s *interestService) accrue(ctx context.Context, accountID string, asOf time.Time) (account.Amount, error) {
+	var total account.Amount
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		total = 0
+
+		// read again in the transaction, another run may have got there first
+		detail, err := s.savingsRepo.GetSavingsAccountDetailByAccountID(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		if detail.LastInterestCalcDate == nil {
+			return nil
+		}
+		from := *detail.LastInterestCalcDate
+		days := account.DaysBetween(from, asOf)
+		if days <= 0 {
+			return nil
+		}
+
+		// Lock the account first, so that no deposit or withdrawal lands
+		// between reading its balance and the transactions behind it
+		acc, err := s.accountRepo.GetAccountForUpdate(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		// closed since it was found due, by a deletion for one
+		if acc.Status != "ACTIVE" {
+			return nil
+		}
+
+		// Each day earns on the balance it ended with, found by taking the
+		// deposits and withdrawals made since then back out of the balance
+		// of now
+		since, err := s.transactionRepo.GetAccountTransactionsSince(ctx, accountID, from)
+		if err != nil {
+			return err
+		}
+		balances := account.EndOfDayBalances(account.AmountFromFloat(acc.Balance), from, days, since)
+		// the fraction left over by the last run is paid with the interest
+		// of this one
+		accrual := account.Accrual{Rate: account.RateFromFloat(detail.AnnualInterestRate), Carry: detail.InterestCarry}
+		for day, balance := range balances {
+			interest := accrual.Accrue(balance, 1)
+			err := s.interestRepo.CreateFlexibleInterestRecord(ctx, &account.FlexibleInterestRecord{
+				AccountID:           accountID,
+				CalculationDate:     from.AddDate(0, 0, day),
+				EODBalance:          balance.Float64(),
+				AnnualRateApplied:   detail.AnnualInterestRate,
+				DailyInterestAmount: interest.Float64(),
+			})
+			if err != nil {
+				return err
+			}
+			total += interest
+		}
+
+		if total > 0 {
+			if err := acc.Credit(total); err != nil {
+				return err
+			}
+			if err := s.accountRepo.UpdateAccountBalance(ctx, accountID, acc.Balance); err != nil {
+				return err
+			}
+			err := s.transactionRepo.CreateTransaction(ctx, &account.Transaction{
+				AccountID:       accountID,
+				TransactionType: account.TransactionTypeInterestCredit,
+				Amount:          total.Float64(),
+				TransactionDate: asOf,
+				Description:     fmt.Sprintf("Interest from %s to %s", from.Format(time.DateOnly), from.AddDate(0, 0, days-1).Format(time.DateOnly)),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if accrual.Carry != detail.InterestCarry {
+			if err := s.savingsRepo.UpdateInterestCarry(ctx, accountID, accrual.Carry); err != nil {
+				return err
+			}
+		}
+
+		calcDate := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+		return s.savingsRepo.UpdateLastInterestCalcDate(ctx, accountID, &calcDate)
+	})
+	return total, err
+}
+
+// MatureFixedSavings pays the interest of every fixed savings account due for
+// maturity and follows its maturity instruction, on business days only: the
+// deposit is paid out to the PAYMENT account of the user and closed, or
+// renewed for the same term at the rate offered today. Each matured term is
+// recorded as a renewal cycle. An account that fails is left for the next run
+// without stopping the others.
+func (s *interestService) MatureFixedSavings(ctx context.Context, asOf time.Time) (int, error) {
+	if !s.calendar.IsBusinessDay(asOf) {
+		return 0, nil
+	}
+
+	details, err := s.savingsRepo.GetFixedSavingsDueForMaturity(ctx, asOf)
+	if err != nil {
+		return 0, err
+	}
+
+	var matured int
+	var errs []error
+	for _, detail := range details {
+		cycle, err := s.mature(ctx, detail.AccountID, asOf)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("mature account %s: %w", detail.AccountID, err))
+			continue
+		}
+		if cycle == nil {
+			continue
+		}
+		matured++
+		if cycle.Interest > 0 {
+			s.metrics.InterestAccrued(account.AccountTypeFixedSavings, cycle.Interest)
+		}
+		if payout := cycle.Principal + cycle.Interest - cycle.RenewedPrincipal; payout > 0 {
+			s.metrics.TransferCompleted(account.TransactionTypeMaturityPayout, payout)
+		}
+	}
+	return matured, errors.Join(errs...)
+}
+
+// mature matures the term of one account, and returns the cycle recorded, nil
+// when the account was not due anymore.
+func (s *interestService) mature(ctx context.Context, accountID string, asOf time.Time) (*account.RenewalCycle, error) {
+	var cycle *account.RenewalCycle
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		cycle = nil
+
+		// read again in the transaction, another run may have got there first
+		detail, err := s.savingsRepo.GetSavingsAccountDetailByAccountID(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		if !detail.IsFixedTerm || detail.TermMonths == nil || detail.MaturityDate == nil || account.DaysBetween(*detail.MaturityDate, asOf) < 0 {
+			return nil
+		}
+		acc, err := s.accountRepo.GetAccountForUpdate(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		if acc.Status != "ACTIVE" {
+			return nil
+		}
+
+		start, maturity := detail.StartDate, *detail.MaturityDate
+		instruction := detail.MaturityInstruction
+		if instruction == "" {
+			instruction = account.DefaultMaturityInstruction
+		}
+		principal := account.AmountFromFloat(acc.Balance)
+		interest := account.FixedTermInterest(principal, account.RateFromFloat(detail.AnnualInterestRate), start, maturity)
+		period := fmt.Sprintf("%s to %s", start.Format(time.DateOnly), maturity.Format(time.DateOnly))
+
+		if interest > 0 {
+			if err := acc.Credit(interest); err != nil {
+				return err
+			}
+			if err := s.accountRepo.UpdateAccountBalance(ctx, accountID, acc.Balance); err != nil {
+				return err
+			}
+			err := s.transactionRepo.CreateTransaction(ctx, &account.Transaction{
+				AccountID:       accountID,
+				TransactionType: account.TransactionTypeInterestCredit,
+				Amount:          interest.Float64(),
+				TransactionDate: asOf,
+				Description:     "Interest from " + period,
+			})
+			if err != nil {
+				return err
+			}
+			err = s.interestRepo.CreateFixedInterestRecord(ctx, &account.FixedInterestRecord{
+				AccountID:           accountID,
+				CalculationPeriod:   period,
+				TotalInterestAmount: interest.Float64(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if err := s.savingsRepo.UpdateLastInterestCalcDate(ctx, accountID, &maturity); err != nil {
+			return err
+		}
+
+		var payout account.Amount
+		switch instruction {
+		case account.MaturityInstructionPayout:
+			payout = principal + interest
+		case account.MaturityInstructionRolloverPrincipal:
+			payout = interest
+		}
+		if payout > 0 {
+			if err := s.payOut(ctx, acc, payout, asOf); err != nil {
+				return err
+			}
+		}
+
+		cycles, err := s.savingsRepo.GetRenewalCyclesByAccountIDs(ctx, []string{accountID})
+		if err != nil {
+			return err
+		}
+
+		var renewed account.Amount
+		if instruction == account.MaturityInstructionPayout {
+			if err := s.accountRepo.UpdateAccountStatus(ctx, accountID, "CLOSED"); err != nil {
+				return err
+			}
+		} else {
+			// The next term starts when this one ended, at the rate of the
+			// term today, and matures on a business day too. Its maturity
+			// is counted in whole terms from the opening of the deposit, not
+			// from this maturity, so that a maturity moved off a closed day
+			// does not move the ones after it.
+			termMonths := *detail.TermMonths
+			rate, ok := account.FixedSavingsRate(termMonths)
+			if !ok {
+				rate = detail.AnnualInterestRate
+			}
+			opened := start
+			if len(cycles) > 0 {
+				opened = cycles[0].StartDate
+			}
+			terms := len(cycles) + 2
+			next := s.calendar.Adjust(calendar.AddMonths(opened, terms*termMonths))
+			if err := s.savingsRepo.RenewFixedTerm(ctx, accountID, maturity, next, rate); err != nil {
+				return err
+			}
+			renewed = account.AmountFromFloat(acc.Balance)
+		}
+
+		cycle = &account.RenewalCycle{
+			AccountID:          accountID,
+			Cycle:              len(cycles) + 1,
+			StartDate:          start,
+			MaturityDate:       maturity,
+			Principal:          principal.Float64(),
+			Interest:           interest.Float64(),
+			AnnualInterestRate: detail.AnnualInterestRate,
+			Instruction:        instruction,
+			RenewedPrincipal:   renewed.Float64(),
+		}
+		return s.savingsRepo.CreateRenewalCycle(ctx, cycle)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cycle, nil
+}
+
+// payOut moves amount from a matured deposit to the PAYMENT account of its
+// owner, recording the withdrawal on one side and the payout on the other.
+func (s *interestService) payOut(ctx context.Context, deposit *account.Account, amount account.Amount, asOf time.Time) error {
+	accounts, err := s.accountRepo.GetAccountsByUserID(ctx, deposit.UserID)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(accounts, func(a *account.Account) bool {
+		return a.AccountType == account.AccountTypePayment && a.Status == "ACTIVE"
+	})
+	if i < 0 {
+		return account.ErrPaymentAccountRequired
+	}
+	payment, err := s.accountRepo.GetAccountForUpdate(ctx, accounts[i].ID)
+	if err != nil {
+		return err
+	}
+
+	if err := account.Transfer(deposit, payment, amount); err != nil {
+		return err
+	}
+	if err := s.accountRepo.UpdateAccountBalance(ctx, deposit.ID, deposit.Balance); err != nil {
+		return err
+	}
+	if err := s.accountRepo.UpdateAccountBalance(ctx, payment.ID, payment.Balance); err != nil {
+		return err
+	}
+	err = s.transactionRepo.CreateTransaction(ctx, &account.Transaction{
+		AccountID:       deposit.ID,
+		TransactionType: account.TransactionTypeWithdrawal,
+		Amount:          amount.Float64(),
+		TransactionDate: asOf,
+		Description:     "Maturity payout to " + payment.AccountNumber,
+	})
+	if err != nil {
+		return err
+	}
+	return s.transactionRepo.CreateTransaction(ctx, &account.Transaction{
+		AccountID:       payment.ID,
+		TransactionType: account.TransactionTypeMaturityPayout,
+		Amount:          amount.Float64(),
+		TransactionDate: asOf,
+		Description:     "Maturity payout from " + deposit.AccountNumber,
+	})
+}
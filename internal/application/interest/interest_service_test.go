@@ -0,0 +1,500 @@
+package interest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"e-wallet/internal/adapters/metrics"
+	"e-wallet/internal/adapters/repository/memory"
+	"e-wallet/internal/domain/account"
+	"e-wallet/internal/domain/calendar"
+	"e-wallet/internal/domain/user"
+	"e-wallet/internal/ports"
+	"e-wallet/mocks"
+	"e-wallet/pkg"
+)
+
+type testEnv struct {
+	store    *memory.Store
+	accounts ports.AccountRepository
+	savings  ports.SavingsAccountDetailRepository
+	txs      ports.TransactionRepository
+	history  ports.InterestHistoryRepository
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	numbers, err := account.NewNumberGenerator("97")
+	require.NoError(t, err)
+
+	store := memory.NewStore()
+	return &testEnv{
+		store:    store,
+		accounts: memory.NewAccountRepository(store, numbers),
+		savings:  memory.NewSavingsAccountDetailRepository(store),
+		txs:      memory.NewTransactionRepository(store),
+		history:  memory.NewInterestHistoryRepository(store),
+	}
+}
+
+func (e *testEnv) service() ports.InterestService {
+	return NewInterestService(e.accounts, e.savings, e.txs, e.history, memory.NewTxManager(e.store), metrics.Noop, businessDays())
+}
+
+// businessDays closes the bank for the Tết break of 2026, Saturday February
+// 14th to Sunday the 22nd.
+func businessDays() *calendar.Calendar {
+	var holidays []calendar.Holiday
+	for d := date(2026, 2, 14); d.Day() <= 22; d = d.AddDate(0, 0, 1) {
+		holidays = append(holidays, calendar.Holiday{Date: d, Name: "Tết Nguyên Đán"})
+	}
+	return calendar.New(calendar.ModifiedFollowing, holidays...)
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// newFlexible opens flexible savings at 0.8% holding balance, last calculated on lastCalc.
+func (e *testEnv) newFlexible(t *testing.T, balance float64, lastCalc time.Time) string {
+	ctx := context.Background()
+	id := pkg.NewUUIDV7()
+	u, err := memory.NewUserRepository(e.store).Create(ctx, &user.User{ID: id, Username: id, Email: id + "@example.com"})
+	require.NoError(t, err)
+	acc, err := e.accounts.CreateFlexibleSavingsAccount(ctx, u.ID)
+	require.NoError(t, err)
+	require.NoError(t, e.accounts.UpdateAccountBalance(ctx, acc.ID, balance))
+	require.NoError(t, e.savings.CreateSavingsAccountDetail(ctx, &account.SavingsAccountDetail{
+		AccountID:            acc.ID,
+		AnnualInterestRate:   0.008,
+		StartDate:            lastCalc,
+		LastInterestCalcDate: &lastCalc,
+	}))
+	return acc.ID
+}
+
+func (e *testEnv) balance(t *testing.T, accountID string) float64 {
+	acc, err := e.accounts.GetAccountByID(context.Background(), accountID)
+	require.NoError(t, err)
+	return acc.Balance
+}
+
+func (e *testEnv) lastCalc(t *testing.T, accountID string) time.Time {
+	detail, err := e.savings.GetSavingsAccountDetailByAccountID(context.Background(), accountID)
+	require.NoError(t, err)
+	require.NotNil(t, detail.LastInterestCalcDate)
+	return *detail.LastInterestCalcDate
+}
+
+func TestInterestService_AccrueFlexibleInterest(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("one day", func(t *testing.T) {
+		env := newTestEnv(t)
+		// 10,000,000.00 earns 219.178… a day at 0.8%
+		id := env.newFlexible(t, 10_000_000, date(2026, 3, 2))
+
+		n, err := env.service().AccrueFlexibleInterest(ctx, time.Date(2026, 3, 3, 1, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.Equal(t, 10_000_219.17, env.balance(t, id))
+		assert.Equal(t, date(2026, 3, 3), env.lastCalc(t, id))
+
+		records, err := env.history.GetFlexibleInterestHistoryByAccountIDs(ctx, []string{id})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, date(2026, 3, 2), records[0].CalculationDate)
+		assert.Equal(t, 10_000_000.0, records[0].EODBalance)
+		assert.Equal(t, 219.17, records[0].DailyInterestAmount)
+
+		txs, err := env.txs.GetTransactionsByAccountIDs(ctx, []string{id})
+		require.NoError(t, err)
+		require.Len(t, txs, 1)
+		assert.Equal(t, account.TransactionTypeInterestCredit, txs[0].TransactionType)
+		assert.Equal(t, 219.17, txs[0].Amount)
+	})
+
+	t.Run("once a day", func(t *testing.T) {
+		env := newTestEnv(t)
+		id := env.newFlexible(t, 10_000_000, date(2026, 3, 2))
+		svc := env.service()
+
+		_, err := svc.AccrueFlexibleInterest(ctx, time.Date(2026, 3, 3, 1, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		n, err := svc.AccrueFlexibleInterest(ctx, time.Date(2026, 3, 3, 23, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Zero(t, n)
+		assert.Equal(t, 10_000_219.17, env.balance(t, id))
+	})
+
+	t.Run("the weekend is accrued on monday", func(t *testing.T) {
+		env := newTestEnv(t)
+		id := env.newFlexible(t, 10_000_000, date(2026, 3, 6))
+		svc := env.service()
+
+		// closed on Saturday and Sunday
+		for _, day := range []time.Time{date(2026, 3, 7), date(2026, 3, 8)} {
+			n, err := svc.AccrueFlexibleInterest(ctx, day)
+			require.NoError(t, err)
+			assert.Zero(t, n)
+		}
+		assert.Equal(t, date(2026, 3, 6), env.lastCalc(t, id))
+
+		n, err := svc.AccrueFlexibleInterest(ctx, date(2026, 3, 9))
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		// Friday to Sunday, three days at the balance of Friday, with the
+		// fractions carried from one day to the next: 657.53, not 3 × 219.17
+		assert.Equal(t, 10_000_657.53, env.balance(t, id))
+		assert.Equal(t, date(2026, 3, 9), env.lastCalc(t, id))
+
+		records, err := env.history.GetFlexibleInterestHistoryByAccountIDs(ctx, []string{id})
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		for i, record := range records {
+			assert.Equal(t, date(2026, 3, 6+i), record.CalculationDate)
+			assert.Equal(t, 10_000_000.0, record.EODBalance)
+		}
+	})
+
+	t.Run("tết is accrued after the break", func(t *testing.T) {
+		env := newTestEnv(t)
+		id := env.newFlexible(t, 10_000_000, date(2026, 2, 13))
+		svc := env.service()
+
+		n, err := svc.AccrueFlexibleInterest(ctx, date(2026, 2, 17))
+		require.NoError(t, err)
+		assert.Zero(t, n)
+
+		n, err = svc.AccrueFlexibleInterest(ctx, date(2026, 2, 23))
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		// ten days, February 13th to 22nd
+		want := account.Amount(1_000_000_000) + account.SimpleInterest(1_000_000_000, 80, 10)
+		assert.Equal(t, want.Float64(), env.balance(t, id))
+	})
+
+	t.Run("interest accrued on the credited interest", func(t *testing.T) {
+		env := newTestEnv(t)
+		id := env.newFlexible(t, 10_000_000, date(2026, 3, 2))
+		svc := env.service()
+
+		_, err := svc.AccrueFlexibleInterest(ctx, date(2026, 3, 3))
+		require.NoError(t, err)
+		_, err = svc.AccrueFlexibleInterest(ctx, date(2026, 3, 4))
+		require.NoError(t, err)
+
+		records, err := env.history.GetFlexibleInterestHistoryByAccountIDs(ctx, []string{id})
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, 10_000_219.17, records[1].EODBalance)
+	})
+
+	t.Run("deposits and withdrawals earn from the end of their day", func(t *testing.T) {
+		env := newTestEnv(t)
+		id := env.newFlexible(t, 10_000_000, date(2026, 3, 6))
+		move := func(transactionType string, amount float64, at time.Time) {
+			tx := &account.Transaction{AccountID: id, TransactionType: transactionType, Amount: amount, TransactionDate: at}
+			require.NoError(t, env.txs.CreateTransaction(ctx, tx))
+			require.NoError(t, env.accounts.UpdateAccountBalance(ctx, id, env.balance(t, id)+tx.BalanceChange().Float64()))
+		}
+		move(account.TransactionTypeDeposit, 5_000_000, time.Date(2026, 3, 6, 10, 0, 0, 0, time.UTC))
+		move(account.TransactionTypeWithdrawal, 12_000_000, time.Date(2026, 3, 7, 9, 0, 0, 0, time.UTC))
+		move(account.TransactionTypeDeposit, 2_000_000, time.Date(2026, 3, 7, 15, 0, 0, 0, time.UTC))
+		// on Monday before the run, earning from Monday night
+		move(account.TransactionTypeDeposit, 1_000_000, time.Date(2026, 3, 9, 8, 0, 0, 0, time.UTC))
+
+		n, err := env.service().AccrueFlexibleInterest(ctx, time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		records, err := env.history.GetFlexibleInterestHistoryByAccountIDs(ctx, []string{id})
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		assert.Equal(t, []float64{15_000_000, 5_000_000, 5_000_000}, []float64{records[0].EODBalance, records[1].EODBalance, records[2].EODBalance})
+		assert.Equal(t, []float64{328.76, 109.59, 109.59}, []float64{records[0].DailyInterestAmount, records[1].DailyInterestAmount, records[2].DailyInterestAmount})
+		assert.Equal(t, 6_000_547.94, env.balance(t, id))
+
+		// the next run starts from the balance the interest was credited to
+		n, err = env.service().AccrueFlexibleInterest(ctx, time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		records, err = env.history.GetFlexibleInterestHistoryByAccountIDs(ctx, []string{id})
+		require.NoError(t, err)
+		require.Len(t, records, 4)
+		assert.Equal(t, 6_000_547.94, records[3].EODBalance)
+	})
+
+	t.Run("fractions carried from one run to the next", func(t *testing.T) {
+		env := newTestEnv(t)
+		// 300.00 earns 0.0065… a day at 0.8%, less than a minor unit
+		id := env.newFlexible(t, 300, date(2026, 3, 2))
+		svc := env.service()
+
+		for _, day := range []time.Time{date(2026, 3, 3), date(2026, 3, 4), date(2026, 3, 5), date(2026, 3, 6)} {
+			_, err := svc.AccrueFlexibleInterest(ctx, day)
+			require.NoError(t, err)
+		}
+
+		// four one-day runs pay what a single run over the four days would
+		want := account.Amount(30_000) + account.SimpleInterest(30_000, 80, 4)
+		assert.Equal(t, 300.02, want.Float64())
+		assert.Equal(t, want.Float64(), env.balance(t, id))
+		detail, err := env.savings.GetSavingsAccountDetailByAccountID(ctx, id)
+		require.NoError(t, err)
+		assert.NotZero(t, detail.InterestCarry)
+	})
+
+	t.Run("no balance", func(t *testing.T) {
+		env := newTestEnv(t)
+		id := env.newFlexible(t, 0, date(2026, 3, 2))
+
+		n, err := env.service().AccrueFlexibleInterest(ctx, date(2026, 3, 3))
+		require.NoError(t, err)
+		assert.Zero(t, n)
+		assert.Equal(t, date(2026, 3, 3), env.lastCalc(t, id))
+
+		txs, err := env.txs.GetTransactionsByAccountIDs(ctx, []string{id})
+		require.NoError(t, err)
+		assert.Empty(t, txs)
+	})
+
+	t.Run("a failing account does not stop the others", func(t *testing.T) {
+		env := newTestEnv(t)
+		first := env.newFlexible(t, 10_000_000, date(2026, 3, 2))
+		second := env.newFlexible(t, 10_000_000, date(2026, 3, 2))
+
+		history := mocks.NewMockInterestHistoryRepository(t)
+		history.EXPECT().CreateFlexibleInterestRecord(mock.Anything, mock.MatchedBy(func(r *account.FlexibleInterestRecord) bool {
+			return r.AccountID == first
+		})).Return(errors.New("disk full"))
+		history.EXPECT().CreateFlexibleInterestRecord(mock.Anything, mock.MatchedBy(func(r *account.FlexibleInterestRecord) bool {
+			return r.AccountID == second
+		})).Return(nil)
+		svc := NewInterestService(env.accounts, env.savings, env.txs, history, memory.NewTxManager(env.store), metrics.Noop, businessDays())
+
+		n, err := svc.AccrueFlexibleInterest(ctx, date(2026, 3, 3))
+		assert.ErrorContains(t, err, first)
+		assert.Equal(t, 1, n)
+		// rolled back, to be accrued by the next run
+		assert.Equal(t, 10_000_000.0, env.balance(t, first))
+		assert.Equal(t, date(2026, 3, 2), env.lastCalc(t, first))
+		assert.Equal(t, 10_000_219.17, env.balance(t, second))
+	})
+}
+
+// newFixed opens a six-month deposit at rate holding balance, from start to
+// maturity, and a PAYMENT account for its owner when withPayment is set.
+func (e *testEnv) newFixed(t *testing.T, balance float64, rate float64, instruction string, start, maturity time.Time, withPayment bool) (fixedID, paymentID string) {
+	ctx := context.Background()
+	id := pkg.NewUUIDV7()
+	u, err := memory.NewUserRepository(e.store).Create(ctx, &user.User{ID: id, Username: id, Email: id + "@example.com"})
+	require.NoError(t, err)
+	if withPayment {
+		payment, err := e.accounts.CreatePaymentAccount(ctx, u.ID)
+		require.NoError(t, err)
+		paymentID = payment.ID
+	}
+	acc, err := e.accounts.CreateFixedSavingsAccount(ctx, u.ID, &account.CreateFixedSavingsAccountRequest{TermCode: "6"})
+	require.NoError(t, err)
+	require.NoError(t, e.accounts.UpdateAccountBalance(ctx, acc.ID, balance))
+	termMonths := 6
+	require.NoError(t, e.savings.CreateSavingsAccountDetail(ctx, &account.SavingsAccountDetail{
+		AccountID:           acc.ID,
+		IsFixedTerm:         true,
+		TermMonths:          &termMonths,
+		AnnualInterestRate:  rate,
+		StartDate:           start,
+		MaturityDate:        &maturity,
+		MaturityInstruction: instruction,
+	}))
+	return acc.ID, paymentID
+}
+
+func (e *testEnv) detail(t *testing.T, accountID string) *account.SavingsAccountDetail {
+	detail, err := e.savings.GetSavingsAccountDetailByAccountID(context.Background(), accountID)
+	require.NoError(t, err)
+	return detail
+}
+
+func (e *testEnv) status(t *testing.T, accountID string) string {
+	acc, err := e.accounts.GetAccountByID(context.Background(), accountID)
+	require.NoError(t, err)
+	return acc.Status
+}
+
+func transactionTypes(t *testing.T, txs ports.TransactionRepository, accountID string) []string {
+	list, err := txs.GetTransactionsByAccountIDs(context.Background(), []string{accountID})
+	require.NoError(t, err)
+	var types []string
+	for _, tx := range list {
+		types = append(types, tx.TransactionType)
+	}
+	return types
+}
+
+func TestInterestService_MatureFixedSavings(t *testing.T) {
+	ctx := context.Background()
+	start, maturity := date(2026, 1, 15), date(2026, 7, 15)
+	// 100,000,000.00 at 3.6% over the 181 days from January 15th to July 15th
+	const interest = 1_785_205.47
+
+	t.Run("pays out", func(t *testing.T) {
+		env := newTestEnv(t)
+		fixed, payment := env.newFixed(t, 100_000_000, 0.036, account.MaturityInstructionPayout, start, maturity, true)
+
+		n, err := env.service().MatureFixedSavings(ctx, time.Date(2026, 7, 15, 1, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		assert.Zero(t, env.balance(t, fixed))
+		assert.Equal(t, "CLOSED", env.status(t, fixed))
+		assert.Equal(t, 100_000_000+interest, env.balance(t, payment))
+		assert.Equal(t, []string{account.TransactionTypeInterestCredit, account.TransactionTypeWithdrawal}, transactionTypes(t, env.txs, fixed))
+		assert.Equal(t, []string{account.TransactionTypeMaturityPayout}, transactionTypes(t, env.txs, payment))
+
+		records, err := env.history.GetFixedInterestHistoryByAccountIDs(ctx, []string{fixed})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, interest, records[0].TotalInterestAmount)
+		assert.Equal(t, "2026-01-15 to 2026-07-15", records[0].CalculationPeriod)
+
+		cycles, err := env.savings.GetRenewalCyclesByAccountIDs(ctx, []string{fixed})
+		require.NoError(t, err)
+		require.Len(t, cycles, 1)
+		assert.Equal(t, 1, cycles[0].Cycle)
+		assert.Equal(t, 100_000_000.0, cycles[0].Principal)
+		assert.Equal(t, interest, cycles[0].Interest)
+		assert.Equal(t, account.MaturityInstructionPayout, cycles[0].Instruction)
+		assert.Zero(t, cycles[0].RenewedPrincipal)
+	})
+
+	t.Run("rolls over the principal", func(t *testing.T) {
+		env := newTestEnv(t)
+		fixed, payment := env.newFixed(t, 100_000_000, 0.036, account.MaturityInstructionRolloverPrincipal, start, maturity, true)
+
+		n, err := env.service().MatureFixedSavings(ctx, maturity)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		assert.Equal(t, 100_000_000.0, env.balance(t, fixed))
+		assert.Equal(t, "ACTIVE", env.status(t, fixed))
+		assert.Equal(t, interest, env.balance(t, payment))
+
+		detail := env.detail(t, fixed)
+		assert.Equal(t, maturity, detail.StartDate)
+		// six months later, a Friday
+		assert.Equal(t, date(2027, 1, 15), *detail.MaturityDate)
+		assert.Equal(t, account.MaturityInstructionRolloverPrincipal, detail.MaturityInstruction)
+	})
+
+	t.Run("rolls over the principal and the interest at the current rate", func(t *testing.T) {
+		env := newTestEnv(t)
+		// opened when six months paid 3%
+		fixed, _ := env.newFixed(t, 100_000_000, 0.03, account.MaturityInstructionRolloverPrincipalAndInterest, start, maturity, false)
+		svc := env.service()
+
+		_, err := svc.MatureFixedSavings(ctx, maturity)
+		require.NoError(t, err)
+		firstInterest := account.FixedTermInterest(10_000_000_000, 300, start, maturity)
+		renewed := 100_000_000 + firstInterest.Float64()
+		assert.Equal(t, renewed, env.balance(t, fixed))
+		assert.Equal(t, 0.036, env.detail(t, fixed).AnnualInterestRate)
+
+		// the second term, on the renewed principal
+		n, err := svc.MatureFixedSavings(ctx, date(2027, 1, 15))
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		cycles, err := env.savings.GetRenewalCyclesByAccountIDs(ctx, []string{fixed})
+		require.NoError(t, err)
+		require.Len(t, cycles, 2)
+		assert.Equal(t, 0.03, cycles[0].AnnualInterestRate)
+		assert.Equal(t, renewed, cycles[0].RenewedPrincipal)
+		assert.Equal(t, 2, cycles[1].Cycle)
+		assert.Equal(t, maturity, cycles[1].StartDate)
+		assert.Equal(t, renewed, cycles[1].Principal)
+		assert.Equal(t, 0.036, cycles[1].AnnualInterestRate)
+		assert.Equal(t, cycles[1].Principal+cycles[1].Interest, env.balance(t, fixed))
+	})
+
+	t.Run("once per term", func(t *testing.T) {
+		env := newTestEnv(t)
+		fixed, _ := env.newFixed(t, 100_000_000, 0.036, account.MaturityInstructionRolloverPrincipalAndInterest, start, maturity, false)
+		svc := env.service()
+
+		_, err := svc.MatureFixedSavings(ctx, maturity)
+		require.NoError(t, err)
+		n, err := svc.MatureFixedSavings(ctx, time.Date(2026, 7, 15, 23, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Zero(t, n)
+		assert.Equal(t, 100_000_000+interest, env.balance(t, fixed))
+	})
+
+	t.Run("not before maturity", func(t *testing.T) {
+		env := newTestEnv(t)
+		fixed, _ := env.newFixed(t, 100_000_000, 0.036, account.MaturityInstructionPayout, start, maturity, true)
+
+		n, err := env.service().MatureFixedSavings(ctx, date(2026, 7, 14))
+		require.NoError(t, err)
+		assert.Zero(t, n)
+		assert.Equal(t, 100_000_000.0, env.balance(t, fixed))
+	})
+
+	t.Run("tết is matured after the break", func(t *testing.T) {
+		env := newTestEnv(t)
+		// maturing on Friday February 13th, before the break
+		fixed, payment := env.newFixed(t, 100_000_000, 0.036, account.MaturityInstructionPayout, date(2025, 8, 13), date(2026, 2, 13), true)
+		svc := env.service()
+
+		n, err := svc.MatureFixedSavings(ctx, date(2026, 2, 16))
+		require.NoError(t, err)
+		assert.Zero(t, n)
+
+		n, err = svc.MatureFixedSavings(ctx, date(2026, 2, 23))
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		// interest runs to the maturity date, not to the day it is paid
+		want := account.Amount(10_000_000_000) + account.FixedTermInterest(10_000_000_000, 360, date(2025, 8, 13), date(2026, 2, 13))
+		assert.Equal(t, want.Float64(), env.balance(t, payment))
+		assert.Equal(t, "CLOSED", env.status(t, fixed))
+	})
+
+	t.Run("rolls over a term that ended on a weekend", func(t *testing.T) {
+		env := newTestEnv(t)
+		// opened on Thursday July 17th 2025, six months later is a Saturday
+		opened := date(2025, 7, 17)
+		fixed, _ := env.newFixed(t, 100_000_000, 0.036, account.MaturityInstructionRolloverPrincipal, opened, date(2026, 1, 19), true)
+		svc := env.service()
+
+		_, err := svc.MatureFixedSavings(ctx, date(2026, 1, 19))
+		require.NoError(t, err)
+		// a year after opening, a Friday, not six months after the Monday
+		detail := env.detail(t, fixed)
+		assert.Equal(t, date(2026, 1, 19), detail.StartDate)
+		assert.Equal(t, date(2026, 7, 17), *detail.MaturityDate)
+
+		_, err = svc.MatureFixedSavings(ctx, date(2026, 7, 17))
+		require.NoError(t, err)
+		// eighteen months after opening is a Sunday
+		assert.Equal(t, date(2027, 1, 18), *env.detail(t, fixed).MaturityDate)
+	})
+
+	t.Run("no payment account to pay out to", func(t *testing.T) {
+		env := newTestEnv(t)
+		fixed, _ := env.newFixed(t, 100_000_000, 0.036, account.MaturityInstructionPayout, start, maturity, false)
+
+		n, err := env.service().MatureFixedSavings(ctx, maturity)
+		assert.ErrorIs(t, err, account.ErrPaymentAccountRequired)
+		assert.Zero(t, n)
+		// rolled back, to be matured by the next run
+		assert.Equal(t, 100_000_000.0, env.balance(t, fixed))
+		assert.Equal(t, "ACTIVE", env.status(t, fixed))
+		assert.Empty(t, transactionTypes(t, env.txs, fixed))
+	})
+}
@@ -0,0 +1,40 @@
+package interest
+
+import (
+	"context"
+	"time"
+
+	"e-wallet/internal/ports"
+	"e-wallet/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var tracer = tracing.Tracer("e-wallet/internal/application/interest")
+
+type tracedInterestService struct {
+	next ports.InterestService
+}
+
+// WithTracing wraps svc so every call runs in its own span.
+func WithTracing(svc ports.InterestService) ports.InterestService {
+	return &tracedInterestService{next: svc}
+}
+
+func (t *tracedInterestService) AccrueFlexibleInterest(ctx context.Context, asOf time.Time) (int, error) {
+	ctx, span := tracer.Start(ctx, "InterestService.AccrueFlexibleInterest")
+	span.SetAttributes(attribute.String("interest.as_of", asOf.Format(time.DateOnly)))
+	credited, err := t.next.AccrueFlexibleInterest(ctx, asOf)
+	span.SetAttributes(attribute.Int("interest.accounts_credited", credited))
+	tracing.End(span, err)
+	return credited, err
+}
+
+func (t *tracedInterestService) MatureFixedSavings(ctx context.Context, asOf time.Time) (int, error) {
+	ctx, span := tracer.Start(ctx, "InterestService.MatureFixedSavings")
+	span.SetAttributes(attribute.String("interest.as_of", asOf.Format(time.DateOnly)))
+	matured, err := t.next.MatureFixedSavings(ctx, asOf)
+	span.SetAttributes(attribute.Int("interest.accounts_matured", matured))
+	tracing.End(span, err)
+	return matured, err
+}
@@ -0,0 +1,274 @@
+package privacy
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"e-wallet/internal/domain/privacy"
+)
+
+type exportedUser struct {
+	ID                 string    `json:"id"`
+	Username           string    `json:"username"`
+	Email              string    `json:"email"`
+	IsEmailVerified    bool      `json:"is_email_verified"`
+	IsProfileCompleted bool      `json:"is_profile_completed"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+type exportedProfile struct {
+	DisplayName       string    `json:"display_name"`
+	AvatarURL         *string   `json:"avatar_url"`
+	PhoneNumber       string    `json:"phone_number"`
+	NationalID        string    `json:"national_id"`
+	BirthYear         int       `json:"birth_year"`
+	Gender            string    `json:"gender"`
+	Team              string    `json:"team"`
+	PreferredLanguage string    `json:"preferred_language"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+type exportedAccount struct {
+	ID            string    `json:"id"`
+	AccountNumber string    `json:"account_number"`
+	AccountType   string    `json:"account_type"`
+	Balance       float64   `json:"balance"`
+	Status        string    `json:"status"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type exportedSavingsDetail struct {
+	AccountID            string     `json:"account_id"`
+	IsFixedTerm          bool       `json:"is_fixed_term"`
+	TermMonths           *int       `json:"term_months,omitempty"`
+	AnnualInterestRate   float64    `json:"annual_interest_rate"`
+	StartDate            time.Time  `json:"start_date"`
+	MaturityDate         *time.Time `json:"maturity_date,omitempty"`
+	LastInterestCalcDate *time.Time `json:"last_interest_calc_date,omitempty"`
+	MaturityInstruction  string     `json:"maturity_instruction,omitempty"`
+}
+
+type exportedTransaction struct {
+	ID              string    `json:"id"`
+	AccountID       string    `json:"account_id"`
+	TransactionType string    `json:"transaction_type"`
+	Amount          float64   `json:"amount"`
+	TransactionDate time.Time `json:"transaction_date"`
+	Description     string    `json:"description"`
+	IsPenalty       bool      `json:"is_penalty"`
+}
+
+type exportedInterestHistory struct {
+	Flexible []exportedFlexibleInterest `json:"flexible_savings"`
+	Fixed    []exportedFixedInterest    `json:"fixed_savings"`
+}
+
+type exportedFlexibleInterest struct {
+	AccountID           string    `json:"account_id"`
+	CalculationDate     time.Time `json:"calculation_date"`
+	EODBalance          float64   `json:"eod_balance"`
+	AnnualRateApplied   float64   `json:"annual_rate_applied"`
+	DailyInterestAmount float64   `json:"daily_interest_amount"`
+	IsPromotionalRate   bool      `json:"is_promotional_rate"`
+}
+
+type exportedFixedInterest struct {
+	AccountID           string    `json:"account_id"`
+	CalculationPeriod   string    `json:"calculation_period"`
+	TotalInterestAmount float64   `json:"total_interest_amount"`
+	IsEarlyWithdrawal   bool      `json:"is_early_withdrawal"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+type exportedRenewalCycle struct {
+	AccountID          string    `json:"account_id"`
+	Cycle              int       `json:"cycle"`
+	StartDate          time.Time `json:"start_date"`
+	MaturityDate       time.Time `json:"maturity_date"`
+	Principal          float64   `json:"principal"`
+	Interest           float64   `json:"interest"`
+	AnnualInterestRate float64   `json:"annual_interest_rate"`
+	Instruction        string    `json:"instruction"`
+	RenewedPrincipal   float64   `json:"renewed_principal"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// writeArchive writes the user's data as a ZIP of JSON documents, plus CSV
+// copies of the tabular data for spreadsheet users.
+func writeArchive(w io.Writer, data *privacy.UserData) error {
+	zw := zip.NewWriter(w)
+
+	u := data.User
+	if err := writeJSON(zw, "user.json", exportedUser{
+		ID:                 u.ID,
+		Username:           u.Username,
+		Email:              u.Email,
+		IsEmailVerified:    u.IsEmailVerified,
+		IsProfileCompleted: u.IsProfileCompleted,
+		CreatedAt:          u.CreatedAt,
+		UpdatedAt:          u.UpdatedAt,
+	}); err != nil {
+		return err
+	}
+
+	if p := data.Profile; p != nil {
+		if err := writeJSON(zw, "profile.json", exportedProfile{
+			DisplayName:       p.DisplayName,
+			AvatarURL:         p.AvatarURL,
+			PhoneNumber:       p.PhoneNumber,
+			NationalID:        p.NationalID,
+			BirthYear:         p.BirthYear,
+			Gender:            p.Gender,
+			Team:              p.Team,
+			PreferredLanguage: p.PreferredLanguage,
+			CreatedAt:         p.CreatedAt,
+			UpdatedAt:         p.UpdatedAt,
+		}); err != nil {
+			return err
+		}
+	}
+
+	accounts := make([]exportedAccount, 0, len(data.Accounts))
+	accountRows := [][]string{{"id", "account_number", "account_type", "balance", "status", "created_at"}}
+	for _, acc := range data.Accounts {
+		accounts = append(accounts, exportedAccount{
+			ID:            acc.ID,
+			AccountNumber: acc.AccountNumber,
+			AccountType:   acc.AccountType,
+			Balance:       acc.Balance,
+			Status:        acc.Status,
+			CreatedAt:     acc.CreatedAt,
+			UpdatedAt:     acc.UpdatedAt,
+		})
+		accountRows = append(accountRows, []string{
+			acc.ID, acc.AccountNumber, acc.AccountType, formatAmount(acc.Balance), acc.Status, acc.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	if err := writeJSON(zw, "accounts.json", accounts); err != nil {
+		return err
+	}
+	if err := writeCSV(zw, "accounts.csv", accountRows); err != nil {
+		return err
+	}
+
+	details := make([]exportedSavingsDetail, 0, len(data.SavingsDetails))
+	for _, d := range data.SavingsDetails {
+		details = append(details, exportedSavingsDetail{
+			AccountID:            d.AccountID,
+			IsFixedTerm:          d.IsFixedTerm,
+			TermMonths:           d.TermMonths,
+			AnnualInterestRate:   d.AnnualInterestRate,
+			StartDate:            d.StartDate,
+			MaturityDate:         d.MaturityDate,
+			LastInterestCalcDate: d.LastInterestCalcDate,
+			MaturityInstruction:  d.MaturityInstruction,
+		})
+	}
+	if err := writeJSON(zw, "savings_details.json", details); err != nil {
+		return err
+	}
+
+	transactions := make([]exportedTransaction, 0, len(data.Transactions))
+	transactionRows := [][]string{{"id", "account_id", "transaction_type", "amount", "transaction_date", "description", "is_penalty"}}
+	for _, t := range data.Transactions {
+		transactions = append(transactions, exportedTransaction{
+			ID:              t.ID,
+			AccountID:       t.AccountID,
+			TransactionType: t.TransactionType,
+			Amount:          t.Amount,
+			TransactionDate: t.TransactionDate,
+			Description:     t.Description,
+			IsPenalty:       t.IsPenalty,
+		})
+		transactionRows = append(transactionRows, []string{
+			t.ID, t.AccountID, t.TransactionType, formatAmount(t.Amount), t.TransactionDate.Format(time.RFC3339), t.Description, strconv.FormatBool(t.IsPenalty),
+		})
+	}
+	if err := writeJSON(zw, "transactions.json", transactions); err != nil {
+		return err
+	}
+	if err := writeCSV(zw, "transactions.csv", transactionRows); err != nil {
+		return err
+	}
+
+	history := exportedInterestHistory{
+		Flexible: make([]exportedFlexibleInterest, 0, len(data.FlexibleInterest)),
+		Fixed:    make([]exportedFixedInterest, 0, len(data.FixedInterest)),
+	}
+	for _, h := range data.FlexibleInterest {
+		history.Flexible = append(history.Flexible, exportedFlexibleInterest{
+			AccountID:           h.AccountID,
+			CalculationDate:     h.CalculationDate,
+			EODBalance:          h.EODBalance,
+			AnnualRateApplied:   h.AnnualRateApplied,
+			DailyInterestAmount: h.DailyInterestAmount,
+			IsPromotionalRate:   h.IsPromotionalRate,
+		})
+	}
+	for _, h := range data.FixedInterest {
+		history.Fixed = append(history.Fixed, exportedFixedInterest{
+			AccountID:           h.AccountID,
+			CalculationPeriod:   h.CalculationPeriod,
+			TotalInterestAmount: h.TotalInterestAmount,
+			IsEarlyWithdrawal:   h.IsEarlyWithdrawal,
+			CreatedAt:           h.CreatedAt,
+		})
+	}
+	if err := writeJSON(zw, "interest_history.json", history); err != nil {
+		return err
+	}
+
+	cycles := make([]exportedRenewalCycle, 0, len(data.RenewalCycles))
+	for _, c := range data.RenewalCycles {
+		cycles = append(cycles, exportedRenewalCycle{
+			AccountID:          c.AccountID,
+			Cycle:              c.Cycle,
+			StartDate:          c.StartDate,
+			MaturityDate:       c.MaturityDate,
+			Principal:          c.Principal,
+			Interest:           c.Interest,
+			AnnualInterestRate: c.AnnualInterestRate,
+			Instruction:        c.Instruction,
+			RenewedPrincipal:   c.RenewedPrincipal,
+			CreatedAt:          c.CreatedAt,
+		})
+	}
+	if err := writeJSON(zw, "renewal_cycles.json", cycles); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+func writeJSON(zw *zip.Writer, name string, v any) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func writeCSV(zw *zip.Writer, name string, rows [][]string) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(f)
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+func formatAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
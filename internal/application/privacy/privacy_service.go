@@ -0,0 +1,347 @@
+package privacy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"e-wallet/internal/domain/account"
+	"e-wallet/internal/domain/privacy"
+	"e-wallet/internal/ports"
+	"e-wallet/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+const exportTimeout = 10 * time.Minute
+
+// exportInterrupted is the error recorded on exports a restart cut short.
+const exportInterrupted = "export interrupted"
+
+type privacyService struct {
+	userRepo        ports.UserRepository
+	profileRepo     ports.ProfileRepository
+	accountRepo     ports.AccountRepository
+	savingsRepo     ports.SavingsAccountDetailRepository
+	transactionRepo ports.TransactionRepository
+	interestRepo    ports.InterestHistoryRepository
+	exportRepo      ports.DataExportRepository
+	storage         ports.ExportStorage
+	txManager       ports.TxManager
+	clock           ports.Clock
+
+	// exports tracks the archives being built in the background
+	exports sync.WaitGroup
+}
+
+func NewPrivacyService(
+	userRepo ports.UserRepository,
+	profileRepo ports.ProfileRepository,
+	accountRepo ports.AccountRepository,
+	savingsRepo ports.SavingsAccountDetailRepository,
+	transactionRepo ports.TransactionRepository,
+	interestRepo ports.InterestHistoryRepository,
+	exportRepo ports.DataExportRepository,
+	storage ports.ExportStorage,
+	txManager ports.TxManager,
+	clock ports.Clock,
+) ports.PrivacyService {
+	return &privacyService{
+		userRepo:        userRepo,
+		profileRepo:     profileRepo,
+		accountRepo:     accountRepo,
+		savingsRepo:     savingsRepo,
+		transactionRepo: transactionRepo,
+		interestRepo:    interestRepo,
+		exportRepo:      exportRepo,
+		storage:         storage,
+		txManager:       txManager,
+		clock:           clock,
+	}
+}
+
+// RequestExport registers an export and assembles the archive in the background.
+func (s *privacyService) RequestExport(ctx context.Context, userID string) (*privacy.DataExport, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	export, err := s.exportRepo.Create(ctx, privacy.NewDataExport(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	// the caller keeps reading export while the copy is being processed
+	pending := *export
+	s.exports.Go(func() { s.processExport(context.WithoutCancel(ctx), &pending) })
+
+	return export, nil
+}
+
+// FailStaleExports fails the exports still pending or processing after
+// exportTimeout: whatever was building them stopped. Younger exports may
+// still be running on another instance, and are left alone.
+func (s *privacyService) FailStaleExports(ctx context.Context) (int, error) {
+	now := s.clock.Now()
+	return s.exportRepo.FailUnfinished(ctx, now.Add(-exportTimeout), now, exportInterrupted)
+}
+
+// Wait blocks until the exports running in the background are done, or
+// until ctx is.
+func (s *privacyService) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.exports.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *privacyService) GetExport(ctx context.Context, userID string, exportID string) (*privacy.DataExport, error) {
+	export, err := s.exportRepo.GetByID(ctx, exportID)
+	if err != nil {
+		return nil, err
+	}
+	if export.UserID != userID || export.Status == privacy.ExportStatusDeleted {
+		return nil, privacy.ErrExportNotFound
+	}
+
+	return export, nil
+}
+
+func (s *privacyService) OpenExport(ctx context.Context, userID string, exportID string) (io.ReadCloser, *privacy.DataExport, error) {
+	export, err := s.GetExport(ctx, userID, exportID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if export.Status != privacy.ExportStatusCompleted {
+		return nil, nil, privacy.ErrExportNotReady
+	}
+
+	file, err := s.storage.Open(ctx, export.FileName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return file, export, nil
+}
+
+// DeleteUserData anonymises the user's personal data and removes their export
+// archives. Accounts, transactions and interest history are kept for
+// bookkeeping, but no longer identify the user.
+func (s *privacyService) DeleteUserData(ctx context.Context, userID string) error {
+	u, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if u.DeletedAt != nil {
+		return privacy.ErrUserAlreadyDeleted
+	}
+
+	// close and anonymize everything or nothing, so a retry starts from a
+	// consistent state
+	var exports []*privacy.DataExport
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Only allowed when all balances are zero. Each account is locked
+		// before its balance is checked, so that no deposit or interest can
+		// land on it before it is closed
+		accounts, err := s.accountRepo.GetAccountsByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		slices.SortFunc(accounts, func(a, b *account.Account) int { return strings.Compare(a.ID, b.ID) })
+		for _, acc := range accounts {
+			locked, err := s.accountRepo.GetAccountForUpdate(ctx, acc.ID)
+			if err != nil {
+				return err
+			}
+			if locked.Balance != 0 {
+				return privacy.ErrNonZeroBalance.With("account_number", locked.AccountNumber)
+			}
+		}
+
+		if err := s.accountRepo.CloseAccountsByUserID(ctx, userID); err != nil {
+			return err
+		}
+
+		if u.IsProfileCompleted {
+			if err := s.profileRepo.Anonymize(ctx, userID, privacy.AnonymizedValue(userID)); err != nil {
+				return err
+			}
+		}
+
+		if err := s.userRepo.Anonymize(ctx, userID, privacy.AnonymizedUsername(userID), privacy.AnonymizedEmail(userID)); err != nil {
+			return err
+		}
+
+		exports, err = s.exportRepo.GetByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		for _, export := range exports {
+			deleted := *export
+			deleted.Status = privacy.ExportStatusDeleted
+			deleted.FileName = ""
+			if err := s.exportRepo.Update(ctx, &deleted); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// The files go only once the rows are committed as deleted, so a failed
+	// deletion leaves every export downloadable as before. A file that can't
+	// be removed is no longer served, and is only logged.
+	log := logger.FromContext(ctx, logger.NOOPLogger)
+	for _, export := range exports {
+		if export.FileName == "" {
+			continue
+		}
+		if err := s.storage.Delete(ctx, export.FileName); err != nil {
+			log.Errorw("cannot delete data export file", "export_id", export.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+func (s *privacyService) processExport(ctx context.Context, export *privacy.DataExport) {
+	ctx, cancel := context.WithTimeout(ctx, exportTimeout)
+	defer cancel()
+
+	log := logger.FromContext(ctx, logger.NOOPLogger).With("export_id", export.ID)
+
+	export.Status = privacy.ExportStatusProcessing
+	if err := s.exportRepo.Update(ctx, export); err != nil {
+		log.Errorw("cannot start data export", "error", err)
+		s.failExport(ctx, log, export, err)
+		return
+	}
+
+	err := s.buildExport(ctx, export)
+	now := s.clock.Now()
+	export.CompletedAt = &now
+	if err != nil {
+		log.Errorw("cannot build data export", "error", err)
+		export.Status = privacy.ExportStatusFailed
+		export.Error = err.Error()
+	} else {
+		export.Status = privacy.ExportStatusCompleted
+	}
+
+	// the user may have been deleted while the archive was being built
+	if current, err := s.exportRepo.GetByID(ctx, export.ID); err == nil && current.Status == privacy.ExportStatusDeleted {
+		if export.FileName != "" {
+			if err := s.storage.Delete(ctx, export.FileName); err != nil {
+				log.Errorw("cannot delete data export file", "error", err)
+			}
+		}
+		return
+	}
+
+	if err := s.exportRepo.Update(ctx, export); err != nil {
+		log.Errorw("cannot save data export", "status", export.Status, "error", err)
+		if export.Status != privacy.ExportStatusFailed {
+			s.failExport(ctx, log, export, err)
+		}
+	}
+}
+
+// failExport marks an export FAILED after its progress could not be saved,
+// so that it doesn't stay pending forever. It is best effort: the update that
+// just failed may well fail again.
+func (s *privacyService) failExport(ctx context.Context, log *zap.SugaredLogger, export *privacy.DataExport, cause error) {
+	now := s.clock.Now()
+	export.Status = privacy.ExportStatusFailed
+	export.Error = cause.Error()
+	export.CompletedAt = &now
+	if err := s.exportRepo.Update(ctx, export); err != nil {
+		log.Errorw("cannot mark data export failed", "error", err)
+	}
+}
+
+func (s *privacyService) buildExport(ctx context.Context, export *privacy.DataExport) error {
+	data, err := s.collectUserData(ctx, export.UserID)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := writeArchive(&buf, data); err != nil {
+		return err
+	}
+
+	fileName := fmt.Sprintf("%s.zip", export.ID)
+	if err := s.storage.Save(ctx, fileName, &buf); err != nil {
+		return err
+	}
+
+	export.FileName = fileName
+	return nil
+}
+
+func (s *privacyService) collectUserData(ctx context.Context, userID string) (*privacy.UserData, error) {
+	u, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	data := &privacy.UserData{User: u}
+
+	if u.IsProfileCompleted {
+		data.Profile, err = s.profileRepo.GetByUserID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	data.Accounts, err = s.accountRepo.GetAccountsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	accountIDs := make([]string, 0, len(data.Accounts))
+	for _, acc := range data.Accounts {
+		accountIDs = append(accountIDs, acc.ID)
+		if acc.AccountType == "FIXED_SAVINGS" || acc.AccountType == "FLEXIBLE_SAVINGS" {
+			detail, err := s.savingsRepo.GetSavingsAccountDetailByAccountID(ctx, acc.ID)
+			if err == nil && detail != nil {
+				data.SavingsDetails = append(data.SavingsDetails, detail)
+			}
+		}
+	}
+
+	data.Transactions, err = s.transactionRepo.GetTransactionsByAccountIDs(ctx, accountIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	data.FlexibleInterest, err = s.interestRepo.GetFlexibleInterestHistoryByAccountIDs(ctx, accountIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	data.FixedInterest, err = s.interestRepo.GetFixedInterestHistoryByAccountIDs(ctx, accountIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	data.RenewalCycles, err = s.savingsRepo.GetRenewalCyclesByAccountIDs(ctx, accountIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
@@ -0,0 +1,272 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"e-wallet/internal/adapters/clock"
+	"e-wallet/internal/adapters/repository/memory"
+	"e-wallet/internal/adapters/storage"
+	"e-wallet/internal/domain/account"
+	"e-wallet/internal/domain/privacy"
+	"e-wallet/internal/domain/user"
+	"e-wallet/mocks"
+	"e-wallet/pkg/logger"
+)
+
+// passThroughTxManager runs the unit of work without a transaction.
+func passThroughTxManager(t *testing.T) *mocks.MockTxManager {
+	txManager := mocks.NewMockTxManager(t)
+	txManager.EXPECT().
+		WithinTransaction(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		Maybe()
+	return txManager
+}
+
+func TestPrivacyService_DeleteUserData(t *testing.T) {
+	userID := "user-123"
+	deletedAt := time.Now()
+
+	tests := []struct {
+		name          string
+		mockSetup     func(*mocks.MockUserRepository, *mocks.MockProfileRepository, *mocks.MockAccountRepository, *mocks.MockDataExportRepository)
+		expectedError error
+	}{
+		{
+			name: "success - anonymise user with zero balances",
+			mockSetup: func(userRepo *mocks.MockUserRepository, profileRepo *mocks.MockProfileRepository, accountRepo *mocks.MockAccountRepository, exportRepo *mocks.MockDataExportRepository) {
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&user.User{ID: userID, IsProfileCompleted: true}, nil).Once()
+				accountRepo.EXPECT().GetAccountsByUserID(mock.Anything, userID).Return([]*account.Account{
+					{ID: "acc-1", AccountNumber: "1234567890", Balance: 0},
+				}, nil).Once()
+				accountRepo.EXPECT().GetAccountForUpdate(mock.Anything, "acc-1").Return(&account.Account{ID: "acc-1", AccountNumber: "1234567890", Balance: 0}, nil).Once()
+				accountRepo.EXPECT().CloseAccountsByUserID(mock.Anything, userID).Return(nil).Once()
+				profileRepo.EXPECT().Anonymize(mock.Anything, userID, privacy.AnonymizedValue(userID)).Return(nil).Once()
+				userRepo.EXPECT().Anonymize(mock.Anything, userID, privacy.AnonymizedUsername(userID), privacy.AnonymizedEmail(userID)).Return(nil).Once()
+				exportRepo.EXPECT().GetByUserID(mock.Anything, userID).Return(nil, nil).Once()
+			},
+			expectedError: nil,
+		},
+		{
+			name: "success - user without profile",
+			mockSetup: func(userRepo *mocks.MockUserRepository, profileRepo *mocks.MockProfileRepository, accountRepo *mocks.MockAccountRepository, exportRepo *mocks.MockDataExportRepository) {
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&user.User{ID: userID}, nil).Once()
+				accountRepo.EXPECT().GetAccountsByUserID(mock.Anything, userID).Return(nil, nil).Once()
+				accountRepo.EXPECT().CloseAccountsByUserID(mock.Anything, userID).Return(nil).Once()
+				userRepo.EXPECT().Anonymize(mock.Anything, userID, privacy.AnonymizedUsername(userID), privacy.AnonymizedEmail(userID)).Return(nil).Once()
+				exportRepo.EXPECT().GetByUserID(mock.Anything, userID).Return(nil, nil).Once()
+			},
+			expectedError: nil,
+		},
+		{
+			name: "error - non-zero balance",
+			mockSetup: func(userRepo *mocks.MockUserRepository, profileRepo *mocks.MockProfileRepository, accountRepo *mocks.MockAccountRepository, exportRepo *mocks.MockDataExportRepository) {
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&user.User{ID: userID, IsProfileCompleted: true}, nil).Once()
+				accountRepo.EXPECT().GetAccountsByUserID(mock.Anything, userID).Return([]*account.Account{
+					{ID: "acc-2", AccountNumber: "0987654321", Balance: 10.5},
+					{ID: "acc-1", AccountNumber: "1234567890", Balance: 0},
+				}, nil).Once()
+				accountRepo.EXPECT().GetAccountForUpdate(mock.Anything, "acc-1").Return(&account.Account{ID: "acc-1", AccountNumber: "1234567890", Balance: 0}, nil).Once()
+				accountRepo.EXPECT().GetAccountForUpdate(mock.Anything, "acc-2").Return(&account.Account{ID: "acc-2", AccountNumber: "0987654321", Balance: 10.5}, nil).Once()
+			},
+			expectedError: errors.New("account 0987654321 still has a non-zero balance"),
+		},
+		{
+			name: "error - credited after it was listed",
+			mockSetup: func(userRepo *mocks.MockUserRepository, profileRepo *mocks.MockProfileRepository, accountRepo *mocks.MockAccountRepository, exportRepo *mocks.MockDataExportRepository) {
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&user.User{ID: userID, IsProfileCompleted: true}, nil).Once()
+				accountRepo.EXPECT().GetAccountsByUserID(mock.Anything, userID).Return([]*account.Account{
+					{ID: "acc-1", AccountNumber: "1234567890", Balance: 0},
+				}, nil).Once()
+				// the balance read once the account is locked is the one that counts
+				accountRepo.EXPECT().GetAccountForUpdate(mock.Anything, "acc-1").Return(&account.Account{ID: "acc-1", AccountNumber: "1234567890", Balance: 0.01}, nil).Once()
+			},
+			expectedError: errors.New("account 1234567890 still has a non-zero balance"),
+		},
+		{
+			name: "error - already deleted",
+			mockSetup: func(userRepo *mocks.MockUserRepository, profileRepo *mocks.MockProfileRepository, accountRepo *mocks.MockAccountRepository, exportRepo *mocks.MockDataExportRepository) {
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&user.User{ID: userID, DeletedAt: &deletedAt}, nil).Once()
+			},
+			expectedError: errors.New("user data already deleted"),
+		},
+		{
+			name: "error - user not found",
+			mockSetup: func(userRepo *mocks.MockUserRepository, profileRepo *mocks.MockProfileRepository, accountRepo *mocks.MockAccountRepository, exportRepo *mocks.MockDataExportRepository) {
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(nil, errors.New("user not found")).Once()
+			},
+			expectedError: errors.New("user not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := mocks.NewMockUserRepository(t)
+			profileRepo := mocks.NewMockProfileRepository(t)
+			accountRepo := mocks.NewMockAccountRepository(t)
+			exportRepo := mocks.NewMockDataExportRepository(t)
+
+			tt.mockSetup(userRepo, profileRepo, accountRepo, exportRepo)
+
+			service := NewPrivacyService(userRepo, profileRepo, accountRepo, nil, nil, nil, exportRepo, nil, passThroughTxManager(t), clock.System)
+			err := service.DeleteUserData(context.Background(), userID)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPrivacyService_DeleteUserData_RemovesExports(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	numbers, err := account.NewNumberGenerator("97")
+	require.NoError(t, err)
+	users := memory.NewUserRepository(store)
+	exports := memory.NewDataExportRepository(store)
+	dir := t.TempDir()
+	svc := NewPrivacyService(
+		users,
+		memory.NewProfileRepository(store),
+		memory.NewAccountRepository(store, numbers),
+		memory.NewSavingsAccountDetailRepository(store),
+		memory.NewTransactionRepository(store),
+		memory.NewInterestHistoryRepository(store),
+		exports,
+		storage.NewLocalExportStorage(dir),
+		memory.NewTxManager(store),
+		clock.System,
+	).(*privacyService)
+
+	u, err := users.Create(ctx, &user.User{ID: "user-123", Username: "alice", Email: "alice@example.com", PasswordHash: "hash"})
+	require.NoError(t, err)
+	export, err := exports.Create(ctx, privacy.NewDataExport(u.ID))
+	require.NoError(t, err)
+	svc.processExport(ctx, export)
+
+	file, _, err := svc.OpenExport(ctx, u.ID, export.ID)
+	require.NoError(t, err)
+	file.Close()
+	require.FileExists(t, filepath.Join(dir, export.FileName))
+
+	require.NoError(t, svc.DeleteUserData(ctx, u.ID))
+
+	_, _, err = svc.OpenExport(ctx, u.ID, export.ID)
+	assert.ErrorIs(t, err, privacy.ErrExportNotFound)
+	_, err = svc.GetExport(ctx, u.ID, export.ID)
+	assert.ErrorIs(t, err, privacy.ErrExportNotFound)
+	assert.NoFileExists(t, filepath.Join(dir, export.FileName))
+
+	got, err := exports.GetByID(ctx, export.ID)
+	require.NoError(t, err)
+	assert.Equal(t, privacy.ExportStatusDeleted, got.Status)
+	assert.Empty(t, got.FileName)
+}
+
+func TestPrivacyService_Wait(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	numbers, err := account.NewNumberGenerator("97")
+	require.NoError(t, err)
+	users := memory.NewUserRepository(store)
+	exports := memory.NewDataExportRepository(store)
+	svc := NewPrivacyService(
+		users,
+		memory.NewProfileRepository(store),
+		memory.NewAccountRepository(store, numbers),
+		memory.NewSavingsAccountDetailRepository(store),
+		memory.NewTransactionRepository(store),
+		memory.NewInterestHistoryRepository(store),
+		exports,
+		storage.NewLocalExportStorage(t.TempDir()),
+		memory.NewTxManager(store),
+		clock.System,
+	)
+
+	u, err := users.Create(ctx, &user.User{ID: "user-123", Username: "alice", Email: "alice@example.com", PasswordHash: "hash"})
+	require.NoError(t, err)
+	export, err := svc.RequestExport(ctx, u.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, svc.Wait(ctx))
+	got, err := exports.GetByID(ctx, export.ID)
+	require.NoError(t, err)
+	assert.Equal(t, privacy.ExportStatusCompleted, got.Status)
+
+	t.Run("deadline", func(t *testing.T) {
+		svc := NewPrivacyService(nil, nil, nil, nil, nil, nil, nil, nil, nil, clock.System).(*privacyService)
+		svc.exports.Add(1)
+		defer svc.exports.Done()
+
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, svc.Wait(ctx), context.DeadlineExceeded)
+	})
+}
+
+func TestPrivacyService_FailStaleExports(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	exportRepo := mocks.NewMockDataExportRepository(t)
+	exportRepo.EXPECT().FailUnfinished(mock.Anything, now.Add(-exportTimeout), now, exportInterrupted).Return(2, nil).Once()
+
+	svc := NewPrivacyService(nil, nil, nil, nil, nil, nil, exportRepo, nil, nil, clock.NewFake(now))
+	failed, err := svc.FailStaleExports(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, failed)
+}
+
+func TestPrivacyService_ProcessExport_UpdateFails(t *testing.T) {
+	withStatus := func(status privacy.ExportStatus) any {
+		return mock.MatchedBy(func(e *privacy.DataExport) bool { return e.Status == status })
+	}
+
+	t.Run("cannot start", func(t *testing.T) {
+		core, logs := observer.New(zap.InfoLevel)
+		ctx := logger.WithContext(context.Background(), zap.New(core).Sugar())
+		exportRepo := mocks.NewMockDataExportRepository(t)
+		exportRepo.EXPECT().Update(mock.Anything, withStatus(privacy.ExportStatusProcessing)).Return(errors.New("connection reset")).Once()
+		exportRepo.EXPECT().Update(mock.Anything, withStatus(privacy.ExportStatusFailed)).Return(nil).Once()
+
+		svc := NewPrivacyService(nil, nil, nil, nil, nil, nil, exportRepo, nil, nil, clock.System).(*privacyService)
+		export := &privacy.DataExport{ID: "exp-1", UserID: "user-123", Status: privacy.ExportStatusPending}
+		svc.processExport(ctx, export)
+
+		assert.Equal(t, privacy.ExportStatusFailed, export.Status)
+		assert.Equal(t, "connection reset", export.Error)
+		entries := logs.FilterMessage("cannot start data export").All()
+		if assert.Len(t, entries, 1) {
+			assert.Equal(t, "exp-1", entries[0].ContextMap()["export_id"])
+		}
+	})
+
+	t.Run("cannot save the result", func(t *testing.T) {
+		core, logs := observer.New(zap.InfoLevel)
+		ctx := logger.WithContext(context.Background(), zap.New(core).Sugar())
+		userRepo := mocks.NewMockUserRepository(t)
+		userRepo.EXPECT().GetByID(mock.Anything, "user-123").Return(nil, user.ErrUserNotFound).Once()
+		exportRepo := mocks.NewMockDataExportRepository(t)
+		exportRepo.EXPECT().Update(mock.Anything, withStatus(privacy.ExportStatusProcessing)).Return(nil).Once()
+		exportRepo.EXPECT().GetByID(mock.Anything, "exp-1").Return(&privacy.DataExport{ID: "exp-1", UserID: "user-123", Status: privacy.ExportStatusProcessing}, nil).Once()
+		exportRepo.EXPECT().Update(mock.Anything, withStatus(privacy.ExportStatusFailed)).Return(errors.New("connection reset")).Once()
+
+		svc := NewPrivacyService(userRepo, nil, nil, nil, nil, nil, exportRepo, nil, nil, clock.System).(*privacyService)
+		svc.processExport(ctx, &privacy.DataExport{ID: "exp-1", UserID: "user-123", Status: privacy.ExportStatusPending})
+
+		assert.Len(t, logs.FilterMessage("cannot build data export").All(), 1)
+		assert.Len(t, logs.FilterMessage("cannot save data export").All(), 1)
+	})
+}
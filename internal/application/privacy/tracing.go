@@ -0,0 +1,67 @@
+package privacy
+
+import (
+	"context"
+	"io"
+
+	"e-wallet/internal/domain/privacy"
+	"e-wallet/internal/ports"
+	"e-wallet/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var tracer = tracing.Tracer("e-wallet/internal/application/privacy")
+
+type tracedPrivacyService struct {
+	next ports.PrivacyService
+}
+
+// WithTracing wraps svc so every call runs in its own span.
+func WithTracing(svc ports.PrivacyService) ports.PrivacyService {
+	return &tracedPrivacyService{next: svc}
+}
+
+func (t *tracedPrivacyService) RequestExport(ctx context.Context, userID string) (*privacy.DataExport, error) {
+	ctx, span := tracer.Start(ctx, "PrivacyService.RequestExport")
+	span.SetAttributes(tracing.UserID(userID))
+	export, err := t.next.RequestExport(ctx, userID)
+	tracing.End(span, err)
+	return export, err
+}
+
+func (t *tracedPrivacyService) GetExport(ctx context.Context, userID string, exportID string) (*privacy.DataExport, error) {
+	ctx, span := tracer.Start(ctx, "PrivacyService.GetExport")
+	span.SetAttributes(tracing.UserID(userID), attribute.String("export.id", exportID))
+	export, err := t.next.GetExport(ctx, userID, exportID)
+	tracing.End(span, err)
+	return export, err
+}
+
+func (t *tracedPrivacyService) OpenExport(ctx context.Context, userID string, exportID string) (io.ReadCloser, *privacy.DataExport, error) {
+	ctx, span := tracer.Start(ctx, "PrivacyService.OpenExport")
+	span.SetAttributes(tracing.UserID(userID), attribute.String("export.id", exportID))
+	content, export, err := t.next.OpenExport(ctx, userID, exportID)
+	tracing.End(span, err)
+	return content, export, err
+}
+
+func (t *tracedPrivacyService) DeleteUserData(ctx context.Context, userID string) error {
+	ctx, span := tracer.Start(ctx, "PrivacyService.DeleteUserData")
+	span.SetAttributes(tracing.UserID(userID))
+	err := t.next.DeleteUserData(ctx, userID)
+	tracing.End(span, err)
+	return err
+}
+
+func (t *tracedPrivacyService) FailStaleExports(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "PrivacyService.FailStaleExports")
+	failed, err := t.next.FailStaleExports(ctx)
+	tracing.End(span, err)
+	return failed, err
+}
+
+// Wait is not traced: it only spans the shutdown.
+func (t *tracedPrivacyService) Wait(ctx context.Context) error {
+	return t.next.Wait(ctx)
+}
@@ -2,24 +2,27 @@ package profile
 
 import (
 	"context"
-	"errors"
-	"fmt"
 	"regexp"
-	"time"
+	"strconv"
+	"strings"
 
+	"e-wallet/internal/domain/apperror"
 	"e-wallet/internal/domain/profile"
+	"e-wallet/internal/i18n"
 	"e-wallet/internal/ports"
 )
 
 type profileService struct {
 	userRepo    ports.UserRepository
 	profileRepo ports.ProfileRepository
+	clock       ports.Clock
 }
 
-func NewProfileService(userRepo ports.UserRepository, profileRepo ports.ProfileRepository) ports.ProfileService {
+func NewProfileService(userRepo ports.UserRepository, profileRepo ports.ProfileRepository, clock ports.Clock) ports.ProfileService {
 	return &profileService{
 		userRepo:    userRepo,
 		profileRepo: profileRepo,
+		clock:       clock,
 	}
 }
 
@@ -35,19 +38,35 @@ func (s *profileService) UpdateProfile(ctx context.Context, userID string, req *
 		return nil, err
 	}
 	if exists {
-		return nil, errors.New("national ID already registered for another user")
+		return nil, profile.ErrNationalIDTaken
+	}
+
+	// Check if phone number already exists for another user
+	exists, err = s.profileRepo.CheckPhoneNumberExists(ctx, req.PhoneNumber, userID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, profile.ErrPhoneNumberTaken
 	}
 
 	// Upsert profile
+	// Default to the language the request was made in
+	preferredLanguage := req.PreferredLanguage
+	if preferredLanguage == "" {
+		preferredLanguage = string(i18n.FromContext(ctx, i18n.Vietnamese))
+	}
+
 	newProfile := &profile.Profile{
-		UserID:      userID,
-		DisplayName: req.DisplayName,
-		AvatarURL:   req.AvatarURL,
-		PhoneNumber: req.PhoneNumber,
-		NationalID:  req.NationalID,
-		BirthYear:   req.BirthYear,
-		Gender:      req.Gender,
-		Team:        req.Team,
+		UserID:            userID,
+		DisplayName:       req.DisplayName,
+		AvatarURL:         req.AvatarURL,
+		PhoneNumber:       req.PhoneNumber,
+		NationalID:        req.NationalID,
+		BirthYear:         req.BirthYear,
+		Gender:            req.Gender,
+		Team:              req.Team,
+		PreferredLanguage: preferredLanguage,
 	}
 	updatedProfile, err := s.profileRepo.Upsert(ctx, newProfile)
 	if err != nil {
@@ -68,37 +87,37 @@ func (s *profileService) GetProfile(ctx context.Context, userID string) (*profil
 
 func (s *profileService) validateUpdateProfileRequest(req *profile.UpdateProfileRequest) error {
 	if req.DisplayName == "" {
-		return errors.New("display name is required")
+		return apperror.FieldInvalid("display_name", "required", "display name is required")
 	}
 	if req.PhoneNumber == "" {
-		return errors.New("phone number is required")
+		return apperror.FieldInvalid("phone_number", "required", "phone number is required")
 	}
 	if req.NationalID == "" {
-		return errors.New("national ID is required")
+		return apperror.FieldInvalid("national_id", "required", "national ID is required")
 	}
 	if req.BirthYear <= 0 {
-		return errors.New("birth year is required")
+		return apperror.FieldInvalid("birth_year", "required", "birth year is required")
 	}
 	if req.Gender == "" {
-		return errors.New("gender is required")
+		return apperror.FieldInvalid("gender", "required", "gender is required")
 	}
 	if req.Team == "" {
-		return errors.New("team is required")
+		return apperror.FieldInvalid("team", "required", "team is required")
 	}
 
 	// Validate phone number format (basic validation)
 	phoneRegex := regexp.MustCompile(`^\+?[0-9]{10,15}$`)
 	if !phoneRegex.MatchString(req.PhoneNumber) {
-		return errors.New("invalid phone number format")
+		return apperror.FieldInvalid("phone_number", "phone", "invalid phone number format")
 	}
 
 	// Validate birth year
-	currentYear := time.Now().Year()
+	currentYear := s.clock.Now().Year()
 	if req.BirthYear > currentYear {
-		return errors.New("birth year cannot be in the future")
+		return apperror.Validation(apperror.FieldError{Field: "birth_year", Code: "max", Param: strconv.Itoa(currentYear), Message: "birth year cannot be in the future"})
 	}
 	if req.BirthYear < 1900 {
-		return errors.New("birth year is too old")
+		return apperror.Validation(apperror.FieldError{Field: "birth_year", Code: "min", Param: "1900", Message: "birth year is too old"})
 	}
 
 	// Validate gender
@@ -111,7 +130,7 @@ func (s *profileService) validateUpdateProfileRequest(req *profile.UpdateProfile
 		}
 	}
 	if !valid {
-		return errors.New("invalid gender value")
+		return apperror.Validation(apperror.FieldError{Field: "gender", Code: "oneof", Param: strings.Join(validGenders, " "), Message: "invalid gender value"})
 	}
 
 	// Validate team
@@ -124,7 +143,21 @@ func (s *profileService) validateUpdateProfileRequest(req *profile.UpdateProfile
 		}
 	}
 	if !valid {
-		return fmt.Errorf("invalid team value: %s", req.Team)
+		return apperror.Validation(apperror.FieldError{Field: "team", Code: "team", Param: strings.Join(validTeams, " "), Message: "invalid team value: {team}"}).With("team", req.Team)
+	}
+
+	// Validate preferred language
+	if req.PreferredLanguage != "" {
+		valid = false
+		for _, lang := range i18n.Supported {
+			if req.PreferredLanguage == string(lang) {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return apperror.Validation(apperror.FieldError{Field: "preferred_language", Code: "oneof", Param: "vi en", Message: "invalid preferred language"})
+		}
 	}
 
 	return nil
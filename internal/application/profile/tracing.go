@@ -0,0 +1,36 @@
+package profile
+
+import (
+	"context"
+
+	"e-wallet/internal/domain/profile"
+	"e-wallet/internal/ports"
+	"e-wallet/pkg/tracing"
+)
+
+var tracer = tracing.Tracer("e-wallet/internal/application/profile")
+
+type tracedProfileService struct {
+	next ports.ProfileService
+}
+
+// WithTracing wraps svc so every call runs in its own span.
+func WithTracing(svc ports.ProfileService) ports.ProfileService {
+	return &tracedProfileService{next: svc}
+}
+
+func (t *tracedProfileService) UpdateProfile(ctx context.Context, userID string, req *profile.UpdateProfileRequest) (*profile.Profile, error) {
+	ctx, span := tracer.Start(ctx, "ProfileService.UpdateProfile")
+	span.SetAttributes(tracing.UserID(userID))
+	p, err := t.next.UpdateProfile(ctx, userID, req)
+	tracing.End(span, err)
+	return p, err
+}
+
+func (t *tracedProfileService) GetProfile(ctx context.Context, userID string) (*profile.Profile, error) {
+	ctx, span := tracer.Start(ctx, "ProfileService.GetProfile")
+	span.SetAttributes(tracing.UserID(userID))
+	p, err := t.next.GetProfile(ctx, userID)
+	tracing.End(span, err)
+	return p, err
+}
@@ -0,0 +1,42 @@
+package user
+
+import (
+	"context"
+
+	"e-wallet/internal/domain/user"
+	"e-wallet/internal/ports"
+	"e-wallet/pkg/tracing"
+)
+
+var tracer = tracing.Tracer("e-wallet/internal/application/user")
+
+type tracedUserService struct {
+	next ports.UserService
+}
+
+// WithTracing wraps svc so every call runs in its own span.
+func WithTracing(svc ports.UserService) ports.UserService {
+	return &tracedUserService{next: svc}
+}
+
+func (t *tracedUserService) CreateUser(ctx context.Context, req *user.CreateUserRequest) (*user.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.CreateUser")
+	u, err := t.next.CreateUser(ctx, req)
+	tracing.End(span, err)
+	return u, err
+}
+
+func (t *tracedUserService) LoginUser(ctx context.Context, req *user.LoginUserRequest) (*user.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.LoginUser")
+	u, err := t.next.LoginUser(ctx, req)
+	tracing.End(span, err)
+	return u, err
+}
+
+func (t *tracedUserService) GetActiveUser(ctx context.Context, userID string) (*user.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetActiveUser")
+	span.SetAttributes(tracing.UserID(userID))
+	u, err := t.next.GetActiveUser(ctx, userID)
+	tracing.End(span, err)
+	return u, err
+}
@@ -2,6 +2,8 @@ package user
 
 import (
 	"context"
+	"errors"
+
 	"e-wallet/internal/domain/user"
 	"e-wallet/internal/ports"
 )
@@ -28,15 +30,31 @@ func (s *userService) CreateUser(ctx context.Context, req *user.CreateUserReques
 
 func (s *userService) LoginUser(ctx context.Context, req *user.LoginUserRequest) (*user.User, error) {
 	u, err := s.repo.GetByEmail(ctx, req.Email)
+	if errors.Is(err, user.ErrUserNotFound) {
+		// Don't tell callers whether the email is registered
+		return nil, user.ErrInvalidCredentials
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	if err := s.passwordService.CheckPassword(u.PasswordHash, req.Password); err != nil {
-		return nil, err
+		return nil, user.ErrInvalidCredentials.Wrap(&user.WrongPasswordError{UserID: u.ID, Err: err})
 	}
 
 	return u, nil
 }
 
+// GetActiveUser returns the user, or ErrUserNotFound when they don't exist or
+// their data was deleted: a deleted user is gone for every caller.
+func (s *userService) GetActiveUser(ctx context.Context, userID string) (*user.User, error) {
+	u, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u.DeletedAt != nil {
+		return nil, user.ErrUserNotFound
+	}
 
+	return u, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -142,10 +143,22 @@ func TestUserService_LoginUser(t *testing.T) {
 				Password: "password123",
 			},
 			mockSetup: func(userRepo *mocks.MockUserRepository, passwordService *mocks.MockPasswordService) {
-				userRepo.EXPECT().GetByEmail(mock.Anything, "nonexistent@example.com").Return(nil, errors.New("user not found")).Once()
+				userRepo.EXPECT().GetByEmail(mock.Anything, "nonexistent@example.com").Return(nil, user.ErrUserNotFound).Once()
 			},
 			expectedUser:  nil,
-			expectedError: errors.New("user not found"),
+			expectedError: user.ErrInvalidCredentials,
+		},
+		{
+			name: "error - repository fails",
+			request: &user.LoginUserRequest{
+				Email:    "test@example.com",
+				Password: "password123",
+			},
+			mockSetup: func(userRepo *mocks.MockUserRepository, passwordService *mocks.MockPasswordService) {
+				userRepo.EXPECT().GetByEmail(mock.Anything, "test@example.com").Return(nil, errors.New("db error")).Once()
+			},
+			expectedUser:  nil,
+			expectedError: errors.New("db error"),
 		},
 		{
 			name: "error - incorrect password",
@@ -162,7 +175,7 @@ func TestUserService_LoginUser(t *testing.T) {
 				passwordService.EXPECT().CheckPassword(mock.Anything, "wrongpassword").Return(bcrypt.ErrMismatchedHashAndPassword).Once()
 			},
 			expectedUser:  nil,
-			expectedError: bcrypt.ErrMismatchedHashAndPassword,
+			expectedError: user.ErrInvalidCredentials.Wrap(&user.WrongPasswordError{UserID: "user-123", Err: bcrypt.ErrMismatchedHashAndPassword}),
 		},
 	}
 
@@ -180,6 +193,12 @@ func TestUserService_LoginUser(t *testing.T) {
 				assert.Error(t, err)
 				assert.Equal(t, tt.expectedError.Error(), err.Error())
 				assert.Nil(t, result)
+
+				// a wrong password is attributed to the account it was for
+				var expected, got *user.WrongPasswordError
+				if errors.As(tt.expectedError, &expected) && assert.ErrorAs(t, err, &got) {
+					assert.Equal(t, expected.UserID, got.UserID)
+				}
 			} else {
 				assert.NoError(t, err)
 				assert.NotNil(t, result)
@@ -187,4 +206,36 @@ func TestUserService_LoginUser(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestUserService_GetActiveUser(t *testing.T) {
+	deletedAt := time.Date(2025, 1, 15, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		stored        *user.User
+		storedErr     error
+		expectedError error
+	}{
+		{name: "active", stored: &user.User{ID: "user-123"}},
+		{name: "deleted", stored: &user.User{ID: "user-123", DeletedAt: &deletedAt}, expectedError: user.ErrUserNotFound},
+		{name: "not found", storedErr: user.ErrUserNotFound, expectedError: user.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := mocks.NewMockUserRepository(t)
+			userRepo.EXPECT().GetByID(mock.Anything, "user-123").Return(tt.stored, tt.storedErr).Once()
+
+			service := NewUserService(userRepo, mocks.NewMockPasswordService(t))
+			result, err := service.GetActiveUser(context.Background(), "user-123")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "user-123", result.ID)
+			}
+		})
+	}
+}
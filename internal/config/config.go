@@ -1,40 +1,177 @@
+// Package config loads the service configuration from the environment.
+//
+// Values are looked up in this order, the first one found wins:
+//
+//  1. the process environment
+//  2. .env.<APP_ENV> (e.g. .env.staging), the per-environment overlay
+//  3. .env
+//  4. the default tag of the field
+//
+// Fields tagged secret:"true" can also be read from the file named by the
+// <NAME>_FILE variable (e.g. JWT_SECRET_KEY_FILE), as mounted by Docker or
+// Kubernetes secrets.
 package config
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
 )
 
+const (
+	EnvLocal      = "local"
+	EnvStaging    = "staging"
+	EnvProduction = "production"
+)
+
 var Empty = new(Config)
 
 type Config struct {
-	AppEnv       string `envconfig:"APP_ENV"`
-	Port         int    `envconfig:"PORT"`
-	SentryDSN    string `envconfig:"SENTRY_DSN"`
+	// AppEnv is local, staging or production
+	AppEnv       string `envconfig:"APP_ENV" default:"local"`
+	Port         int    `envconfig:"PORT" default:"5111"`
+	SentryDSN    string `envconfig:"SENTRY_DSN" secret:"true"`
 	AllowOrigins string `envconfig:"ALLOW_ORIGINS"`
-	JWTSecret    string `envconfig:"JWT_SECRET_KEY"`
+	JWTSecret    string `envconfig:"JWT_SECRET_KEY" secret:"true"`
+	AdminUserIDs string `envconfig:"ADMIN_USER_IDS"`
+	ExportDir    string `envconfig:"EXPORT_DIR" default:"storage/exports"`
+	KeyFile      string `envconfig:"ENCRYPTION_KEY_FILE"`
+	// DefaultLanguage is used when Accept-Language names no supported language
+	DefaultLanguage string `envconfig:"DEFAULT_LANGUAGE" default:"vi"`
+	// HealthReportToken unlocks the detailed /healthz/ready report; empty disables it
+	HealthReportToken  string        `envconfig:"HEALTH_REPORT_TOKEN" secret:"true"`
+	HealthCheckTimeout time.Duration `envconfig:"HEALTH_CHECK_TIMEOUT" default:"2s"`
+	// MetricsToken is the bearer token Prometheus scrapes /metrics of the API
+	// with; empty disables /metrics on the API port
+	MetricsToken string `envconfig:"METRICS_TOKEN" secret:"true"`
+	// AccountNumberPrefix is the 2-digit bank code every account number starts with
+	AccountNumberPrefix string `envconfig:"ACCOUNT_NUMBER_PREFIX" default:"97"`
+
+	HTTP struct {
+		ReadTimeout       time.Duration `envconfig:"HTTP_READ_TIMEOUT" default:"15s"`
+		ReadHeaderTimeout time.Duration `envconfig:"HTTP_READ_HEADER_TIMEOUT" default:"5s"`
+		WriteTimeout      time.Duration `envconfig:"HTTP_WRITE_TIMEOUT" default:"30s"`
+		IdleTimeout       time.Duration `envconfig:"HTTP_IDLE_TIMEOUT" default:"120s"`
+		// ShutdownTimeout bounds how long in-flight requests may drain on SIGTERM
+		ShutdownTimeout time.Duration `envconfig:"HTTP_SHUTDOWN_TIMEOUT" default:"20s"`
+		// ShutdownDelay is how long /healthz/ready fails on SIGTERM before the
+		// server stops accepting connections, for the load balancer to notice
+		ShutdownDelay time.Duration `envconfig:"HTTP_SHUTDOWN_DELAY" default:"5s"`
+		// TLS is served when both files are set
+		TLSCertFile string `envconfig:"TLS_CERT_FILE"`
+		TLSKeyFile  string `envconfig:"TLS_KEY_FILE"`
+		// TrustedProxies are the CIDR ranges of the proxies whose
+		// X-Forwarded-For is believed; when empty the client address is the
+		// peer of the connection and forwarding headers are ignored
+		TrustedProxies []string `envconfig:"HTTP_TRUSTED_PROXIES"`
+	}
+
+	RateLimit struct {
+		Enabled bool `envconfig:"RATE_LIMIT_ENABLED" default:"true"`
+		// Store is memory (single instance) or postgres (shared by every instance)
+		Store string `envconfig:"RATE_LIMIT_STORE" default:"memory"`
+		// every request, per client IP
+		IPPerMinute int `envconfig:"RATE_LIMIT_IP_PER_MINUTE" default:"300"`
+		IPBurst     int `envconfig:"RATE_LIMIT_IP_BURST" default:"100"`
+		// /api/auth (login, register), per client IP
+		AuthPerMinute int `envconfig:"RATE_LIMIT_AUTH_PER_MINUTE" default:"10"`
+		AuthBurst     int `envconfig:"RATE_LIMIT_AUTH_BURST" default:"5"`
+		// the rest of /api, per authenticated user
+		UserPerMinute int `envconfig:"RATE_LIMIT_USER_PER_MINUTE" default:"120"`
+		UserBurst     int `envconfig:"RATE_LIMIT_USER_BURST" default:"30"`
+	}
+
+	Calendar struct {
+		// HolidayFiles are the holiday sets, in addition to weekends, on which
+		// the bank is closed
+		HolidayFiles []string `envconfig:"HOLIDAY_FILES" default:"holidays/vn.json"`
+		// Convention moves maturity and payout dates off closed days:
+		// unadjusted, following or modified_following
+		Convention string `envconfig:"BUSINESS_DAY_CONVENTION" default:"modified_following"`
+	}
+
+	Worker struct {
+		// Interval is how often the worker looks for interest to accrue
+		Interval time.Duration `envconfig:"WORKER_INTERVAL" default:"1h"`
+		// Port serves the /metrics and /healthz probes of the worker
+		Port int `envconfig:"WORKER_PORT" default:"5112"`
+	}
+
+	Tracing struct {
+		// Exporter is none, stdout (local debugging) or otlp
+		Exporter     string  `envconfig:"TRACING_EXPORTER" default:"none"`
+		ServiceName  string  `envconfig:"OTEL_SERVICE_NAME" default:"e-wallet"`
+		OTLPEndpoint string  `envconfig:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+		OTLPInsecure bool    `envconfig:"OTEL_EXPORTER_OTLP_INSECURE"`
+		SampleRatio  float64 `envconfig:"TRACING_SAMPLE_RATIO" default:"1"`
+	}
 
 	DB struct {
 		Name      string `envconfig:"DB_NAME"`
 		Host      string `envconfig:"DB_HOST"`
-		Port      int    `envconfig:"DB_PORT"`
+		Port      int    `envconfig:"DB_PORT" default:"5432"`
 		User      string `envconfig:"DB_USER"`
-		Pass      string `envconfig:"DB_PASS"`
+		Pass      string `envconfig:"DB_PASS" secret:"true"`
 		EnableSSL bool   `envconfig:"ENABLE_SSL"`
 	}
 }
 
+// LoadConfig loads the configuration and validates it.
 func LoadConfig() (*Config, error) {
-	// load default .env file, ignore the error
-	_ = godotenv.Load()
+	cfg, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Load loads the configuration without validating it, so that an invalid
+// configuration can still be inspected.
+func Load() (*Config, error) {
+	if err := loadEnvFiles(); err != nil {
+		return nil, err
+	}
 
 	cfg := new(Config)
 	err := envconfig.Process("", cfg)
 	if err != nil {
 		return nil, fmt.Errorf("load config error: %v", err)
 	}
+	if err := cfg.readSecretFiles(); err != nil {
+		return nil, err
+	}
 
 	return cfg, nil
 }
+
+// loadEnvFiles loads the APP_ENV overlay then .env. godotenv never overrides a
+// variable that is already set, so the overlay wins over .env and the process
+// environment wins over both. Missing files are ignored.
+func loadEnvFiles() error {
+	env := os.Getenv("APP_ENV")
+	if env == "" {
+		base, err := godotenv.Read()
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("load .env: %w", err)
+		}
+		env = base["APP_ENV"]
+	}
+	if env == "" {
+		env = EnvLocal
+	}
+
+	for _, file := range []string{".env." + env, ".env"} {
+		if err := godotenv.Load(file); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("load %s: %w", file, err)
+		}
+	}
+	return nil
+}
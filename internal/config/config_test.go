@@ -0,0 +1,245 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unsetenv clears keys for the duration of the test, so that the values the
+// .env files load do not leak into other tests.
+func unsetenv(t *testing.T, keys ...string) {
+	for _, k := range keys {
+		t.Setenv(k, "")
+		require.NoError(t, os.Unsetenv(k))
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// loadValid loads a configuration that passes Validate in the local environment.
+func loadValid(t *testing.T) *Config {
+	t.Chdir(t.TempDir())
+	for _, f := range new(Config).fields() {
+		unsetenv(t, f.Name, f.Name+"_FILE")
+	}
+	t.Setenv("JWT_SECRET_KEY", "local-secret")
+	t.Setenv("ENCRYPTION_KEY_FILE", "keys.json")
+	t.Setenv("DB_HOST", "localhost")
+	t.Setenv("DB_NAME", "ewallet")
+	t.Setenv("DB_USER", "ewallet")
+
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	cfg := loadValid(t)
+
+	assert.Equal(t, EnvLocal, cfg.AppEnv)
+	assert.Equal(t, 5111, cfg.Port)
+	assert.Equal(t, 5432, cfg.DB.Port)
+	assert.Equal(t, 15*time.Second, cfg.HTTP.ReadTimeout)
+	assert.Equal(t, []string{"holidays/vn.json"}, cfg.Calendar.HolidayFiles)
+}
+
+func TestLoad_EnvironmentOverlay(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	unsetenv(t, "APP_ENV", "PORT", "DB_HOST", "DB_NAME")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte("APP_ENV=staging\nPORT=6000\nDB_HOST=db.local\nDB_NAME=ewallet\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env.staging"), []byte("DB_HOST=db.staging\n"), 0o600))
+	t.Setenv("DB_NAME", "from-env")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, EnvStaging, cfg.AppEnv)
+	assert.Equal(t, 6000, cfg.Port, "from .env")
+	assert.Equal(t, "db.staging", cfg.DB.Host, "the overlay wins over .env")
+	assert.Equal(t, "from-env", cfg.DB.Name, "the environment wins over both")
+}
+
+func TestLoad_SecretFiles(t *testing.T) {
+	t.Run("read from file", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		unsetenv(t, "JWT_SECRET_KEY", "DB_PASS")
+		t.Setenv("JWT_SECRET_KEY_FILE", writeFile(t, "jwt", "file-secret\n"))
+		t.Setenv("DB_PASS_FILE", writeFile(t, "db", "p@ss word"))
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, "file-secret", cfg.JWTSecret)
+		assert.Equal(t, "p@ss word", cfg.DB.Pass)
+	})
+
+	t.Run("both set", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		t.Setenv("JWT_SECRET_KEY", "env-secret")
+		t.Setenv("JWT_SECRET_KEY_FILE", writeFile(t, "jwt", "file-secret"))
+
+		_, err := Load()
+		assert.ErrorContains(t, err, "both JWT_SECRET_KEY and JWT_SECRET_KEY_FILE are set")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		unsetenv(t, "JWT_SECRET_KEY")
+		t.Setenv("JWT_SECRET_KEY_FILE", filepath.Join(t.TempDir(), "missing"))
+
+		_, err := Load()
+		assert.ErrorContains(t, err, "JWT_SECRET_KEY_FILE")
+	})
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(c *Config)
+		problems []string
+	}{
+		{
+			name:     "missing jwt secret",
+			modify:   func(c *Config) { c.JWTSecret = "" },
+			problems: []string{"JWT_SECRET_KEY is required"},
+		},
+		{
+			name:     "port zero",
+			modify:   func(c *Config) { c.Port = 0 },
+			problems: []string{"PORT must be between 1 and 65535, got 0"},
+		},
+		{
+			name:     "worker port out of range",
+			modify:   func(c *Config) { c.Worker.Port = 70000 },
+			problems: []string{"WORKER_PORT must be between 1 and 65535, got 70000"},
+		},
+		{
+			name:     "unknown environment",
+			modify:   func(c *Config) { c.AppEnv = "prod" },
+			problems: []string{`APP_ENV must be one of local, staging, production, got "prod"`},
+		},
+		{
+			name:     "half tls",
+			modify:   func(c *Config) { c.HTTP.TLSCertFile = "cert.pem" },
+			problems: []string{"TLS_CERT_FILE and TLS_KEY_FILE must be set together"},
+		},
+		{
+			name:     "zero timeout",
+			modify:   func(c *Config) { c.HTTP.WriteTimeout = 0 },
+			problems: []string{"HTTP_WRITE_TIMEOUT must be a positive duration, got 0s"},
+		},
+		{
+			name:     "negative shutdown delay",
+			modify:   func(c *Config) { c.HTTP.ShutdownDelay = -time.Second },
+			problems: []string{"HTTP_SHUTDOWN_DELAY must not be negative, got -1s"},
+		},
+		{
+			name:     "unknown rate limit store",
+			modify:   func(c *Config) { c.RateLimit.Store = "redis" },
+			problems: []string{`RATE_LIMIT_STORE must be one of memory, postgres, got "redis"`},
+		},
+		{
+			name: "rate limit store ignored when disabled",
+			modify: func(c *Config) {
+				c.RateLimit.Enabled = false
+				c.RateLimit.Store = "redis"
+			},
+		},
+		{
+			name:     "unsupported language",
+			modify:   func(c *Config) { c.DefaultLanguage = "fr" },
+			problems: []string{`DEFAULT_LANGUAGE "fr" is not a supported language`},
+		},
+		{
+			name:     "account number prefix not 2 digits",
+			modify:   func(c *Config) { c.AccountNumberPrefix = "9A" },
+			problems: []string{`ACCOUNT_NUMBER_PREFIX must be 2 digits, got "9A"`},
+		},
+		{
+			name:     "trusted proxy not a CIDR range",
+			modify:   func(c *Config) { c.HTTP.TrustedProxies = []string{"10.0.0.0/8", "10.0.0.1"} },
+			problems: []string{`HTTP_TRUSTED_PROXIES must list CIDR ranges, got "10.0.0.1"`},
+		},
+		{
+			name:     "unknown business day convention",
+			modify:   func(c *Config) { c.Calendar.Convention = "preceding" },
+			problems: []string{`BUSINESS_DAY_CONVENTION must be one of unadjusted, following, modified_following, got "preceding"`},
+		},
+		{
+			name:     "short secret outside local",
+			modify:   func(c *Config) { c.AppEnv = EnvStaging },
+			problems: []string{"JWT_SECRET_KEY must be at least 32 characters outside local"},
+		},
+		{
+			name: "production rules",
+			modify: func(c *Config) {
+				c.AppEnv = EnvProduction
+				c.JWTSecret = "0123456789abcdef0123456789abcdef"
+				c.AllowOrigins = "*"
+			},
+			problems: []string{
+				"DB_PASS is required",
+				"ENABLE_SSL must be true in production",
+				"ALLOW_ORIGINS must list the allowed origins in production, not *",
+			},
+		},
+		{
+			name: "every problem is reported",
+			modify: func(c *Config) {
+				c.DB.Host = ""
+				c.DB.Port = 70000
+				c.Tracing.SampleRatio = 2
+			},
+			problems: []string{
+				"TRACING_SAMPLE_RATIO must be between 0 and 1, got 2",
+				"DB_HOST is required",
+				"DB_PORT must be between 1 and 65535, got 70000",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := loadValid(t)
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if len(tt.problems) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var invalid *ValidationError
+			require.ErrorAs(t, err, &invalid)
+			assert.Equal(t, tt.problems, invalid.Problems)
+		})
+	}
+}
+
+func TestConfig_Print(t *testing.T) {
+	cfg := loadValid(t)
+	cfg.DB.Pass = "db-password"
+
+	var redacted bytes.Buffer
+	require.NoError(t, cfg.Print(&redacted, true))
+	assert.Contains(t, redacted.String(), "JWT_SECRET_KEY=[REDACTED]\n")
+	assert.Contains(t, redacted.String(), "DB_PASS=[REDACTED]\n")
+	assert.Contains(t, redacted.String(), "SENTRY_DSN=\n", "unset secrets stay visibly unset")
+	assert.Contains(t, redacted.String(), "DB_HOST=localhost\n")
+	assert.Contains(t, redacted.String(), "HTTP_READ_TIMEOUT=15s\n")
+	assert.NotContains(t, redacted.String(), "local-secret")
+	assert.NotContains(t, redacted.String(), "db-password")
+
+	var plain bytes.Buffer
+	require.NoError(t, cfg.Print(&plain, false))
+	assert.Contains(t, plain.String(), "JWT_SECRET_KEY=local-secret\n")
+}
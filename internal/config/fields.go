@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+
+	"e-wallet/pkg/logger"
+)
+
+// field is a setting of Config together with the variable it is read from.
+type field struct {
+	Name   string
+	Secret bool
+	Value  reflect.Value
+}
+
+// fields lists the settings of c in declaration order.
+func (c *Config) fields() []field {
+	var out []field
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			name := sf.Tag.Get("envconfig")
+			if name == "" && sf.Type.Kind() == reflect.Struct {
+				walk(v.Field(i))
+				continue
+			}
+			if name == "" || !sf.IsExported() {
+				continue
+			}
+			out = append(out, field{Name: name, Secret: sf.Tag.Get("secret") == "true", Value: v.Field(i)})
+		}
+	}
+	walk(reflect.ValueOf(c).Elem())
+	return out
+}
+
+// Print writes the effective configuration to w as NAME=value lines. When
+// redacted is set, secrets that are set are replaced with [REDACTED].
+func (c *Config) Print(w io.Writer, redacted bool) error {
+	for _, f := range c.fields() {
+		value := fmt.Sprint(f.Value.Interface())
+		if redacted && f.Secret && !f.Value.IsZero() {
+			value = logger.Redacted
+		}
+		if _, err := fmt.Fprintf(w, "%s=%s\n", f.Name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
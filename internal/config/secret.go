@@ -0,0 +1,34 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// readSecretFiles fills every secret from the file named by its <NAME>_FILE
+// variable. Setting both the variable and its _FILE variant is an error, as
+// it is unclear which one is meant.
+func (c *Config) readSecretFiles() error {
+	for _, f := range c.fields() {
+		if !f.Secret || f.Value.Kind() != reflect.String {
+			continue
+		}
+		path := os.Getenv(f.Name + "_FILE")
+		if path == "" {
+			continue
+		}
+		if os.Getenv(f.Name) != "" {
+			return fmt.Errorf("load config error: both %s and %s_FILE are set", f.Name, f.Name)
+		}
+
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("load config error: %s_FILE: %w", f.Name, err)
+		}
+		// editors and `echo` leave a trailing newline that is not part of the secret
+		f.Value.SetString(strings.TrimRight(string(raw), "\r\n"))
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"e-wallet/internal/domain/calendar"
+	"e-wallet/internal/i18n"
+	"e-wallet/pkg/tracing"
+)
+
+// minSecretLength is the shortest JWT secret accepted outside local, 256 bits
+// as recommended for HS256.
+const minSecretLength = 32
+
+var accountNumberPrefixRegex = regexp.MustCompile(`^[0-9]{2}$`)
+
+// ValidationError lists every invalid setting, so that they can all be fixed
+// before the next start.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid config:\n  " + strings.Join(e.Problems, "\n  ")
+}
+
+// Validate reports the settings the service cannot start with. Staging and
+// production are held to stricter rules than local.
+func (c *Config) Validate() error {
+	v := new(validator)
+
+	v.oneOf("APP_ENV", c.AppEnv, EnvLocal, EnvStaging, EnvProduction)
+	v.port("PORT", c.Port)
+	v.required("JWT_SECRET_KEY", c.JWTSecret)
+	if (c.AppEnv == EnvStaging || c.AppEnv == EnvProduction) && c.JWTSecret != "" && len(c.JWTSecret) < minSecretLength {
+		v.addf("JWT_SECRET_KEY must be at least %d characters outside %s", minSecretLength, EnvLocal)
+	}
+	v.required("ENCRYPTION_KEY_FILE", c.KeyFile)
+	v.required("EXPORT_DIR", c.ExportDir)
+	if _, ok := i18n.Parse(c.DefaultLanguage); !ok {
+		v.addf("DEFAULT_LANGUAGE %q is not a supported language", c.DefaultLanguage)
+	}
+	v.positive("HEALTH_CHECK_TIMEOUT", c.HealthCheckTimeout)
+	if !accountNumberPrefixRegex.MatchString(c.AccountNumberPrefix) {
+		v.addf("ACCOUNT_NUMBER_PREFIX must be 2 digits, got %q", c.AccountNumberPrefix)
+	}
+
+	v.positive("HTTP_READ_TIMEOUT", c.HTTP.ReadTimeout)
+	v.positive("HTTP_READ_HEADER_TIMEOUT", c.HTTP.ReadHeaderTimeout)
+	v.positive("HTTP_WRITE_TIMEOUT", c.HTTP.WriteTimeout)
+	v.positive("HTTP_IDLE_TIMEOUT", c.HTTP.IdleTimeout)
+	v.positive("HTTP_SHUTDOWN_TIMEOUT", c.HTTP.ShutdownTimeout)
+	if c.HTTP.ShutdownDelay < 0 {
+		v.addf("HTTP_SHUTDOWN_DELAY must not be negative, got %s", c.HTTP.ShutdownDelay)
+	}
+	if (c.HTTP.TLSCertFile == "") != (c.HTTP.TLSKeyFile == "") {
+		v.addf("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	for _, cidr := range c.HTTP.TrustedProxies {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			v.addf("HTTP_TRUSTED_PROXIES must list CIDR ranges, got %q", cidr)
+		}
+	}
+
+	if c.RateLimit.Enabled {
+		v.oneOf("RATE_LIMIT_STORE", c.RateLimit.Store, "memory", "postgres")
+		v.atLeastOne("RATE_LIMIT_IP_PER_MINUTE", c.RateLimit.IPPerMinute)
+		v.atLeastOne("RATE_LIMIT_IP_BURST", c.RateLimit.IPBurst)
+		v.atLeastOne("RATE_LIMIT_AUTH_PER_MINUTE", c.RateLimit.AuthPerMinute)
+		v.atLeastOne("RATE_LIMIT_AUTH_BURST", c.RateLimit.AuthBurst)
+		v.atLeastOne("RATE_LIMIT_USER_PER_MINUTE", c.RateLimit.UserPerMinute)
+		v.atLeastOne("RATE_LIMIT_USER_BURST", c.RateLimit.UserBurst)
+	}
+
+	conventions := make([]string, 0, len(calendar.Conventions))
+	for _, c := range calendar.Conventions {
+		conventions = append(conventions, string(c))
+	}
+	v.oneOf("BUSINESS_DAY_CONVENTION", c.Calendar.Convention, conventions...)
+	v.positive("WORKER_INTERVAL", c.Worker.Interval)
+	v.port("WORKER_PORT", c.Worker.Port)
+
+	v.oneOf("TRACING_EXPORTER", c.Tracing.Exporter, tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP)
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		v.addf("TRACING_SAMPLE_RATIO must be between 0 and 1, got %v", c.Tracing.SampleRatio)
+	}
+
+	v.required("DB_HOST", c.DB.Host)
+	v.required("DB_NAME", c.DB.Name)
+	v.required("DB_USER", c.DB.User)
+	v.port("DB_PORT", c.DB.Port)
+
+	if c.AppEnv == EnvProduction {
+		v.required("DB_PASS", c.DB.Pass)
+		if !c.DB.EnableSSL {
+			v.addf("ENABLE_SSL must be true in %s", EnvProduction)
+		}
+		if strings.Contains(c.AllowOrigins, "*") {
+			v.addf("ALLOW_ORIGINS must list the allowed origins in %s, not *", EnvProduction)
+		}
+		if c.Tracing.Exporter == tracing.ExporterStdout {
+			v.addf("TRACING_EXPORTER must not be %s in %s", tracing.ExporterStdout, EnvProduction)
+		}
+	}
+
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
+
+type validator struct {
+	problems []string
+}
+
+func (v *validator) addf(format string, args ...any) {
+	v.problems = append(v.problems, fmt.Sprintf(format, args...))
+}
+
+func (v *validator) required(name, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.addf("%s is required", name)
+	}
+}
+
+func (v *validator) oneOf(name, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.addf("%s must be one of %s, got %q", name, strings.Join(allowed, ", "), value)
+}
+
+func (v *validator) port(name string, value int) {
+	if value < 1 || value > 65535 {
+		v.addf("%s must be between 1 and 65535, got %d", name, value)
+	}
+}
+
+func (v *validator) positive(name string, value time.Duration) {
+	if value <= 0 {
+		v.addf("%s must be a positive duration, got %s", name, value)
+	}
+}
+
+func (v *validator) atLeastOne(name string, value int) {
+	if value < 1 {
+		v.addf("%s must be at least 1, got %d", name, value)
+	}
+}
@@ -4,6 +4,12 @@ import (
 	"time"
 )
 
+const (
+	AccountTypePayment         = "PAYMENT"
+	AccountTypeFixedSavings    = "FIXED_SAVINGS"
+	AccountTypeFlexibleSavings = "FLEXIBLE_SAVINGS"
+)
+
 type Account struct {
 	ID            string
 	UserID        string
@@ -18,7 +24,8 @@ type Account struct {
 type CreatePaymentAccountRequest struct{}
 
 type CreateFixedSavingsAccountRequest struct {
-	TermCode string `json:"term_code" validate:"required,oneof=1 3 6 8 12"`
+	TermCode            string `json:"term_code" validate:"required,oneof=1 3 6 8 12"`
+	MaturityInstruction string `json:"maturity_instruction" validate:"omitempty,oneof=PAYOUT ROLLOVER_PRINCIPAL ROLLOVER_PRINCIPAL_AND_INTEREST"`
 }
 
 type CreateFlexibleSavingsAccountRequest struct{}
@@ -42,6 +49,10 @@ type SavingsAccountDetail struct {
 	StartDate             time.Time
 	MaturityDate          *time.Time
 	LastInterestCalcDate  *time.Time
+	MaturityInstruction   string
+	// InterestCarry is the interest accrued but not credited yet, the
+	// Carry of the Accrual of flexible savings
+	InterestCarry int64
 }
 
 type SavingsAccountDetailResponse struct {
@@ -52,6 +63,7 @@ type SavingsAccountDetailResponse struct {
 	StartDate             time.Time  `json:"start_date"`
 	MaturityDate          *time.Time `json:"maturity_date,omitempty"`
 	LastInterestCalcDate  *time.Time `json:"last_interest_calc_date,omitempty"`
+	MaturityInstruction   string     `json:"maturity_instruction,omitempty"`
 }
 
 type ListAccountsResponse struct {
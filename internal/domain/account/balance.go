@@ -0,0 +1,38 @@
+package account
+
+// Credit adds amount to the balance of the account.
+func (a *Account) Credit(amount Amount) error {
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+	a.Balance = (AmountFromFloat(a.Balance) + amount).Float64()
+	return nil
+}
+
+// Debit takes amount from the balance of the account, which can't go below
+// zero.
+func (a *Account) Debit(amount Amount) error {
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+	balance := AmountFromFloat(a.Balance)
+	if balance < amount {
+		return ErrInsufficientFunds.With("account_number", a.AccountNumber)
+	}
+	a.Balance = (balance - amount).Float64()
+	return nil
+}
+
+// Transfer moves amount from one account to another. Either both balances
+// change or neither does.
+func Transfer(from, to *Account, amount Amount) error {
+	if from.ID == to.ID {
+		return ErrSameAccountTransfer
+	}
+	if err := from.Debit(amount); err != nil {
+		return err
+	}
+	// can't fail: the amount was checked by Debit
+	_ = to.Credit(amount)
+	return nil
+}
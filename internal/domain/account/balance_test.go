@@ -0,0 +1,89 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransfer(t *testing.T) {
+	newAccounts := func() (*Account, *Account) {
+		return &Account{ID: "a", AccountNumber: "971045182377", Balance: 100.10},
+			&Account{ID: "b", AccountNumber: "973000000016", Balance: 0.20}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		from, to := newAccounts()
+		require.NoError(t, Transfer(from, to, 10))
+		// exactly 100.00 and 0.30, not 100.00000000000001 or 0.30000000000000004
+		assert.Equal(t, 100.00, from.Balance)
+		assert.Equal(t, 0.30, to.Balance)
+	})
+
+	t.Run("whole balance", func(t *testing.T) {
+		from, to := newAccounts()
+		require.NoError(t, Transfer(from, to, 10010))
+		assert.Equal(t, 0.0, from.Balance)
+		assert.Equal(t, 100.30, to.Balance)
+	})
+
+	t.Run("insufficient funds", func(t *testing.T) {
+		from, to := newAccounts()
+		err := Transfer(from, to, 10011)
+		assert.ErrorIs(t, err, ErrInsufficientFunds)
+		assert.Equal(t, 100.10, from.Balance)
+		assert.Equal(t, 0.20, to.Balance)
+	})
+
+	t.Run("invalid amount", func(t *testing.T) {
+		from, to := newAccounts()
+		assert.ErrorIs(t, Transfer(from, to, 0), ErrInvalidAmount)
+		assert.ErrorIs(t, Transfer(from, to, -1), ErrInvalidAmount)
+		assert.Equal(t, 100.10, from.Balance)
+	})
+
+	t.Run("same account", func(t *testing.T) {
+		from, _ := newAccounts()
+		assert.ErrorIs(t, Transfer(from, from, 1), ErrSameAccountTransfer)
+	})
+}
+
+// Whatever transfers are attempted between a few accounts, successful or not,
+// the total is what it was and no balance goes negative.
+func FuzzTransfer_ConservesMoney(f *testing.F) {
+	f.Add(int64(10_010), int64(20), int64(0), []byte{0, 1, 10, 1, 2, 255, 2, 0, 3})
+	f.Add(int64(1), int64(1), int64(1), []byte{0, 1, 1, 1, 0, 2})
+	f.Fuzz(func(t *testing.T, a, b, c int64, ops []byte) {
+		accounts := []*Account{
+			{ID: "a", Balance: fuzzPrincipal(a).Float64()},
+			{ID: "b", Balance: fuzzPrincipal(b).Float64()},
+			{ID: "c", Balance: fuzzPrincipal(c).Float64()},
+		}
+		total := func() Amount {
+			var sum Amount
+			for _, acc := range accounts {
+				sum += AmountFromFloat(acc.Balance)
+			}
+			return sum
+		}
+		want := total()
+
+		// each transfer is three bytes: from, to and the amount, a
+		// fraction of the balance so that some succeed
+		for i := 0; i+2 < len(ops); i += 3 {
+			from, to := accounts[int(ops[i])%len(accounts)], accounts[int(ops[i+1])%len(accounts)]
+			amount := AmountFromFloat(from.Balance)*Amount(ops[i+2])/128 - 1
+			_ = Transfer(from, to, amount)
+
+			if got := total(); got != want {
+				t.Fatalf("after transferring %d from %s to %s the total is %d, was %d", amount, from.ID, to.ID, got, want)
+			}
+			for _, acc := range accounts {
+				if acc.Balance < 0 {
+					t.Fatalf("balance of %s is %v", acc.ID, acc.Balance)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,25 @@
+package account
+
+import "e-wallet/internal/domain/apperror"
+
+var (
+	ErrAccountNotFound              = apperror.NotFound("account_not_found", "account not found")
+	ErrSavingsAccountDetailNotFound = apperror.NotFound("savings_account_detail_not_found", "savings account detail not found")
+	ErrProfileNotCompleted          = apperror.PreconditionFailed("profile_not_completed", "user profile must be completed before creating accounts")
+	ErrPaymentAccountLimit          = apperror.LimitExceeded("payment_account_limit_exceeded", "user can have at most 1 payment account")
+	ErrSavingsAccountLimit          = apperror.LimitExceeded("savings_account_limit_exceeded", "user can have at most 5 savings accounts")
+	ErrInvalidTermCode              = apperror.Validation(apperror.FieldError{Field: "term_code", Code: "oneof", Param: "1 3 6 8 12", Message: "invalid term code: {term_code}"})
+	ErrInvalidAccountNumber         = apperror.Validation(apperror.FieldError{Field: "account_number", Code: "account_number", Message: "invalid account number: {account_number}"})
+	ErrAccountNumberUnavailable     = apperror.Conflict("account_number_unavailable", "no free account number was found, please retry")
+	ErrInvalidAmount                = apperror.Validation(apperror.FieldError{Field: "amount", Code: "gt", Param: "0", Message: "amount must be greater than 0"})
+	ErrInsufficientFunds            = apperror.PreconditionFailed("insufficient_funds", "the balance of account {account_number} is too low")
+	ErrSameAccountTransfer          = apperror.Invalid("same_account_transfer", "cannot transfer to the same account")
+	ErrInvalidMaturityInstruction   = apperror.Validation(apperror.FieldError{Field: "maturity_instruction", Code: "oneof", Param: "PAYOUT ROLLOVER_PRINCIPAL ROLLOVER_PRINCIPAL_AND_INTEREST", Message: "invalid maturity instruction: {maturity_instruction}"})
+	ErrNotFixedSavings              = apperror.Invalid("not_fixed_savings", "account {account_number} is not a fixed savings account")
+	ErrAccountNotActive             = apperror.PreconditionFailed("account_not_active", "account {account_number} is not active")
+	ErrAccountMatured               = apperror.PreconditionFailed("account_matured", "account {account_number} has reached its maturity date")
+	ErrPaymentAccountRequired       = apperror.PreconditionFailed("payment_account_required", "a payment account is required to move money in or out of savings")
+	ErrNotFlexibleSavings           = apperror.Invalid("not_flexible_savings", "account {account_number} is not a flexible savings account")
+	ErrDepositBelowMinimum          = apperror.Validation(apperror.FieldError{Field: "amount", Code: "min", Param: MinFlexibleSavingsDeposit.String(), Message: "amount must be at least " + MinFlexibleSavingsDeposit.String()})
+	ErrWithdrawalBelowMinimum       = apperror.Validation(apperror.FieldError{Field: "amount", Code: "min", Param: MinFlexibleSavingsWithdrawal.String(), Message: "amount must be at least " + MinFlexibleSavingsWithdrawal.String()})
+)
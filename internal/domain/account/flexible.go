@@ -0,0 +1,29 @@
+package account
+
+import "time"
+
+// The smallest amounts moved in or out of flexible savings at once.
+const (
+	MinFlexibleSavingsDeposit    Amount = 10_000 * MinorUnits
+	MinFlexibleSavingsWithdrawal Amount = 10_000 * MinorUnits
+)
+
+// EndOfDayBalances returns the balance at the end of each of days days from
+// the date of from, working back from balance, the balance of now, through
+// the transactions made since then: a transaction made after a day ended is
+// taken out of the balance of that day. Days end at midnight in the location
+// of from.
+func EndOfDayBalances(balance Amount, from time.Time, days int, since []*Transaction) []Amount {
+	balances := make([]Amount, days)
+	for day := range days {
+		end := time.Date(from.Year(), from.Month(), from.Day()+day+1, 0, 0, 0, 0, from.Location())
+		eod := balance
+		for _, t := range since {
+			if !t.TransactionDate.Before(end) {
+				eod -= t.BalanceChange()
+			}
+		}
+		balances[day] = eod
+	}
+	return balances
+}
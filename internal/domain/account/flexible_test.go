@@ -0,0 +1,47 @@
+package account
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransaction_BalanceChange(t *testing.T) {
+	tests := []struct {
+		transactionType string
+		want            Amount
+	}{
+		{TransactionTypeDeposit, 1050},
+		{TransactionTypeInterestCredit, 1050},
+		{TransactionTypeMaturityPayout, 1050},
+		{TransactionTypeWithdrawal, -1050},
+		{TransactionTypePaymentInitiation, -1050},
+		{TransactionTypeWithdrawalPenalty, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.transactionType, func(t *testing.T) {
+			tx := &Transaction{TransactionType: tt.transactionType, Amount: 10.5}
+			assert.Equal(t, tt.want, tx.BalanceChange())
+		})
+	}
+}
+
+func TestEndOfDayBalances(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	at := func(day, hour int) time.Time { return from.AddDate(0, 0, day).Add(time.Duration(hour) * time.Hour) }
+
+	// 1000 on March 1st, +500 on the 2nd, -300 and +100 on the 3rd, +200
+	// on the 5th, after the last day asked for
+	since := []*Transaction{
+		{TransactionType: TransactionTypeDeposit, Amount: 5, TransactionDate: at(1, 9)},
+		{TransactionType: TransactionTypeWithdrawal, Amount: 3, TransactionDate: at(2, 0)},
+		{TransactionType: TransactionTypeDeposit, Amount: 1, TransactionDate: at(2, 23)},
+		{TransactionType: TransactionTypeDeposit, Amount: 2, TransactionDate: at(4, 8)},
+	}
+	got := EndOfDayBalances(1500, from, 4, since)
+	assert.Equal(t, []Amount{1000, 1500, 1300, 1300}, got)
+
+	assert.Equal(t, []Amount{1500, 1500}, EndOfDayBalances(1500, from, 2, nil))
+	assert.Empty(t, EndOfDayBalances(1500, from, 0, since))
+}
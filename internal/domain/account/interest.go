@@ -0,0 +1,66 @@
+package account
+
+import (
+	"math/big"
+	"time"
+)
+
+// DayCountBasis is the number of days in a year of interest: interest is
+// computed actual/365, leap years included.
+const DayCountBasis = 365
+
+// interestDenominator turns principal × rate × days into minor units.
+var interestDenominator = big.NewInt(RateScale * DayCountBasis)
+
+// SimpleInterest returns the interest earned by principal at rate over days,
+// rounded down to the minor unit so that interest is never overpaid. Nothing
+// is earned on a balance that isn't positive.
+func SimpleInterest(principal Amount, rate Rate, days int) Amount {
+	interest, _ := divideInterest(principal, rate, days, 0)
+	return interest
+}
+
+// divideInterest computes (principal × rate × days + carry) / denominator in
+// 128 bits at least, as the product overflows int64 on large balances.
+func divideInterest(principal Amount, rate Rate, days int, carry int64) (Amount, int64) {
+	if principal <= 0 || rate <= 0 || days <= 0 {
+		return 0, carry
+	}
+	n := new(big.Int).Mul(big.NewInt(int64(principal)), big.NewInt(int64(rate)))
+	n.Mul(n, big.NewInt(int64(days)))
+	n.Add(n, big.NewInt(carry))
+	q, r := n.QuoRem(n, interestDenominator, new(big.Int))
+	return Amount(q.Int64()), r.Int64()
+}
+
+// Accrual accrues interest on a balance that changes from day to day, as the
+// balance of flexible savings does. It carries over the fraction of a minor
+// unit that rounding leaves out, so accruing day by day pays exactly what
+// accruing the same balances at once would.
+type Accrual struct {
+	Rate Rate
+	// Carry is the interest accrued but not paid yet, in
+	// 1/(RateScale × DayCountBasis) of a minor unit
+	Carry int64
+}
+
+// Accrue returns the interest earned by an end-of-day balance held for days.
+func (a *Accrual) Accrue(eodBalance Amount, days int) Amount {
+	interest, carry := divideInterest(eodBalance, a.Rate, days, a.Carry)
+	a.Carry = carry
+	return interest
+}
+
+// DaysBetween returns the number of calendar days from from to to, each read
+// as a date in its own location.
+func DaysBetween(from, to time.Time) int {
+	fromDate := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDate.Sub(fromDate).Hours() / 24)
+}
+
+// FixedTermInterest returns the interest paid at maturity on a term deposit
+// of principal at rate, opened on start.
+func FixedTermInterest(principal Amount, rate Rate, start, maturity time.Time) Amount {
+	return SimpleInterest(principal, rate, DaysBetween(start, maturity))
+}
@@ -0,0 +1,145 @@
+package account
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimpleInterest(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal Amount
+		rate      Rate
+		days      int
+		want      Amount
+	}{
+		{"a year at 7.2%", 1_000_000_000, 720, 365, 72_000_000},
+		{"a day at 0.8%", 1_000_000_000, 80, 1, 21_917}, // 21917.8 rounded down
+		{"a leap year is 366 days", 1_000_000_000, 720, 366, 72_197_260},
+		{"no balance", 0, 720, 365, 0},
+		{"negative balance", -1_000_000_000, 720, 365, 0},
+		{"no days", 1_000_000_000, 720, 0, 0},
+		{"below a minor unit", 100, 80, 1, 0},
+		// principal × rate × days overflows int64
+		{"largest balance", maxAmount, 9999, 3660, 10_026_394_520_547_935},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SimpleInterest(tt.principal, tt.rate, tt.days))
+		})
+	}
+}
+
+func TestDaysBetween(t *testing.T) {
+	ict := time.FixedZone("ICT", 7*60*60)
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     int
+	}{
+		{"same day", date(2025, 1, 1), date(2025, 1, 1), 0},
+		{"across a month", date(2025, 1, 31), date(2025, 2, 1), 1},
+		{"february of a leap year", date(2024, 2, 1), date(2024, 3, 1), 29},
+		{"a year", date(2025, 3, 1), date(2026, 3, 1), 365},
+		{"backwards", date(2025, 1, 2), date(2025, 1, 1), -1},
+		// 23:30 in Hanoi is the same day, although it is 16:30 UTC
+		{"each in its location", time.Date(2025, 1, 1, 23, 30, 0, 0, ict), date(2025, 1, 2), 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DaysBetween(tt.from, tt.to))
+		})
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// The fuzz targets below check invariants on arbitrary inputs; run one with
+// e.g. go test ./internal/domain/account -fuzz FuzzAccrual_DailyEqualsOnce.
+
+// fuzzPrincipal, fuzzRate and fuzzDays bring fuzzed integers in the range of
+// the database columns and of a term.
+func fuzzPrincipal(n int64) Amount {
+	if n < 0 {
+		n = -(n + 1)
+	}
+	return Amount(n % (maxAmount + 1))
+}
+
+func fuzzRate(n uint16) Rate { return Rate(n % RateScale) }
+
+func fuzzDays(n uint16) int { return int(n % 3661) }
+
+// Interest is the exact interest rounded down: it never pays more, and never
+// a whole minor unit less.
+func FuzzSimpleInterest_RoundsDown(f *testing.F) {
+	f.Add(int64(1_000_000_000), uint16(720), uint16(365))
+	f.Add(int64(100), uint16(80), uint16(1))
+	f.Add(int64(maxAmount), uint16(9999), uint16(3660))
+	f.Fuzz(func(t *testing.T, p int64, r, d uint16) {
+		principal, rate, days := fuzzPrincipal(p), fuzzRate(r), fuzzDays(d)
+		interest := SimpleInterest(principal, rate, days)
+
+		exact := new(big.Int).Mul(big.NewInt(int64(principal)), big.NewInt(int64(rate)))
+		exact.Mul(exact, big.NewInt(int64(days)))
+		paid := new(big.Int).Mul(big.NewInt(int64(interest)), interestDenominator)
+		if paid.Cmp(exact) > 0 {
+			t.Fatalf("SimpleInterest(%d, %d, %d) = %d pays more than %s/%s", principal, rate, days, interest, exact, interestDenominator)
+		}
+		if paid.Add(paid, interestDenominator).Cmp(exact) <= 0 {
+			t.Fatalf("SimpleInterest(%d, %d, %d) = %d pays a minor unit less than %s/%s", principal, rate, days, interest, exact, interestDenominator)
+		}
+	})
+}
+
+// Accruing a balance day by day for N days pays what accruing it once for N
+// days does, whatever the rounding of each day.
+func FuzzAccrual_DailyEqualsOnce(f *testing.F) {
+	f.Add(int64(1_000_000_000), uint16(80), uint16(365))
+	f.Add(int64(100), uint16(80), uint16(3660))
+	f.Add(int64(1), uint16(1), uint16(1))
+	f.Fuzz(func(t *testing.T, p int64, r, d uint16) {
+		principal, rate, days := fuzzPrincipal(p), fuzzRate(r), fuzzDays(d)
+
+		accrual := Accrual{Rate: rate}
+		var daily Amount
+		for range days {
+			daily += accrual.Accrue(principal, 1)
+		}
+		if once := SimpleInterest(principal, rate, days); daily != once {
+			t.Fatalf("%d at %d accrued daily for %d days is %d, at once %d", principal, rate, days, daily, once)
+		}
+		if accrual.Carry < 0 || accrual.Carry >= RateScale*DayCountBasis {
+			t.Fatalf("carry %d is not a fraction of a minor unit", accrual.Carry)
+		}
+	})
+}
+
+// Accruing balances that change every day pays the interest of their sum,
+// as if each day's balance had been accrued on its own without rounding.
+func FuzzAccrual_ChangingBalances(f *testing.F) {
+	f.Add(uint16(80), []byte{1, 200, 3, 0, 255, 17})
+	f.Add(uint16(9999), []byte{255, 255, 255, 255})
+	f.Fuzz(func(t *testing.T, r uint16, balances []byte) {
+		rate := fuzzRate(r)
+
+		accrual := Accrual{Rate: rate}
+		var paid Amount
+		exact := new(big.Int)
+		for i, b := range balances {
+			// spread the balances over several orders of magnitude
+			balance := Amount(b) * Amount(1+i%7*1_000_003)
+			paid += accrual.Accrue(balance, 1)
+			exact.Add(exact, new(big.Int).Mul(big.NewInt(int64(balance)), big.NewInt(int64(rate))))
+		}
+		want := exact.Quo(exact, interestDenominator).Int64()
+		if int64(paid) != want {
+			t.Fatalf("accrued %d, want %d", paid, want)
+		}
+	})
+}
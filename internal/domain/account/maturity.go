@@ -0,0 +1,77 @@
+package account
+
+import (
+	"slices"
+	"time"
+)
+
+// What is done with a fixed savings account when its term matures.
+const (
+	// MaturityInstructionPayout pays the principal and the interest to the
+	// PAYMENT account of the user and closes the deposit
+	MaturityInstructionPayout = "PAYOUT"
+	// MaturityInstructionRolloverPrincipal pays the interest to the PAYMENT
+	// account and renews the deposit with its principal
+	MaturityInstructionRolloverPrincipal = "ROLLOVER_PRINCIPAL"
+	// MaturityInstructionRolloverPrincipalAndInterest renews the deposit with
+	// the principal and the interest
+	MaturityInstructionRolloverPrincipalAndInterest = "ROLLOVER_PRINCIPAL_AND_INTEREST"
+)
+
+// DefaultMaturityInstruction renews a deposit when the user didn't say
+// otherwise, as it needs no PAYMENT account.
+const DefaultMaturityInstruction = MaturityInstructionRolloverPrincipalAndInterest
+
+var MaturityInstructions = []string{
+	MaturityInstructionPayout,
+	MaturityInstructionRolloverPrincipal,
+	MaturityInstructionRolloverPrincipalAndInterest,
+}
+
+// ValidMaturityInstruction reports whether instruction is one of
+// MaturityInstructions.
+func ValidMaturityInstruction(instruction string) bool {
+	return slices.Contains(MaturityInstructions, instruction)
+}
+
+// PaysOut reports whether instruction pays money out at maturity, which needs
+// a PAYMENT account to pay it to.
+func PaysOut(instruction string) bool {
+	return instruction == MaturityInstructionPayout || instruction == MaturityInstructionRolloverPrincipal
+}
+
+// fixedSavingsRates are the annual interest rates of fixed savings, by term
+// in months.
+var fixedSavingsRates = map[int]float64{
+	1:  0.006, // 0.6%
+	3:  0.018, // 1.8%
+	6:  0.036, // 3.6%
+	8:  0.048, // 4.8%
+	12: 0.072, // 7.2%
+}
+
+// FixedSavingsRate returns the current annual interest rate of fixed savings
+// over termMonths, false when no such term is offered.
+func FixedSavingsRate(termMonths int) (float64, bool) {
+	rate, ok := fixedSavingsRates[termMonths]
+	return rate, ok
+}
+
+// RenewalCycle records a term of a fixed savings account that matured, and
+// what its maturity instruction did with it.
+type RenewalCycle struct {
+	ID        string
+	AccountID string
+	// Cycle counts the terms of the account, from 1
+	Cycle              int
+	StartDate          time.Time
+	MaturityDate       time.Time
+	Principal          float64
+	Interest           float64
+	AnnualInterestRate float64
+	Instruction        string
+	// RenewedPrincipal is the principal of the next term, 0 when the deposit
+	// was paid out
+	RenewedPrincipal float64
+	CreatedAt        time.Time
+}
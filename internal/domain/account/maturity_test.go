@@ -0,0 +1,37 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaturityInstruction(t *testing.T) {
+	tests := []struct {
+		instruction string
+		valid       bool
+		paysOut     bool
+	}{
+		{MaturityInstructionPayout, true, true},
+		{MaturityInstructionRolloverPrincipal, true, true},
+		{MaturityInstructionRolloverPrincipalAndInterest, true, false},
+		{"", false, false},
+		{"payout", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.instruction, func(t *testing.T) {
+			assert.Equal(t, tt.valid, ValidMaturityInstruction(tt.instruction))
+			assert.Equal(t, tt.paysOut, PaysOut(tt.instruction))
+		})
+	}
+	assert.True(t, ValidMaturityInstruction(DefaultMaturityInstruction))
+}
+
+func TestFixedSavingsRate(t *testing.T) {
+	rate, ok := FixedSavingsRate(12)
+	assert.True(t, ok)
+	assert.Equal(t, 0.072, rate)
+
+	_, ok = FixedSavingsRate(7)
+	assert.False(t, ok)
+}
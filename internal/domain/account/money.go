@@ -0,0 +1,45 @@
+package account
+
+import (
+	"math"
+	"strconv"
+)
+
+// Amount is an amount of money in minor units, 1/100 of the currency unit as
+// in the DECIMAL(15,2) columns. Money is computed on Amounts so that sums are
+// exact; balances are only converted from and to float64 at the edges.
+type Amount int64
+
+// MinorUnits is the number of minor units in one currency unit.
+const MinorUnits = 100
+
+// AmountFromFloat converts a balance to minor units, rounding half away from
+// zero to the nearest minor unit.
+func AmountFromFloat(f float64) Amount {
+	return Amount(math.Round(f * MinorUnits))
+}
+
+func (a Amount) Float64() float64 {
+	return float64(a) / MinorUnits
+}
+
+func (a Amount) String() string {
+	return strconv.FormatFloat(a.Float64(), 'f', 2, 64)
+}
+
+// Rate is an annual interest rate in 1/10000, as in the DECIMAL(5,4)
+// annual_interest_rate column: 7.2% is 720.
+type Rate int64
+
+// RateScale is the Rate of 100%.
+const RateScale = 10_000
+
+// RateFromFloat converts a rate such as 0.072 to a Rate, rounding to the
+// nearest 1/10000.
+func RateFromFloat(f float64) Rate {
+	return Rate(math.Round(f * RateScale))
+}
+
+func (r Rate) Float64() float64 {
+	return float64(r) / RateScale
+}
@@ -0,0 +1,53 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAmountFromFloat(t *testing.T) {
+	tests := []struct {
+		f    float64
+		want Amount
+	}{
+		{0, 0},
+		{1000.50, 100050},
+		// 0.1 + 0.2 is 0.30000000000000004 in float64
+		{0.1 + 0.2, 30},
+		{0.005, 1},
+		{-0.005, -1},
+		{0.0049, 0},
+		{9_999_999_999_999.99, 999_999_999_999_999},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, AmountFromFloat(tt.f), "%v", tt.f)
+	}
+}
+
+func TestAmount_String(t *testing.T) {
+	assert.Equal(t, "1000.50", Amount(100050).String())
+	assert.Equal(t, "-0.01", Amount(-1).String())
+}
+
+func TestRateFromFloat(t *testing.T) {
+	assert.Equal(t, Rate(720), RateFromFloat(0.072))
+	assert.Equal(t, Rate(60), RateFromFloat(0.006))
+	assert.InDelta(t, 0.008, Rate(80).Float64(), 1e-12)
+}
+
+// maxAmount is the largest balance of the DECIMAL(15,2) columns.
+const maxAmount = 999_999_999_999_999
+
+// Any balance the database can hold survives the trip through float64.
+func FuzzAmount_FloatRoundTrip(f *testing.F) {
+	for _, seed := range []int64{0, 1, -1, 30, 100050, maxAmount, -maxAmount} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, n int64) {
+		a := Amount(n % (maxAmount + 1))
+		if got := AmountFromFloat(a.Float64()); got != a {
+			t.Fatalf("AmountFromFloat(%v) = %d, want %d", a.Float64(), got, a)
+		}
+	})
+}
@@ -0,0 +1,115 @@
+package account
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// An account number is made of, in order:
+//
+//	bank prefix      2 digits, configured per deployment
+//	product segment  1 digit, see productSegments
+//	serial           8 random digits
+//	check digit      1 Luhn digit over the 11 digits before it
+//
+// e.g. 97 1 04518237 7.
+const (
+	NumberLength     = 12
+	BankPrefixLength = 2
+	serialDigits     = 8
+)
+
+var productSegments = map[string]byte{
+	AccountTypePayment:         '1',
+	AccountTypeFixedSavings:    '2',
+	AccountTypeFlexibleSavings: '3',
+}
+
+var serialRange = big.NewInt(100_000_000) // 10^serialDigits
+
+// NumberGenerator issues random account numbers of the bank. It does not
+// know which numbers are taken: callers retry with a new number when the
+// one they got collides.
+type NumberGenerator struct {
+	bankPrefix string
+	random     io.Reader
+}
+
+func NewNumberGenerator(bankPrefix string) (*NumberGenerator, error) {
+	if len(bankPrefix) != BankPrefixLength || !isDigits(bankPrefix) {
+		return nil, fmt.Errorf("bank prefix %q must be %d digits", bankPrefix, BankPrefixLength)
+	}
+	return &NumberGenerator{bankPrefix: bankPrefix, random: rand.Reader}, nil
+}
+
+// Generate returns a new account number for an account of the given type.
+func (g *NumberGenerator) Generate(accountType string) (string, error) {
+	segment, ok := productSegments[accountType]
+	if !ok {
+		return "", fmt.Errorf("no account number segment for account type %q", accountType)
+	}
+	serial, err := rand.Int(g.random, serialRange)
+	if err != nil {
+		return "", fmt.Errorf("generate account number: %w", err)
+	}
+	body := fmt.Sprintf("%s%c%0*d", g.bankPrefix, segment, serialDigits, serial)
+	return body + string(luhnCheckDigit(body)), nil
+}
+
+// ValidateNumber checks the structure of an account number: its length, its
+// product segment and its check digit. It catches most typos, including
+// every single wrong digit and most swapped neighbours, before a lookup.
+func ValidateNumber(number string) error {
+	if len(number) != NumberLength || !isDigits(number) {
+		return ErrInvalidAccountNumber.With("account_number", number)
+	}
+	if !knownSegment(number[BankPrefixLength]) {
+		return ErrInvalidAccountNumber.With("account_number", number)
+	}
+	body, check := number[:NumberLength-1], number[NumberLength-1]
+	if luhnCheckDigit(body) != check {
+		return ErrInvalidAccountNumber.With("account_number", number)
+	}
+	return nil
+}
+
+// luhnCheckDigit returns the digit that makes body followed by it pass the
+// Luhn check.
+func luhnCheckDigit(body string) byte {
+	sum := 0
+	// Doubling starts from the rightmost digit of body, as the check digit
+	// will be appended after it
+	double := true
+	for i := len(body) - 1; i >= 0; i-- {
+		d := int(body[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+func knownSegment(segment byte) bool {
+	for _, s := range productSegments {
+		if s == segment {
+			return true
+		}
+	}
+	return false
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
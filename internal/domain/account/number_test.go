@@ -0,0 +1,89 @@
+package account
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNumberGenerator_InvalidPrefix(t *testing.T) {
+	for _, prefix := range []string{"", "9", "970", "9A"} {
+		_, err := NewNumberGenerator(prefix)
+		assert.Error(t, err, "prefix %q", prefix)
+	}
+}
+
+func TestNumberGenerator_Generate(t *testing.T) {
+	g, err := NewNumberGenerator("97")
+	require.NoError(t, err)
+
+	tests := []struct {
+		accountType string
+		segment     string
+	}{
+		{AccountTypePayment, "1"},
+		{AccountTypeFixedSavings, "2"},
+		{AccountTypeFlexibleSavings, "3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.accountType, func(t *testing.T) {
+			number, err := g.Generate(tt.accountType)
+			require.NoError(t, err)
+
+			assert.Len(t, number, NumberLength)
+			assert.True(t, strings.HasPrefix(number, "97"+tt.segment), number)
+			assert.NoError(t, ValidateNumber(number))
+		})
+	}
+
+	_, err = g.Generate("CREDIT")
+	assert.Error(t, err)
+}
+
+func TestNumberGenerator_Generate_Deterministic(t *testing.T) {
+	// rand.Int reads big-endian bytes and rejects values out of range, so
+	// zeroes give serial 0
+	g, err := NewNumberGenerator("97")
+	require.NoError(t, err)
+	g.random = bytes.NewReader(make([]byte, 8))
+
+	number, err := g.Generate(AccountTypePayment)
+	require.NoError(t, err)
+	assert.Equal(t, "971000000002", number)
+}
+
+func TestValidateNumber(t *testing.T) {
+	tests := []struct {
+		name   string
+		number string
+		valid  bool
+	}{
+		{"valid", "971045182377", true},
+		{"wrong check digit", "971045182370", false},
+		{"single digit typo", "971045182477", false},
+		{"swapped neighbours", "971045128377", false},
+		{"too short", "97104518237", false},
+		{"too long", "9710451823770", false},
+		{"not digits", "97104518237A", false},
+		{"unknown product segment", "979045182370", false},
+		{"empty", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateNumber(tt.number)
+			if tt.valid {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidAccountNumber)
+		})
+	}
+}
+
+func TestLuhnCheckDigit(t *testing.T) {
+	// the classic example of the Luhn algorithm
+	assert.Equal(t, byte('3'), luhnCheckDigit("7992739871"))
+}
@@ -0,0 +1,57 @@
+package account
+
+import "time"
+
+const (
+	TransactionTypePaymentInitiation = "PAYMENT_INITIATION"
+	TransactionTypeInterestCredit    = "INTEREST_CREDIT"
+	TransactionTypeWithdrawal        = "WITHDRAWAL"
+	TransactionTypeWithdrawalPenalty = "WITHDRAWAL_PENALTY"
+	TransactionTypeMaturityPayout    = "MATURITY_PAYOUT"
+	TransactionTypeDeposit           = "DEPOSIT"
+)
+
+type Transaction struct {
+	ID              string
+	AccountID       string
+	TransactionType string
+	Amount          float64
+	TransactionDate time.Time
+	Description     string
+	IsPenalty       bool
+	CreatedAt       time.Time
+}
+
+// BalanceChange returns how much the transaction moved the balance of its
+// account: credits are positive and debits negative. A WITHDRAWAL_PENALTY
+// records interest forfeited and moves nothing.
+func (t *Transaction) BalanceChange() Amount {
+	amount := AmountFromFloat(t.Amount)
+	switch t.TransactionType {
+	case TransactionTypeInterestCredit, TransactionTypeDeposit, TransactionTypeMaturityPayout:
+		return amount
+	case TransactionTypePaymentInitiation, TransactionTypeWithdrawal:
+		return -amount
+	}
+	return 0
+}
+
+type FlexibleInterestRecord struct {
+	ID                  string
+	AccountID           string
+	CalculationDate     time.Time
+	EODBalance          float64
+	AnnualRateApplied   float64
+	DailyInterestAmount float64
+	IsPromotionalRate   bool
+	CreatedAt           time.Time
+}
+
+type FixedInterestRecord struct {
+	ID                  string
+	AccountID           string
+	CalculationPeriod   string
+	TotalInterestAmount float64
+	IsEarlyWithdrawal   bool
+	CreatedAt           time.Time
+}
@@ -0,0 +1,169 @@
+// Package apperror defines the typed errors returned by the domain and
+// application layers. Adapters translate a Kind into their own status codes
+// (HTTP, exit codes, ...) and expose Code to clients as a stable identifier.
+package apperror
+
+import (
+	"errors"
+	"strings"
+)
+
+type Kind string
+
+const (
+	KindValidation         Kind = "VALIDATION"
+	KindUnauthorized       Kind = "UNAUTHORIZED"
+	KindForbidden          Kind = "FORBIDDEN"
+	KindNotFound           Kind = "NOT_FOUND"
+	KindConflict           Kind = "CONFLICT"
+	KindPreconditionFailed Kind = "PRECONDITION_FAILED"
+	KindLimitExceeded      Kind = "LIMIT_EXCEEDED"
+	KindRateLimited        Kind = "RATE_LIMITED"
+	KindInternal           Kind = "INTERNAL"
+)
+
+// CodeValidationFailed is the code of errors built from field errors.
+const CodeValidationFailed = "validation_failed"
+
+// FieldError describes why a single input field was rejected. Param is the
+// rule's argument, e.g. the minimum for "min" or the allowed values for "oneof".
+type FieldError struct {
+	Field   string
+	Code    string
+	Param   string
+	Message string
+}
+
+// Error is a domain error with a machine-readable code. Two errors are equal
+// for errors.Is when their codes (and first fields) match, so a sentinel can
+// be returned with parameters via With and still be recognised by callers.
+//
+// Message is English and may contain {name} placeholders filled by With;
+// Code and Params let adapters render the message in other languages.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Params  map[string]string
+	Fields  []FieldError
+	Err     error
+}
+
+func New(kind Kind, code string, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func NotFound(code string, message string) *Error {
+	return New(KindNotFound, code, message)
+}
+
+func Conflict(code string, message string) *Error {
+	return New(KindConflict, code, message)
+}
+
+func PreconditionFailed(code string, message string) *Error {
+	return New(KindPreconditionFailed, code, message)
+}
+
+func LimitExceeded(code string, message string) *Error {
+	return New(KindLimitExceeded, code, message)
+}
+
+func RateLimited(code string, message string) *Error {
+	return New(KindRateLimited, code, message)
+}
+
+func Unauthorized(code string, message string) *Error {
+	return New(KindUnauthorized, code, message)
+}
+
+func Forbidden(code string, message string) *Error {
+	return New(KindForbidden, code, message)
+}
+
+func Invalid(code string, message string) *Error {
+	return New(KindValidation, code, message)
+}
+
+// Validation builds a validation error from one or more rejected fields. The
+// message of the first field becomes the error message.
+func Validation(fields ...FieldError) *Error {
+	e := New(KindValidation, CodeValidationFailed, "validation failed")
+	if len(fields) > 0 {
+		e.Message = fields[0].Message
+	}
+	e.Fields = fields
+	return e
+}
+
+// FieldInvalid is a shorthand for a validation error on a single field.
+func FieldInvalid(field string, code string, message string) *Error {
+	return Validation(FieldError{Field: field, Code: code, Message: message})
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok || t.Code != e.Code {
+		return false
+	}
+	// Validation sentinels are told apart by their (first) field
+	if len(t.Fields) == 0 {
+		return true
+	}
+	return len(e.Fields) > 0 && e.Fields[0].Field == t.Fields[0].Field && e.Fields[0].Code == t.Fields[0].Code
+}
+
+// With returns a copy of the error with the {key} placeholder of its
+// messages replaced by value.
+func (e *Error) With(key string, value string) *Error {
+	cp := *e
+	cp.Params = make(map[string]string, len(e.Params)+1)
+	for k, v := range e.Params {
+		cp.Params[k] = v
+	}
+	cp.Params[key] = value
+
+	placeholder := "{" + key + "}"
+	cp.Message = strings.ReplaceAll(e.Message, placeholder, value)
+	cp.Fields = make([]FieldError, len(e.Fields))
+	for i, f := range e.Fields {
+		f.Message = strings.ReplaceAll(f.Message, placeholder, value)
+		cp.Fields[i] = f
+	}
+	return &cp
+}
+
+// Wrap returns a copy of the error that also carries the underlying cause.
+func (e *Error) Wrap(err error) *Error {
+	cp := *e
+	cp.Err = err
+	return &cp
+}
+
+// As returns the first *Error in err's chain.
+func As(err error) (*Error, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
+// KindOf returns the kind of err, or KindInternal for untyped errors.
+func KindOf(err error) Kind {
+	if e, ok := As(err); ok {
+		return e.Kind
+	}
+	return KindInternal
+}
@@ -0,0 +1,65 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError_Is(t *testing.T) {
+	errLimit := LimitExceeded("savings_account_limit_exceeded", "user can have at most 5 savings accounts")
+	errTermCode := FieldInvalid("term_code", "oneof", "invalid term code")
+
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{name: "same sentinel", err: errLimit, target: errLimit, want: true},
+		{name: "sentinel with params", err: errLimit.With("limit", "5"), target: errLimit, want: true},
+		{name: "wrapped with fmt", err: fmt.Errorf("create account: %w", errLimit), target: errLimit, want: true},
+		{name: "different code", err: NotFound("account_not_found", "account not found"), target: errLimit, want: false},
+		{name: "same field", err: errTermCode.With("term_code", "7"), target: errTermCode, want: true},
+		{name: "different field", err: FieldInvalid("team", "oneof", "invalid team"), target: errTermCode, want: false},
+		{name: "any validation error", err: errTermCode, target: Validation(), want: true},
+		{name: "untyped error", err: errors.New("boom"), target: errLimit, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, errors.Is(tt.err, tt.target))
+		})
+	}
+}
+
+func TestError_With(t *testing.T) {
+	sentinel := FieldInvalid("term_code", "oneof", "invalid term code: {term_code}")
+	err := sentinel.With("term_code", "7")
+
+	assert.Equal(t, "invalid term code: 7", err.Message)
+	assert.Equal(t, "invalid term code: 7", err.Fields[0].Message)
+	assert.Equal(t, map[string]string{"term_code": "7"}, err.Params)
+
+	// The sentinel itself is left untouched
+	assert.Equal(t, "invalid term code: {term_code}", sentinel.Message)
+	assert.Equal(t, "invalid term code: {term_code}", sentinel.Fields[0].Message)
+	assert.Nil(t, sentinel.Params)
+}
+
+func TestError_Wrap(t *testing.T) {
+	cause := errors.New("crypto/bcrypt: hashedPassword is not the hash of the given password")
+	err := Unauthorized("invalid_credentials", "invalid email or password").Wrap(cause)
+
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, "invalid email or password: "+cause.Error(), err.Error())
+	assert.Equal(t, "invalid email or password", err.Message)
+}
+
+func TestKindOf(t *testing.T) {
+	assert.Equal(t, KindNotFound, KindOf(fmt.Errorf("get: %w", NotFound("user_not_found", "user not found"))))
+	assert.Equal(t, KindValidation, KindOf(FieldInvalid("email", "required", "email is required")))
+	assert.Equal(t, KindInternal, KindOf(errors.New("db error")))
+}
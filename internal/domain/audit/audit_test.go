@@ -0,0 +1,88 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newChain(n int) []*Entry {
+	prevHash := GenesisHash
+	entries := make([]*Entry, 0, n)
+	for i := 0; i < n; i++ {
+		e := &Entry{
+			ID:         "entry-" + string(rune('a'+i)),
+			Action:     ActionLogin,
+			ActorID:    "user-123",
+			TargetType: TargetUser,
+			TargetID:   "user-123",
+			IP:         "127.0.0.1",
+			RequestID:  "req-123",
+			Metadata:   map[string]string{"email": "test@example.com"},
+			CreatedAt:  time.Date(2025, 10, 1, 0, 0, i, 123456789, time.UTC),
+		}
+		e.Seal(prevHash)
+		prevHash = e.Hash
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+func TestEntry_ComputeHash(t *testing.T) {
+	e := newChain(1)[0]
+
+	// Hash is stable across a microsecond round trip of the timestamp
+	roundTripped := *e
+	roundTripped.CreatedAt = e.CreatedAt.Truncate(time.Microsecond).In(time.FixedZone("ICT", 7*3600))
+	assert.Equal(t, e.Hash, roundTripped.ComputeHash(e.PrevHash))
+
+	// Hash changes when any field changes
+	tampered := *e
+	tampered.ActorID = "user-456"
+	assert.NotEqual(t, e.Hash, tampered.ComputeHash(e.PrevHash))
+}
+
+func TestVerifyChain(t *testing.T) {
+	tests := []struct {
+		name     string
+		tamper   func([]*Entry)
+		expected int
+	}{
+		{
+			name:     "success - intact chain",
+			tamper:   func([]*Entry) {},
+			expected: -1,
+		},
+		{
+			name: "error - modified field",
+			tamper: func(entries []*Entry) {
+				entries[2].IP = "10.0.0.1"
+			},
+			expected: 2,
+		},
+		{
+			name: "error - modified metadata",
+			tamper: func(entries []*Entry) {
+				entries[1].Metadata["email"] = "attacker@example.com"
+			},
+			expected: 1,
+		},
+		{
+			name: "error - deleted entry",
+			tamper: func(entries []*Entry) {
+				copy(entries[3:], entries[4:])
+				entries[len(entries)-1] = entries[len(entries)-2]
+			},
+			expected: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := newChain(5)
+			tt.tamper(entries)
+			assert.Equal(t, tt.expected, VerifyChain(entries, GenesisHash))
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package banklink
+
+import (
+	"time"
+)
+
+type BankLink struct {
+	ID           string
+	UserID       string
+	BankCode     string
+	AccountType  string
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int
+	CreatedAt    time.Time
+}
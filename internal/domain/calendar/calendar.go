@@ -0,0 +1,125 @@
+// Package calendar tells business days from weekends and public holidays, and
+// moves dates that fall on neither to a business day.
+//
+// Dates are read in the location of the time.Time they are given, and the
+// dates it returns keep the time of day and location of the one adjusted.
+package calendar
+
+import (
+	"fmt"
+	"time"
+)
+
+// Convention is how a date that isn't a business day is moved to one.
+type Convention string
+
+const (
+	// Unadjusted leaves the date as it is.
+	Unadjusted Convention = "unadjusted"
+	// Following moves the date to the next business day.
+	Following Convention = "following"
+	// ModifiedFollowing moves the date to the next business day, unless that
+	// is in the next month, in which case it moves to the previous one.
+	ModifiedFollowing Convention = "modified_following"
+)
+
+// Conventions are the conventions ParseConvention accepts.
+var Conventions = []Convention{Unadjusted, Following, ModifiedFollowing}
+
+func ParseConvention(s string) (Convention, error) {
+	for _, c := range Conventions {
+		if string(c) == s {
+			return c, nil
+		}
+	}
+	return "", fmt.Errorf("unknown business day convention %q", s)
+}
+
+// Holiday is a day off of a holiday set, e.g. one of the days of Tết.
+type Holiday struct {
+	Date time.Time
+	Name string
+}
+
+// Calendar is the business days of the bank: every day but Saturdays, Sundays
+// and its holidays.
+type Calendar struct {
+	convention Convention
+	holidays   map[time.Time]string
+}
+
+// New returns the calendar of holidays, which may come from several holiday
+// sets, adjusting dates by convention.
+func New(convention Convention, holidays ...Holiday) *Calendar {
+	c := &Calendar{convention: convention, holidays: make(map[time.Time]string, len(holidays))}
+	for _, h := range holidays {
+		c.holidays[dateOf(h.Date)] = h.Name
+	}
+	return c
+}
+
+// Holiday returns the name of the holiday on the date of t, if it is one.
+func (c *Calendar) Holiday(t time.Time) (string, bool) {
+	name, ok := c.holidays[dateOf(t)]
+	return name, ok
+}
+
+func (c *Calendar) IsBusinessDay(t time.Time) bool {
+	if wd := t.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return false
+	}
+	_, holiday := c.Holiday(t)
+	return !holiday
+}
+
+// Adjust moves t to a business day by the convention of the calendar.
+func (c *Calendar) Adjust(t time.Time) time.Time {
+	switch c.convention {
+	case Following:
+		return c.Following(t)
+	case ModifiedFollowing:
+		return c.ModifiedFollowing(t)
+	default:
+		return t
+	}
+}
+
+// Following returns the first business day on or after t.
+func (c *Calendar) Following(t time.Time) time.Time {
+	for !c.IsBusinessDay(t) {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t
+}
+
+// Preceding returns the last business day on or before t.
+func (c *Calendar) Preceding(t time.Time) time.Time {
+	for !c.IsBusinessDay(t) {
+		t = t.AddDate(0, 0, -1)
+	}
+	return t
+}
+
+// ModifiedFollowing returns the first business day on or after t when it is
+// in the month of t, and the last one before t otherwise, so that a date at
+// the end of a month isn't moved into the next.
+func (c *Calendar) ModifiedFollowing(t time.Time) time.Time {
+	if following := c.Following(t); following.Month() == t.Month() {
+		return following
+	}
+	return c.Preceding(t)
+}
+
+// AddMonths returns t moved by months, on the same day of the month or the
+// last day of the month when it is shorter: a month after January 31st is
+// the end of February, not March 3rd as time.Time.AddDate has it.
+func AddMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(t.Day(), lastDay)-1)
+}
+
+// dateOf returns the date of t, as a key of the holidays.
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
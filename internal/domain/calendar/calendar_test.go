@@ -0,0 +1,117 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// tet2026 is the Tết break of 2026, Saturday February 14th to Sunday the 22nd.
+func tet2026() []Holiday {
+	var holidays []Holiday
+	for d := date(2026, time.February, 14); !d.After(date(2026, time.February, 22)); d = d.AddDate(0, 0, 1) {
+		holidays = append(holidays, Holiday{Date: d, Name: "Tết Nguyên Đán"})
+	}
+	return append(holidays,
+		Holiday{Date: date(2026, time.April, 30), Name: "Reunification Day"},
+		Holiday{Date: date(2026, time.May, 1), Name: "Labour Day"},
+	)
+}
+
+func TestCalendar_IsBusinessDay(t *testing.T) {
+	c := New(Following, tet2026()...)
+	ict := time.FixedZone("ICT", 7*60*60)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want bool
+	}{
+		{"weekday", date(2026, time.February, 13), true},
+		{"saturday", date(2026, time.March, 7), false},
+		{"sunday", date(2026, time.March, 8), false},
+		{"tết", date(2026, time.February, 17), false},
+		{"after tết", date(2026, time.February, 23), true},
+		// 06:00 on the 30th in Hanoi is still the 29th in UTC
+		{"in its location", time.Date(2026, time.April, 30, 6, 0, 0, 0, ict), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, c.IsBusinessDay(tt.t))
+		})
+	}
+
+	name, ok := c.Holiday(date(2026, time.February, 17))
+	assert.True(t, ok)
+	assert.Equal(t, "Tết Nguyên Đán", name)
+}
+
+func TestCalendar_Adjust(t *testing.T) {
+	holidays := append(tet2026(), Holiday{Date: date(2025, time.August, 29), Name: "Bridge day"})
+	tests := []struct {
+		name       string
+		convention Convention
+		t          time.Time
+		want       time.Time
+	}{
+		{"business day", ModifiedFollowing, date(2026, time.March, 4), date(2026, time.March, 4)},
+		{"following over a weekend", Following, date(2026, time.March, 7), date(2026, time.March, 9)},
+		{"following over tết", Following, date(2026, time.February, 16), date(2026, time.February, 23)},
+		{"following over a holiday and a weekend", Following, date(2026, time.April, 30), date(2026, time.May, 4)},
+		// Saturday May 30th 2026, Monday is June 1st
+		{"following into the next month", Following, date(2026, time.May, 30), date(2026, time.June, 1)},
+		{"modified following stays in the month", ModifiedFollowing, date(2026, time.May, 30), date(2026, time.May, 29)},
+		{"modified following across a weekend", ModifiedFollowing, date(2026, time.March, 7), date(2026, time.March, 9)},
+		// Saturday August 30th 2025, and the Friday before is a holiday too
+		{"modified following back over a holiday", ModifiedFollowing, date(2025, time.August, 30), date(2025, time.August, 28)},
+		{"unadjusted", Unadjusted, date(2026, time.March, 7), date(2026, time.March, 7)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := New(tt.convention, holidays...).Adjust(tt.t)
+			assert.True(t, tt.want.Equal(got), "got %s, want %s", got.Format(time.DateOnly), tt.want.Format(time.DateOnly))
+		})
+	}
+}
+
+func TestCalendar_AdjustKeepsTimeOfDay(t *testing.T) {
+	ict := time.FixedZone("ICT", 7*60*60)
+	got := New(Following).Adjust(time.Date(2026, time.March, 7, 9, 30, 0, 0, ict))
+	assert.Equal(t, time.Date(2026, time.March, 9, 9, 30, 0, 0, ict), got)
+}
+
+func TestAddMonths(t *testing.T) {
+	tests := []struct {
+		name   string
+		t      time.Time
+		months int
+		want   time.Time
+	}{
+		{"same day", date(2025, time.January, 15), 6, date(2025, time.July, 15)},
+		{"end of january", date(2025, time.January, 31), 1, date(2025, time.February, 28)},
+		{"leap february", date(2024, time.January, 31), 1, date(2024, time.February, 29)},
+		{"end of a 31-day month", date(2025, time.August, 31), 3, date(2025, time.November, 30)},
+		{"across a year", date(2025, time.October, 31), 12, date(2026, time.October, 31)},
+		{"leap day to a common year", date(2024, time.February, 29), 12, date(2025, time.February, 28)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, AddMonths(tt.t, tt.months))
+		})
+	}
+}
+
+func TestParseConvention(t *testing.T) {
+	c, err := ParseConvention("modified_following")
+	require.NoError(t, err)
+	assert.Equal(t, ModifiedFollowing, c)
+
+	_, err = ParseConvention("preceding")
+	assert.Error(t, err)
+}
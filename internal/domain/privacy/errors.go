@@ -0,0 +1,10 @@
+package privacy
+
+import "e-wallet/internal/domain/apperror"
+
+var (
+	ErrExportNotFound     = apperror.NotFound("data_export_not_found", "data export not found")
+	ErrExportNotReady     = apperror.PreconditionFailed("data_export_not_ready", "data export is not ready")
+	ErrUserAlreadyDeleted = apperror.Conflict("user_data_already_deleted", "user data already deleted")
+	ErrNonZeroBalance     = apperror.PreconditionFailed("non_zero_balance", "account {account_number} still has a non-zero balance")
+)
@@ -0,0 +1,69 @@
+package privacy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"e-wallet/internal/domain/account"
+	"e-wallet/internal/domain/profile"
+	"e-wallet/internal/domain/user"
+	"e-wallet/pkg"
+)
+
+type ExportStatus string
+
+const (
+	ExportStatusPending    ExportStatus = "PENDING"
+	ExportStatusProcessing ExportStatus = "PROCESSING"
+	ExportStatusCompleted  ExportStatus = "COMPLETED"
+	ExportStatusFailed     ExportStatus = "FAILED"
+	// ExportStatusDeleted marks an export whose archive was removed when its
+	// user was deleted.
+	ExportStatusDeleted ExportStatus = "DELETED"
+)
+
+type DataExport struct {
+	ID          string
+	UserID      string
+	Status      ExportStatus
+	FileName    string
+	Error       string
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+func NewDataExport(userID string) *DataExport {
+	return &DataExport{
+		ID:     pkg.NewUUIDV7(),
+		UserID: userID,
+		Status: ExportStatusPending,
+	}
+}
+
+// UserData is everything we hold about a user, as assembled for an export.
+type UserData struct {
+	User             *user.User
+	Profile          *profile.Profile
+	Accounts         []*account.Account
+	SavingsDetails   []*account.SavingsAccountDetail
+	Transactions     []*account.Transaction
+	FlexibleInterest []*account.FlexibleInterestRecord
+	FixedInterest    []*account.FixedInterestRecord
+	RenewalCycles    []*account.RenewalCycle
+}
+
+// AnonymizedValue returns a stable, non-reversible placeholder for a unique
+// PII column. It fits the 20 character national_id and phone_number columns.
+func AnonymizedValue(userID string) string {
+	sum := sha256.Sum256([]byte(userID))
+	return "DEL-" + hex.EncodeToString(sum[:])[:16]
+}
+
+func AnonymizedUsername(userID string) string {
+	return "deleted-" + userID
+}
+
+func AnonymizedEmail(userID string) string {
+	return "deleted+" + userID + "@deleted.invalid"
+}
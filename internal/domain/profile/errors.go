@@ -0,0 +1,9 @@
+package profile
+
+import "e-wallet/internal/domain/apperror"
+
+var (
+	ErrProfileNotFound  = apperror.NotFound("profile_not_found", "profile not found")
+	ErrNationalIDTaken  = apperror.Conflict("national_id_taken", "national ID already registered for another user")
+	ErrPhoneNumberTaken = apperror.Conflict("phone_number_taken", "phone number already registered for another user")
+)
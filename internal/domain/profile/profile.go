@@ -5,24 +5,26 @@ import (
 )
 
 type Profile struct {
-	UserID       string
-	DisplayName  string
-	AvatarURL    *string
-	PhoneNumber  string
-	NationalID   string
-	BirthYear    int
-	Gender       string
-	Team         string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	UserID            string
+	DisplayName       string
+	AvatarURL         *string
+	PhoneNumber       string
+	NationalID        string
+	BirthYear         int
+	Gender            string
+	Team              string
+	PreferredLanguage string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
 
 type UpdateProfileRequest struct {
-	DisplayName string
-	AvatarURL   *string
-	PhoneNumber string
-	NationalID  string
-	BirthYear   int
-	Gender      string
-	Team        string
-}
\ No newline at end of file
+	DisplayName       string
+	AvatarURL         *string
+	PhoneNumber       string
+	NationalID        string
+	BirthYear         int
+	Gender            string
+	Team              string
+	PreferredLanguage string
+}
@@ -0,0 +1,25 @@
+package user
+
+import "e-wallet/internal/domain/apperror"
+
+var (
+	ErrUserNotFound       = apperror.NotFound("user_not_found", "user not found")
+	ErrUserAlreadyExists  = apperror.Conflict("user_already_exists", "username or email already registered")
+	ErrInvalidCredentials = apperror.Unauthorized("invalid_credentials", "invalid email or password")
+)
+
+// WrongPasswordError is the cause of ErrInvalidCredentials when the account
+// exists, so that the failed login can be attributed to it. Clients are only
+// ever told ErrInvalidCredentials.
+type WrongPasswordError struct {
+	UserID string
+	Err    error
+}
+
+func (e *WrongPasswordError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *WrongPasswordError) Unwrap() error {
+	return e.Err
+}
@@ -16,6 +16,7 @@ type User struct {
 	IsProfileCompleted  bool
 	CreatedAt           time.Time
 	UpdatedAt           time.Time
+	DeletedAt           *time.Time
 }
 
 type CreateUserRequest struct {
@@ -0,0 +1,154 @@
+// Package health runs the dependency checks behind the readiness probe.
+package health
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"e-wallet/internal/ports"
+)
+
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+// DefaultTimeout applies to checks registered without their own timeout.
+const DefaultTimeout = 2 * time.Second
+
+// CheckResult is the outcome of a single check.
+type CheckResult struct {
+	Name     string        `json:"name"`
+	Status   Status        `json:"status"`
+	Duration time.Duration `json:"-"`
+	Latency  string        `json:"latency"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// Report aggregates the results of every registered check. Status is down as
+// soon as one check fails.
+type Report struct {
+	Status    Status        `json:"status"`
+	CheckedAt time.Time     `json:"checked_at"`
+	Checks    []CheckResult `json:"checks"`
+}
+
+type registration struct {
+	checker ports.HealthChecker
+	timeout time.Duration
+}
+
+// Registry holds the checks of the dependencies that must be reachable for the
+// service to accept traffic. It is safe for concurrent use.
+type Registry struct {
+	mu     sync.RWMutex
+	checks []registration
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds checker to the registry. A timeout <= 0 uses DefaultTimeout.
+// Registering a name twice replaces the previous checker.
+func (r *Registry) Register(checker ports.HealthChecker, timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, reg := range r.checks {
+		if reg.checker.Name() == checker.Name() {
+			r.checks[i] = registration{checker: checker, timeout: timeout}
+			return
+		}
+	}
+	r.checks = append(r.checks, registration{checker: checker, timeout: timeout})
+}
+
+// Check runs every registered check concurrently, each bounded by its own
+// timeout, and returns the results sorted by name.
+func (r *Registry) Check(ctx context.Context) *Report {
+	r.mu.RLock()
+	checks := make([]registration, len(r.checks))
+	copy(checks, r.checks)
+	r.mu.RUnlock()
+
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, reg := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = run(ctx, reg)
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+
+	report := &Report{Status: StatusUp, CheckedAt: time.Now().UTC(), Checks: results}
+	for _, res := range results {
+		if res.Status == StatusDown {
+			report.Status = StatusDown
+			break
+		}
+	}
+	return report
+}
+
+func run(ctx context.Context, reg registration) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, reg.timeout)
+	defer cancel()
+
+	start := time.Now()
+	errCh := make(chan error, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				errCh <- errors.New("check panicked")
+			}
+		}()
+		errCh <- reg.checker.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		// the checker ignored its context; don't wait for it
+		err = ctx.Err()
+	}
+
+	res := CheckResult{Name: reg.checker.Name(), Status: StatusUp, Duration: time.Since(start)}
+	res.Latency = res.Duration.String()
+	if err != nil {
+		res.Status = StatusDown
+		res.Error = err.Error()
+	}
+	return res
+}
+
+type checkFunc struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// CheckFunc adapts a function to ports.HealthChecker.
+func CheckFunc(name string, fn func(ctx context.Context) error) ports.HealthChecker {
+	return &checkFunc{name: name, fn: fn}
+}
+
+func (c *checkFunc) Name() string {
+	return c.name
+}
+
+func (c *checkFunc) Check(ctx context.Context) error {
+	return c.fn(ctx)
+}
@@ -0,0 +1,142 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"e-wallet/internal/adapters/clock"
+)
+
+func TestRegistry_Check(t *testing.T) {
+	tests := []struct {
+		name           string
+		register       func(r *Registry)
+		expectedStatus Status
+		expectedChecks map[string]string // name -> error
+	}{
+		{
+			name:           "no checks",
+			register:       func(r *Registry) {},
+			expectedStatus: StatusUp,
+			expectedChecks: map[string]string{},
+		},
+		{
+			name: "all up",
+			register: func(r *Registry) {
+				r.Register(CheckFunc("postgres", func(ctx context.Context) error { return nil }), 0)
+				r.Register(CheckFunc("export_storage", func(ctx context.Context) error { return nil }), time.Second)
+			},
+			expectedStatus: StatusUp,
+			expectedChecks: map[string]string{"postgres": "", "export_storage": ""},
+		},
+		{
+			name: "one failing check marks the report down",
+			register: func(r *Registry) {
+				r.Register(CheckFunc("postgres", func(ctx context.Context) error { return errors.New("connection refused") }), 0)
+				r.Register(CheckFunc("export_storage", func(ctx context.Context) error { return nil }), 0)
+			},
+			expectedStatus: StatusDown,
+			expectedChecks: map[string]string{"postgres": "connection refused", "export_storage": ""},
+		},
+		{
+			name: "timeout",
+			register: func(r *Registry) {
+				r.Register(CheckFunc("gateway", func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				}), 10*time.Millisecond)
+			},
+			expectedStatus: StatusDown,
+			expectedChecks: map[string]string{"gateway": context.DeadlineExceeded.Error()},
+		},
+		{
+			name: "checker ignoring its context",
+			register: func(r *Registry) {
+				r.Register(CheckFunc("scheduler", func(ctx context.Context) error {
+					time.Sleep(time.Second)
+					return nil
+				}), 10*time.Millisecond)
+			},
+			expectedStatus: StatusDown,
+			expectedChecks: map[string]string{"scheduler": context.DeadlineExceeded.Error()},
+		},
+		{
+			name: "panicking checker",
+			register: func(r *Registry) {
+				r.Register(CheckFunc("gateway", func(ctx context.Context) error { panic("boom") }), 0)
+			},
+			expectedStatus: StatusDown,
+			expectedChecks: map[string]string{"gateway": "check panicked"},
+		},
+		{
+			name: "re-registering replaces the check",
+			register: func(r *Registry) {
+				r.Register(CheckFunc("postgres", func(ctx context.Context) error { return errors.New("down") }), 0)
+				r.Register(CheckFunc("postgres", func(ctx context.Context) error { return nil }), 0)
+			},
+			expectedStatus: StatusUp,
+			expectedChecks: map[string]string{"postgres": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRegistry()
+			tt.register(r)
+
+			report := r.Check(context.Background())
+
+			assert.Equal(t, tt.expectedStatus, report.Status)
+			require.Len(t, report.Checks, len(tt.expectedChecks))
+			for _, res := range report.Checks {
+				expectedErr, ok := tt.expectedChecks[res.Name]
+				require.True(t, ok, "unexpected check %q", res.Name)
+				assert.Equal(t, expectedErr, res.Error)
+				if expectedErr == "" {
+					assert.Equal(t, StatusUp, res.Status)
+				} else {
+					assert.Equal(t, StatusDown, res.Status)
+				}
+			}
+		})
+	}
+}
+
+func TestRegistry_Check_SortedByName(t *testing.T) {
+	r := NewRegistry()
+	for _, name := range []string{"postgres", "export_storage", "gateway"} {
+		r.Register(CheckFunc(name, func(ctx context.Context) error { return nil }), 0)
+	}
+
+	report := r.Check(context.Background())
+
+	var names []string
+	for _, res := range report.Checks {
+		names = append(names, res.Name)
+	}
+	assert.Equal(t, []string{"export_storage", "gateway", "postgres"}, names)
+}
+
+func TestHeartbeat_Check(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 2, 1, 0, 0, 0, time.UTC)
+	c := clock.NewFake(now)
+	h := NewHeartbeat("worker", 2*time.Hour, c)
+	assert.Equal(t, "worker", h.Name())
+
+	// the first round has maxAge to complete
+	c.Advance(2 * time.Hour)
+	assert.NoError(t, h.Check(ctx))
+	c.Advance(time.Minute)
+	assert.EqualError(t, h.Check(ctx), "no heartbeat for 2h1m0s, since 2026-03-02T01:00:00Z")
+
+	h.Beat()
+	assert.NoError(t, h.Check(ctx))
+	c.Advance(90 * time.Minute)
+	assert.NoError(t, h.Check(ctx))
+}
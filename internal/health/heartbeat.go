@@ -0,0 +1,50 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"e-wallet/internal/ports"
+)
+
+// Heartbeat checks that a loop is still running: the loop beats at the end of
+// each round, and the check fails once no beat came for longer than maxAge.
+// The first round is given maxAge from the creation of the heartbeat.
+type Heartbeat struct {
+	name   string
+	maxAge time.Duration
+	clock  ports.Clock
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+var _ ports.HealthChecker = (*Heartbeat)(nil)
+
+func NewHeartbeat(name string, maxAge time.Duration, clock ports.Clock) *Heartbeat {
+	return &Heartbeat{name: name, maxAge: maxAge, clock: clock, last: clock.Now()}
+}
+
+// Beat records that the loop completed a round.
+func (h *Heartbeat) Beat() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.last = h.clock.Now()
+}
+
+func (h *Heartbeat) Name() string {
+	return h.name
+}
+
+func (h *Heartbeat) Check(ctx context.Context) error {
+	h.mu.Lock()
+	last := h.last
+	h.mu.Unlock()
+
+	if age := h.clock.Now().Sub(last); age > h.maxAge {
+		return fmt.Errorf("no heartbeat for %s, since %s", age.Truncate(time.Second), last.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
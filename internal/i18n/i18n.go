@@ -0,0 +1,127 @@
+// Package i18n holds the message catalogue of the API and picks the language
+// of each request from its Accept-Language header.
+//
+// Messages live in locales/<language>.json as flat "key": "message" pairs.
+// A message may contain {name} placeholders that are filled from params.
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+type Language string
+
+const (
+	English    Language = "en"
+	Vietnamese Language = "vi"
+)
+
+// Supported lists the languages with a catalogue. English is the reference
+// catalogue every other language falls back to.
+var Supported = []Language{English, Vietnamese}
+
+//go:embed locales/*.json
+var locales embed.FS
+
+var catalogue = mustLoad()
+
+func mustLoad() map[Language]map[string]string {
+	c := make(map[Language]map[string]string, len(Supported))
+	for _, lang := range Supported {
+		raw, err := locales.ReadFile(path.Join("locales", string(lang)+".json"))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: %v", err))
+		}
+		messages := make(map[string]string)
+		if err := json.Unmarshal(raw, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: locales/%s.json: %v", lang, err))
+		}
+		c[lang] = messages
+	}
+	return c
+}
+
+// Parse returns the supported language matching s ("vi", "en-US", ...).
+func Parse(s string) (Language, bool) {
+	tag, err := language.Parse(s)
+	if err != nil {
+		return "", false
+	}
+	base, _ := tag.Base()
+	for _, lang := range Supported {
+		if base.String() == string(lang) {
+			return lang, true
+		}
+	}
+	return "", false
+}
+
+// Negotiate picks the best supported language for an Accept-Language header,
+// or fallback when none of the requested languages is supported.
+func Negotiate(acceptLanguage string, fallback Language) Language {
+	tags, q, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil {
+		return fallback
+	}
+
+	best, bestQ := fallback, float32(0)
+	for i, tag := range tags {
+		if lang, ok := Parse(tag.String()); ok && q[i] > bestQ {
+			best, bestQ = lang, q[i]
+		}
+	}
+	return best
+}
+
+// Lookup returns the message for key in lang, falling back to English.
+func Lookup(lang Language, key string, params map[string]string) (string, bool) {
+	message, ok := catalogue[lang][key]
+	if !ok {
+		message, ok = catalogue[English][key]
+	}
+	if !ok {
+		return "", false
+	}
+	return render(message, params), true
+}
+
+// T is Lookup returning the key itself for unknown messages.
+func T(lang Language, key string, params map[string]string) string {
+	if message, ok := Lookup(lang, key, params); ok {
+		return message
+	}
+	return key
+}
+
+func render(message string, params map[string]string) string {
+	if len(params) == 0 {
+		return message
+	}
+	oldnew := make([]string, 0, len(params)*2)
+	for k, v := range params {
+		oldnew = append(oldnew, "{"+k+"}", v)
+	}
+	return strings.NewReplacer(oldnew...).Replace(message)
+}
+
+type contextKey struct{}
+
+// WithLanguage stores the language of the current request in ctx.
+func WithLanguage(ctx context.Context, lang Language) context.Context {
+	return context.WithValue(ctx, contextKey{}, lang)
+}
+
+// FromContext returns the language stored by WithLanguage, or fallback.
+func FromContext(ctx context.Context, fallback Language) Language {
+	if lang, ok := ctx.Value(contextKey{}).(Language); ok {
+		return lang
+	}
+	return fallback
+}
@@ -0,0 +1,79 @@
+package i18n
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var placeholderRegex = regexp.MustCompile(`\{[a-z_]+\}`)
+
+func TestCatalogue_Complete(t *testing.T) {
+	for _, lang := range Supported {
+		if lang == English {
+			continue
+		}
+		for key, reference := range catalogue[English] {
+			message, ok := catalogue[lang][key]
+			if !assert.True(t, ok, "%s: missing %q", lang, key) {
+				continue
+			}
+			assert.Equal(t, placeholders(reference), placeholders(message), "%s: placeholders of %q", lang, key)
+		}
+		for key := range catalogue[lang] {
+			// Field names default to their json name in English
+			if strings.HasPrefix(key, "field.") {
+				continue
+			}
+			_, ok := catalogue[English][key]
+			assert.True(t, ok, "%s: %q is not in the English catalogue", lang, key)
+		}
+	}
+}
+
+func placeholders(message string) []string {
+	p := placeholderRegex.FindAllString(message, -1)
+	sort.Strings(p)
+	return p
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		fallback       Language
+		expected       Language
+	}{
+		{name: "empty header", acceptLanguage: "", fallback: Vietnamese, expected: Vietnamese},
+		{name: "exact match", acceptLanguage: "en", fallback: Vietnamese, expected: English},
+		{name: "region subtag", acceptLanguage: "vi-VN", fallback: English, expected: Vietnamese},
+		{name: "quality values", acceptLanguage: "en;q=0.5, vi;q=0.9", fallback: English, expected: Vietnamese},
+		{name: "unsupported first", acceptLanguage: "fr-FR, en;q=0.8", fallback: Vietnamese, expected: English},
+		{name: "nothing supported", acceptLanguage: "fr, de", fallback: Vietnamese, expected: Vietnamese},
+		{name: "malformed header", acceptLanguage: ";;;", fallback: English, expected: English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Negotiate(tt.acceptLanguage, tt.fallback))
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	message, ok := Lookup(Vietnamese, "error.non_zero_balance", map[string]string{"account_number": "1234567890"})
+	assert.True(t, ok)
+	assert.Equal(t, "tài khoản 1234567890 vẫn còn số dư", message)
+
+	message, ok = Lookup(English, "error.non_zero_balance", map[string]string{"account_number": "1234567890"})
+	assert.True(t, ok)
+	assert.Equal(t, "account 1234567890 still has a non-zero balance", message)
+
+	_, ok = Lookup(English, "field.display_name", nil)
+	assert.False(t, ok)
+
+	assert.Equal(t, "error.unknown", T(Vietnamese, "error.unknown", nil))
+}
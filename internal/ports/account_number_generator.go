@@ -0,0 +1,7 @@
+package ports
+
+// AccountNumberGenerator issues candidate account numbers. Uniqueness is
+// left to the repository, which asks for another number on a collision.
+type AccountNumberGenerator interface {
+	Generate(accountType string) (string, error)
+}
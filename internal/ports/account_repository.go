@@ -12,13 +12,36 @@ type AccountRepository interface {
 	CreateFlexibleSavingsAccount(ctx context.Context, userID string) (*account.Account, error)
 	GetAccountsByUserID(ctx context.Context, userID string) ([]*account.Account, error)
 	GetAccountByID(ctx context.Context, accountID string) (*account.Account, error)
+	// GetAccountForUpdate reads an account and locks it until the end of the
+	// transaction of ctx, so that its balance can't change in between.
+	GetAccountForUpdate(ctx context.Context, accountID string) (*account.Account, error)
 	CountPaymentAccountsByUserID(ctx context.Context, userID string) (int64, error)
 	CountSavingsAccountsByUserID(ctx context.Context, userID string) (int64, error)
 	UpdateAccountBalance(ctx context.Context, accountID string, newBalance float64) error
+	UpdateAccountStatus(ctx context.Context, accountID string, status string) error
+	CloseAccountsByUserID(ctx context.Context, userID string) error
 }
 
 type SavingsAccountDetailRepository interface {
 	CreateSavingsAccountDetail(ctx context.Context, detail *account.SavingsAccountDetail) error
 	GetSavingsAccountDetailByAccountID(ctx context.Context, accountID string) (*account.SavingsAccountDetail, error)
 	UpdateLastInterestCalcDate(ctx context.Context, accountID string, date *time.Time) error
+	// UpdateInterestCarry saves the fraction of a minor unit of interest left
+	// over by the last accrual, paid with the interest of the next one.
+	UpdateInterestCarry(ctx context.Context, accountID string, carry int64) error
+	// GetFlexibleSavingsDueForInterest returns the details of the active
+	// flexible savings accounts whose interest was last calculated before
+	// the date of asOf.
+	GetFlexibleSavingsDueForInterest(ctx context.Context, asOf time.Time) ([]*account.SavingsAccountDetail, error)
+	UpdateMaturityInstruction(ctx context.Context, accountID string, instruction string) error
+	// GetFixedSavingsDueForMaturity returns the details of the active fixed
+	// savings accounts maturing on the date of asOf or before.
+	GetFixedSavingsDueForMaturity(ctx context.Context, asOf time.Time) ([]*account.SavingsAccountDetail, error)
+	// RenewFixedTerm starts a new term of a fixed savings account, from
+	// startDate to maturityDate at rate.
+	RenewFixedTerm(ctx context.Context, accountID string, startDate, maturityDate time.Time, rate float64) error
+	// CreateRenewalCycle records a matured term, setting its ID when empty.
+	// Cycles are numbered from 1 within an account.
+	CreateRenewalCycle(ctx context.Context, cycle *account.RenewalCycle) error
+	GetRenewalCyclesByAccountIDs(ctx context.Context, accountIDs []string) ([]*account.RenewalCycle, error)
 }
\ No newline at end of file
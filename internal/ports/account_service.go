@@ -10,4 +10,11 @@ type AccountService interface {
 	CreateFixedSavingsAccount(ctx context.Context, userID string, req *account.CreateFixedSavingsAccountRequest) (*account.Account, error)
 	CreateFlexibleSavingsAccount(ctx context.Context, userID string) (*account.Account, error)
 	ListAccounts(ctx context.Context, userID string) (*account.ListAccountsResponse, error)
+	UpdateMaturityInstruction(ctx context.Context, userID, accountID, instruction string) (*account.SavingsAccountDetail, error)
+	// DepositToFlexibleSavings moves amount from the PAYMENT account of the
+	// user to their flexible savings account, and returns the latter.
+	DepositToFlexibleSavings(ctx context.Context, userID, accountID string, amount float64) (*account.Account, error)
+	// WithdrawFromFlexibleSavings moves amount from a flexible savings
+	// account of the user to their PAYMENT account, and returns the former.
+	WithdrawFromFlexibleSavings(ctx context.Context, userID, accountID string, amount float64) (*account.Account, error)
 }
\ No newline at end of file
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+	"e-wallet/internal/domain/audit"
+)
+
+type AuditLogger interface {
+	Log(ctx context.Context, entry *audit.Entry) error
+	Query(ctx context.Context, filter *audit.Filter) (*audit.QueryResult, error)
+	Verify(ctx context.Context) (*audit.VerifyResult, error)
+}
@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"context"
+	"e-wallet/internal/domain/banklink"
+)
+
+type BankLinkRepository interface {
+	Create(ctx context.Context, link *banklink.BankLink) (*banklink.BankLink, error)
+	GetByUserID(ctx context.Context, userID string) ([]*banklink.BankLink, error)
+}
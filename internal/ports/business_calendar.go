@@ -0,0 +1,11 @@
+package ports
+
+import "time"
+
+// BusinessCalendar tells business days from weekends and public holidays.
+// Maturity and payout dates are moved to a business day with Adjust, by the
+// convention the calendar was configured with.
+type BusinessCalendar interface {
+	IsBusinessDay(t time.Time) bool
+	Adjust(t time.Time) time.Time
+}
@@ -0,0 +1,9 @@
+package ports
+
+import "time"
+
+// Clock tells the time to the code whose behaviour depends on it (token
+// expiry, maturity dates, accrual), so that tests can control it.
+type Clock interface {
+	Now() time.Time
+}
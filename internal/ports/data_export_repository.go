@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+	"e-wallet/internal/domain/privacy"
+	"time"
+)
+
+type DataExportRepository interface {
+	Create(ctx context.Context, export *privacy.DataExport) (*privacy.DataExport, error)
+	Update(ctx context.Context, export *privacy.DataExport) error
+	GetByID(ctx context.Context, id string) (*privacy.DataExport, error)
+	GetByUserID(ctx context.Context, userID string) ([]*privacy.DataExport, error)
+	// FailUnfinished marks the pending and processing exports created before
+	// createdBefore as failed with reason, and returns how many it marked.
+	FailUnfinished(ctx context.Context, createdBefore, completedAt time.Time, reason string) (int, error)
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+	"io"
+)
+
+type ExportStorage interface {
+	Save(ctx context.Context, name string, content io.Reader) error
+	Open(ctx context.Context, name string) (io.ReadCloser, error)
+	// Delete removes the named file. Deleting a missing file is not an error.
+	Delete(ctx context.Context, name string) error
+}
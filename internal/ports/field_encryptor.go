@@ -0,0 +1,10 @@
+package ports
+
+type FieldEncryptor interface {
+	Encrypt(plaintext string) (string, error)
+	Decrypt(ciphertext string) (string, error)
+	BlindIndex(value string) (string, error)
+	// NeedsRotation reports whether a stored value is plaintext or was
+	// encrypted with a key other than the active one.
+	NeedsRotation(ciphertext string) bool
+}
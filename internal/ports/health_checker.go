@@ -0,0 +1,9 @@
+package ports
+
+import "context"
+
+// HealthChecker reports whether a dependency the service relies on is usable.
+type HealthChecker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+type InterestService interface {
+	// AccrueFlexibleInterest accrues the interest of flexible savings up to
+	// the date of asOf and returns the number of accounts credited.
+	AccrueFlexibleInterest(ctx context.Context, asOf time.Time) (int, error)
+	// MatureFixedSavings pays the interest of the fixed savings maturing on
+	// the date of asOf or before, follows their maturity instruction and
+	// returns the number of accounts matured.
+	MatureFixedSavings(ctx context.Context, asOf time.Time) (int, error)
+}
@@ -0,0 +1,10 @@
+package ports
+
+type KeyProvider interface {
+	// ActiveKey returns the key encryption key used for new values.
+	ActiveKey() (id string, key []byte, err error)
+	// Key returns a key encryption key by ID, including retired ones.
+	Key(id string) ([]byte, error)
+	// IndexKey returns the HMAC key used for blind indexes.
+	IndexKey() ([]byte, error)
+}
@@ -0,0 +1,9 @@
+package ports
+
+// Metrics records business events so application services can be observed
+// without depending on a metrics backend.
+type Metrics interface {
+	AccountCreated(accountType string)
+	TransferCompleted(transactionType string, amount float64)
+	InterestAccrued(accountType string, amount float64)
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+	"e-wallet/internal/domain/privacy"
+	"io"
+)
+
+type PrivacyService interface {
+	RequestExport(ctx context.Context, userID string) (*privacy.DataExport, error)
+	GetExport(ctx context.Context, userID string, exportID string) (*privacy.DataExport, error)
+	OpenExport(ctx context.Context, userID string, exportID string) (io.ReadCloser, *privacy.DataExport, error)
+	DeleteUserData(ctx context.Context, userID string) error
+	// FailStaleExports marks the exports an earlier run left unfinished as
+	// failed, and returns how many it marked.
+	FailStaleExports(ctx context.Context) (int, error)
+	// Wait blocks until the exports running in the background are done, or
+	// until ctx is.
+	Wait(ctx context.Context) error
+}
@@ -9,4 +9,6 @@ type ProfileRepository interface {
 	GetByUserID(ctx context.Context, userID string) (*profile.Profile, error)
 	Upsert(ctx context.Context, profile *profile.Profile) (*profile.Profile, error)
 	CheckNationalIDExists(ctx context.Context, nationalID string, excludeUserID string) (bool, error)
+	CheckPhoneNumberExists(ctx context.Context, phoneNumber string, excludeUserID string) (bool, error)
+	Anonymize(ctx context.Context, userID string, placeholder string) error
 }
\ No newline at end of file
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"e-wallet/internal/ratelimit"
+)
+
+// RateLimitStore keeps the token buckets of the rate limiter. Take must be
+// atomic per key so that concurrent requests can't spend the same token.
+// now is the time of the request; a store shared by several instances may
+// keep time with a clock of its own instead.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, limit ratelimit.Limit, now time.Time) (ratelimit.Result, error)
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"e-wallet/internal/domain/account"
+	"time"
+)
+
+type TransactionRepository interface {
+	CreateTransaction(ctx context.Context, tx *account.Transaction) error
+	GetTransactionsByAccountIDs(ctx context.Context, accountIDs []string) ([]*account.Transaction, error)
+	// GetAccountTransactionsSince returns the transactions of an account
+	// dated since or after since, oldest first.
+	GetAccountTransactionsSince(ctx context.Context, accountID string, since time.Time) ([]*account.Transaction, error)
+}
+
+type InterestHistoryRepository interface {
+	CreateFlexibleInterestRecord(ctx context.Context, record *account.FlexibleInterestRecord) error
+	CreateFixedInterestRecord(ctx context.Context, record *account.FixedInterestRecord) error
+	GetFlexibleInterestHistoryByAccountIDs(ctx context.Context, accountIDs []string) ([]*account.FlexibleInterestRecord, error)
+	GetFixedInterestHistoryByAccountIDs(ctx context.Context, accountIDs []string) ([]*account.FixedInterestRecord, error)
+}
@@ -0,0 +1,11 @@
+package ports
+
+import "context"
+
+// TxManager runs a unit of work atomically. Repository calls made with the
+// context passed to fn take part in the transaction, which is committed when
+// fn returns nil and rolled back otherwise. Nested calls run in a savepoint,
+// so an inner failure can be handled without aborting the outer work.
+type TxManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
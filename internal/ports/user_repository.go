@@ -10,4 +10,5 @@ type UserRepository interface {
 	GetByEmail(ctx context.Context, email string) (*user.User, error)
 	GetByID(ctx context.Context, id string) (*user.User, error)
 	UpdateProfileCompleted(ctx context.Context, id string, completed bool) error
+	Anonymize(ctx context.Context, id string, username string, email string) error
 }
\ No newline at end of file
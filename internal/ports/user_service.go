@@ -9,4 +9,7 @@ import (
 type UserService interface {
 	CreateUser(ctx context.Context, req *user.CreateUserRequest) (*user.User, error)
 	LoginUser(ctx context.Context, req *user.LoginUserRequest) (*user.User, error)
+	// GetActiveUser returns the user, or ErrUserNotFound when they don't
+	// exist or their data was deleted.
+	GetActiveUser(ctx context.Context, userID string) (*user.User, error)
 }
\ No newline at end of file
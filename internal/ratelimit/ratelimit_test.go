@@ -0,0 +1,53 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucket_Take(t *testing.T) {
+	limit := PerMinute(60, 3) // one token per second
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	b := NewBucket(limit, now)
+
+	// the burst is available at once
+	for i := 2; i >= 0; i-- {
+		var res Result
+		b, res = b.Take(limit, now)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, i, res.Remaining)
+		assert.Equal(t, 3, res.Limit)
+	}
+
+	// then the client has to wait for the refill
+	b, res := b.Take(limit, now)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+	assert.Equal(t, time.Second, res.RetryAfter)
+	assert.Equal(t, 3*time.Second, res.ResetAfter)
+
+	b, res = b.Take(limit, now.Add(500*time.Millisecond))
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 500*time.Millisecond, res.RetryAfter)
+
+	b, res = b.Take(limit, now.Add(time.Second))
+	assert.True(t, res.Allowed)
+	assert.Equal(t, time.Duration(0), res.RetryAfter)
+
+	// refill never exceeds the burst
+	_, res = b.Take(limit, now.Add(time.Hour))
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 2, res.Remaining)
+}
+
+func TestBucket_Full(t *testing.T) {
+	limit := PerMinute(60, 3)
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	b, _ := NewBucket(limit, now).Take(limit, now)
+
+	assert.False(t, b.Full(limit, now))
+	assert.False(t, b.Full(limit, now.Add(500*time.Millisecond)))
+	assert.True(t, b.Full(limit, now.Add(time.Second)))
+}
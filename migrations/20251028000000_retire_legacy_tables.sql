@@ -0,0 +1,38 @@
+-- +migrate Up
+-- The 2025-09 migrations created accounts, transactions and interest_history
+-- in a shape the application no longer uses, so the 2025-10-29 migrations
+-- could not create their own versions. Move the legacy tables (and their
+-- indexes and constraints, whose names would clash) out of the way;
+-- 20251029000005 copies their data into the new tables and drops them.
+-- Databases that never had the legacy shape are left untouched.
+-- +migrate StatementBegin
+DO $$
+DECLARE
+    t TEXT;
+    idx RECORD;
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = 'accounts' AND column_name = 'account_name'
+    ) THEN
+        RETURN;
+    END IF;
+
+    FOREACH t IN ARRAY ARRAY['accounts', 'transactions', 'interest_history'] LOOP
+        IF to_regclass(t) IS NULL THEN
+            CONTINUE;
+        END IF;
+        EXECUTE format('ALTER TABLE %I RENAME TO %I', t, 'legacy_' || t);
+        -- renaming an index renames the constraint it backs as well
+        FOR idx IN
+            SELECT indexname FROM pg_indexes
+            WHERE schemaname = current_schema() AND tablename = 'legacy_' || t
+        LOOP
+            EXECUTE format('ALTER INDEX %I RENAME TO %I', idx.indexname, 'legacy_' || idx.indexname);
+        END LOOP;
+    END LOOP;
+END $$;
+-- +migrate StatementEnd
+
+-- +migrate Down
+-- Forward-only: 20251029000005 refuses to be rolled back, so this is never reached.
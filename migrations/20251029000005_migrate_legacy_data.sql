@@ -0,0 +1,154 @@
+-- +migrate Up
+-- Copies the data of installations created from the 2025-09 migrations into
+-- the current tables, then drops the legacy tables. Legacy rates were stored
+-- in percent, current ones as fractions. Legacy interest history maps
+-- loosely; the original date is kept in the calculation period. Account
+-- numbers longer than the 20 characters of the current column make the
+-- migration fail and must be shortened first.
+-- Legacy transactions become posted entries, so only COMPLETED ones of a known
+-- type are migrated: any other status or type makes the migration fail, and
+-- must be settled or mapped by hand first. Migrated flexible savings accrue
+-- interest from the day after their last legacy interest, or their start date.
+-- Profiles missing a phone number or national id cannot be migrated and are
+-- reported with a notice.
+-- Run `go run ./cmd/keys rotate` afterwards to encrypt the migrated profiles.
+
+-- Legacy credits are migrated as deposits.
+ALTER TABLE transactions DROP CONSTRAINT transactions_transaction_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_transaction_type_check CHECK (transaction_type IN ('PAYMENT_INITIATION', 'INTEREST_CREDIT', 'WITHDRAWAL', 'WITHDRAWAL_PENALTY', 'DEPOSIT'));
+
+-- legacy_transaction_type maps a legacy transaction type to the current one,
+-- or to NULL when it is unknown. A transfer is only known when its type tells
+-- on which side of it the account was.
+-- +migrate StatementBegin
+CREATE FUNCTION legacy_transaction_type(legacy TEXT) RETURNS TEXT
+LANGUAGE SQL IMMUTABLE AS $$
+    SELECT CASE upper(legacy)
+        WHEN 'DEPOSIT' THEN 'DEPOSIT'
+        WHEN 'TOP_UP' THEN 'DEPOSIT'
+        WHEN 'TRANSFER_IN' THEN 'DEPOSIT'
+        WHEN 'INTEREST' THEN 'INTEREST_CREDIT'
+        WHEN 'INTEREST_CREDIT' THEN 'INTEREST_CREDIT'
+        WHEN 'WITHDRAW' THEN 'WITHDRAWAL'
+        WHEN 'WITHDRAWAL' THEN 'WITHDRAWAL'
+        WHEN 'PAYMENT' THEN 'PAYMENT_INITIATION'
+        WHEN 'TRANSFER_OUT' THEN 'PAYMENT_INITIATION'
+    END
+$$;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+DO $$
+DECLARE
+    skipped BIGINT;
+    unmigratable TEXT;
+BEGIN
+    IF to_regclass('legacy_accounts') IS NOT NULL THEN
+        INSERT INTO accounts (id, user_id, account_number, account_type, balance, status, created_at, updated_at)
+        SELECT id, user_id, account_number,
+            CASE
+                WHEN upper(account_type) IN ('PAYMENT', 'FIXED_SAVINGS', 'FLEXIBLE_SAVINGS') THEN upper(account_type)
+                WHEN fixed_term_months IS NOT NULL THEN 'FIXED_SAVINGS'
+                WHEN interest_rate IS NOT NULL THEN 'FLEXIBLE_SAVINGS'
+                ELSE 'PAYMENT'
+            END,
+            COALESCE(balance, 0), 'ACTIVE', created_at, updated_at
+        FROM legacy_accounts
+        ON CONFLICT (id) DO NOTHING;
+
+        INSERT INTO savings_account_details (account_id, is_fixed_term, term_months, annual_interest_rate, start_date, maturity_date, last_interest_calc_date, created_at, updated_at)
+        SELECT a.id, a.account_type = 'FIXED_SAVINGS', l.fixed_term_months,
+            COALESCE(l.interest_rate, 0) / 100, COALESCE(l.created_at, CURRENT_TIMESTAMP)::date,
+            CASE WHEN l.fixed_term_months IS NOT NULL
+                THEN (COALESCE(l.created_at, CURRENT_TIMESTAMP) + make_interval(months => l.fixed_term_months))::date
+            END,
+            -- as for new accounts: flexible savings accrue from their start date
+            CASE WHEN a.account_type = 'FLEXIBLE_SAVINGS'
+                THEN COALESCE(l.created_at, CURRENT_TIMESTAMP)::date
+            END,
+            l.created_at, l.updated_at
+        FROM legacy_accounts l
+        JOIN accounts a ON a.id = l.id
+        WHERE a.account_type IN ('FIXED_SAVINGS', 'FLEXIBLE_SAVINGS')
+        ON CONFLICT (account_id) DO NOTHING;
+    END IF;
+
+    IF to_regclass('legacy_transactions') IS NOT NULL THEN
+        SELECT string_agg(DISTINCT format('%s (%s)', transaction_type, status), ', ') INTO unmigratable
+        FROM legacy_transactions
+        WHERE upper(status) <> 'COMPLETED' OR legacy_transaction_type(transaction_type) IS NULL;
+        IF unmigratable IS NOT NULL THEN
+            RAISE EXCEPTION 'legacy transactions cannot be migrated: %', unmigratable
+                USING HINT = 'only COMPLETED transactions of a known type are migrated, see 20251029000005';
+        END IF;
+
+        INSERT INTO transactions (id, account_id, transaction_type, amount, transaction_date, description, created_at)
+        SELECT id, account_id, legacy_transaction_type(transaction_type),
+            amount, created_at, left(format('legacy %s', transaction_type), 255), created_at
+        FROM legacy_transactions
+        ON CONFLICT (id) DO NOTHING;
+    END IF;
+
+    IF to_regclass('legacy_interest_history') IS NOT NULL THEN
+        INSERT INTO fixed_savings_interest_history (id, account_id, calculation_period, total_interest_amount, created_at)
+        SELECT h.id, h.account_id, to_char(h.date, 'YYYY-MM-DD'), h.interest_amount, h.created_at
+        FROM legacy_interest_history h
+        JOIN accounts a ON a.id = h.account_id
+        WHERE a.account_type = 'FIXED_SAVINGS'
+        ON CONFLICT (id) DO NOTHING;
+
+        -- the legacy history did not record the end-of-day balance
+        INSERT INTO flexible_savings_interest_history (id, account_id, calculation_date, eod_balance, annual_rate_applied, daily_interest_amount, created_at)
+        SELECT h.id, h.account_id, h.date, 0, COALESCE(d.annual_interest_rate, 0), h.interest_amount, h.created_at
+        FROM legacy_interest_history h
+        JOIN accounts a ON a.id = h.account_id
+        LEFT JOIN savings_account_details d ON d.account_id = h.account_id
+        WHERE a.account_type <> 'FIXED_SAVINGS'
+        ON CONFLICT (id) DO NOTHING;
+
+        -- interest already paid out by the legacy system is not accrued again
+        UPDATE savings_account_details d SET last_interest_calc_date = h.last_date
+        FROM (
+            SELECT account_id, max(date) AS last_date
+            FROM legacy_interest_history
+            GROUP BY account_id
+        ) h
+        WHERE d.account_id = h.account_id AND NOT d.is_fixed_term AND h.last_date > d.last_interest_calc_date;
+    END IF;
+
+    IF to_regclass('profiles') IS NOT NULL THEN
+        INSERT INTO user_profiles (user_id, display_name, avatar_url, phone_number, national_id, birth_year, gender, team, created_at, updated_at)
+        SELECT DISTINCT ON (user_id)
+            user_id, COALESCE(display_name, ''), avatar_url, phone_number, national_id,
+            COALESCE(birth_year, 0), COALESCE(gender, ''), COALESCE(team, ''), created_at, updated_at
+        FROM profiles
+        WHERE phone_number IS NOT NULL AND national_id IS NOT NULL
+        ORDER BY user_id, updated_at DESC
+        ON CONFLICT DO NOTHING;
+
+        UPDATE users SET is_profile_completed = TRUE
+        WHERE id IN (SELECT user_id FROM user_profiles);
+
+        SELECT count(*) INTO skipped FROM profiles p
+        WHERE NOT EXISTS (SELECT 1 FROM user_profiles up WHERE up.user_id = p.user_id);
+        IF skipped > 0 THEN
+            RAISE NOTICE '% legacy profiles could not be migrated', skipped;
+        END IF;
+    END IF;
+END $$;
+-- +migrate StatementEnd
+
+DROP TABLE IF EXISTS legacy_interest_history;
+DROP TABLE IF EXISTS legacy_transactions;
+DROP TABLE IF EXISTS legacy_accounts;
+DROP TABLE IF EXISTS profiles;
+DROP FUNCTION legacy_transaction_type(TEXT);
+
+-- +migrate Down
+-- Forward-only: the legacy tables cannot be restored, so rolling back stops here.
+-- +migrate StatementBegin
+DO $$
+BEGIN
+    RAISE EXCEPTION 'migration 20251029000005 is forward-only and cannot be rolled back';
+END $$;
+-- +migrate StatementEnd
@@ -0,0 +1,37 @@
+-- +migrate Up
+CREATE TABLE audit_logs (
+    id UUID PRIMARY KEY,
+    seq BIGSERIAL UNIQUE NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    actor_id VARCHAR(64),
+    target_type VARCHAR(50),
+    target_id VARCHAR(64),
+    ip VARCHAR(64),
+    request_id VARCHAR(64),
+    user_agent VARCHAR(500),
+    metadata TEXT NOT NULL DEFAULT '{}',
+    prev_hash CHAR(64) NOT NULL,
+    hash CHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_audit_logs_actor_id ON audit_logs(actor_id);
+CREATE INDEX idx_audit_logs_target ON audit_logs(target_type, target_id);
+CREATE INDEX idx_audit_logs_action ON audit_logs(action);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at);
+
+-- audit logs are append-only
+-- +migrate StatementBegin
+CREATE FUNCTION audit_logs_prevent_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+CREATE TRIGGER trg_audit_logs_prevent_change
+    BEFORE UPDATE OR DELETE ON audit_logs
+    FOR EACH ROW EXECUTE FUNCTION audit_logs_prevent_change();
+
+-- +migrate Down
+DROP TRIGGER trg_audit_logs_prevent_change ON audit_logs;
+DROP FUNCTION audit_logs_prevent_change();
+DROP TABLE audit_logs;
@@ -0,0 +1,17 @@
+-- +migrate Up
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE TABLE data_exports (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('PENDING', 'PROCESSING', 'COMPLETED', 'FAILED')),
+    file_name VARCHAR(255),
+    error TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMPTZ
+);
+CREATE INDEX idx_data_exports_user_id ON data_exports(user_id);
+
+-- +migrate Down
+DROP TABLE data_exports;
+ALTER TABLE users DROP COLUMN deleted_at;
@@ -0,0 +1,36 @@
+-- +migrate Up
+-- Values are encrypted by the application; uniqueness moves to blind indexes.
+-- Run `go run ./cmd/keys rotate` after deploying to encrypt existing rows.
+ALTER TABLE user_profiles DROP CONSTRAINT IF EXISTS user_profiles_phone_number_key;
+ALTER TABLE user_profiles DROP CONSTRAINT IF EXISTS user_profiles_national_id_key;
+DROP INDEX IF EXISTS idx_user_profiles_phone_number;
+DROP INDEX IF EXISTS idx_user_profiles_national_id;
+ALTER TABLE user_profiles
+    ALTER COLUMN phone_number TYPE TEXT,
+    ALTER COLUMN national_id TYPE TEXT,
+    ADD COLUMN phone_number_bidx CHAR(64),
+    ADD COLUMN national_id_bidx CHAR(64);
+CREATE UNIQUE INDEX ux_user_profiles_phone_number_bidx ON user_profiles(phone_number_bidx);
+CREATE UNIQUE INDEX ux_user_profiles_national_id_bidx ON user_profiles(national_id_bidx);
+
+ALTER TABLE bank_links
+    ALTER COLUMN access_token TYPE TEXT,
+    ALTER COLUMN refresh_token TYPE TEXT;
+
+-- +migrate Down
+-- Encrypted values do not fit the old column sizes; decrypt before rolling back.
+ALTER TABLE bank_links
+    ALTER COLUMN access_token TYPE VARCHAR(255),
+    ALTER COLUMN refresh_token TYPE VARCHAR(255);
+
+DROP INDEX ux_user_profiles_national_id_bidx;
+DROP INDEX ux_user_profiles_phone_number_bidx;
+ALTER TABLE user_profiles
+    DROP COLUMN national_id_bidx,
+    DROP COLUMN phone_number_bidx,
+    ALTER COLUMN national_id TYPE VARCHAR(20),
+    ALTER COLUMN phone_number TYPE VARCHAR(20);
+ALTER TABLE user_profiles ADD CONSTRAINT user_profiles_phone_number_key UNIQUE (phone_number);
+ALTER TABLE user_profiles ADD CONSTRAINT user_profiles_national_id_key UNIQUE (national_id);
+CREATE INDEX idx_user_profiles_phone_number ON user_profiles(phone_number);
+CREATE INDEX idx_user_profiles_national_id ON user_profiles(national_id);
@@ -0,0 +1,6 @@
+-- +migrate Up
+ALTER TABLE user_profiles ADD COLUMN preferred_language VARCHAR(5) NOT NULL DEFAULT 'vi';
+ALTER TABLE user_profiles ADD CONSTRAINT user_profiles_preferred_language_check CHECK (preferred_language IN ('vi', 'en'));
+
+-- +migrate Down
+ALTER TABLE user_profiles DROP COLUMN preferred_language;
@@ -0,0 +1,41 @@
+-- +migrate Up
+ALTER TABLE savings_account_details ADD COLUMN maturity_instruction VARCHAR(50);
+ALTER TABLE savings_account_details ADD CONSTRAINT savings_account_details_maturity_instruction_check CHECK (maturity_instruction IN ('PAYOUT', 'ROLLOVER_PRINCIPAL', 'ROLLOVER_PRINCIPAL_AND_INTEREST'));
+UPDATE savings_account_details SET maturity_instruction = 'ROLLOVER_PRINCIPAL_AND_INTEREST' WHERE is_fixed_term;
+
+ALTER TABLE transactions DROP CONSTRAINT transactions_transaction_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_transaction_type_check CHECK (transaction_type IN ('PAYMENT_INITIATION', 'INTEREST_CREDIT', 'WITHDRAWAL', 'WITHDRAWAL_PENALTY', 'MATURITY_PAYOUT', 'DEPOSIT'));
+
+CREATE TABLE fixed_savings_renewal_cycles (
+    id UUID PRIMARY KEY,
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    cycle INTEGER NOT NULL CHECK (cycle > 0),
+    start_date DATE NOT NULL,
+    maturity_date DATE NOT NULL,
+    principal DECIMAL(15,2) NOT NULL,
+    interest DECIMAL(15,2) NOT NULL,
+    annual_interest_rate DECIMAL(5,4) NOT NULL,
+    instruction VARCHAR(50) NOT NULL CHECK (instruction IN ('PAYOUT', 'ROLLOVER_PRINCIPAL', 'ROLLOVER_PRINCIPAL_AND_INTEREST')),
+    renewed_principal DECIMAL(15,2) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (account_id, cycle)
+);
+
+-- +migrate Down
+-- Refuses to roll back once fixed savings matured: maturity payouts and
+-- renewal cycles are financial records, and are never deleted.
+-- +migrate StatementBegin
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM transactions WHERE transaction_type = 'MATURITY_PAYOUT')
+        OR EXISTS (SELECT 1 FROM fixed_savings_renewal_cycles) THEN
+        RAISE EXCEPTION 'migration 20261018000005 cannot be rolled back: fixed savings have matured';
+    END IF;
+END $$;
+-- +migrate StatementEnd
+DROP TABLE fixed_savings_renewal_cycles;
+
+ALTER TABLE transactions DROP CONSTRAINT transactions_transaction_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_transaction_type_check CHECK (transaction_type IN ('PAYMENT_INITIATION', 'INTEREST_CREDIT', 'WITHDRAWAL', 'WITHDRAWAL_PENALTY', 'DEPOSIT'));
+
+ALTER TABLE savings_account_details DROP COLUMN maturity_instruction;
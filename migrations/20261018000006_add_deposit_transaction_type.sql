@@ -0,0 +1,10 @@
+-- +migrate Up
+-- DEPOSIT is allowed since 20251029000005, which migrates legacy credits as
+-- deposits. This only restates the check for databases that applied an
+-- earlier version of that migration.
+ALTER TABLE transactions DROP CONSTRAINT transactions_transaction_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_transaction_type_check CHECK (transaction_type IN ('PAYMENT_INITIATION', 'INTEREST_CREDIT', 'WITHDRAWAL', 'WITHDRAWAL_PENALTY', 'MATURITY_PAYOUT', 'DEPOSIT'));
+
+-- +migrate Down
+-- Keeps DEPOSIT, which predates this migration: deposits are financial
+-- records, and are never deleted.
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE savings_account_details ADD COLUMN interest_carry BIGINT NOT NULL DEFAULT 0;
+
+-- +migrate Down
+ALTER TABLE savings_account_details DROP COLUMN interest_carry;
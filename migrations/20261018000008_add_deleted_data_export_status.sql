@@ -0,0 +1,10 @@
+-- +migrate Up
+ALTER TABLE data_exports DROP CONSTRAINT data_exports_status_check;
+ALTER TABLE data_exports ADD CONSTRAINT data_exports_status_check CHECK (status IN ('PENDING', 'PROCESSING', 'COMPLETED', 'FAILED', 'DELETED'));
+
+-- +migrate Down
+-- The archives of deleted exports are already gone, so they roll back as
+-- failed exports rather than completed ones.
+UPDATE data_exports SET status = 'FAILED', error = 'export deleted' WHERE status = 'DELETED';
+ALTER TABLE data_exports DROP CONSTRAINT data_exports_status_check;
+ALTER TABLE data_exports ADD CONSTRAINT data_exports_status_check CHECK (status IN ('PENDING', 'PROCESSING', 'COMPLETED', 'FAILED'));
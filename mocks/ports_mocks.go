@@ -7,13 +7,105 @@ package mocks
 import (
 	"context"
 	"e-wallet/internal/domain/account"
+	"e-wallet/internal/domain/audit"
+	"e-wallet/internal/domain/banklink"
+	"e-wallet/internal/domain/privacy"
 	"e-wallet/internal/domain/profile"
 	"e-wallet/internal/domain/user"
+	"e-wallet/internal/ratelimit"
+	"io"
 	"time"
 
 	mock "github.com/stretchr/testify/mock"
 )
 
+// NewMockAccountNumberGenerator creates a new instance of MockAccountNumberGenerator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAccountNumberGenerator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAccountNumberGenerator {
+	mock := &MockAccountNumberGenerator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAccountNumberGenerator is an autogenerated mock type for the AccountNumberGenerator type
+type MockAccountNumberGenerator struct {
+	mock.Mock
+}
+
+type MockAccountNumberGenerator_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAccountNumberGenerator) EXPECT() *MockAccountNumberGenerator_Expecter {
+	return &MockAccountNumberGenerator_Expecter{mock: &_m.Mock}
+}
+
+// Generate provides a mock function for the type MockAccountNumberGenerator
+func (_mock *MockAccountNumberGenerator) Generate(accountType string) (string, error) {
+	ret := _mock.Called(accountType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Generate")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return returnFunc(accountType)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
+		r0 = returnFunc(accountType)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(accountType)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountNumberGenerator_Generate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Generate'
+type MockAccountNumberGenerator_Generate_Call struct {
+	*mock.Call
+}
+
+// Generate is a helper method to define mock.On call
+//   - accountType string
+func (_e *MockAccountNumberGenerator_Expecter) Generate(accountType interface{}) *MockAccountNumberGenerator_Generate_Call {
+	return &MockAccountNumberGenerator_Generate_Call{Call: _e.mock.On("Generate", accountType)}
+}
+
+func (_c *MockAccountNumberGenerator_Generate_Call) Run(run func(accountType string)) *MockAccountNumberGenerator_Generate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountNumberGenerator_Generate_Call) Return(s string, err error) *MockAccountNumberGenerator_Generate_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockAccountNumberGenerator_Generate_Call) RunAndReturn(run func(accountType string) (string, error)) *MockAccountNumberGenerator_Generate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockAccountRepository creates a new instance of MockAccountRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockAccountRepository(t interface {
@@ -41,6 +133,63 @@ func (_m *MockAccountRepository) EXPECT() *MockAccountRepository_Expecter {
 	return &MockAccountRepository_Expecter{mock: &_m.Mock}
 }
 
+// CloseAccountsByUserID provides a mock function for the type MockAccountRepository
+func (_mock *MockAccountRepository) CloseAccountsByUserID(ctx context.Context, userID string) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseAccountsByUserID")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAccountRepository_CloseAccountsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseAccountsByUserID'
+type MockAccountRepository_CloseAccountsByUserID_Call struct {
+	*mock.Call
+}
+
+// CloseAccountsByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockAccountRepository_Expecter) CloseAccountsByUserID(ctx interface{}, userID interface{}) *MockAccountRepository_CloseAccountsByUserID_Call {
+	return &MockAccountRepository_CloseAccountsByUserID_Call{Call: _e.mock.On("CloseAccountsByUserID", ctx, userID)}
+}
+
+func (_c *MockAccountRepository_CloseAccountsByUserID_Call) Run(run func(ctx context.Context, userID string)) *MockAccountRepository_CloseAccountsByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountRepository_CloseAccountsByUserID_Call) Return(err error) *MockAccountRepository_CloseAccountsByUserID_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAccountRepository_CloseAccountsByUserID_Call) RunAndReturn(run func(ctx context.Context, userID string) error) *MockAccountRepository_CloseAccountsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CountPaymentAccountsByUserID provides a mock function for the type MockAccountRepository
 func (_mock *MockAccountRepository) CountPaymentAccountsByUserID(ctx context.Context, userID string) (int64, error) {
 	ret := _mock.Called(ctx, userID)
@@ -451,6 +600,74 @@ func (_c *MockAccountRepository_GetAccountByID_Call) RunAndReturn(run func(ctx c
 	return _c
 }
 
+// GetAccountForUpdate provides a mock function for the type MockAccountRepository
+func (_mock *MockAccountRepository) GetAccountForUpdate(ctx context.Context, accountID string) (*account.Account, error) {
+	ret := _mock.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountForUpdate")
+	}
+
+	var r0 *account.Account
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*account.Account, error)); ok {
+		return returnFunc(ctx, accountID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *account.Account); ok {
+		r0 = returnFunc(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*account.Account)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountRepository_GetAccountForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountForUpdate'
+type MockAccountRepository_GetAccountForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetAccountForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID string
+func (_e *MockAccountRepository_Expecter) GetAccountForUpdate(ctx interface{}, accountID interface{}) *MockAccountRepository_GetAccountForUpdate_Call {
+	return &MockAccountRepository_GetAccountForUpdate_Call{Call: _e.mock.On("GetAccountForUpdate", ctx, accountID)}
+}
+
+func (_c *MockAccountRepository_GetAccountForUpdate_Call) Run(run func(ctx context.Context, accountID string)) *MockAccountRepository_GetAccountForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountRepository_GetAccountForUpdate_Call) Return(account1 *account.Account, err error) *MockAccountRepository_GetAccountForUpdate_Call {
+	_c.Call.Return(account1, err)
+	return _c
+}
+
+func (_c *MockAccountRepository_GetAccountForUpdate_Call) RunAndReturn(run func(ctx context.Context, accountID string) (*account.Account, error)) *MockAccountRepository_GetAccountForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAccountsByUserID provides a mock function for the type MockAccountRepository
 func (_mock *MockAccountRepository) GetAccountsByUserID(ctx context.Context, userID string) ([]*account.Account, error) {
 	ret := _mock.Called(ctx, userID)
@@ -582,6 +799,69 @@ func (_c *MockAccountRepository_UpdateAccountBalance_Call) RunAndReturn(run func
 	return _c
 }
 
+// UpdateAccountStatus provides a mock function for the type MockAccountRepository
+func (_mock *MockAccountRepository) UpdateAccountStatus(ctx context.Context, accountID string, status string) error {
+	ret := _mock.Called(ctx, accountID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAccountStatus")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, accountID, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAccountRepository_UpdateAccountStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAccountStatus'
+type MockAccountRepository_UpdateAccountStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateAccountStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID string
+//   - status string
+func (_e *MockAccountRepository_Expecter) UpdateAccountStatus(ctx interface{}, accountID interface{}, status interface{}) *MockAccountRepository_UpdateAccountStatus_Call {
+	return &MockAccountRepository_UpdateAccountStatus_Call{Call: _e.mock.On("UpdateAccountStatus", ctx, accountID, status)}
+}
+
+func (_c *MockAccountRepository_UpdateAccountStatus_Call) Run(run func(ctx context.Context, accountID string, status string)) *MockAccountRepository_UpdateAccountStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountRepository_UpdateAccountStatus_Call) Return(err error) *MockAccountRepository_UpdateAccountStatus_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAccountRepository_UpdateAccountStatus_Call) RunAndReturn(run func(ctx context.Context, accountID string, status string) error) *MockAccountRepository_UpdateAccountStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockSavingsAccountDetailRepository creates a new instance of MockSavingsAccountDetailRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockSavingsAccountDetailRepository(t interface {
@@ -609,6 +889,63 @@ func (_m *MockSavingsAccountDetailRepository) EXPECT() *MockSavingsAccountDetail
 	return &MockSavingsAccountDetailRepository_Expecter{mock: &_m.Mock}
 }
 
+// CreateRenewalCycle provides a mock function for the type MockSavingsAccountDetailRepository
+func (_mock *MockSavingsAccountDetailRepository) CreateRenewalCycle(ctx context.Context, cycle *account.RenewalCycle) error {
+	ret := _mock.Called(ctx, cycle)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRenewalCycle")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *account.RenewalCycle) error); ok {
+		r0 = returnFunc(ctx, cycle)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSavingsAccountDetailRepository_CreateRenewalCycle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRenewalCycle'
+type MockSavingsAccountDetailRepository_CreateRenewalCycle_Call struct {
+	*mock.Call
+}
+
+// CreateRenewalCycle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cycle *account.RenewalCycle
+func (_e *MockSavingsAccountDetailRepository_Expecter) CreateRenewalCycle(ctx interface{}, cycle interface{}) *MockSavingsAccountDetailRepository_CreateRenewalCycle_Call {
+	return &MockSavingsAccountDetailRepository_CreateRenewalCycle_Call{Call: _e.mock.On("CreateRenewalCycle", ctx, cycle)}
+}
+
+func (_c *MockSavingsAccountDetailRepository_CreateRenewalCycle_Call) Run(run func(ctx context.Context, cycle *account.RenewalCycle)) *MockSavingsAccountDetailRepository_CreateRenewalCycle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *account.RenewalCycle
+		if args[1] != nil {
+			arg1 = args[1].(*account.RenewalCycle)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSavingsAccountDetailRepository_CreateRenewalCycle_Call) Return(err error) *MockSavingsAccountDetailRepository_CreateRenewalCycle_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSavingsAccountDetailRepository_CreateRenewalCycle_Call) RunAndReturn(run func(ctx context.Context, cycle *account.RenewalCycle) error) *MockSavingsAccountDetailRepository_CreateRenewalCycle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateSavingsAccountDetail provides a mock function for the type MockSavingsAccountDetailRepository
 func (_mock *MockSavingsAccountDetailRepository) CreateSavingsAccountDetail(ctx context.Context, detail *account.SavingsAccountDetail) error {
 	ret := _mock.Called(ctx, detail)
@@ -666,55 +1003,55 @@ func (_c *MockSavingsAccountDetailRepository_CreateSavingsAccountDetail_Call) Ru
 	return _c
 }
 
-// GetSavingsAccountDetailByAccountID provides a mock function for the type MockSavingsAccountDetailRepository
-func (_mock *MockSavingsAccountDetailRepository) GetSavingsAccountDetailByAccountID(ctx context.Context, accountID string) (*account.SavingsAccountDetail, error) {
-	ret := _mock.Called(ctx, accountID)
+// GetFixedSavingsDueForMaturity provides a mock function for the type MockSavingsAccountDetailRepository
+func (_mock *MockSavingsAccountDetailRepository) GetFixedSavingsDueForMaturity(ctx context.Context, asOf time.Time) ([]*account.SavingsAccountDetail, error) {
+	ret := _mock.Called(ctx, asOf)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSavingsAccountDetailByAccountID")
+		panic("no return value specified for GetFixedSavingsDueForMaturity")
 	}
 
-	var r0 *account.SavingsAccountDetail
+	var r0 []*account.SavingsAccountDetail
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*account.SavingsAccountDetail, error)); ok {
-		return returnFunc(ctx, accountID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) ([]*account.SavingsAccountDetail, error)); ok {
+		return returnFunc(ctx, asOf)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *account.SavingsAccountDetail); ok {
-		r0 = returnFunc(ctx, accountID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) []*account.SavingsAccountDetail); ok {
+		r0 = returnFunc(ctx, asOf)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*account.SavingsAccountDetail)
+			r0 = ret.Get(0).([]*account.SavingsAccountDetail)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, accountID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, asOf)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockSavingsAccountDetailRepository_GetSavingsAccountDetailByAccountID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSavingsAccountDetailByAccountID'
-type MockSavingsAccountDetailRepository_GetSavingsAccountDetailByAccountID_Call struct {
+// MockSavingsAccountDetailRepository_GetFixedSavingsDueForMaturity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFixedSavingsDueForMaturity'
+type MockSavingsAccountDetailRepository_GetFixedSavingsDueForMaturity_Call struct {
 	*mock.Call
 }
 
-// GetSavingsAccountDetailByAccountID is a helper method to define mock.On call
+// GetFixedSavingsDueForMaturity is a helper method to define mock.On call
 //   - ctx context.Context
-//   - accountID string
-func (_e *MockSavingsAccountDetailRepository_Expecter) GetSavingsAccountDetailByAccountID(ctx interface{}, accountID interface{}) *MockSavingsAccountDetailRepository_GetSavingsAccountDetailByAccountID_Call {
-	return &MockSavingsAccountDetailRepository_GetSavingsAccountDetailByAccountID_Call{Call: _e.mock.On("GetSavingsAccountDetailByAccountID", ctx, accountID)}
+//   - asOf time.Time
+func (_e *MockSavingsAccountDetailRepository_Expecter) GetFixedSavingsDueForMaturity(ctx interface{}, asOf interface{}) *MockSavingsAccountDetailRepository_GetFixedSavingsDueForMaturity_Call {
+	return &MockSavingsAccountDetailRepository_GetFixedSavingsDueForMaturity_Call{Call: _e.mock.On("GetFixedSavingsDueForMaturity", ctx, asOf)}
 }
 
-func (_c *MockSavingsAccountDetailRepository_GetSavingsAccountDetailByAccountID_Call) Run(run func(ctx context.Context, accountID string)) *MockSavingsAccountDetailRepository_GetSavingsAccountDetailByAccountID_Call {
+func (_c *MockSavingsAccountDetailRepository_GetFixedSavingsDueForMaturity_Call) Run(run func(ctx context.Context, asOf time.Time)) *MockSavingsAccountDetailRepository_GetFixedSavingsDueForMaturity_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 time.Time
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(time.Time)
 		}
 		run(
 			arg0,
@@ -724,221 +1061,193 @@ func (_c *MockSavingsAccountDetailRepository_GetSavingsAccountDetailByAccountID_
 	return _c
 }
 
-func (_c *MockSavingsAccountDetailRepository_GetSavingsAccountDetailByAccountID_Call) Return(savingsAccountDetail *account.SavingsAccountDetail, err error) *MockSavingsAccountDetailRepository_GetSavingsAccountDetailByAccountID_Call {
-	_c.Call.Return(savingsAccountDetail, err)
+func (_c *MockSavingsAccountDetailRepository_GetFixedSavingsDueForMaturity_Call) Return(savingsAccountDetails []*account.SavingsAccountDetail, err error) *MockSavingsAccountDetailRepository_GetFixedSavingsDueForMaturity_Call {
+	_c.Call.Return(savingsAccountDetails, err)
 	return _c
 }
 
-func (_c *MockSavingsAccountDetailRepository_GetSavingsAccountDetailByAccountID_Call) RunAndReturn(run func(ctx context.Context, accountID string) (*account.SavingsAccountDetail, error)) *MockSavingsAccountDetailRepository_GetSavingsAccountDetailByAccountID_Call {
+func (_c *MockSavingsAccountDetailRepository_GetFixedSavingsDueForMaturity_Call) RunAndReturn(run func(ctx context.Context, asOf time.Time) ([]*account.SavingsAccountDetail, error)) *MockSavingsAccountDetailRepository_GetFixedSavingsDueForMaturity_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateLastInterestCalcDate provides a mock function for the type MockSavingsAccountDetailRepository
-func (_mock *MockSavingsAccountDetailRepository) UpdateLastInterestCalcDate(ctx context.Context, accountID string, date *time.Time) error {
-	ret := _mock.Called(ctx, accountID, date)
+// GetFlexibleSavingsDueForInterest provides a mock function for the type MockSavingsAccountDetailRepository
+func (_mock *MockSavingsAccountDetailRepository) GetFlexibleSavingsDueForInterest(ctx context.Context, asOf time.Time) ([]*account.SavingsAccountDetail, error) {
+	ret := _mock.Called(ctx, asOf)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateLastInterestCalcDate")
+		panic("no return value specified for GetFlexibleSavingsDueForInterest")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *time.Time) error); ok {
-		r0 = returnFunc(ctx, accountID, date)
+	var r0 []*account.SavingsAccountDetail
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) ([]*account.SavingsAccountDetail, error)); ok {
+		return returnFunc(ctx, asOf)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) []*account.SavingsAccountDetail); ok {
+		r0 = returnFunc(ctx, asOf)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*account.SavingsAccountDetail)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockSavingsAccountDetailRepository_UpdateLastInterestCalcDate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLastInterestCalcDate'
-type MockSavingsAccountDetailRepository_UpdateLastInterestCalcDate_Call struct {
+// MockSavingsAccountDetailRepository_GetFlexibleSavingsDueForInterest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFlexibleSavingsDueForInterest'
+type MockSavingsAccountDetailRepository_GetFlexibleSavingsDueForInterest_Call struct {
 	*mock.Call
 }
 
-// UpdateLastInterestCalcDate is a helper method to define mock.On call
+// GetFlexibleSavingsDueForInterest is a helper method to define mock.On call
 //   - ctx context.Context
-//   - accountID string
-//   - date *time.Time
-func (_e *MockSavingsAccountDetailRepository_Expecter) UpdateLastInterestCalcDate(ctx interface{}, accountID interface{}, date interface{}) *MockSavingsAccountDetailRepository_UpdateLastInterestCalcDate_Call {
-	return &MockSavingsAccountDetailRepository_UpdateLastInterestCalcDate_Call{Call: _e.mock.On("UpdateLastInterestCalcDate", ctx, accountID, date)}
+//   - asOf time.Time
+func (_e *MockSavingsAccountDetailRepository_Expecter) GetFlexibleSavingsDueForInterest(ctx interface{}, asOf interface{}) *MockSavingsAccountDetailRepository_GetFlexibleSavingsDueForInterest_Call {
+	return &MockSavingsAccountDetailRepository_GetFlexibleSavingsDueForInterest_Call{Call: _e.mock.On("GetFlexibleSavingsDueForInterest", ctx, asOf)}
 }
 
-func (_c *MockSavingsAccountDetailRepository_UpdateLastInterestCalcDate_Call) Run(run func(ctx context.Context, accountID string, date *time.Time)) *MockSavingsAccountDetailRepository_UpdateLastInterestCalcDate_Call {
+func (_c *MockSavingsAccountDetailRepository_GetFlexibleSavingsDueForInterest_Call) Run(run func(ctx context.Context, asOf time.Time)) *MockSavingsAccountDetailRepository_GetFlexibleSavingsDueForInterest_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 time.Time
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 *time.Time
-		if args[2] != nil {
-			arg2 = args[2].(*time.Time)
+			arg1 = args[1].(time.Time)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSavingsAccountDetailRepository_UpdateLastInterestCalcDate_Call) Return(err error) *MockSavingsAccountDetailRepository_UpdateLastInterestCalcDate_Call {
-	_c.Call.Return(err)
+func (_c *MockSavingsAccountDetailRepository_GetFlexibleSavingsDueForInterest_Call) Return(savingsAccountDetails []*account.SavingsAccountDetail, err error) *MockSavingsAccountDetailRepository_GetFlexibleSavingsDueForInterest_Call {
+	_c.Call.Return(savingsAccountDetails, err)
 	return _c
 }
 
-func (_c *MockSavingsAccountDetailRepository_UpdateLastInterestCalcDate_Call) RunAndReturn(run func(ctx context.Context, accountID string, date *time.Time) error) *MockSavingsAccountDetailRepository_UpdateLastInterestCalcDate_Call {
+func (_c *MockSavingsAccountDetailRepository_GetFlexibleSavingsDueForInterest_Call) RunAndReturn(run func(ctx context.Context, asOf time.Time) ([]*account.SavingsAccountDetail, error)) *MockSavingsAccountDetailRepository_GetFlexibleSavingsDueForInterest_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockAccountService creates a new instance of MockAccountService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockAccountService(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockAccountService {
-	mock := &MockAccountService{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockAccountService is an autogenerated mock type for the AccountService type
-type MockAccountService struct {
-	mock.Mock
-}
-
-type MockAccountService_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockAccountService) EXPECT() *MockAccountService_Expecter {
-	return &MockAccountService_Expecter{mock: &_m.Mock}
-}
-
-// CreateFixedSavingsAccount provides a mock function for the type MockAccountService
-func (_mock *MockAccountService) CreateFixedSavingsAccount(ctx context.Context, userID string, req *account.CreateFixedSavingsAccountRequest) (*account.Account, error) {
-	ret := _mock.Called(ctx, userID, req)
+// GetRenewalCyclesByAccountIDs provides a mock function for the type MockSavingsAccountDetailRepository
+func (_mock *MockSavingsAccountDetailRepository) GetRenewalCyclesByAccountIDs(ctx context.Context, accountIDs []string) ([]*account.RenewalCycle, error) {
+	ret := _mock.Called(ctx, accountIDs)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateFixedSavingsAccount")
+		panic("no return value specified for GetRenewalCyclesByAccountIDs")
 	}
 
-	var r0 *account.Account
+	var r0 []*account.RenewalCycle
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *account.CreateFixedSavingsAccountRequest) (*account.Account, error)); ok {
-		return returnFunc(ctx, userID, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]*account.RenewalCycle, error)); ok {
+		return returnFunc(ctx, accountIDs)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *account.CreateFixedSavingsAccountRequest) *account.Account); ok {
-		r0 = returnFunc(ctx, userID, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []*account.RenewalCycle); ok {
+		r0 = returnFunc(ctx, accountIDs)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*account.Account)
+			r0 = ret.Get(0).([]*account.RenewalCycle)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *account.CreateFixedSavingsAccountRequest) error); ok {
-		r1 = returnFunc(ctx, userID, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, accountIDs)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockAccountService_CreateFixedSavingsAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateFixedSavingsAccount'
-type MockAccountService_CreateFixedSavingsAccount_Call struct {
+// MockSavingsAccountDetailRepository_GetRenewalCyclesByAccountIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRenewalCyclesByAccountIDs'
+type MockSavingsAccountDetailRepository_GetRenewalCyclesByAccountIDs_Call struct {
 	*mock.Call
 }
 
-// CreateFixedSavingsAccount is a helper method to define mock.On call
+// GetRenewalCyclesByAccountIDs is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-//   - req *account.CreateFixedSavingsAccountRequest
-func (_e *MockAccountService_Expecter) CreateFixedSavingsAccount(ctx interface{}, userID interface{}, req interface{}) *MockAccountService_CreateFixedSavingsAccount_Call {
-	return &MockAccountService_CreateFixedSavingsAccount_Call{Call: _e.mock.On("CreateFixedSavingsAccount", ctx, userID, req)}
+//   - accountIDs []string
+func (_e *MockSavingsAccountDetailRepository_Expecter) GetRenewalCyclesByAccountIDs(ctx interface{}, accountIDs interface{}) *MockSavingsAccountDetailRepository_GetRenewalCyclesByAccountIDs_Call {
+	return &MockSavingsAccountDetailRepository_GetRenewalCyclesByAccountIDs_Call{Call: _e.mock.On("GetRenewalCyclesByAccountIDs", ctx, accountIDs)}
 }
 
-func (_c *MockAccountService_CreateFixedSavingsAccount_Call) Run(run func(ctx context.Context, userID string, req *account.CreateFixedSavingsAccountRequest)) *MockAccountService_CreateFixedSavingsAccount_Call {
+func (_c *MockSavingsAccountDetailRepository_GetRenewalCyclesByAccountIDs_Call) Run(run func(ctx context.Context, accountIDs []string)) *MockSavingsAccountDetailRepository_GetRenewalCyclesByAccountIDs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 *account.CreateFixedSavingsAccountRequest
-		if args[2] != nil {
-			arg2 = args[2].(*account.CreateFixedSavingsAccountRequest)
+			arg1 = args[1].([]string)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockAccountService_CreateFixedSavingsAccount_Call) Return(account1 *account.Account, err error) *MockAccountService_CreateFixedSavingsAccount_Call {
-	_c.Call.Return(account1, err)
+func (_c *MockSavingsAccountDetailRepository_GetRenewalCyclesByAccountIDs_Call) Return(renewalCycles []*account.RenewalCycle, err error) *MockSavingsAccountDetailRepository_GetRenewalCyclesByAccountIDs_Call {
+	_c.Call.Return(renewalCycles, err)
 	return _c
 }
 
-func (_c *MockAccountService_CreateFixedSavingsAccount_Call) RunAndReturn(run func(ctx context.Context, userID string, req *account.CreateFixedSavingsAccountRequest) (*account.Account, error)) *MockAccountService_CreateFixedSavingsAccount_Call {
+func (_c *MockSavingsAccountDetailRepository_GetRenewalCyclesByAccountIDs_Call) RunAndReturn(run func(ctx context.Context, accountIDs []string) ([]*account.RenewalCycle, error)) *MockSavingsAccountDetailRepository_GetRenewalCyclesByAccountIDs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateFlexibleSavingsAccount provides a mock function for the type MockAccountService
-func (_mock *MockAccountService) CreateFlexibleSavingsAccount(ctx context.Context, userID string) (*account.Account, error) {
-	ret := _mock.Called(ctx, userID)
+// GetSavingsAccountDetailByAccountID provides a mock function for the type MockSavingsAccountDetailRepository
+func (_mock *MockSavingsAccountDetailRepository) GetSavingsAccountDetailByAccountID(ctx context.Context, accountID string) (*account.SavingsAccountDetail, error) {
+	ret := _mock.Called(ctx, accountID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateFlexibleSavingsAccount")
+		panic("no return value specified for GetSavingsAccountDetailByAccountID")
 	}
 
-	var r0 *account.Account
+	var r0 *account.SavingsAccountDetail
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*account.Account, error)); ok {
-		return returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*account.SavingsAccountDetail, error)); ok {
+		return returnFunc(ctx, accountID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *account.Account); ok {
-		r0 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *account.SavingsAccountDetail); ok {
+		r0 = returnFunc(ctx, accountID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*account.Account)
+			r0 = ret.Get(0).(*account.SavingsAccountDetail)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, userID)
+		r1 = returnFunc(ctx, accountID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockAccountService_CreateFlexibleSavingsAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateFlexibleSavingsAccount'
-type MockAccountService_CreateFlexibleSavingsAccount_Call struct {
+// MockSavingsAccountDetailRepository_GetSavingsAccountDetailByAccountID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSavingsAccountDetailByAccountID'
+type MockSavingsAccountDetailRepository_GetSavingsAccountDetailByAccountID_Call struct {
 	*mock.Call
 }
 
-// CreateFlexibleSavingsAccount is a helper method to define mock.On call
+// GetSavingsAccountDetailByAccountID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-func (_e *MockAccountService_Expecter) CreateFlexibleSavingsAccount(ctx interface{}, userID interface{}) *MockAccountService_CreateFlexibleSavingsAccount_Call {
-	return &MockAccountService_CreateFlexibleSavingsAccount_Call{Call: _e.mock.On("CreateFlexibleSavingsAccount", ctx, userID)}
+//   - accountID string
+func (_e *MockSavingsAccountDetailRepository_Expecter) GetSavingsAccountDetailByAccountID(ctx interface{}, accountID interface{}) *MockSavingsAccountDetailRepository_GetSavingsAccountDetailByAccountID_Call {
+	return &MockSavingsAccountDetailRepository_GetSavingsAccountDetailByAccountID_Call{Call: _e.mock.On("GetSavingsAccountDetailByAccountID", ctx, accountID)}
 }
 
-func (_c *MockAccountService_CreateFlexibleSavingsAccount_Call) Run(run func(ctx context.Context, userID string)) *MockAccountService_CreateFlexibleSavingsAccount_Call {
+func (_c *MockSavingsAccountDetailRepository_GetSavingsAccountDetailByAccountID_Call) Run(run func(ctx context.Context, accountID string)) *MockSavingsAccountDetailRepository_GetSavingsAccountDetailByAccountID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -956,57 +1265,49 @@ func (_c *MockAccountService_CreateFlexibleSavingsAccount_Call) Run(run func(ctx
 	return _c
 }
 
-func (_c *MockAccountService_CreateFlexibleSavingsAccount_Call) Return(account1 *account.Account, err error) *MockAccountService_CreateFlexibleSavingsAccount_Call {
-	_c.Call.Return(account1, err)
+func (_c *MockSavingsAccountDetailRepository_GetSavingsAccountDetailByAccountID_Call) Return(savingsAccountDetail *account.SavingsAccountDetail, err error) *MockSavingsAccountDetailRepository_GetSavingsAccountDetailByAccountID_Call {
+	_c.Call.Return(savingsAccountDetail, err)
 	return _c
 }
 
-func (_c *MockAccountService_CreateFlexibleSavingsAccount_Call) RunAndReturn(run func(ctx context.Context, userID string) (*account.Account, error)) *MockAccountService_CreateFlexibleSavingsAccount_Call {
+func (_c *MockSavingsAccountDetailRepository_GetSavingsAccountDetailByAccountID_Call) RunAndReturn(run func(ctx context.Context, accountID string) (*account.SavingsAccountDetail, error)) *MockSavingsAccountDetailRepository_GetSavingsAccountDetailByAccountID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreatePaymentAccount provides a mock function for the type MockAccountService
-func (_mock *MockAccountService) CreatePaymentAccount(ctx context.Context, userID string) (*account.Account, error) {
-	ret := _mock.Called(ctx, userID)
+// RenewFixedTerm provides a mock function for the type MockSavingsAccountDetailRepository
+func (_mock *MockSavingsAccountDetailRepository) RenewFixedTerm(ctx context.Context, accountID string, startDate time.Time, maturityDate time.Time, rate float64) error {
+	ret := _mock.Called(ctx, accountID, startDate, maturityDate, rate)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreatePaymentAccount")
+		panic("no return value specified for RenewFixedTerm")
 	}
 
-	var r0 *account.Account
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*account.Account, error)); ok {
-		return returnFunc(ctx, userID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *account.Account); ok {
-		r0 = returnFunc(ctx, userID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*account.Account)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, userID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time, float64) error); ok {
+		r0 = returnFunc(ctx, accountID, startDate, maturityDate, rate)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockAccountService_CreatePaymentAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePaymentAccount'
-type MockAccountService_CreatePaymentAccount_Call struct {
+// MockSavingsAccountDetailRepository_RenewFixedTerm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenewFixedTerm'
+type MockSavingsAccountDetailRepository_RenewFixedTerm_Call struct {
 	*mock.Call
 }
 
-// CreatePaymentAccount is a helper method to define mock.On call
+// RenewFixedTerm is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-func (_e *MockAccountService_Expecter) CreatePaymentAccount(ctx interface{}, userID interface{}) *MockAccountService_CreatePaymentAccount_Call {
-	return &MockAccountService_CreatePaymentAccount_Call{Call: _e.mock.On("CreatePaymentAccount", ctx, userID)}
+//   - accountID string
+//   - startDate time.Time
+//   - maturityDate time.Time
+//   - rate float64
+func (_e *MockSavingsAccountDetailRepository_Expecter) RenewFixedTerm(ctx interface{}, accountID interface{}, startDate interface{}, maturityDate interface{}, rate interface{}) *MockSavingsAccountDetailRepository_RenewFixedTerm_Call {
+	return &MockSavingsAccountDetailRepository_RenewFixedTerm_Call{Call: _e.mock.On("RenewFixedTerm", ctx, accountID, startDate, maturityDate, rate)}
 }
 
-func (_c *MockAccountService_CreatePaymentAccount_Call) Run(run func(ctx context.Context, userID string)) *MockAccountService_CreatePaymentAccount_Call {
+func (_c *MockSavingsAccountDetailRepository_RenewFixedTerm_Call) Run(run func(ctx context.Context, accountID string, startDate time.Time, maturityDate time.Time, rate float64)) *MockSavingsAccountDetailRepository_RenewFixedTerm_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1016,65 +1317,70 @@ func (_c *MockAccountService_CreatePaymentAccount_Call) Run(run func(ctx context
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		var arg4 float64
+		if args[4] != nil {
+			arg4 = args[4].(float64)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
+			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockAccountService_CreatePaymentAccount_Call) Return(account1 *account.Account, err error) *MockAccountService_CreatePaymentAccount_Call {
-	_c.Call.Return(account1, err)
+func (_c *MockSavingsAccountDetailRepository_RenewFixedTerm_Call) Return(err error) *MockSavingsAccountDetailRepository_RenewFixedTerm_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockAccountService_CreatePaymentAccount_Call) RunAndReturn(run func(ctx context.Context, userID string) (*account.Account, error)) *MockAccountService_CreatePaymentAccount_Call {
+func (_c *MockSavingsAccountDetailRepository_RenewFixedTerm_Call) RunAndReturn(run func(ctx context.Context, accountID string, startDate time.Time, maturityDate time.Time, rate float64) error) *MockSavingsAccountDetailRepository_RenewFixedTerm_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListAccounts provides a mock function for the type MockAccountService
-func (_mock *MockAccountService) ListAccounts(ctx context.Context, userID string) (*account.ListAccountsResponse, error) {
-	ret := _mock.Called(ctx, userID)
+// UpdateInterestCarry provides a mock function for the type MockSavingsAccountDetailRepository
+func (_mock *MockSavingsAccountDetailRepository) UpdateInterestCarry(ctx context.Context, accountID string, carry int64) error {
+	ret := _mock.Called(ctx, accountID, carry)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListAccounts")
+		panic("no return value specified for UpdateInterestCarry")
 	}
 
-	var r0 *account.ListAccountsResponse
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*account.ListAccountsResponse, error)); ok {
-		return returnFunc(ctx, userID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *account.ListAccountsResponse); ok {
-		r0 = returnFunc(ctx, userID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*account.ListAccountsResponse)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, userID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = returnFunc(ctx, accountID, carry)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockAccountService_ListAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAccounts'
-type MockAccountService_ListAccounts_Call struct {
+// MockSavingsAccountDetailRepository_UpdateInterestCarry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateInterestCarry'
+type MockSavingsAccountDetailRepository_UpdateInterestCarry_Call struct {
 	*mock.Call
 }
 
-// ListAccounts is a helper method to define mock.On call
+// UpdateInterestCarry is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-func (_e *MockAccountService_Expecter) ListAccounts(ctx interface{}, userID interface{}) *MockAccountService_ListAccounts_Call {
-	return &MockAccountService_ListAccounts_Call{Call: _e.mock.On("ListAccounts", ctx, userID)}
+//   - accountID string
+//   - carry int64
+func (_e *MockSavingsAccountDetailRepository_Expecter) UpdateInterestCarry(ctx interface{}, accountID interface{}, carry interface{}) *MockSavingsAccountDetailRepository_UpdateInterestCarry_Call {
+	return &MockSavingsAccountDetailRepository_UpdateInterestCarry_Call{Call: _e.mock.On("UpdateInterestCarry", ctx, accountID, carry)}
 }
 
-func (_c *MockAccountService_ListAccounts_Call) Run(run func(ctx context.Context, userID string)) *MockAccountService_ListAccounts_Call {
+func (_c *MockSavingsAccountDetailRepository_UpdateInterestCarry_Call) Run(run func(ctx context.Context, accountID string, carry int64)) *MockSavingsAccountDetailRepository_UpdateInterestCarry_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1084,175 +1390,4201 @@ func (_c *MockAccountService_ListAccounts_Call) Run(run func(ctx context.Context
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockAccountService_ListAccounts_Call) Return(listAccountsResponse *account.ListAccountsResponse, err error) *MockAccountService_ListAccounts_Call {
-	_c.Call.Return(listAccountsResponse, err)
+func (_c *MockSavingsAccountDetailRepository_UpdateInterestCarry_Call) Return(err error) *MockSavingsAccountDetailRepository_UpdateInterestCarry_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockAccountService_ListAccounts_Call) RunAndReturn(run func(ctx context.Context, userID string) (*account.ListAccountsResponse, error)) *MockAccountService_ListAccounts_Call {
+func (_c *MockSavingsAccountDetailRepository_UpdateInterestCarry_Call) RunAndReturn(run func(ctx context.Context, accountID string, carry int64) error) *MockSavingsAccountDetailRepository_UpdateInterestCarry_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockPasswordService creates a new instance of MockPasswordService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockPasswordService(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockPasswordService {
-	mock := &MockPasswordService{}
-	mock.Mock.Test(t)
+// UpdateLastInterestCalcDate provides a mock function for the type MockSavingsAccountDetailRepository
+func (_mock *MockSavingsAccountDetailRepository) UpdateLastInterestCalcDate(ctx context.Context, accountID string, date *time.Time) error {
+	ret := _mock.Called(ctx, accountID, date)
 
-	t.Cleanup(func() { mock.AssertExpectations(t) })
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLastInterestCalcDate")
+	}
 
-	return mock
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *time.Time) error); ok {
+		r0 = returnFunc(ctx, accountID, date)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
 }
 
-// MockPasswordService is an autogenerated mock type for the PasswordService type
+// MockSavingsAccountDetailRepository_UpdateLastInterestCalcDate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLastInterestCalcDate'
+type MockSavingsAccountDetailRepository_UpdateLastInterestCalcDate_Call struct {
+	*mock.Call
+}
+
+// UpdateLastInterestCalcDate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID string
+//   - date *time.Time
+func (_e *MockSavingsAccountDetailRepository_Expecter) UpdateLastInterestCalcDate(ctx interface{}, accountID interface{}, date interface{}) *MockSavingsAccountDetailRepository_UpdateLastInterestCalcDate_Call {
+	return &MockSavingsAccountDetailRepository_UpdateLastInterestCalcDate_Call{Call: _e.mock.On("UpdateLastInterestCalcDate", ctx, accountID, date)}
+}
+
+func (_c *MockSavingsAccountDetailRepository_UpdateLastInterestCalcDate_Call) Run(run func(ctx context.Context, accountID string, date *time.Time)) *MockSavingsAccountDetailRepository_UpdateLastInterestCalcDate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *time.Time
+		if args[2] != nil {
+			arg2 = args[2].(*time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSavingsAccountDetailRepository_UpdateLastInterestCalcDate_Call) Return(err error) *MockSavingsAccountDetailRepository_UpdateLastInterestCalcDate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSavingsAccountDetailRepository_UpdateLastInterestCalcDate_Call) RunAndReturn(run func(ctx context.Context, accountID string, date *time.Time) error) *MockSavingsAccountDetailRepository_UpdateLastInterestCalcDate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMaturityInstruction provides a mock function for the type MockSavingsAccountDetailRepository
+func (_mock *MockSavingsAccountDetailRepository) UpdateMaturityInstruction(ctx context.Context, accountID string, instruction string) error {
+	ret := _mock.Called(ctx, accountID, instruction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMaturityInstruction")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, accountID, instruction)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSavingsAccountDetailRepository_UpdateMaturityInstruction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMaturityInstruction'
+type MockSavingsAccountDetailRepository_UpdateMaturityInstruction_Call struct {
+	*mock.Call
+}
+
+// UpdateMaturityInstruction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID string
+//   - instruction string
+func (_e *MockSavingsAccountDetailRepository_Expecter) UpdateMaturityInstruction(ctx interface{}, accountID interface{}, instruction interface{}) *MockSavingsAccountDetailRepository_UpdateMaturityInstruction_Call {
+	return &MockSavingsAccountDetailRepository_UpdateMaturityInstruction_Call{Call: _e.mock.On("UpdateMaturityInstruction", ctx, accountID, instruction)}
+}
+
+func (_c *MockSavingsAccountDetailRepository_UpdateMaturityInstruction_Call) Run(run func(ctx context.Context, accountID string, instruction string)) *MockSavingsAccountDetailRepository_UpdateMaturityInstruction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSavingsAccountDetailRepository_UpdateMaturityInstruction_Call) Return(err error) *MockSavingsAccountDetailRepository_UpdateMaturityInstruction_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSavingsAccountDetailRepository_UpdateMaturityInstruction_Call) RunAndReturn(run func(ctx context.Context, accountID string, instruction string) error) *MockSavingsAccountDetailRepository_UpdateMaturityInstruction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAccountService creates a new instance of MockAccountService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAccountService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAccountService {
+	mock := &MockAccountService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAccountService is an autogenerated mock type for the AccountService type
+type MockAccountService struct {
+	mock.Mock
+}
+
+type MockAccountService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAccountService) EXPECT() *MockAccountService_Expecter {
+	return &MockAccountService_Expecter{mock: &_m.Mock}
+}
+
+// CreateFixedSavingsAccount provides a mock function for the type MockAccountService
+func (_mock *MockAccountService) CreateFixedSavingsAccount(ctx context.Context, userID string, req *account.CreateFixedSavingsAccountRequest) (*account.Account, error) {
+	ret := _mock.Called(ctx, userID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFixedSavingsAccount")
+	}
+
+	var r0 *account.Account
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *account.CreateFixedSavingsAccountRequest) (*account.Account, error)); ok {
+		return returnFunc(ctx, userID, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *account.CreateFixedSavingsAccountRequest) *account.Account); ok {
+		r0 = returnFunc(ctx, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*account.Account)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *account.CreateFixedSavingsAccountRequest) error); ok {
+		r1 = returnFunc(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountService_CreateFixedSavingsAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateFixedSavingsAccount'
+type MockAccountService_CreateFixedSavingsAccount_Call struct {
+	*mock.Call
+}
+
+// CreateFixedSavingsAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - req *account.CreateFixedSavingsAccountRequest
+func (_e *MockAccountService_Expecter) CreateFixedSavingsAccount(ctx interface{}, userID interface{}, req interface{}) *MockAccountService_CreateFixedSavingsAccount_Call {
+	return &MockAccountService_CreateFixedSavingsAccount_Call{Call: _e.mock.On("CreateFixedSavingsAccount", ctx, userID, req)}
+}
+
+func (_c *MockAccountService_CreateFixedSavingsAccount_Call) Run(run func(ctx context.Context, userID string, req *account.CreateFixedSavingsAccountRequest)) *MockAccountService_CreateFixedSavingsAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *account.CreateFixedSavingsAccountRequest
+		if args[2] != nil {
+			arg2 = args[2].(*account.CreateFixedSavingsAccountRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountService_CreateFixedSavingsAccount_Call) Return(account1 *account.Account, err error) *MockAccountService_CreateFixedSavingsAccount_Call {
+	_c.Call.Return(account1, err)
+	return _c
+}
+
+func (_c *MockAccountService_CreateFixedSavingsAccount_Call) RunAndReturn(run func(ctx context.Context, userID string, req *account.CreateFixedSavingsAccountRequest) (*account.Account, error)) *MockAccountService_CreateFixedSavingsAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateFlexibleSavingsAccount provides a mock function for the type MockAccountService
+func (_mock *MockAccountService) CreateFlexibleSavingsAccount(ctx context.Context, userID string) (*account.Account, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFlexibleSavingsAccount")
+	}
+
+	var r0 *account.Account
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*account.Account, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *account.Account); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*account.Account)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountService_CreateFlexibleSavingsAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateFlexibleSavingsAccount'
+type MockAccountService_CreateFlexibleSavingsAccount_Call struct {
+	*mock.Call
+}
+
+// CreateFlexibleSavingsAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockAccountService_Expecter) CreateFlexibleSavingsAccount(ctx interface{}, userID interface{}) *MockAccountService_CreateFlexibleSavingsAccount_Call {
+	return &MockAccountService_CreateFlexibleSavingsAccount_Call{Call: _e.mock.On("CreateFlexibleSavingsAccount", ctx, userID)}
+}
+
+func (_c *MockAccountService_CreateFlexibleSavingsAccount_Call) Run(run func(ctx context.Context, userID string)) *MockAccountService_CreateFlexibleSavingsAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountService_CreateFlexibleSavingsAccount_Call) Return(account1 *account.Account, err error) *MockAccountService_CreateFlexibleSavingsAccount_Call {
+	_c.Call.Return(account1, err)
+	return _c
+}
+
+func (_c *MockAccountService_CreateFlexibleSavingsAccount_Call) RunAndReturn(run func(ctx context.Context, userID string) (*account.Account, error)) *MockAccountService_CreateFlexibleSavingsAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePaymentAccount provides a mock function for the type MockAccountService
+func (_mock *MockAccountService) CreatePaymentAccount(ctx context.Context, userID string) (*account.Account, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePaymentAccount")
+	}
+
+	var r0 *account.Account
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*account.Account, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *account.Account); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*account.Account)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountService_CreatePaymentAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePaymentAccount'
+type MockAccountService_CreatePaymentAccount_Call struct {
+	*mock.Call
+}
+
+// CreatePaymentAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockAccountService_Expecter) CreatePaymentAccount(ctx interface{}, userID interface{}) *MockAccountService_CreatePaymentAccount_Call {
+	return &MockAccountService_CreatePaymentAccount_Call{Call: _e.mock.On("CreatePaymentAccount", ctx, userID)}
+}
+
+func (_c *MockAccountService_CreatePaymentAccount_Call) Run(run func(ctx context.Context, userID string)) *MockAccountService_CreatePaymentAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountService_CreatePaymentAccount_Call) Return(account1 *account.Account, err error) *MockAccountService_CreatePaymentAccount_Call {
+	_c.Call.Return(account1, err)
+	return _c
+}
+
+func (_c *MockAccountService_CreatePaymentAccount_Call) RunAndReturn(run func(ctx context.Context, userID string) (*account.Account, error)) *MockAccountService_CreatePaymentAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DepositToFlexibleSavings provides a mock function for the type MockAccountService
+func (_mock *MockAccountService) DepositToFlexibleSavings(ctx context.Context, userID string, accountID string, amount float64) (*account.Account, error) {
+	ret := _mock.Called(ctx, userID, accountID, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DepositToFlexibleSavings")
+	}
+
+	var r0 *account.Account
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, float64) (*account.Account, error)); ok {
+		return returnFunc(ctx, userID, accountID, amount)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, float64) *account.Account); ok {
+		r0 = returnFunc(ctx, userID, accountID, amount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*account.Account)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, float64) error); ok {
+		r1 = returnFunc(ctx, userID, accountID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountService_DepositToFlexibleSavings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DepositToFlexibleSavings'
+type MockAccountService_DepositToFlexibleSavings_Call struct {
+	*mock.Call
+}
+
+// DepositToFlexibleSavings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - accountID string
+//   - amount float64
+func (_e *MockAccountService_Expecter) DepositToFlexibleSavings(ctx interface{}, userID interface{}, accountID interface{}, amount interface{}) *MockAccountService_DepositToFlexibleSavings_Call {
+	return &MockAccountService_DepositToFlexibleSavings_Call{Call: _e.mock.On("DepositToFlexibleSavings", ctx, userID, accountID, amount)}
+}
+
+func (_c *MockAccountService_DepositToFlexibleSavings_Call) Run(run func(ctx context.Context, userID string, accountID string, amount float64)) *MockAccountService_DepositToFlexibleSavings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 float64
+		if args[3] != nil {
+			arg3 = args[3].(float64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountService_DepositToFlexibleSavings_Call) Return(account1 *account.Account, err error) *MockAccountService_DepositToFlexibleSavings_Call {
+	_c.Call.Return(account1, err)
+	return _c
+}
+
+func (_c *MockAccountService_DepositToFlexibleSavings_Call) RunAndReturn(run func(ctx context.Context, userID string, accountID string, amount float64) (*account.Account, error)) *MockAccountService_DepositToFlexibleSavings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAccounts provides a mock function for the type MockAccountService
+func (_mock *MockAccountService) ListAccounts(ctx context.Context, userID string) (*account.ListAccountsResponse, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAccounts")
+	}
+
+	var r0 *account.ListAccountsResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*account.ListAccountsResponse, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *account.ListAccountsResponse); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*account.ListAccountsResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountService_ListAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAccounts'
+type MockAccountService_ListAccounts_Call struct {
+	*mock.Call
+}
+
+// ListAccounts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockAccountService_Expecter) ListAccounts(ctx interface{}, userID interface{}) *MockAccountService_ListAccounts_Call {
+	return &MockAccountService_ListAccounts_Call{Call: _e.mock.On("ListAccounts", ctx, userID)}
+}
+
+func (_c *MockAccountService_ListAccounts_Call) Run(run func(ctx context.Context, userID string)) *MockAccountService_ListAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountService_ListAccounts_Call) Return(listAccountsResponse *account.ListAccountsResponse, err error) *MockAccountService_ListAccounts_Call {
+	_c.Call.Return(listAccountsResponse, err)
+	return _c
+}
+
+func (_c *MockAccountService_ListAccounts_Call) RunAndReturn(run func(ctx context.Context, userID string) (*account.ListAccountsResponse, error)) *MockAccountService_ListAccounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMaturityInstruction provides a mock function for the type MockAccountService
+func (_mock *MockAccountService) UpdateMaturityInstruction(ctx context.Context, userID string, accountID string, instruction string) (*account.SavingsAccountDetail, error) {
+	ret := _mock.Called(ctx, userID, accountID, instruction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMaturityInstruction")
+	}
+
+	var r0 *account.SavingsAccountDetail
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (*account.SavingsAccountDetail, error)); ok {
+		return returnFunc(ctx, userID, accountID, instruction)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) *account.SavingsAccountDetail); ok {
+		r0 = returnFunc(ctx, userID, accountID, instruction)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*account.SavingsAccountDetail)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, accountID, instruction)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountService_UpdateMaturityInstruction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMaturityInstruction'
+type MockAccountService_UpdateMaturityInstruction_Call struct {
+	*mock.Call
+}
+
+// UpdateMaturityInstruction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - accountID string
+//   - instruction string
+func (_e *MockAccountService_Expecter) UpdateMaturityInstruction(ctx interface{}, userID interface{}, accountID interface{}, instruction interface{}) *MockAccountService_UpdateMaturityInstruction_Call {
+	return &MockAccountService_UpdateMaturityInstruction_Call{Call: _e.mock.On("UpdateMaturityInstruction", ctx, userID, accountID, instruction)}
+}
+
+func (_c *MockAccountService_UpdateMaturityInstruction_Call) Run(run func(ctx context.Context, userID string, accountID string, instruction string)) *MockAccountService_UpdateMaturityInstruction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountService_UpdateMaturityInstruction_Call) Return(savingsAccountDetail *account.SavingsAccountDetail, err error) *MockAccountService_UpdateMaturityInstruction_Call {
+	_c.Call.Return(savingsAccountDetail, err)
+	return _c
+}
+
+func (_c *MockAccountService_UpdateMaturityInstruction_Call) RunAndReturn(run func(ctx context.Context, userID string, accountID string, instruction string) (*account.SavingsAccountDetail, error)) *MockAccountService_UpdateMaturityInstruction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithdrawFromFlexibleSavings provides a mock function for the type MockAccountService
+func (_mock *MockAccountService) WithdrawFromFlexibleSavings(ctx context.Context, userID string, accountID string, amount float64) (*account.Account, error) {
+	ret := _mock.Called(ctx, userID, accountID, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithdrawFromFlexibleSavings")
+	}
+
+	var r0 *account.Account
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, float64) (*account.Account, error)); ok {
+		return returnFunc(ctx, userID, accountID, amount)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, float64) *account.Account); ok {
+		r0 = returnFunc(ctx, userID, accountID, amount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*account.Account)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, float64) error); ok {
+		r1 = returnFunc(ctx, userID, accountID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountService_WithdrawFromFlexibleSavings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithdrawFromFlexibleSavings'
+type MockAccountService_WithdrawFromFlexibleSavings_Call struct {
+	*mock.Call
+}
+
+// WithdrawFromFlexibleSavings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - accountID string
+//   - amount float64
+func (_e *MockAccountService_Expecter) WithdrawFromFlexibleSavings(ctx interface{}, userID interface{}, accountID interface{}, amount interface{}) *MockAccountService_WithdrawFromFlexibleSavings_Call {
+	return &MockAccountService_WithdrawFromFlexibleSavings_Call{Call: _e.mock.On("WithdrawFromFlexibleSavings", ctx, userID, accountID, amount)}
+}
+
+func (_c *MockAccountService_WithdrawFromFlexibleSavings_Call) Run(run func(ctx context.Context, userID string, accountID string, amount float64)) *MockAccountService_WithdrawFromFlexibleSavings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 float64
+		if args[3] != nil {
+			arg3 = args[3].(float64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountService_WithdrawFromFlexibleSavings_Call) Return(account1 *account.Account, err error) *MockAccountService_WithdrawFromFlexibleSavings_Call {
+	_c.Call.Return(account1, err)
+	return _c
+}
+
+func (_c *MockAccountService_WithdrawFromFlexibleSavings_Call) RunAndReturn(run func(ctx context.Context, userID string, accountID string, amount float64) (*account.Account, error)) *MockAccountService_WithdrawFromFlexibleSavings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAuditLogger creates a new instance of MockAuditLogger. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuditLogger(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuditLogger {
+	mock := &MockAuditLogger{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAuditLogger is an autogenerated mock type for the AuditLogger type
+type MockAuditLogger struct {
+	mock.Mock
+}
+
+type MockAuditLogger_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuditLogger) EXPECT() *MockAuditLogger_Expecter {
+	return &MockAuditLogger_Expecter{mock: &_m.Mock}
+}
+
+// Log provides a mock function for the type MockAuditLogger
+func (_mock *MockAuditLogger) Log(ctx context.Context, entry *audit.Entry) error {
+	ret := _mock.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Log")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *audit.Entry) error); ok {
+		r0 = returnFunc(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuditLogger_Log_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Log'
+type MockAuditLogger_Log_Call struct {
+	*mock.Call
+}
+
+// Log is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entry *audit.Entry
+func (_e *MockAuditLogger_Expecter) Log(ctx interface{}, entry interface{}) *MockAuditLogger_Log_Call {
+	return &MockAuditLogger_Log_Call{Call: _e.mock.On("Log", ctx, entry)}
+}
+
+func (_c *MockAuditLogger_Log_Call) Run(run func(ctx context.Context, entry *audit.Entry)) *MockAuditLogger_Log_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *audit.Entry
+		if args[1] != nil {
+			arg1 = args[1].(*audit.Entry)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditLogger_Log_Call) Return(err error) *MockAuditLogger_Log_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuditLogger_Log_Call) RunAndReturn(run func(ctx context.Context, entry *audit.Entry) error) *MockAuditLogger_Log_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Query provides a mock function for the type MockAuditLogger
+func (_mock *MockAuditLogger) Query(ctx context.Context, filter *audit.Filter) (*audit.QueryResult, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Query")
+	}
+
+	var r0 *audit.QueryResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *audit.Filter) (*audit.QueryResult, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *audit.Filter) *audit.QueryResult); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*audit.QueryResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *audit.Filter) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuditLogger_Query_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Query'
+type MockAuditLogger_Query_Call struct {
+	*mock.Call
+}
+
+// Query is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *audit.Filter
+func (_e *MockAuditLogger_Expecter) Query(ctx interface{}, filter interface{}) *MockAuditLogger_Query_Call {
+	return &MockAuditLogger_Query_Call{Call: _e.mock.On("Query", ctx, filter)}
+}
+
+func (_c *MockAuditLogger_Query_Call) Run(run func(ctx context.Context, filter *audit.Filter)) *MockAuditLogger_Query_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *audit.Filter
+		if args[1] != nil {
+			arg1 = args[1].(*audit.Filter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditLogger_Query_Call) Return(queryResult *audit.QueryResult, err error) *MockAuditLogger_Query_Call {
+	_c.Call.Return(queryResult, err)
+	return _c
+}
+
+func (_c *MockAuditLogger_Query_Call) RunAndReturn(run func(ctx context.Context, filter *audit.Filter) (*audit.QueryResult, error)) *MockAuditLogger_Query_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Verify provides a mock function for the type MockAuditLogger
+func (_mock *MockAuditLogger) Verify(ctx context.Context) (*audit.VerifyResult, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 *audit.VerifyResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*audit.VerifyResult, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *audit.VerifyResult); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*audit.VerifyResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuditLogger_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type MockAuditLogger_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAuditLogger_Expecter) Verify(ctx interface{}) *MockAuditLogger_Verify_Call {
+	return &MockAuditLogger_Verify_Call{Call: _e.mock.On("Verify", ctx)}
+}
+
+func (_c *MockAuditLogger_Verify_Call) Run(run func(ctx context.Context)) *MockAuditLogger_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditLogger_Verify_Call) Return(verifyResult *audit.VerifyResult, err error) *MockAuditLogger_Verify_Call {
+	_c.Call.Return(verifyResult, err)
+	return _c
+}
+
+func (_c *MockAuditLogger_Verify_Call) RunAndReturn(run func(ctx context.Context) (*audit.VerifyResult, error)) *MockAuditLogger_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockBankLinkRepository creates a new instance of MockBankLinkRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBankLinkRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBankLinkRepository {
+	mock := &MockBankLinkRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockBankLinkRepository is an autogenerated mock type for the BankLinkRepository type
+type MockBankLinkRepository struct {
+	mock.Mock
+}
+
+type MockBankLinkRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBankLinkRepository) EXPECT() *MockBankLinkRepository_Expecter {
+	return &MockBankLinkRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockBankLinkRepository
+func (_mock *MockBankLinkRepository) Create(ctx context.Context, link *banklink.BankLink) (*banklink.BankLink, error) {
+	ret := _mock.Called(ctx, link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *banklink.BankLink
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *banklink.BankLink) (*banklink.BankLink, error)); ok {
+		return returnFunc(ctx, link)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *banklink.BankLink) *banklink.BankLink); ok {
+		r0 = returnFunc(ctx, link)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*banklink.BankLink)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *banklink.BankLink) error); ok {
+		r1 = returnFunc(ctx, link)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBankLinkRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockBankLinkRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - link *banklink.BankLink
+func (_e *MockBankLinkRepository_Expecter) Create(ctx interface{}, link interface{}) *MockBankLinkRepository_Create_Call {
+	return &MockBankLinkRepository_Create_Call{Call: _e.mock.On("Create", ctx, link)}
+}
+
+func (_c *MockBankLinkRepository_Create_Call) Run(run func(ctx context.Context, link *banklink.BankLink)) *MockBankLinkRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *banklink.BankLink
+		if args[1] != nil {
+			arg1 = args[1].(*banklink.BankLink)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBankLinkRepository_Create_Call) Return(bankLink *banklink.BankLink, err error) *MockBankLinkRepository_Create_Call {
+	_c.Call.Return(bankLink, err)
+	return _c
+}
+
+func (_c *MockBankLinkRepository_Create_Call) RunAndReturn(run func(ctx context.Context, link *banklink.BankLink) (*banklink.BankLink, error)) *MockBankLinkRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUserID provides a mock function for the type MockBankLinkRepository
+func (_mock *MockBankLinkRepository) GetByUserID(ctx context.Context, userID string) ([]*banklink.BankLink, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserID")
+	}
+
+	var r0 []*banklink.BankLink
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*banklink.BankLink, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*banklink.BankLink); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*banklink.BankLink)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBankLinkRepository_GetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserID'
+type MockBankLinkRepository_GetByUserID_Call struct {
+	*mock.Call
+}
+
+// GetByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockBankLinkRepository_Expecter) GetByUserID(ctx interface{}, userID interface{}) *MockBankLinkRepository_GetByUserID_Call {
+	return &MockBankLinkRepository_GetByUserID_Call{Call: _e.mock.On("GetByUserID", ctx, userID)}
+}
+
+func (_c *MockBankLinkRepository_GetByUserID_Call) Run(run func(ctx context.Context, userID string)) *MockBankLinkRepository_GetByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBankLinkRepository_GetByUserID_Call) Return(bankLinks []*banklink.BankLink, err error) *MockBankLinkRepository_GetByUserID_Call {
+	_c.Call.Return(bankLinks, err)
+	return _c
+}
+
+func (_c *MockBankLinkRepository_GetByUserID_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]*banklink.BankLink, error)) *MockBankLinkRepository_GetByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockBusinessCalendar creates a new instance of MockBusinessCalendar. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBusinessCalendar(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBusinessCalendar {
+	mock := &MockBusinessCalendar{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockBusinessCalendar is an autogenerated mock type for the BusinessCalendar type
+type MockBusinessCalendar struct {
+	mock.Mock
+}
+
+type MockBusinessCalendar_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBusinessCalendar) EXPECT() *MockBusinessCalendar_Expecter {
+	return &MockBusinessCalendar_Expecter{mock: &_m.Mock}
+}
+
+// Adjust provides a mock function for the type MockBusinessCalendar
+func (_mock *MockBusinessCalendar) Adjust(t time.Time) time.Time {
+	ret := _mock.Called(t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Adjust")
+	}
+
+	var r0 time.Time
+	if returnFunc, ok := ret.Get(0).(func(time.Time) time.Time); ok {
+		r0 = returnFunc(t)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+	return r0
+}
+
+// MockBusinessCalendar_Adjust_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Adjust'
+type MockBusinessCalendar_Adjust_Call struct {
+	*mock.Call
+}
+
+// Adjust is a helper method to define mock.On call
+//   - t time.Time
+func (_e *MockBusinessCalendar_Expecter) Adjust(t interface{}) *MockBusinessCalendar_Adjust_Call {
+	return &MockBusinessCalendar_Adjust_Call{Call: _e.mock.On("Adjust", t)}
+}
+
+func (_c *MockBusinessCalendar_Adjust_Call) Run(run func(t time.Time)) *MockBusinessCalendar_Adjust_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 time.Time
+		if args[0] != nil {
+			arg0 = args[0].(time.Time)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBusinessCalendar_Adjust_Call) Return(time1 time.Time) *MockBusinessCalendar_Adjust_Call {
+	_c.Call.Return(time1)
+	return _c
+}
+
+func (_c *MockBusinessCalendar_Adjust_Call) RunAndReturn(run func(t time.Time) time.Time) *MockBusinessCalendar_Adjust_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsBusinessDay provides a mock function for the type MockBusinessCalendar
+func (_mock *MockBusinessCalendar) IsBusinessDay(t time.Time) bool {
+	ret := _mock.Called(t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsBusinessDay")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(time.Time) bool); ok {
+		r0 = returnFunc(t)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockBusinessCalendar_IsBusinessDay_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsBusinessDay'
+type MockBusinessCalendar_IsBusinessDay_Call struct {
+	*mock.Call
+}
+
+// IsBusinessDay is a helper method to define mock.On call
+//   - t time.Time
+func (_e *MockBusinessCalendar_Expecter) IsBusinessDay(t interface{}) *MockBusinessCalendar_IsBusinessDay_Call {
+	return &MockBusinessCalendar_IsBusinessDay_Call{Call: _e.mock.On("IsBusinessDay", t)}
+}
+
+func (_c *MockBusinessCalendar_IsBusinessDay_Call) Run(run func(t time.Time)) *MockBusinessCalendar_IsBusinessDay_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 time.Time
+		if args[0] != nil {
+			arg0 = args[0].(time.Time)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBusinessCalendar_IsBusinessDay_Call) Return(b bool) *MockBusinessCalendar_IsBusinessDay_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockBusinessCalendar_IsBusinessDay_Call) RunAndReturn(run func(t time.Time) bool) *MockBusinessCalendar_IsBusinessDay_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockClock creates a new instance of MockClock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockClock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockClock {
+	mock := &MockClock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockClock is an autogenerated mock type for the Clock type
+type MockClock struct {
+	mock.Mock
+}
+
+type MockClock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockClock) EXPECT() *MockClock_Expecter {
+	return &MockClock_Expecter{mock: &_m.Mock}
+}
+
+// Now provides a mock function for the type MockClock
+func (_mock *MockClock) Now() time.Time {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Now")
+	}
+
+	var r0 time.Time
+	if returnFunc, ok := ret.Get(0).(func() time.Time); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+	return r0
+}
+
+// MockClock_Now_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Now'
+type MockClock_Now_Call struct {
+	*mock.Call
+}
+
+// Now is a helper method to define mock.On call
+func (_e *MockClock_Expecter) Now() *MockClock_Now_Call {
+	return &MockClock_Now_Call{Call: _e.mock.On("Now")}
+}
+
+func (_c *MockClock_Now_Call) Run(run func()) *MockClock_Now_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockClock_Now_Call) Return(time1 time.Time) *MockClock_Now_Call {
+	_c.Call.Return(time1)
+	return _c
+}
+
+func (_c *MockClock_Now_Call) RunAndReturn(run func() time.Time) *MockClock_Now_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDataExportRepository creates a new instance of MockDataExportRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDataExportRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDataExportRepository {
+	mock := &MockDataExportRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDataExportRepository is an autogenerated mock type for the DataExportRepository type
+type MockDataExportRepository struct {
+	mock.Mock
+}
+
+type MockDataExportRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDataExportRepository) EXPECT() *MockDataExportRepository_Expecter {
+	return &MockDataExportRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockDataExportRepository
+func (_mock *MockDataExportRepository) Create(ctx context.Context, export *privacy.DataExport) (*privacy.DataExport, error) {
+	ret := _mock.Called(ctx, export)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *privacy.DataExport
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *privacy.DataExport) (*privacy.DataExport, error)); ok {
+		return returnFunc(ctx, export)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *privacy.DataExport) *privacy.DataExport); ok {
+		r0 = returnFunc(ctx, export)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*privacy.DataExport)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *privacy.DataExport) error); ok {
+		r1 = returnFunc(ctx, export)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDataExportRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockDataExportRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - export *privacy.DataExport
+func (_e *MockDataExportRepository_Expecter) Create(ctx interface{}, export interface{}) *MockDataExportRepository_Create_Call {
+	return &MockDataExportRepository_Create_Call{Call: _e.mock.On("Create", ctx, export)}
+}
+
+func (_c *MockDataExportRepository_Create_Call) Run(run func(ctx context.Context, export *privacy.DataExport)) *MockDataExportRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *privacy.DataExport
+		if args[1] != nil {
+			arg1 = args[1].(*privacy.DataExport)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDataExportRepository_Create_Call) Return(dataExport *privacy.DataExport, err error) *MockDataExportRepository_Create_Call {
+	_c.Call.Return(dataExport, err)
+	return _c
+}
+
+func (_c *MockDataExportRepository_Create_Call) RunAndReturn(run func(ctx context.Context, export *privacy.DataExport) (*privacy.DataExport, error)) *MockDataExportRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FailUnfinished provides a mock function for the type MockDataExportRepository
+func (_mock *MockDataExportRepository) FailUnfinished(ctx context.Context, createdBefore time.Time, completedAt time.Time, reason string) (int, error) {
+	ret := _mock.Called(ctx, createdBefore, completedAt, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailUnfinished")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, string) (int, error)); ok {
+		return returnFunc(ctx, createdBefore, completedAt, reason)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, string) int); ok {
+		r0 = returnFunc(ctx, createdBefore, completedAt, reason)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, string) error); ok {
+		r1 = returnFunc(ctx, createdBefore, completedAt, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDataExportRepository_FailUnfinished_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FailUnfinished'
+type MockDataExportRepository_FailUnfinished_Call struct {
+	*mock.Call
+}
+
+// FailUnfinished is a helper method to define mock.On call
+//   - ctx context.Context
+//   - createdBefore time.Time
+//   - completedAt time.Time
+//   - reason string
+func (_e *MockDataExportRepository_Expecter) FailUnfinished(ctx interface{}, createdBefore interface{}, completedAt interface{}, reason interface{}) *MockDataExportRepository_FailUnfinished_Call {
+	return &MockDataExportRepository_FailUnfinished_Call{Call: _e.mock.On("FailUnfinished", ctx, createdBefore, completedAt, reason)}
+}
+
+func (_c *MockDataExportRepository_FailUnfinished_Call) Run(run func(ctx context.Context, createdBefore time.Time, completedAt time.Time, reason string)) *MockDataExportRepository_FailUnfinished_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDataExportRepository_FailUnfinished_Call) Return(n int, err error) *MockDataExportRepository_FailUnfinished_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockDataExportRepository_FailUnfinished_Call) RunAndReturn(run func(ctx context.Context, createdBefore time.Time, completedAt time.Time, reason string) (int, error)) *MockDataExportRepository_FailUnfinished_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function for the type MockDataExportRepository
+func (_mock *MockDataExportRepository) GetByID(ctx context.Context, id string) (*privacy.DataExport, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *privacy.DataExport
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*privacy.DataExport, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *privacy.DataExport); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*privacy.DataExport)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDataExportRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockDataExportRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDataExportRepository_Expecter) GetByID(ctx interface{}, id interface{}) *MockDataExportRepository_GetByID_Call {
+	return &MockDataExportRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *MockDataExportRepository_GetByID_Call) Run(run func(ctx context.Context, id string)) *MockDataExportRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDataExportRepository_GetByID_Call) Return(dataExport *privacy.DataExport, err error) *MockDataExportRepository_GetByID_Call {
+	_c.Call.Return(dataExport, err)
+	return _c
+}
+
+func (_c *MockDataExportRepository_GetByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*privacy.DataExport, error)) *MockDataExportRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUserID provides a mock function for the type MockDataExportRepository
+func (_mock *MockDataExportRepository) GetByUserID(ctx context.Context, userID string) ([]*privacy.DataExport, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserID")
+	}
+
+	var r0 []*privacy.DataExport
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*privacy.DataExport, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*privacy.DataExport); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*privacy.DataExport)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDataExportRepository_GetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserID'
+type MockDataExportRepository_GetByUserID_Call struct {
+	*mock.Call
+}
+
+// GetByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockDataExportRepository_Expecter) GetByUserID(ctx interface{}, userID interface{}) *MockDataExportRepository_GetByUserID_Call {
+	return &MockDataExportRepository_GetByUserID_Call{Call: _e.mock.On("GetByUserID", ctx, userID)}
+}
+
+func (_c *MockDataExportRepository_GetByUserID_Call) Run(run func(ctx context.Context, userID string)) *MockDataExportRepository_GetByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDataExportRepository_GetByUserID_Call) Return(dataExports []*privacy.DataExport, err error) *MockDataExportRepository_GetByUserID_Call {
+	_c.Call.Return(dataExports, err)
+	return _c
+}
+
+func (_c *MockDataExportRepository_GetByUserID_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]*privacy.DataExport, error)) *MockDataExportRepository_GetByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockDataExportRepository
+func (_mock *MockDataExportRepository) Update(ctx context.Context, export *privacy.DataExport) error {
+	ret := _mock.Called(ctx, export)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *privacy.DataExport) error); ok {
+		r0 = returnFunc(ctx, export)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDataExportRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockDataExportRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - export *privacy.DataExport
+func (_e *MockDataExportRepository_Expecter) Update(ctx interface{}, export interface{}) *MockDataExportRepository_Update_Call {
+	return &MockDataExportRepository_Update_Call{Call: _e.mock.On("Update", ctx, export)}
+}
+
+func (_c *MockDataExportRepository_Update_Call) Run(run func(ctx context.Context, export *privacy.DataExport)) *MockDataExportRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *privacy.DataExport
+		if args[1] != nil {
+			arg1 = args[1].(*privacy.DataExport)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDataExportRepository_Update_Call) Return(err error) *MockDataExportRepository_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDataExportRepository_Update_Call) RunAndReturn(run func(ctx context.Context, export *privacy.DataExport) error) *MockDataExportRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockExportStorage creates a new instance of MockExportStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExportStorage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExportStorage {
+	mock := &MockExportStorage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockExportStorage is an autogenerated mock type for the ExportStorage type
+type MockExportStorage struct {
+	mock.Mock
+}
+
+type MockExportStorage_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExportStorage) EXPECT() *MockExportStorage_Expecter {
+	return &MockExportStorage_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function for the type MockExportStorage
+func (_mock *MockExportStorage) Delete(ctx context.Context, name string) error {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockExportStorage_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockExportStorage_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockExportStorage_Expecter) Delete(ctx interface{}, name interface{}) *MockExportStorage_Delete_Call {
+	return &MockExportStorage_Delete_Call{Call: _e.mock.On("Delete", ctx, name)}
+}
+
+func (_c *MockExportStorage_Delete_Call) Run(run func(ctx context.Context, name string)) *MockExportStorage_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExportStorage_Delete_Call) Return(err error) *MockExportStorage_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockExportStorage_Delete_Call) RunAndReturn(run func(ctx context.Context, name string) error) *MockExportStorage_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Open provides a mock function for the type MockExportStorage
+func (_mock *MockExportStorage) Open(ctx context.Context, name string) (io.ReadCloser, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Open")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (io.ReadCloser, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) io.ReadCloser); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockExportStorage_Open_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Open'
+type MockExportStorage_Open_Call struct {
+	*mock.Call
+}
+
+// Open is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockExportStorage_Expecter) Open(ctx interface{}, name interface{}) *MockExportStorage_Open_Call {
+	return &MockExportStorage_Open_Call{Call: _e.mock.On("Open", ctx, name)}
+}
+
+func (_c *MockExportStorage_Open_Call) Run(run func(ctx context.Context, name string)) *MockExportStorage_Open_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExportStorage_Open_Call) Return(readCloser io.ReadCloser, err error) *MockExportStorage_Open_Call {
+	_c.Call.Return(readCloser, err)
+	return _c
+}
+
+func (_c *MockExportStorage_Open_Call) RunAndReturn(run func(ctx context.Context, name string) (io.ReadCloser, error)) *MockExportStorage_Open_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function for the type MockExportStorage
+func (_mock *MockExportStorage) Save(ctx context.Context, name string, content io.Reader) error {
+	ret := _mock.Called(ctx, name, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, io.Reader) error); ok {
+		r0 = returnFunc(ctx, name, content)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockExportStorage_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockExportStorage_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - content io.Reader
+func (_e *MockExportStorage_Expecter) Save(ctx interface{}, name interface{}, content interface{}) *MockExportStorage_Save_Call {
+	return &MockExportStorage_Save_Call{Call: _e.mock.On("Save", ctx, name, content)}
+}
+
+func (_c *MockExportStorage_Save_Call) Run(run func(ctx context.Context, name string, content io.Reader)) *MockExportStorage_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 io.Reader
+		if args[2] != nil {
+			arg2 = args[2].(io.Reader)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExportStorage_Save_Call) Return(err error) *MockExportStorage_Save_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockExportStorage_Save_Call) RunAndReturn(run func(ctx context.Context, name string, content io.Reader) error) *MockExportStorage_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockFieldEncryptor creates a new instance of MockFieldEncryptor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFieldEncryptor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFieldEncryptor {
+	mock := &MockFieldEncryptor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockFieldEncryptor is an autogenerated mock type for the FieldEncryptor type
+type MockFieldEncryptor struct {
+	mock.Mock
+}
+
+type MockFieldEncryptor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFieldEncryptor) EXPECT() *MockFieldEncryptor_Expecter {
+	return &MockFieldEncryptor_Expecter{mock: &_m.Mock}
+}
+
+// BlindIndex provides a mock function for the type MockFieldEncryptor
+func (_mock *MockFieldEncryptor) BlindIndex(value string) (string, error) {
+	ret := _mock.Called(value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlindIndex")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return returnFunc(value)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
+		r0 = returnFunc(value)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(value)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFieldEncryptor_BlindIndex_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlindIndex'
+type MockFieldEncryptor_BlindIndex_Call struct {
+	*mock.Call
+}
+
+// BlindIndex is a helper method to define mock.On call
+//   - value string
+func (_e *MockFieldEncryptor_Expecter) BlindIndex(value interface{}) *MockFieldEncryptor_BlindIndex_Call {
+	return &MockFieldEncryptor_BlindIndex_Call{Call: _e.mock.On("BlindIndex", value)}
+}
+
+func (_c *MockFieldEncryptor_BlindIndex_Call) Run(run func(value string)) *MockFieldEncryptor_BlindIndex_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFieldEncryptor_BlindIndex_Call) Return(s string, err error) *MockFieldEncryptor_BlindIndex_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockFieldEncryptor_BlindIndex_Call) RunAndReturn(run func(value string) (string, error)) *MockFieldEncryptor_BlindIndex_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Decrypt provides a mock function for the type MockFieldEncryptor
+func (_mock *MockFieldEncryptor) Decrypt(ciphertext string) (string, error) {
+	ret := _mock.Called(ciphertext)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Decrypt")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return returnFunc(ciphertext)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
+		r0 = returnFunc(ciphertext)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(ciphertext)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFieldEncryptor_Decrypt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Decrypt'
+type MockFieldEncryptor_Decrypt_Call struct {
+	*mock.Call
+}
+
+// Decrypt is a helper method to define mock.On call
+//   - ciphertext string
+func (_e *MockFieldEncryptor_Expecter) Decrypt(ciphertext interface{}) *MockFieldEncryptor_Decrypt_Call {
+	return &MockFieldEncryptor_Decrypt_Call{Call: _e.mock.On("Decrypt", ciphertext)}
+}
+
+func (_c *MockFieldEncryptor_Decrypt_Call) Run(run func(ciphertext string)) *MockFieldEncryptor_Decrypt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFieldEncryptor_Decrypt_Call) Return(s string, err error) *MockFieldEncryptor_Decrypt_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockFieldEncryptor_Decrypt_Call) RunAndReturn(run func(ciphertext string) (string, error)) *MockFieldEncryptor_Decrypt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Encrypt provides a mock function for the type MockFieldEncryptor
+func (_mock *MockFieldEncryptor) Encrypt(plaintext string) (string, error) {
+	ret := _mock.Called(plaintext)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Encrypt")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return returnFunc(plaintext)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
+		r0 = returnFunc(plaintext)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(plaintext)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFieldEncryptor_Encrypt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Encrypt'
+type MockFieldEncryptor_Encrypt_Call struct {
+	*mock.Call
+}
+
+// Encrypt is a helper method to define mock.On call
+//   - plaintext string
+func (_e *MockFieldEncryptor_Expecter) Encrypt(plaintext interface{}) *MockFieldEncryptor_Encrypt_Call {
+	return &MockFieldEncryptor_Encrypt_Call{Call: _e.mock.On("Encrypt", plaintext)}
+}
+
+func (_c *MockFieldEncryptor_Encrypt_Call) Run(run func(plaintext string)) *MockFieldEncryptor_Encrypt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFieldEncryptor_Encrypt_Call) Return(s string, err error) *MockFieldEncryptor_Encrypt_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockFieldEncryptor_Encrypt_Call) RunAndReturn(run func(plaintext string) (string, error)) *MockFieldEncryptor_Encrypt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NeedsRotation provides a mock function for the type MockFieldEncryptor
+func (_mock *MockFieldEncryptor) NeedsRotation(ciphertext string) bool {
+	ret := _mock.Called(ciphertext)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NeedsRotation")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = returnFunc(ciphertext)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockFieldEncryptor_NeedsRotation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NeedsRotation'
+type MockFieldEncryptor_NeedsRotation_Call struct {
+	*mock.Call
+}
+
+// NeedsRotation is a helper method to define mock.On call
+//   - ciphertext string
+func (_e *MockFieldEncryptor_Expecter) NeedsRotation(ciphertext interface{}) *MockFieldEncryptor_NeedsRotation_Call {
+	return &MockFieldEncryptor_NeedsRotation_Call{Call: _e.mock.On("NeedsRotation", ciphertext)}
+}
+
+func (_c *MockFieldEncryptor_NeedsRotation_Call) Run(run func(ciphertext string)) *MockFieldEncryptor_NeedsRotation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFieldEncryptor_NeedsRotation_Call) Return(b bool) *MockFieldEncryptor_NeedsRotation_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockFieldEncryptor_NeedsRotation_Call) RunAndReturn(run func(ciphertext string) bool) *MockFieldEncryptor_NeedsRotation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockHealthChecker creates a new instance of MockHealthChecker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHealthChecker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHealthChecker {
+	mock := &MockHealthChecker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockHealthChecker is an autogenerated mock type for the HealthChecker type
+type MockHealthChecker struct {
+	mock.Mock
+}
+
+type MockHealthChecker_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHealthChecker) EXPECT() *MockHealthChecker_Expecter {
+	return &MockHealthChecker_Expecter{mock: &_m.Mock}
+}
+
+// Check provides a mock function for the type MockHealthChecker
+func (_mock *MockHealthChecker) Check(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockHealthChecker_Check_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Check'
+type MockHealthChecker_Check_Call struct {
+	*mock.Call
+}
+
+// Check is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockHealthChecker_Expecter) Check(ctx interface{}) *MockHealthChecker_Check_Call {
+	return &MockHealthChecker_Check_Call{Call: _e.mock.On("Check", ctx)}
+}
+
+func (_c *MockHealthChecker_Check_Call) Run(run func(ctx context.Context)) *MockHealthChecker_Check_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHealthChecker_Check_Call) Return(err error) *MockHealthChecker_Check_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockHealthChecker_Check_Call) RunAndReturn(run func(ctx context.Context) error) *MockHealthChecker_Check_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Name provides a mock function for the type MockHealthChecker
+func (_mock *MockHealthChecker) Name() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Name")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockHealthChecker_Name_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Name'
+type MockHealthChecker_Name_Call struct {
+	*mock.Call
+}
+
+// Name is a helper method to define mock.On call
+func (_e *MockHealthChecker_Expecter) Name() *MockHealthChecker_Name_Call {
+	return &MockHealthChecker_Name_Call{Call: _e.mock.On("Name")}
+}
+
+func (_c *MockHealthChecker_Name_Call) Run(run func()) *MockHealthChecker_Name_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockHealthChecker_Name_Call) Return(s string) *MockHealthChecker_Name_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockHealthChecker_Name_Call) RunAndReturn(run func() string) *MockHealthChecker_Name_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockInterestService creates a new instance of MockInterestService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockInterestService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockInterestService {
+	mock := &MockInterestService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockInterestService is an autogenerated mock type for the InterestService type
+type MockInterestService struct {
+	mock.Mock
+}
+
+type MockInterestService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockInterestService) EXPECT() *MockInterestService_Expecter {
+	return &MockInterestService_Expecter{mock: &_m.Mock}
+}
+
+// AccrueFlexibleInterest provides a mock function for the type MockInterestService
+func (_mock *MockInterestService) AccrueFlexibleInterest(ctx context.Context, asOf time.Time) (int, error) {
+	ret := _mock.Called(ctx, asOf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AccrueFlexibleInterest")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return returnFunc(ctx, asOf)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = returnFunc(ctx, asOf)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockInterestService_AccrueFlexibleInterest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AccrueFlexibleInterest'
+type MockInterestService_AccrueFlexibleInterest_Call struct {
+	*mock.Call
+}
+
+// AccrueFlexibleInterest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - asOf time.Time
+func (_e *MockInterestService_Expecter) AccrueFlexibleInterest(ctx interface{}, asOf interface{}) *MockInterestService_AccrueFlexibleInterest_Call {
+	return &MockInterestService_AccrueFlexibleInterest_Call{Call: _e.mock.On("AccrueFlexibleInterest", ctx, asOf)}
+}
+
+func (_c *MockInterestService_AccrueFlexibleInterest_Call) Run(run func(ctx context.Context, asOf time.Time)) *MockInterestService_AccrueFlexibleInterest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInterestService_AccrueFlexibleInterest_Call) Return(n int, err error) *MockInterestService_AccrueFlexibleInterest_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockInterestService_AccrueFlexibleInterest_Call) RunAndReturn(run func(ctx context.Context, asOf time.Time) (int, error)) *MockInterestService_AccrueFlexibleInterest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MatureFixedSavings provides a mock function for the type MockInterestService
+func (_mock *MockInterestService) MatureFixedSavings(ctx context.Context, asOf time.Time) (int, error) {
+	ret := _mock.Called(ctx, asOf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MatureFixedSavings")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return returnFunc(ctx, asOf)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = returnFunc(ctx, asOf)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockInterestService_MatureFixedSavings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MatureFixedSavings'
+type MockInterestService_MatureFixedSavings_Call struct {
+	*mock.Call
+}
+
+// MatureFixedSavings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - asOf time.Time
+func (_e *MockInterestService_Expecter) MatureFixedSavings(ctx interface{}, asOf interface{}) *MockInterestService_MatureFixedSavings_Call {
+	return &MockInterestService_MatureFixedSavings_Call{Call: _e.mock.On("MatureFixedSavings", ctx, asOf)}
+}
+
+func (_c *MockInterestService_MatureFixedSavings_Call) Run(run func(ctx context.Context, asOf time.Time)) *MockInterestService_MatureFixedSavings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInterestService_MatureFixedSavings_Call) Return(n int, err error) *MockInterestService_MatureFixedSavings_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockInterestService_MatureFixedSavings_Call) RunAndReturn(run func(ctx context.Context, asOf time.Time) (int, error)) *MockInterestService_MatureFixedSavings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockKeyProvider creates a new instance of MockKeyProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockKeyProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockKeyProvider {
+	mock := &MockKeyProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockKeyProvider is an autogenerated mock type for the KeyProvider type
+type MockKeyProvider struct {
+	mock.Mock
+}
+
+type MockKeyProvider_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockKeyProvider) EXPECT() *MockKeyProvider_Expecter {
+	return &MockKeyProvider_Expecter{mock: &_m.Mock}
+}
+
+// ActiveKey provides a mock function for the type MockKeyProvider
+func (_mock *MockKeyProvider) ActiveKey() (string, []byte, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ActiveKey")
+	}
+
+	var r0 string
+	var r1 []byte
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func() (string, []byte, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func() []byte); ok {
+		r1 = returnFunc()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func() error); ok {
+		r2 = returnFunc()
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockKeyProvider_ActiveKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ActiveKey'
+type MockKeyProvider_ActiveKey_Call struct {
+	*mock.Call
+}
+
+// ActiveKey is a helper method to define mock.On call
+func (_e *MockKeyProvider_Expecter) ActiveKey() *MockKeyProvider_ActiveKey_Call {
+	return &MockKeyProvider_ActiveKey_Call{Call: _e.mock.On("ActiveKey")}
+}
+
+func (_c *MockKeyProvider_ActiveKey_Call) Run(run func()) *MockKeyProvider_ActiveKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockKeyProvider_ActiveKey_Call) Return(id string, key []byte, err error) *MockKeyProvider_ActiveKey_Call {
+	_c.Call.Return(id, key, err)
+	return _c
+}
+
+func (_c *MockKeyProvider_ActiveKey_Call) RunAndReturn(run func() (string, []byte, error)) *MockKeyProvider_ActiveKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IndexKey provides a mock function for the type MockKeyProvider
+func (_mock *MockKeyProvider) IndexKey() ([]byte, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IndexKey")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]byte, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []byte); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockKeyProvider_IndexKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IndexKey'
+type MockKeyProvider_IndexKey_Call struct {
+	*mock.Call
+}
+
+// IndexKey is a helper method to define mock.On call
+func (_e *MockKeyProvider_Expecter) IndexKey() *MockKeyProvider_IndexKey_Call {
+	return &MockKeyProvider_IndexKey_Call{Call: _e.mock.On("IndexKey")}
+}
+
+func (_c *MockKeyProvider_IndexKey_Call) Run(run func()) *MockKeyProvider_IndexKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockKeyProvider_IndexKey_Call) Return(bytes []byte, err error) *MockKeyProvider_IndexKey_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *MockKeyProvider_IndexKey_Call) RunAndReturn(run func() ([]byte, error)) *MockKeyProvider_IndexKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Key provides a mock function for the type MockKeyProvider
+func (_mock *MockKeyProvider) Key(id string) ([]byte, error) {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Key")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) ([]byte, error)); ok {
+		return returnFunc(id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) []byte); ok {
+		r0 = returnFunc(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockKeyProvider_Key_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Key'
+type MockKeyProvider_Key_Call struct {
+	*mock.Call
+}
+
+// Key is a helper method to define mock.On call
+//   - id string
+func (_e *MockKeyProvider_Expecter) Key(id interface{}) *MockKeyProvider_Key_Call {
+	return &MockKeyProvider_Key_Call{Call: _e.mock.On("Key", id)}
+}
+
+func (_c *MockKeyProvider_Key_Call) Run(run func(id string)) *MockKeyProvider_Key_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockKeyProvider_Key_Call) Return(bytes []byte, err error) *MockKeyProvider_Key_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *MockKeyProvider_Key_Call) RunAndReturn(run func(id string) ([]byte, error)) *MockKeyProvider_Key_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMetrics creates a new instance of MockMetrics. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMetrics(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMetrics {
+	mock := &MockMetrics{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMetrics is an autogenerated mock type for the Metrics type
+type MockMetrics struct {
+	mock.Mock
+}
+
+type MockMetrics_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMetrics) EXPECT() *MockMetrics_Expecter {
+	return &MockMetrics_Expecter{mock: &_m.Mock}
+}
+
+// AccountCreated provides a mock function for the type MockMetrics
+func (_mock *MockMetrics) AccountCreated(accountType string) {
+	_mock.Called(accountType)
+	return
+}
+
+// MockMetrics_AccountCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AccountCreated'
+type MockMetrics_AccountCreated_Call struct {
+	*mock.Call
+}
+
+// AccountCreated is a helper method to define mock.On call
+//   - accountType string
+func (_e *MockMetrics_Expecter) AccountCreated(accountType interface{}) *MockMetrics_AccountCreated_Call {
+	return &MockMetrics_AccountCreated_Call{Call: _e.mock.On("AccountCreated", accountType)}
+}
+
+func (_c *MockMetrics_AccountCreated_Call) Run(run func(accountType string)) *MockMetrics_AccountCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMetrics_AccountCreated_Call) Return() *MockMetrics_AccountCreated_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockMetrics_AccountCreated_Call) RunAndReturn(run func(accountType string)) *MockMetrics_AccountCreated_Call {
+	_c.Run(run)
+	return _c
+}
+
+// InterestAccrued provides a mock function for the type MockMetrics
+func (_mock *MockMetrics) InterestAccrued(accountType string, amount float64) {
+	_mock.Called(accountType, amount)
+	return
+}
+
+// MockMetrics_InterestAccrued_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InterestAccrued'
+type MockMetrics_InterestAccrued_Call struct {
+	*mock.Call
+}
+
+// InterestAccrued is a helper method to define mock.On call
+//   - accountType string
+//   - amount float64
+func (_e *MockMetrics_Expecter) InterestAccrued(accountType interface{}, amount interface{}) *MockMetrics_InterestAccrued_Call {
+	return &MockMetrics_InterestAccrued_Call{Call: _e.mock.On("InterestAccrued", accountType, amount)}
+}
+
+func (_c *MockMetrics_InterestAccrued_Call) Run(run func(accountType string, amount float64)) *MockMetrics_InterestAccrued_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 float64
+		if args[1] != nil {
+			arg1 = args[1].(float64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMetrics_InterestAccrued_Call) Return() *MockMetrics_InterestAccrued_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockMetrics_InterestAccrued_Call) RunAndReturn(run func(accountType string, amount float64)) *MockMetrics_InterestAccrued_Call {
+	_c.Run(run)
+	return _c
+}
+
+// TransferCompleted provides a mock function for the type MockMetrics
+func (_mock *MockMetrics) TransferCompleted(transactionType string, amount float64) {
+	_mock.Called(transactionType, amount)
+	return
+}
+
+// MockMetrics_TransferCompleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransferCompleted'
+type MockMetrics_TransferCompleted_Call struct {
+	*mock.Call
+}
+
+// TransferCompleted is a helper method to define mock.On call
+//   - transactionType string
+//   - amount float64
+func (_e *MockMetrics_Expecter) TransferCompleted(transactionType interface{}, amount interface{}) *MockMetrics_TransferCompleted_Call {
+	return &MockMetrics_TransferCompleted_Call{Call: _e.mock.On("TransferCompleted", transactionType, amount)}
+}
+
+func (_c *MockMetrics_TransferCompleted_Call) Run(run func(transactionType string, amount float64)) *MockMetrics_TransferCompleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 float64
+		if args[1] != nil {
+			arg1 = args[1].(float64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMetrics_TransferCompleted_Call) Return() *MockMetrics_TransferCompleted_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockMetrics_TransferCompleted_Call) RunAndReturn(run func(transactionType string, amount float64)) *MockMetrics_TransferCompleted_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockPasswordService creates a new instance of MockPasswordService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPasswordService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPasswordService {
+	mock := &MockPasswordService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockPasswordService is an autogenerated mock type for the PasswordService type
 type MockPasswordService struct {
 	mock.Mock
 }
 
-type MockPasswordService_Expecter struct {
+type MockPasswordService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPasswordService) EXPECT() *MockPasswordService_Expecter {
+	return &MockPasswordService_Expecter{mock: &_m.Mock}
+}
+
+// CheckPassword provides a mock function for the type MockPasswordService
+func (_mock *MockPasswordService) CheckPassword(hashedPassword string, password string) error {
+	ret := _mock.Called(hashedPassword, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckPassword")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = returnFunc(hashedPassword, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockPasswordService_CheckPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckPassword'
+type MockPasswordService_CheckPassword_Call struct {
+	*mock.Call
+}
+
+// CheckPassword is a helper method to define mock.On call
+//   - hashedPassword string
+//   - password string
+func (_e *MockPasswordService_Expecter) CheckPassword(hashedPassword interface{}, password interface{}) *MockPasswordService_CheckPassword_Call {
+	return &MockPasswordService_CheckPassword_Call{Call: _e.mock.On("CheckPassword", hashedPassword, password)}
+}
+
+func (_c *MockPasswordService_CheckPassword_Call) Run(run func(hashedPassword string, password string)) *MockPasswordService_CheckPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPasswordService_CheckPassword_Call) Return(err error) *MockPasswordService_CheckPassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockPasswordService_CheckPassword_Call) RunAndReturn(run func(hashedPassword string, password string) error) *MockPasswordService_CheckPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HashPassword provides a mock function for the type MockPasswordService
+func (_mock *MockPasswordService) HashPassword(password string) (string, error) {
+	ret := _mock.Called(password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HashPassword")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return returnFunc(password)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
+		r0 = returnFunc(password)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(password)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPasswordService_HashPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HashPassword'
+type MockPasswordService_HashPassword_Call struct {
+	*mock.Call
+}
+
+// HashPassword is a helper method to define mock.On call
+//   - password string
+func (_e *MockPasswordService_Expecter) HashPassword(password interface{}) *MockPasswordService_HashPassword_Call {
+	return &MockPasswordService_HashPassword_Call{Call: _e.mock.On("HashPassword", password)}
+}
+
+func (_c *MockPasswordService_HashPassword_Call) Run(run func(password string)) *MockPasswordService_HashPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPasswordService_HashPassword_Call) Return(s string, err error) *MockPasswordService_HashPassword_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockPasswordService_HashPassword_Call) RunAndReturn(run func(password string) (string, error)) *MockPasswordService_HashPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPrivacyService creates a new instance of MockPrivacyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPrivacyService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPrivacyService {
+	mock := &MockPrivacyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockPrivacyService is an autogenerated mock type for the PrivacyService type
+type MockPrivacyService struct {
+	mock.Mock
+}
+
+type MockPrivacyService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPrivacyService) EXPECT() *MockPrivacyService_Expecter {
+	return &MockPrivacyService_Expecter{mock: &_m.Mock}
+}
+
+// DeleteUserData provides a mock function for the type MockPrivacyService
+func (_mock *MockPrivacyService) DeleteUserData(ctx context.Context, userID string) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserData")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockPrivacyService_DeleteUserData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserData'
+type MockPrivacyService_DeleteUserData_Call struct {
+	*mock.Call
+}
+
+// DeleteUserData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockPrivacyService_Expecter) DeleteUserData(ctx interface{}, userID interface{}) *MockPrivacyService_DeleteUserData_Call {
+	return &MockPrivacyService_DeleteUserData_Call{Call: _e.mock.On("DeleteUserData", ctx, userID)}
+}
+
+func (_c *MockPrivacyService_DeleteUserData_Call) Run(run func(ctx context.Context, userID string)) *MockPrivacyService_DeleteUserData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPrivacyService_DeleteUserData_Call) Return(err error) *MockPrivacyService_DeleteUserData_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockPrivacyService_DeleteUserData_Call) RunAndReturn(run func(ctx context.Context, userID string) error) *MockPrivacyService_DeleteUserData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FailStaleExports provides a mock function for the type MockPrivacyService
+func (_mock *MockPrivacyService) FailStaleExports(ctx context.Context) (int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailStaleExports")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPrivacyService_FailStaleExports_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FailStaleExports'
+type MockPrivacyService_FailStaleExports_Call struct {
+	*mock.Call
+}
+
+// FailStaleExports is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockPrivacyService_Expecter) FailStaleExports(ctx interface{}) *MockPrivacyService_FailStaleExports_Call {
+	return &MockPrivacyService_FailStaleExports_Call{Call: _e.mock.On("FailStaleExports", ctx)}
+}
+
+func (_c *MockPrivacyService_FailStaleExports_Call) Run(run func(ctx context.Context)) *MockPrivacyService_FailStaleExports_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPrivacyService_FailStaleExports_Call) Return(n int, err error) *MockPrivacyService_FailStaleExports_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockPrivacyService_FailStaleExports_Call) RunAndReturn(run func(ctx context.Context) (int, error)) *MockPrivacyService_FailStaleExports_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetExport provides a mock function for the type MockPrivacyService
+func (_mock *MockPrivacyService) GetExport(ctx context.Context, userID string, exportID string) (*privacy.DataExport, error) {
+	ret := _mock.Called(ctx, userID, exportID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExport")
+	}
+
+	var r0 *privacy.DataExport
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*privacy.DataExport, error)); ok {
+		return returnFunc(ctx, userID, exportID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *privacy.DataExport); ok {
+		r0 = returnFunc(ctx, userID, exportID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*privacy.DataExport)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, exportID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPrivacyService_GetExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExport'
+type MockPrivacyService_GetExport_Call struct {
+	*mock.Call
+}
+
+// GetExport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - exportID string
+func (_e *MockPrivacyService_Expecter) GetExport(ctx interface{}, userID interface{}, exportID interface{}) *MockPrivacyService_GetExport_Call {
+	return &MockPrivacyService_GetExport_Call{Call: _e.mock.On("GetExport", ctx, userID, exportID)}
+}
+
+func (_c *MockPrivacyService_GetExport_Call) Run(run func(ctx context.Context, userID string, exportID string)) *MockPrivacyService_GetExport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPrivacyService_GetExport_Call) Return(dataExport *privacy.DataExport, err error) *MockPrivacyService_GetExport_Call {
+	_c.Call.Return(dataExport, err)
+	return _c
+}
+
+func (_c *MockPrivacyService_GetExport_Call) RunAndReturn(run func(ctx context.Context, userID string, exportID string) (*privacy.DataExport, error)) *MockPrivacyService_GetExport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OpenExport provides a mock function for the type MockPrivacyService
+func (_mock *MockPrivacyService) OpenExport(ctx context.Context, userID string, exportID string) (io.ReadCloser, *privacy.DataExport, error) {
+	ret := _mock.Called(ctx, userID, exportID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenExport")
+	}
+
+	var r0 io.ReadCloser
+	var r1 *privacy.DataExport
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (io.ReadCloser, *privacy.DataExport, error)); ok {
+		return returnFunc(ctx, userID, exportID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) io.ReadCloser); ok {
+		r0 = returnFunc(ctx, userID, exportID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) *privacy.DataExport); ok {
+		r1 = returnFunc(ctx, userID, exportID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*privacy.DataExport)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = returnFunc(ctx, userID, exportID)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockPrivacyService_OpenExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenExport'
+type MockPrivacyService_OpenExport_Call struct {
+	*mock.Call
+}
+
+// OpenExport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - exportID string
+func (_e *MockPrivacyService_Expecter) OpenExport(ctx interface{}, userID interface{}, exportID interface{}) *MockPrivacyService_OpenExport_Call {
+	return &MockPrivacyService_OpenExport_Call{Call: _e.mock.On("OpenExport", ctx, userID, exportID)}
+}
+
+func (_c *MockPrivacyService_OpenExport_Call) Run(run func(ctx context.Context, userID string, exportID string)) *MockPrivacyService_OpenExport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPrivacyService_OpenExport_Call) Return(readCloser io.ReadCloser, dataExport *privacy.DataExport, err error) *MockPrivacyService_OpenExport_Call {
+	_c.Call.Return(readCloser, dataExport, err)
+	return _c
+}
+
+func (_c *MockPrivacyService_OpenExport_Call) RunAndReturn(run func(ctx context.Context, userID string, exportID string) (io.ReadCloser, *privacy.DataExport, error)) *MockPrivacyService_OpenExport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequestExport provides a mock function for the type MockPrivacyService
+func (_mock *MockPrivacyService) RequestExport(ctx context.Context, userID string) (*privacy.DataExport, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestExport")
+	}
+
+	var r0 *privacy.DataExport
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*privacy.DataExport, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *privacy.DataExport); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*privacy.DataExport)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPrivacyService_RequestExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestExport'
+type MockPrivacyService_RequestExport_Call struct {
+	*mock.Call
+}
+
+// RequestExport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockPrivacyService_Expecter) RequestExport(ctx interface{}, userID interface{}) *MockPrivacyService_RequestExport_Call {
+	return &MockPrivacyService_RequestExport_Call{Call: _e.mock.On("RequestExport", ctx, userID)}
+}
+
+func (_c *MockPrivacyService_RequestExport_Call) Run(run func(ctx context.Context, userID string)) *MockPrivacyService_RequestExport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPrivacyService_RequestExport_Call) Return(dataExport *privacy.DataExport, err error) *MockPrivacyService_RequestExport_Call {
+	_c.Call.Return(dataExport, err)
+	return _c
+}
+
+func (_c *MockPrivacyService_RequestExport_Call) RunAndReturn(run func(ctx context.Context, userID string) (*privacy.DataExport, error)) *MockPrivacyService_RequestExport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Wait provides a mock function for the type MockPrivacyService
+func (_mock *MockPrivacyService) Wait(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Wait")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockPrivacyService_Wait_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Wait'
+type MockPrivacyService_Wait_Call struct {
+	*mock.Call
+}
+
+// Wait is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockPrivacyService_Expecter) Wait(ctx interface{}) *MockPrivacyService_Wait_Call {
+	return &MockPrivacyService_Wait_Call{Call: _e.mock.On("Wait", ctx)}
+}
+
+func (_c *MockPrivacyService_Wait_Call) Run(run func(ctx context.Context)) *MockPrivacyService_Wait_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPrivacyService_Wait_Call) Return(err error) *MockPrivacyService_Wait_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockPrivacyService_Wait_Call) RunAndReturn(run func(ctx context.Context) error) *MockPrivacyService_Wait_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockProfileRepository creates a new instance of MockProfileRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockProfileRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockProfileRepository {
+	mock := &MockProfileRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockProfileRepository is an autogenerated mock type for the ProfileRepository type
+type MockProfileRepository struct {
+	mock.Mock
+}
+
+type MockProfileRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockProfileRepository) EXPECT() *MockProfileRepository_Expecter {
+	return &MockProfileRepository_Expecter{mock: &_m.Mock}
+}
+
+// Anonymize provides a mock function for the type MockProfileRepository
+func (_mock *MockProfileRepository) Anonymize(ctx context.Context, userID string, placeholder string) error {
+	ret := _mock.Called(ctx, userID, placeholder)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Anonymize")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, userID, placeholder)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockProfileRepository_Anonymize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Anonymize'
+type MockProfileRepository_Anonymize_Call struct {
+	*mock.Call
+}
+
+// Anonymize is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - placeholder string
+func (_e *MockProfileRepository_Expecter) Anonymize(ctx interface{}, userID interface{}, placeholder interface{}) *MockProfileRepository_Anonymize_Call {
+	return &MockProfileRepository_Anonymize_Call{Call: _e.mock.On("Anonymize", ctx, userID, placeholder)}
+}
+
+func (_c *MockProfileRepository_Anonymize_Call) Run(run func(ctx context.Context, userID string, placeholder string)) *MockProfileRepository_Anonymize_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProfileRepository_Anonymize_Call) Return(err error) *MockProfileRepository_Anonymize_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockProfileRepository_Anonymize_Call) RunAndReturn(run func(ctx context.Context, userID string, placeholder string) error) *MockProfileRepository_Anonymize_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckNationalIDExists provides a mock function for the type MockProfileRepository
+func (_mock *MockProfileRepository) CheckNationalIDExists(ctx context.Context, nationalID string, excludeUserID string) (bool, error) {
+	ret := _mock.Called(ctx, nationalID, excludeUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckNationalIDExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return returnFunc(ctx, nationalID, excludeUserID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = returnFunc(ctx, nationalID, excludeUserID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, nationalID, excludeUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProfileRepository_CheckNationalIDExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckNationalIDExists'
+type MockProfileRepository_CheckNationalIDExists_Call struct {
+	*mock.Call
+}
+
+// CheckNationalIDExists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - nationalID string
+//   - excludeUserID string
+func (_e *MockProfileRepository_Expecter) CheckNationalIDExists(ctx interface{}, nationalID interface{}, excludeUserID interface{}) *MockProfileRepository_CheckNationalIDExists_Call {
+	return &MockProfileRepository_CheckNationalIDExists_Call{Call: _e.mock.On("CheckNationalIDExists", ctx, nationalID, excludeUserID)}
+}
+
+func (_c *MockProfileRepository_CheckNationalIDExists_Call) Run(run func(ctx context.Context, nationalID string, excludeUserID string)) *MockProfileRepository_CheckNationalIDExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProfileRepository_CheckNationalIDExists_Call) Return(b bool, err error) *MockProfileRepository_CheckNationalIDExists_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockProfileRepository_CheckNationalIDExists_Call) RunAndReturn(run func(ctx context.Context, nationalID string, excludeUserID string) (bool, error)) *MockProfileRepository_CheckNationalIDExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckPhoneNumberExists provides a mock function for the type MockProfileRepository
+func (_mock *MockProfileRepository) CheckPhoneNumberExists(ctx context.Context, phoneNumber string, excludeUserID string) (bool, error) {
+	ret := _mock.Called(ctx, phoneNumber, excludeUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckPhoneNumberExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return returnFunc(ctx, phoneNumber, excludeUserID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = returnFunc(ctx, phoneNumber, excludeUserID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, phoneNumber, excludeUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProfileRepository_CheckPhoneNumberExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckPhoneNumberExists'
+type MockProfileRepository_CheckPhoneNumberExists_Call struct {
+	*mock.Call
+}
+
+// CheckPhoneNumberExists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phoneNumber string
+//   - excludeUserID string
+func (_e *MockProfileRepository_Expecter) CheckPhoneNumberExists(ctx interface{}, phoneNumber interface{}, excludeUserID interface{}) *MockProfileRepository_CheckPhoneNumberExists_Call {
+	return &MockProfileRepository_CheckPhoneNumberExists_Call{Call: _e.mock.On("CheckPhoneNumberExists", ctx, phoneNumber, excludeUserID)}
+}
+
+func (_c *MockProfileRepository_CheckPhoneNumberExists_Call) Run(run func(ctx context.Context, phoneNumber string, excludeUserID string)) *MockProfileRepository_CheckPhoneNumberExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProfileRepository_CheckPhoneNumberExists_Call) Return(b bool, err error) *MockProfileRepository_CheckPhoneNumberExists_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockProfileRepository_CheckPhoneNumberExists_Call) RunAndReturn(run func(ctx context.Context, phoneNumber string, excludeUserID string) (bool, error)) *MockProfileRepository_CheckPhoneNumberExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUserID provides a mock function for the type MockProfileRepository
+func (_mock *MockProfileRepository) GetByUserID(ctx context.Context, userID string) (*profile.Profile, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserID")
+	}
+
+	var r0 *profile.Profile
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*profile.Profile, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *profile.Profile); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*profile.Profile)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProfileRepository_GetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserID'
+type MockProfileRepository_GetByUserID_Call struct {
+	*mock.Call
+}
+
+// GetByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockProfileRepository_Expecter) GetByUserID(ctx interface{}, userID interface{}) *MockProfileRepository_GetByUserID_Call {
+	return &MockProfileRepository_GetByUserID_Call{Call: _e.mock.On("GetByUserID", ctx, userID)}
+}
+
+func (_c *MockProfileRepository_GetByUserID_Call) Run(run func(ctx context.Context, userID string)) *MockProfileRepository_GetByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProfileRepository_GetByUserID_Call) Return(profile1 *profile.Profile, err error) *MockProfileRepository_GetByUserID_Call {
+	_c.Call.Return(profile1, err)
+	return _c
+}
+
+func (_c *MockProfileRepository_GetByUserID_Call) RunAndReturn(run func(ctx context.Context, userID string) (*profile.Profile, error)) *MockProfileRepository_GetByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function for the type MockProfileRepository
+func (_mock *MockProfileRepository) Upsert(ctx context.Context, profile1 *profile.Profile) (*profile.Profile, error) {
+	ret := _mock.Called(ctx, profile1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 *profile.Profile
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *profile.Profile) (*profile.Profile, error)); ok {
+		return returnFunc(ctx, profile1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *profile.Profile) *profile.Profile); ok {
+		r0 = returnFunc(ctx, profile1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*profile.Profile)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *profile.Profile) error); ok {
+		r1 = returnFunc(ctx, profile1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProfileRepository_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type MockProfileRepository_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - profile1 *profile.Profile
+func (_e *MockProfileRepository_Expecter) Upsert(ctx interface{}, profile1 interface{}) *MockProfileRepository_Upsert_Call {
+	return &MockProfileRepository_Upsert_Call{Call: _e.mock.On("Upsert", ctx, profile1)}
+}
+
+func (_c *MockProfileRepository_Upsert_Call) Run(run func(ctx context.Context, profile1 *profile.Profile)) *MockProfileRepository_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *profile.Profile
+		if args[1] != nil {
+			arg1 = args[1].(*profile.Profile)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProfileRepository_Upsert_Call) Return(profile11 *profile.Profile, err error) *MockProfileRepository_Upsert_Call {
+	_c.Call.Return(profile11, err)
+	return _c
+}
+
+func (_c *MockProfileRepository_Upsert_Call) RunAndReturn(run func(ctx context.Context, profile1 *profile.Profile) (*profile.Profile, error)) *MockProfileRepository_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockProfileService creates a new instance of MockProfileService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockProfileService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockProfileService {
+	mock := &MockProfileService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockProfileService is an autogenerated mock type for the ProfileService type
+type MockProfileService struct {
+	mock.Mock
+}
+
+type MockProfileService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockProfileService) EXPECT() *MockProfileService_Expecter {
+	return &MockProfileService_Expecter{mock: &_m.Mock}
+}
+
+// GetProfile provides a mock function for the type MockProfileService
+func (_mock *MockProfileService) GetProfile(ctx context.Context, userID string) (*profile.Profile, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProfile")
+	}
+
+	var r0 *profile.Profile
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*profile.Profile, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *profile.Profile); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*profile.Profile)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProfileService_GetProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProfile'
+type MockProfileService_GetProfile_Call struct {
+	*mock.Call
+}
+
+// GetProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockProfileService_Expecter) GetProfile(ctx interface{}, userID interface{}) *MockProfileService_GetProfile_Call {
+	return &MockProfileService_GetProfile_Call{Call: _e.mock.On("GetProfile", ctx, userID)}
+}
+
+func (_c *MockProfileService_GetProfile_Call) Run(run func(ctx context.Context, userID string)) *MockProfileService_GetProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProfileService_GetProfile_Call) Return(profile1 *profile.Profile, err error) *MockProfileService_GetProfile_Call {
+	_c.Call.Return(profile1, err)
+	return _c
+}
+
+func (_c *MockProfileService_GetProfile_Call) RunAndReturn(run func(ctx context.Context, userID string) (*profile.Profile, error)) *MockProfileService_GetProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateProfile provides a mock function for the type MockProfileService
+func (_mock *MockProfileService) UpdateProfile(ctx context.Context, userID string, req *profile.UpdateProfileRequest) (*profile.Profile, error) {
+	ret := _mock.Called(ctx, userID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProfile")
+	}
+
+	var r0 *profile.Profile
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *profile.UpdateProfileRequest) (*profile.Profile, error)); ok {
+		return returnFunc(ctx, userID, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *profile.UpdateProfileRequest) *profile.Profile); ok {
+		r0 = returnFunc(ctx, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*profile.Profile)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *profile.UpdateProfileRequest) error); ok {
+		r1 = returnFunc(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProfileService_UpdateProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProfile'
+type MockProfileService_UpdateProfile_Call struct {
+	*mock.Call
+}
+
+// UpdateProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - req *profile.UpdateProfileRequest
+func (_e *MockProfileService_Expecter) UpdateProfile(ctx interface{}, userID interface{}, req interface{}) *MockProfileService_UpdateProfile_Call {
+	return &MockProfileService_UpdateProfile_Call{Call: _e.mock.On("UpdateProfile", ctx, userID, req)}
+}
+
+func (_c *MockProfileService_UpdateProfile_Call) Run(run func(ctx context.Context, userID string, req *profile.UpdateProfileRequest)) *MockProfileService_UpdateProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *profile.UpdateProfileRequest
+		if args[2] != nil {
+			arg2 = args[2].(*profile.UpdateProfileRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProfileService_UpdateProfile_Call) Return(profile1 *profile.Profile, err error) *MockProfileService_UpdateProfile_Call {
+	_c.Call.Return(profile1, err)
+	return _c
+}
+
+func (_c *MockProfileService_UpdateProfile_Call) RunAndReturn(run func(ctx context.Context, userID string, req *profile.UpdateProfileRequest) (*profile.Profile, error)) *MockProfileService_UpdateProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRateLimitStore creates a new instance of MockRateLimitStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRateLimitStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRateLimitStore {
+	mock := &MockRateLimitStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockRateLimitStore is an autogenerated mock type for the RateLimitStore type
+type MockRateLimitStore struct {
+	mock.Mock
+}
+
+type MockRateLimitStore_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockPasswordService) EXPECT() *MockPasswordService_Expecter {
-	return &MockPasswordService_Expecter{mock: &_m.Mock}
+func (_m *MockRateLimitStore) EXPECT() *MockRateLimitStore_Expecter {
+	return &MockRateLimitStore_Expecter{mock: &_m.Mock}
 }
 
-// CheckPassword provides a mock function for the type MockPasswordService
-func (_mock *MockPasswordService) CheckPassword(hashedPassword string, password string) error {
-	ret := _mock.Called(hashedPassword, password)
+// Take provides a mock function for the type MockRateLimitStore
+func (_mock *MockRateLimitStore) Take(ctx context.Context, key string, limit ratelimit.Limit, now time.Time) (ratelimit.Result, error) {
+	ret := _mock.Called(ctx, key, limit, now)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CheckPassword")
+		panic("no return value specified for Take")
+	}
+
+	var r0 ratelimit.Result
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, ratelimit.Limit, time.Time) (ratelimit.Result, error)); ok {
+		return returnFunc(ctx, key, limit, now)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, ratelimit.Limit, time.Time) ratelimit.Result); ok {
+		r0 = returnFunc(ctx, key, limit, now)
+	} else {
+		r0 = ret.Get(0).(ratelimit.Result)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, ratelimit.Limit, time.Time) error); ok {
+		r1 = returnFunc(ctx, key, limit, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRateLimitStore_Take_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Take'
+type MockRateLimitStore_Take_Call struct {
+	*mock.Call
+}
+
+// Take is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - limit ratelimit.Limit
+//   - now time.Time
+func (_e *MockRateLimitStore_Expecter) Take(ctx interface{}, key interface{}, limit interface{}, now interface{}) *MockRateLimitStore_Take_Call {
+	return &MockRateLimitStore_Take_Call{Call: _e.mock.On("Take", ctx, key, limit, now)}
+}
+
+func (_c *MockRateLimitStore_Take_Call) Run(run func(ctx context.Context, key string, limit ratelimit.Limit, now time.Time)) *MockRateLimitStore_Take_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 ratelimit.Limit
+		if args[2] != nil {
+			arg2 = args[2].(ratelimit.Limit)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRateLimitStore_Take_Call) Return(result ratelimit.Result, err error) *MockRateLimitStore_Take_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *MockRateLimitStore_Take_Call) RunAndReturn(run func(ctx context.Context, key string, limit ratelimit.Limit, now time.Time) (ratelimit.Result, error)) *MockRateLimitStore_Take_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTransactionRepository creates a new instance of MockTransactionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTransactionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTransactionRepository {
+	mock := &MockTransactionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTransactionRepository is an autogenerated mock type for the TransactionRepository type
+type MockTransactionRepository struct {
+	mock.Mock
+}
+
+type MockTransactionRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTransactionRepository) EXPECT() *MockTransactionRepository_Expecter {
+	return &MockTransactionRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateTransaction provides a mock function for the type MockTransactionRepository
+func (_mock *MockTransactionRepository) CreateTransaction(ctx context.Context, tx *account.Transaction) error {
+	ret := _mock.Called(ctx, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTransaction")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = returnFunc(hashedPassword, password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *account.Transaction) error); ok {
+		r0 = returnFunc(ctx, tx)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockPasswordService_CheckPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckPassword'
-type MockPasswordService_CheckPassword_Call struct {
+// MockTransactionRepository_CreateTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTransaction'
+type MockTransactionRepository_CreateTransaction_Call struct {
 	*mock.Call
 }
 
-// CheckPassword is a helper method to define mock.On call
-//   - hashedPassword string
-//   - password string
-func (_e *MockPasswordService_Expecter) CheckPassword(hashedPassword interface{}, password interface{}) *MockPasswordService_CheckPassword_Call {
-	return &MockPasswordService_CheckPassword_Call{Call: _e.mock.On("CheckPassword", hashedPassword, password)}
+// CreateTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx *account.Transaction
+func (_e *MockTransactionRepository_Expecter) CreateTransaction(ctx interface{}, tx interface{}) *MockTransactionRepository_CreateTransaction_Call {
+	return &MockTransactionRepository_CreateTransaction_Call{Call: _e.mock.On("CreateTransaction", ctx, tx)}
 }
 
-func (_c *MockPasswordService_CheckPassword_Call) Run(run func(hashedPassword string, password string)) *MockPasswordService_CheckPassword_Call {
+func (_c *MockTransactionRepository_CreateTransaction_Call) Run(run func(ctx context.Context, tx *account.Transaction)) *MockTransactionRepository_CreateTransaction_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *account.Transaction
+		if args[1] != nil {
+			arg1 = args[1].(*account.Transaction)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_CreateTransaction_Call) Return(err error) *MockTransactionRepository_CreateTransaction_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTransactionRepository_CreateTransaction_Call) RunAndReturn(run func(ctx context.Context, tx *account.Transaction) error) *MockTransactionRepository_CreateTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccountTransactionsSince provides a mock function for the type MockTransactionRepository
+func (_mock *MockTransactionRepository) GetAccountTransactionsSince(ctx context.Context, accountID string, since time.Time) ([]*account.Transaction, error) {
+	ret := _mock.Called(ctx, accountID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountTransactionsSince")
+	}
+
+	var r0 []*account.Transaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]*account.Transaction, error)); ok {
+		return returnFunc(ctx, accountID, since)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) []*account.Transaction); ok {
+		r0 = returnFunc(ctx, accountID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*account.Transaction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, accountID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransactionRepository_GetAccountTransactionsSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountTransactionsSince'
+type MockTransactionRepository_GetAccountTransactionsSince_Call struct {
+	*mock.Call
+}
+
+// GetAccountTransactionsSince is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID string
+//   - since time.Time
+func (_e *MockTransactionRepository_Expecter) GetAccountTransactionsSince(ctx interface{}, accountID interface{}, since interface{}) *MockTransactionRepository_GetAccountTransactionsSince_Call {
+	return &MockTransactionRepository_GetAccountTransactionsSince_Call{Call: _e.mock.On("GetAccountTransactionsSince", ctx, accountID, since)}
+}
+
+func (_c *MockTransactionRepository_GetAccountTransactionsSince_Call) Run(run func(ctx context.Context, accountID string, since time.Time)) *MockTransactionRepository_GetAccountTransactionsSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
 		}
 		var arg1 string
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockPasswordService_CheckPassword_Call) Return(err error) *MockPasswordService_CheckPassword_Call {
-	_c.Call.Return(err)
+func (_c *MockTransactionRepository_GetAccountTransactionsSince_Call) Return(transactions []*account.Transaction, err error) *MockTransactionRepository_GetAccountTransactionsSince_Call {
+	_c.Call.Return(transactions, err)
 	return _c
 }
 
-func (_c *MockPasswordService_CheckPassword_Call) RunAndReturn(run func(hashedPassword string, password string) error) *MockPasswordService_CheckPassword_Call {
+func (_c *MockTransactionRepository_GetAccountTransactionsSince_Call) RunAndReturn(run func(ctx context.Context, accountID string, since time.Time) ([]*account.Transaction, error)) *MockTransactionRepository_GetAccountTransactionsSince_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// HashPassword provides a mock function for the type MockPasswordService
-func (_mock *MockPasswordService) HashPassword(password string) (string, error) {
-	ret := _mock.Called(password)
+// GetTransactionsByAccountIDs provides a mock function for the type MockTransactionRepository
+func (_mock *MockTransactionRepository) GetTransactionsByAccountIDs(ctx context.Context, accountIDs []string) ([]*account.Transaction, error) {
+	ret := _mock.Called(ctx, accountIDs)
 
 	if len(ret) == 0 {
-		panic("no return value specified for HashPassword")
+		panic("no return value specified for GetTransactionsByAccountIDs")
 	}
 
-	var r0 string
+	var r0 []*account.Transaction
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return returnFunc(password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]*account.Transaction, error)); ok {
+		return returnFunc(ctx, accountIDs)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
-		r0 = returnFunc(password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []*account.Transaction); ok {
+		r0 = returnFunc(ctx, accountIDs)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*account.Transaction)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(password)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, accountIDs)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockPasswordService_HashPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HashPassword'
-type MockPasswordService_HashPassword_Call struct {
+// MockTransactionRepository_GetTransactionsByAccountIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionsByAccountIDs'
+type MockTransactionRepository_GetTransactionsByAccountIDs_Call struct {
 	*mock.Call
 }
 
-// HashPassword is a helper method to define mock.On call
-//   - password string
-func (_e *MockPasswordService_Expecter) HashPassword(password interface{}) *MockPasswordService_HashPassword_Call {
-	return &MockPasswordService_HashPassword_Call{Call: _e.mock.On("HashPassword", password)}
+// GetTransactionsByAccountIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountIDs []string
+func (_e *MockTransactionRepository_Expecter) GetTransactionsByAccountIDs(ctx interface{}, accountIDs interface{}) *MockTransactionRepository_GetTransactionsByAccountIDs_Call {
+	return &MockTransactionRepository_GetTransactionsByAccountIDs_Call{Call: _e.mock.On("GetTransactionsByAccountIDs", ctx, accountIDs)}
 }
 
-func (_c *MockPasswordService_HashPassword_Call) Run(run func(password string)) *MockPasswordService_HashPassword_Call {
+func (_c *MockTransactionRepository_GetTransactionsByAccountIDs_Call) Run(run func(ctx context.Context, accountIDs []string)) *MockTransactionRepository_GetTransactionsByAccountIDs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockPasswordService_HashPassword_Call) Return(s string, err error) *MockPasswordService_HashPassword_Call {
-	_c.Call.Return(s, err)
+func (_c *MockTransactionRepository_GetTransactionsByAccountIDs_Call) Return(transactions []*account.Transaction, err error) *MockTransactionRepository_GetTransactionsByAccountIDs_Call {
+	_c.Call.Return(transactions, err)
 	return _c
 }
 
-func (_c *MockPasswordService_HashPassword_Call) RunAndReturn(run func(password string) (string, error)) *MockPasswordService_HashPassword_Call {
+func (_c *MockTransactionRepository_GetTransactionsByAccountIDs_Call) RunAndReturn(run func(ctx context.Context, accountIDs []string) ([]*account.Transaction, error)) *MockTransactionRepository_GetTransactionsByAccountIDs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockProfileRepository creates a new instance of MockProfileRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockInterestHistoryRepository creates a new instance of MockInterestHistoryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockProfileRepository(t interface {
+func NewMockInterestHistoryRepository(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockProfileRepository {
-	mock := &MockProfileRepository{}
+}) *MockInterestHistoryRepository {
+	mock := &MockInterestHistoryRepository{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -1260,140 +5592,182 @@ func NewMockProfileRepository(t interface {
 	return mock
 }
 
-// MockProfileRepository is an autogenerated mock type for the ProfileRepository type
-type MockProfileRepository struct {
+// MockInterestHistoryRepository is an autogenerated mock type for the InterestHistoryRepository type
+type MockInterestHistoryRepository struct {
 	mock.Mock
 }
 
-type MockProfileRepository_Expecter struct {
+type MockInterestHistoryRepository_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockProfileRepository) EXPECT() *MockProfileRepository_Expecter {
-	return &MockProfileRepository_Expecter{mock: &_m.Mock}
+func (_m *MockInterestHistoryRepository) EXPECT() *MockInterestHistoryRepository_Expecter {
+	return &MockInterestHistoryRepository_Expecter{mock: &_m.Mock}
 }
 
-// CheckNationalIDExists provides a mock function for the type MockProfileRepository
-func (_mock *MockProfileRepository) CheckNationalIDExists(ctx context.Context, nationalID string, excludeUserID string) (bool, error) {
-	ret := _mock.Called(ctx, nationalID, excludeUserID)
+// CreateFixedInterestRecord provides a mock function for the type MockInterestHistoryRepository
+func (_mock *MockInterestHistoryRepository) CreateFixedInterestRecord(ctx context.Context, record *account.FixedInterestRecord) error {
+	ret := _mock.Called(ctx, record)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CheckNationalIDExists")
+		panic("no return value specified for CreateFixedInterestRecord")
 	}
 
-	var r0 bool
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
-		return returnFunc(ctx, nationalID, excludeUserID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
-		r0 = returnFunc(ctx, nationalID, excludeUserID)
-	} else {
-		r0 = ret.Get(0).(bool)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, nationalID, excludeUserID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *account.FixedInterestRecord) error); ok {
+		r0 = returnFunc(ctx, record)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockProfileRepository_CheckNationalIDExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckNationalIDExists'
-type MockProfileRepository_CheckNationalIDExists_Call struct {
+// MockInterestHistoryRepository_CreateFixedInterestRecord_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateFixedInterestRecord'
+type MockInterestHistoryRepository_CreateFixedInterestRecord_Call struct {
 	*mock.Call
 }
 
-// CheckNationalIDExists is a helper method to define mock.On call
+// CreateFixedInterestRecord is a helper method to define mock.On call
 //   - ctx context.Context
-//   - nationalID string
-//   - excludeUserID string
-func (_e *MockProfileRepository_Expecter) CheckNationalIDExists(ctx interface{}, nationalID interface{}, excludeUserID interface{}) *MockProfileRepository_CheckNationalIDExists_Call {
-	return &MockProfileRepository_CheckNationalIDExists_Call{Call: _e.mock.On("CheckNationalIDExists", ctx, nationalID, excludeUserID)}
+//   - record *account.FixedInterestRecord
+func (_e *MockInterestHistoryRepository_Expecter) CreateFixedInterestRecord(ctx interface{}, record interface{}) *MockInterestHistoryRepository_CreateFixedInterestRecord_Call {
+	return &MockInterestHistoryRepository_CreateFixedInterestRecord_Call{Call: _e.mock.On("CreateFixedInterestRecord", ctx, record)}
 }
 
-func (_c *MockProfileRepository_CheckNationalIDExists_Call) Run(run func(ctx context.Context, nationalID string, excludeUserID string)) *MockProfileRepository_CheckNationalIDExists_Call {
+func (_c *MockInterestHistoryRepository_CreateFixedInterestRecord_Call) Run(run func(ctx context.Context, record *account.FixedInterestRecord)) *MockInterestHistoryRepository_CreateFixedInterestRecord_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *account.FixedInterestRecord
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(*account.FixedInterestRecord)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInterestHistoryRepository_CreateFixedInterestRecord_Call) Return(err error) *MockInterestHistoryRepository_CreateFixedInterestRecord_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockInterestHistoryRepository_CreateFixedInterestRecord_Call) RunAndReturn(run func(ctx context.Context, record *account.FixedInterestRecord) error) *MockInterestHistoryRepository_CreateFixedInterestRecord_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateFlexibleInterestRecord provides a mock function for the type MockInterestHistoryRepository
+func (_mock *MockInterestHistoryRepository) CreateFlexibleInterestRecord(ctx context.Context, record *account.FlexibleInterestRecord) error {
+	ret := _mock.Called(ctx, record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFlexibleInterestRecord")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *account.FlexibleInterestRecord) error); ok {
+		r0 = returnFunc(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockInterestHistoryRepository_CreateFlexibleInterestRecord_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateFlexibleInterestRecord'
+type MockInterestHistoryRepository_CreateFlexibleInterestRecord_Call struct {
+	*mock.Call
+}
+
+// CreateFlexibleInterestRecord is a helper method to define mock.On call
+//   - ctx context.Context
+//   - record *account.FlexibleInterestRecord
+func (_e *MockInterestHistoryRepository_Expecter) CreateFlexibleInterestRecord(ctx interface{}, record interface{}) *MockInterestHistoryRepository_CreateFlexibleInterestRecord_Call {
+	return &MockInterestHistoryRepository_CreateFlexibleInterestRecord_Call{Call: _e.mock.On("CreateFlexibleInterestRecord", ctx, record)}
+}
+
+func (_c *MockInterestHistoryRepository_CreateFlexibleInterestRecord_Call) Run(run func(ctx context.Context, record *account.FlexibleInterestRecord)) *MockInterestHistoryRepository_CreateFlexibleInterestRecord_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
 		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
+		var arg1 *account.FlexibleInterestRecord
+		if args[1] != nil {
+			arg1 = args[1].(*account.FlexibleInterestRecord)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockProfileRepository_CheckNationalIDExists_Call) Return(b bool, err error) *MockProfileRepository_CheckNationalIDExists_Call {
-	_c.Call.Return(b, err)
+func (_c *MockInterestHistoryRepository_CreateFlexibleInterestRecord_Call) Return(err error) *MockInterestHistoryRepository_CreateFlexibleInterestRecord_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockProfileRepository_CheckNationalIDExists_Call) RunAndReturn(run func(ctx context.Context, nationalID string, excludeUserID string) (bool, error)) *MockProfileRepository_CheckNationalIDExists_Call {
+func (_c *MockInterestHistoryRepository_CreateFlexibleInterestRecord_Call) RunAndReturn(run func(ctx context.Context, record *account.FlexibleInterestRecord) error) *MockInterestHistoryRepository_CreateFlexibleInterestRecord_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByUserID provides a mock function for the type MockProfileRepository
-func (_mock *MockProfileRepository) GetByUserID(ctx context.Context, userID string) (*profile.Profile, error) {
-	ret := _mock.Called(ctx, userID)
+// GetFixedInterestHistoryByAccountIDs provides a mock function for the type MockInterestHistoryRepository
+func (_mock *MockInterestHistoryRepository) GetFixedInterestHistoryByAccountIDs(ctx context.Context, accountIDs []string) ([]*account.FixedInterestRecord, error) {
+	ret := _mock.Called(ctx, accountIDs)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByUserID")
+		panic("no return value specified for GetFixedInterestHistoryByAccountIDs")
 	}
 
-	var r0 *profile.Profile
+	var r0 []*account.FixedInterestRecord
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*profile.Profile, error)); ok {
-		return returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]*account.FixedInterestRecord, error)); ok {
+		return returnFunc(ctx, accountIDs)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *profile.Profile); ok {
-		r0 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []*account.FixedInterestRecord); ok {
+		r0 = returnFunc(ctx, accountIDs)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*profile.Profile)
+			r0 = ret.Get(0).([]*account.FixedInterestRecord)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, accountIDs)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockProfileRepository_GetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserID'
-type MockProfileRepository_GetByUserID_Call struct {
+// MockInterestHistoryRepository_GetFixedInterestHistoryByAccountIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFixedInterestHistoryByAccountIDs'
+type MockInterestHistoryRepository_GetFixedInterestHistoryByAccountIDs_Call struct {
 	*mock.Call
 }
 
-// GetByUserID is a helper method to define mock.On call
+// GetFixedInterestHistoryByAccountIDs is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-func (_e *MockProfileRepository_Expecter) GetByUserID(ctx interface{}, userID interface{}) *MockProfileRepository_GetByUserID_Call {
-	return &MockProfileRepository_GetByUserID_Call{Call: _e.mock.On("GetByUserID", ctx, userID)}
+//   - accountIDs []string
+func (_e *MockInterestHistoryRepository_Expecter) GetFixedInterestHistoryByAccountIDs(ctx interface{}, accountIDs interface{}) *MockInterestHistoryRepository_GetFixedInterestHistoryByAccountIDs_Call {
+	return &MockInterestHistoryRepository_GetFixedInterestHistoryByAccountIDs_Call{Call: _e.mock.On("GetFixedInterestHistoryByAccountIDs", ctx, accountIDs)}
 }
 
-func (_c *MockProfileRepository_GetByUserID_Call) Run(run func(ctx context.Context, userID string)) *MockProfileRepository_GetByUserID_Call {
+func (_c *MockInterestHistoryRepository_GetFixedInterestHistoryByAccountIDs_Call) Run(run func(ctx context.Context, accountIDs []string)) *MockInterestHistoryRepository_GetFixedInterestHistoryByAccountIDs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].([]string)
 		}
 		run(
 			arg0,
@@ -1403,65 +5777,65 @@ func (_c *MockProfileRepository_GetByUserID_Call) Run(run func(ctx context.Conte
 	return _c
 }
 
-func (_c *MockProfileRepository_GetByUserID_Call) Return(profile1 *profile.Profile, err error) *MockProfileRepository_GetByUserID_Call {
-	_c.Call.Return(profile1, err)
+func (_c *MockInterestHistoryRepository_GetFixedInterestHistoryByAccountIDs_Call) Return(fixedInterestRecords []*account.FixedInterestRecord, err error) *MockInterestHistoryRepository_GetFixedInterestHistoryByAccountIDs_Call {
+	_c.Call.Return(fixedInterestRecords, err)
 	return _c
 }
 
-func (_c *MockProfileRepository_GetByUserID_Call) RunAndReturn(run func(ctx context.Context, userID string) (*profile.Profile, error)) *MockProfileRepository_GetByUserID_Call {
+func (_c *MockInterestHistoryRepository_GetFixedInterestHistoryByAccountIDs_Call) RunAndReturn(run func(ctx context.Context, accountIDs []string) ([]*account.FixedInterestRecord, error)) *MockInterestHistoryRepository_GetFixedInterestHistoryByAccountIDs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Upsert provides a mock function for the type MockProfileRepository
-func (_mock *MockProfileRepository) Upsert(ctx context.Context, profile1 *profile.Profile) (*profile.Profile, error) {
-	ret := _mock.Called(ctx, profile1)
+// GetFlexibleInterestHistoryByAccountIDs provides a mock function for the type MockInterestHistoryRepository
+func (_mock *MockInterestHistoryRepository) GetFlexibleInterestHistoryByAccountIDs(ctx context.Context, accountIDs []string) ([]*account.FlexibleInterestRecord, error) {
+	ret := _mock.Called(ctx, accountIDs)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Upsert")
+		panic("no return value specified for GetFlexibleInterestHistoryByAccountIDs")
 	}
 
-	var r0 *profile.Profile
+	var r0 []*account.FlexibleInterestRecord
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *profile.Profile) (*profile.Profile, error)); ok {
-		return returnFunc(ctx, profile1)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]*account.FlexibleInterestRecord, error)); ok {
+		return returnFunc(ctx, accountIDs)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *profile.Profile) *profile.Profile); ok {
-		r0 = returnFunc(ctx, profile1)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []*account.FlexibleInterestRecord); ok {
+		r0 = returnFunc(ctx, accountIDs)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*profile.Profile)
+			r0 = ret.Get(0).([]*account.FlexibleInterestRecord)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *profile.Profile) error); ok {
-		r1 = returnFunc(ctx, profile1)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, accountIDs)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockProfileRepository_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
-type MockProfileRepository_Upsert_Call struct {
+// MockInterestHistoryRepository_GetFlexibleInterestHistoryByAccountIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFlexibleInterestHistoryByAccountIDs'
+type MockInterestHistoryRepository_GetFlexibleInterestHistoryByAccountIDs_Call struct {
 	*mock.Call
 }
 
-// Upsert is a helper method to define mock.On call
+// GetFlexibleInterestHistoryByAccountIDs is a helper method to define mock.On call
 //   - ctx context.Context
-//   - profile1 *profile.Profile
-func (_e *MockProfileRepository_Expecter) Upsert(ctx interface{}, profile1 interface{}) *MockProfileRepository_Upsert_Call {
-	return &MockProfileRepository_Upsert_Call{Call: _e.mock.On("Upsert", ctx, profile1)}
+//   - accountIDs []string
+func (_e *MockInterestHistoryRepository_Expecter) GetFlexibleInterestHistoryByAccountIDs(ctx interface{}, accountIDs interface{}) *MockInterestHistoryRepository_GetFlexibleInterestHistoryByAccountIDs_Call {
+	return &MockInterestHistoryRepository_GetFlexibleInterestHistoryByAccountIDs_Call{Call: _e.mock.On("GetFlexibleInterestHistoryByAccountIDs", ctx, accountIDs)}
 }
 
-func (_c *MockProfileRepository_Upsert_Call) Run(run func(ctx context.Context, profile1 *profile.Profile)) *MockProfileRepository_Upsert_Call {
+func (_c *MockInterestHistoryRepository_GetFlexibleInterestHistoryByAccountIDs_Call) Run(run func(ctx context.Context, accountIDs []string)) *MockInterestHistoryRepository_GetFlexibleInterestHistoryByAccountIDs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *profile.Profile
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(*profile.Profile)
+			arg1 = args[1].([]string)
 		}
 		run(
 			arg0,
@@ -1471,23 +5845,23 @@ func (_c *MockProfileRepository_Upsert_Call) Run(run func(ctx context.Context, p
 	return _c
 }
 
-func (_c *MockProfileRepository_Upsert_Call) Return(profile11 *profile.Profile, err error) *MockProfileRepository_Upsert_Call {
-	_c.Call.Return(profile11, err)
+func (_c *MockInterestHistoryRepository_GetFlexibleInterestHistoryByAccountIDs_Call) Return(flexibleInterestRecords []*account.FlexibleInterestRecord, err error) *MockInterestHistoryRepository_GetFlexibleInterestHistoryByAccountIDs_Call {
+	_c.Call.Return(flexibleInterestRecords, err)
 	return _c
 }
 
-func (_c *MockProfileRepository_Upsert_Call) RunAndReturn(run func(ctx context.Context, profile1 *profile.Profile) (*profile.Profile, error)) *MockProfileRepository_Upsert_Call {
+func (_c *MockInterestHistoryRepository_GetFlexibleInterestHistoryByAccountIDs_Call) RunAndReturn(run func(ctx context.Context, accountIDs []string) ([]*account.FlexibleInterestRecord, error)) *MockInterestHistoryRepository_GetFlexibleInterestHistoryByAccountIDs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockProfileService creates a new instance of MockProfileService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockTxManager creates a new instance of MockTxManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockProfileService(t interface {
+func NewMockTxManager(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockProfileService {
-	mock := &MockProfileService{}
+}) *MockTxManager {
+	mock := &MockTxManager{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -1495,68 +5869,57 @@ func NewMockProfileService(t interface {
 	return mock
 }
 
-// MockProfileService is an autogenerated mock type for the ProfileService type
-type MockProfileService struct {
+// MockTxManager is an autogenerated mock type for the TxManager type
+type MockTxManager struct {
 	mock.Mock
 }
 
-type MockProfileService_Expecter struct {
+type MockTxManager_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockProfileService) EXPECT() *MockProfileService_Expecter {
-	return &MockProfileService_Expecter{mock: &_m.Mock}
+func (_m *MockTxManager) EXPECT() *MockTxManager_Expecter {
+	return &MockTxManager_Expecter{mock: &_m.Mock}
 }
 
-// GetProfile provides a mock function for the type MockProfileService
-func (_mock *MockProfileService) GetProfile(ctx context.Context, userID string) (*profile.Profile, error) {
-	ret := _mock.Called(ctx, userID)
+// WithinTransaction provides a mock function for the type MockTxManager
+func (_mock *MockTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	ret := _mock.Called(ctx, fn)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetProfile")
+		panic("no return value specified for WithinTransaction")
 	}
 
-	var r0 *profile.Profile
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*profile.Profile, error)); ok {
-		return returnFunc(ctx, userID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *profile.Profile); ok {
-		r0 = returnFunc(ctx, userID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*profile.Profile)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, userID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, func(ctx context.Context) error) error); ok {
+		r0 = returnFunc(ctx, fn)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockProfileService_GetProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProfile'
-type MockProfileService_GetProfile_Call struct {
+// MockTxManager_WithinTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithinTransaction'
+type MockTxManager_WithinTransaction_Call struct {
 	*mock.Call
 }
 
-// GetProfile is a helper method to define mock.On call
+// WithinTransaction is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-func (_e *MockProfileService_Expecter) GetProfile(ctx interface{}, userID interface{}) *MockProfileService_GetProfile_Call {
-	return &MockProfileService_GetProfile_Call{Call: _e.mock.On("GetProfile", ctx, userID)}
+//   - fn func(ctx context.Context) error
+func (_e *MockTxManager_Expecter) WithinTransaction(ctx interface{}, fn interface{}) *MockTxManager_WithinTransaction_Call {
+	return &MockTxManager_WithinTransaction_Call{Call: _e.mock.On("WithinTransaction", ctx, fn)}
 }
 
-func (_c *MockProfileService_GetProfile_Call) Run(run func(ctx context.Context, userID string)) *MockProfileService_GetProfile_Call {
+func (_c *MockTxManager_WithinTransaction_Call) Run(run func(ctx context.Context, fn func(ctx context.Context) error)) *MockTxManager_WithinTransaction_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 func(ctx context.Context) error
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(func(ctx context.Context) error)
 		}
 		run(
 			arg0,
@@ -1566,58 +5929,75 @@ func (_c *MockProfileService_GetProfile_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockProfileService_GetProfile_Call) Return(profile1 *profile.Profile, err error) *MockProfileService_GetProfile_Call {
-	_c.Call.Return(profile1, err)
+func (_c *MockTxManager_WithinTransaction_Call) Return(err error) *MockTxManager_WithinTransaction_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockProfileService_GetProfile_Call) RunAndReturn(run func(ctx context.Context, userID string) (*profile.Profile, error)) *MockProfileService_GetProfile_Call {
+func (_c *MockTxManager_WithinTransaction_Call) RunAndReturn(run func(ctx context.Context, fn func(ctx context.Context) error) error) *MockTxManager_WithinTransaction_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateProfile provides a mock function for the type MockProfileService
-func (_mock *MockProfileService) UpdateProfile(ctx context.Context, userID string, req *profile.UpdateProfileRequest) (*profile.Profile, error) {
-	ret := _mock.Called(ctx, userID, req)
+// NewMockUserRepository creates a new instance of MockUserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUserRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUserRepository {
+	mock := &MockUserRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockUserRepository is an autogenerated mock type for the UserRepository type
+type MockUserRepository struct {
+	mock.Mock
+}
+
+type MockUserRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUserRepository) EXPECT() *MockUserRepository_Expecter {
+	return &MockUserRepository_Expecter{mock: &_m.Mock}
+}
+
+// Anonymize provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) Anonymize(ctx context.Context, id string, username string, email string) error {
+	ret := _mock.Called(ctx, id, username, email)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateProfile")
+		panic("no return value specified for Anonymize")
 	}
 
-	var r0 *profile.Profile
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *profile.UpdateProfileRequest) (*profile.Profile, error)); ok {
-		return returnFunc(ctx, userID, req)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *profile.UpdateProfileRequest) *profile.Profile); ok {
-		r0 = returnFunc(ctx, userID, req)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*profile.Profile)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *profile.UpdateProfileRequest) error); ok {
-		r1 = returnFunc(ctx, userID, req)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, id, username, email)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockProfileService_UpdateProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProfile'
-type MockProfileService_UpdateProfile_Call struct {
+// MockUserRepository_Anonymize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Anonymize'
+type MockUserRepository_Anonymize_Call struct {
 	*mock.Call
 }
 
-// UpdateProfile is a helper method to define mock.On call
+// Anonymize is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-//   - req *profile.UpdateProfileRequest
-func (_e *MockProfileService_Expecter) UpdateProfile(ctx interface{}, userID interface{}, req interface{}) *MockProfileService_UpdateProfile_Call {
-	return &MockProfileService_UpdateProfile_Call{Call: _e.mock.On("UpdateProfile", ctx, userID, req)}
+//   - id string
+//   - username string
+//   - email string
+func (_e *MockUserRepository_Expecter) Anonymize(ctx interface{}, id interface{}, username interface{}, email interface{}) *MockUserRepository_Anonymize_Call {
+	return &MockUserRepository_Anonymize_Call{Call: _e.mock.On("Anonymize", ctx, id, username, email)}
 }
 
-func (_c *MockProfileService_UpdateProfile_Call) Run(run func(ctx context.Context, userID string, req *profile.UpdateProfileRequest)) *MockProfileService_UpdateProfile_Call {
+func (_c *MockUserRepository_Anonymize_Call) Run(run func(ctx context.Context, id string, username string, email string)) *MockUserRepository_Anonymize_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1627,56 +6007,34 @@ func (_c *MockProfileService_UpdateProfile_Call) Run(run func(ctx context.Contex
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 *profile.UpdateProfileRequest
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(*profile.UpdateProfileRequest)
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockProfileService_UpdateProfile_Call) Return(profile1 *profile.Profile, err error) *MockProfileService_UpdateProfile_Call {
-	_c.Call.Return(profile1, err)
+func (_c *MockUserRepository_Anonymize_Call) Return(err error) *MockUserRepository_Anonymize_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockProfileService_UpdateProfile_Call) RunAndReturn(run func(ctx context.Context, userID string, req *profile.UpdateProfileRequest) (*profile.Profile, error)) *MockProfileService_UpdateProfile_Call {
+func (_c *MockUserRepository_Anonymize_Call) RunAndReturn(run func(ctx context.Context, id string, username string, email string) error) *MockUserRepository_Anonymize_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockUserRepository creates a new instance of MockUserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockUserRepository(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockUserRepository {
-	mock := &MockUserRepository{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockUserRepository is an autogenerated mock type for the UserRepository type
-type MockUserRepository struct {
-	mock.Mock
-}
-
-type MockUserRepository_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockUserRepository) EXPECT() *MockUserRepository_Expecter {
-	return &MockUserRepository_Expecter{mock: &_m.Mock}
-}
-
 // Create provides a mock function for the type MockUserRepository
 func (_mock *MockUserRepository) Create(ctx context.Context, user1 *user.User) (*user.User, error) {
 	ret := _mock.Called(ctx, user1)
@@ -2039,6 +6397,74 @@ func (_c *MockUserService_CreateUser_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
+// GetActiveUser provides a mock function for the type MockUserService
+func (_mock *MockUserService) GetActiveUser(ctx context.Context, userID string) (*user.User, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveUser")
+	}
+
+	var r0 *user.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*user.User, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *user.User); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_GetActiveUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveUser'
+type MockUserService_GetActiveUser_Call struct {
+	*mock.Call
+}
+
+// GetActiveUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockUserService_Expecter) GetActiveUser(ctx interface{}, userID interface{}) *MockUserService_GetActiveUser_Call {
+	return &MockUserService_GetActiveUser_Call{Call: _e.mock.On("GetActiveUser", ctx, userID)}
+}
+
+func (_c *MockUserService_GetActiveUser_Call) Run(run func(ctx context.Context, userID string)) *MockUserService_GetActiveUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_GetActiveUser_Call) Return(user1 *user.User, err error) *MockUserService_GetActiveUser_Call {
+	_c.Call.Return(user1, err)
+	return _c
+}
+
+func (_c *MockUserService_GetActiveUser_Call) RunAndReturn(run func(ctx context.Context, userID string) (*user.User, error)) *MockUserService_GetActiveUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // LoginUser provides a mock function for the type MockUserService
 func (_mock *MockUserService) LoginUser(ctx context.Context, req *user.LoginUserRequest) (*user.User, error) {
 	ret := _mock.Called(ctx, req)
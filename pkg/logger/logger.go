@@ -1,6 +1,10 @@
 package logger
 
 import (
+	"context"
+
+	"e-wallet/pkg/tracing"
+
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -15,7 +19,7 @@ func NewAppLogger() (*zap.SugaredLogger, error) {
 	cfg.EncoderConfig.CallerKey = "func"
 	cfg.EncoderConfig.EncodeCaller = zapcore.FullCallerEncoder
 
-	logger, err := cfg.Build()
+	logger, err := cfg.Build(zap.WrapCore(NewRedactingCore))
 	if err != nil {
 		return nil, err
 	}
@@ -28,3 +32,28 @@ func Sync(l *zap.SugaredLogger) {
 		l.Error("cannot sync logger: ", err)
 	}
 }
+
+// WithTrace adds the trace and span ids found in ctx to l, so log lines can be
+// joined with their trace.
+func WithTrace(ctx context.Context, l *zap.SugaredLogger) *zap.SugaredLogger {
+	traceID := tracing.TraceID(ctx)
+	if traceID == "" {
+		return l
+	}
+	return l.With("trace_id", traceID, "span_id", tracing.SpanID(ctx))
+}
+
+type contextKey struct{}
+
+// WithContext returns a copy of ctx carrying l.
+func WithContext(ctx context.Context, l *zap.SugaredLogger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the request-scoped logger stored in ctx, or fallback.
+func FromContext(ctx context.Context, fallback *zap.SugaredLogger) *zap.SugaredLogger {
+	if l, ok := ctx.Value(contextKey{}).(*zap.SugaredLogger); ok {
+		return l
+	}
+	return fallback
+}
@@ -0,0 +1,181 @@
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Redacted replaces secret values in logs.
+const Redacted = "[REDACTED]"
+
+// secretKeys are dropped entirely; maskedKeys keep their last 4 characters so
+// support can still tell two values apart. Keys are compared lower-cased with
+// "-" treated as "_".
+var (
+	secretKeys = map[string]bool{
+		"password":      true,
+		"new_password":  true,
+		"password_hash": true,
+		"token":         true,
+		"access_token":  true,
+		"refresh_token": true,
+		"authorization": true,
+		"secret":        true,
+		"api_key":       true,
+		"bank_token":    true,
+	}
+	maskedKeys = map[string]bool{
+		"national_id":  true,
+		"phone":        true,
+		"phone_number": true,
+	}
+)
+
+// Patterns scrubbed from messages and free-text values. National ids start
+// with the code of a province, 001 to 096, which tells them apart from the
+// 12-digit account numbers, starting with the bank prefix, left readable.
+var (
+	bearerRegex     = regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9\-._~+/]+=*`)
+	jwtRegex        = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	phoneRegex      = regexp.MustCompile(`(\+\d{10,14}|\b0\d{9})\b`)
+	nationalIDRegex = regexp.MustCompile(`\b0\d{11}\b`)
+)
+
+// Scrub masks tokens, phone numbers and national ids found in s.
+func Scrub(s string) string {
+	s = bearerRegex.ReplaceAllString(s, "Bearer "+Redacted)
+	s = jwtRegex.ReplaceAllString(s, Redacted)
+	s = phoneRegex.ReplaceAllStringFunc(s, mask)
+	s = nationalIDRegex.ReplaceAllStringFunc(s, mask)
+	return s
+}
+
+func mask(s string) string {
+	if len(s) <= 4 {
+		return strings.Repeat("*", len(s))
+	}
+	return strings.Repeat("*", len(s)-4) + s[len(s)-4:]
+}
+
+func normalizeKey(key string) string {
+	return strings.ReplaceAll(strings.ToLower(key), "-", "_")
+}
+
+// redactValue applies the key rules to a single value.
+func redactValue(key string, value string) string {
+	k := normalizeKey(key)
+	switch {
+	case secretKeys[k]:
+		return Redacted
+	case maskedKeys[k]:
+		return mask(value)
+	default:
+		return Scrub(value)
+	}
+}
+
+// redactingCore masks sensitive data before it reaches the wrapped core.
+type redactingCore struct {
+	zapcore.Core
+}
+
+// NewRedactingCore wraps core so that secret fields, tokens, national ids
+// and phone numbers never reach the output. Structs logged with zap.Any are
+// walked through their JSON form.
+func NewRedactingCore(core zapcore.Core) zapcore.Core {
+	return &redactingCore{Core: core}
+}
+
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{Core: c.Core.With(redactFields(fields))}
+}
+
+func (c *redactingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *redactingCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ent.Message = Scrub(ent.Message)
+	return c.Core.Write(ent, redactFields(fields))
+}
+
+func redactFields(fields []zapcore.Field) []zapcore.Field {
+	out := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		out[i] = redactField(f)
+	}
+	return out
+}
+
+func redactField(f zapcore.Field) zapcore.Field {
+	k := normalizeKey(f.Key)
+	if secretKeys[k] {
+		return zap.String(f.Key, Redacted)
+	}
+
+	switch f.Type {
+	case zapcore.StringType:
+		return zap.String(f.Key, redactValue(f.Key, f.String))
+	case zapcore.StringerType:
+		if s, ok := f.Interface.(interface{ String() string }); ok {
+			return zap.String(f.Key, redactValue(f.Key, s.String()))
+		}
+	case zapcore.ErrorType:
+		if err, ok := f.Interface.(error); ok {
+			return zap.NamedError(f.Key, errors.New(Scrub(err.Error())))
+		}
+	case zapcore.ReflectType, zapcore.ObjectMarshalerType, zapcore.ArrayMarshalerType:
+		if redacted, ok := redactReflect(f.Interface); ok {
+			return zap.Any(f.Key, redacted)
+		}
+		return zap.String(f.Key, Redacted)
+	}
+	if maskedKeys[k] {
+		return zap.String(f.Key, Redacted)
+	}
+	return f
+}
+
+// redactReflect walks v through its JSON form, applying the key rules at
+// every level.
+func redactReflect(v any) (any, bool) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, false
+	}
+	var decoded any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, false
+	}
+	return redactJSON("", decoded), true
+}
+
+func redactJSON(key string, v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, child := range val {
+			val[k] = redactJSON(k, child)
+		}
+		return val
+	case []any:
+		for i, child := range val {
+			val[i] = redactJSON(key, child)
+		}
+		return val
+	case string:
+		return redactValue(key, val)
+	default:
+		if secretKeys[normalizeKey(key)] {
+			return Redacted
+		}
+		return val
+	}
+}
@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newObservedLogger() (*zap.SugaredLogger, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	return zap.New(NewRedactingCore(core)).Sugar(), logs
+}
+
+func TestScrub(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "bearer token", input: "header Authorization: Bearer abc.def-ghi", expected: "header Authorization: Bearer [REDACTED]"},
+		{name: "jwt", input: "token eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.sig rejected", expected: "token [REDACTED] rejected"},
+		{name: "local phone number", input: "phone 0912345678 taken", expected: "phone ******5678 taken"},
+		{name: "international phone number", input: "sms to +84912345678", expected: "sms to ********5678"},
+		{name: "national id", input: "duplicate national id 079201001234", expected: "duplicate national id ********1234"},
+		{name: "account number", input: "account 971045182377 is not active", expected: "account 971045182377 is not active"},
+		{name: "nothing sensitive", input: "account not found", expected: "account not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Scrub(tt.input))
+		})
+	}
+}
+
+func TestRedactingCore(t *testing.T) {
+	log, logs := newObservedLogger()
+
+	type request struct {
+		Email       string `json:"email"`
+		Password    string `json:"password"`
+		PhoneNumber string `json:"phone_number"`
+		Nested      struct {
+			AccessToken string `json:"access_token"`
+		} `json:"nested"`
+	}
+	req := request{Email: "alice@example.com", Password: "TestPass123@!", PhoneNumber: "0912345678"}
+	req.Nested.AccessToken = "abc"
+
+	log.With("national_id", "079201001234").Infow(
+		"login for 0912345678",
+		"password", "TestPass123@!",
+		"Authorization", "Bearer abc",
+		"phone", "0912345678",
+		"error", errors.New("phone 0912345678 already used"),
+		"request", req,
+		"user_id", "user-123",
+	)
+
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, "login for ******5678", entry.Message)
+
+	fields := entry.ContextMap()
+	assert.Equal(t, "********1234", fields["national_id"])
+	assert.Equal(t, Redacted, fields["password"])
+	assert.Equal(t, Redacted, fields["Authorization"])
+	assert.Equal(t, "******5678", fields["phone"])
+	assert.Equal(t, "phone ******5678 already used", fields["error"])
+	assert.Equal(t, "user-123", fields["user_id"])
+	assert.Equal(t, map[string]any{
+		"email":        "alice@example.com",
+		"password":     Redacted,
+		"phone_number": "******5678",
+		"nested":       map[string]any{"access_token": Redacted},
+	}, fields["request"])
+}
@@ -2,7 +2,6 @@ package sentry
 
 import (
 	"fmt"
-	"os"
 	"time"
 
 	sentrygo "github.com/getsentry/sentry-go"
@@ -81,12 +80,22 @@ func (s *Sentry) getHub() *sentrygo.Hub {
 	return currentHub
 }
 
-func (s *Sentry) sendError() {
-	if os.Getenv("APP_ENV") == "local" || len(os.Getenv("SENTRY_DSN")) == 0 {
-		return
+// enabled reports whether hub sends events: it needs a DSN, which may have been
+// read from SENTRY_DSN_FILE, and nothing is sent from the local environment.
+func enabled(hub *sentrygo.Hub) bool {
+	client := hub.Client()
+	if client == nil {
+		return false
 	}
+	opts := client.Options()
+	return opts.Dsn != "" && opts.Environment != "local"
+}
 
+func (s *Sentry) sendError() {
 	hub := s.getHub()
+	if !enabled(hub) {
+		return
+	}
 
 	// config basic info into scope
 	hub.ConfigureScope(s.configScope)
@@ -99,12 +108,11 @@ func (s *Sentry) sendError() {
 }
 
 func (s *Sentry) sendMessage() {
-	if os.Getenv("APP_ENV") == "local" || len(os.Getenv("SENTRY_DSN")) == 0 {
+	hub := s.getHub()
+	if !enabled(hub) {
 		return
 	}
 
-	hub := s.getHub()
-
 	// config basic info into scope
 	hub.ConfigureScope(s.configScope)
 	// capture message and send